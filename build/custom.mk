@@ -15,6 +15,10 @@ LDFLAGS += -X "main.BuildDate=$(BUILD_DATE)"
 LDFLAGS += -X "main.BuildHash=$(BUILD_HASH)"
 LDFLAGS += -X "main.BuildHashShort=$(BUILD_HASH_SHORT)"
 
+# Calendar provider the plugin is built for: mscalendar (default), gcal or caldav
+CALENDAR_PROVIDER ?= mscalendar
+LDFLAGS += -X "main.CalendarProvider=$(CALENDAR_PROVIDER)"
+
 GO_BUILD_FLAGS = -ldflags '$(LDFLAGS)'
 
 # Generates mock golang interfaces for testing
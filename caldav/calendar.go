@@ -0,0 +1,180 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func (c *client) GetCalendars(remoteUserID string) ([]*remote.Calendar, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav GetCalendars")
+	}
+
+	c.Logger.With(bot.LogContext{
+		"UserID": remoteUserID,
+	}).Infof("caldav: GetUserCalendars returned `%d` calendars.", len(p.Calendars))
+	return p.Calendars, nil
+}
+
+func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	return c.GetEventsBetweenDates(remoteUserID, start, end)
+}
+
+// GetCalendarView queries one of the calendars of the user, identified by its
+// href.
+func (c *client) GetCalendarView(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	events, err := c.getEventsBetweenDates(remoteUserID, calendarID, start, end)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav GetCalendarView")
+	}
+	return events, nil
+}
+
+// DoBatchViewCalendarRequests queries the calendars one user at a time, since
+// CalDAV has no batching. Per-user failures are reported in the response
+// instead of failing the whole batch.
+func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+	result := []*remote.ViewCalendarResponse{}
+	for _, params := range allParams {
+		res := &remote.ViewCalendarResponse{
+			RemoteUserID: params.RemoteUserID,
+			CalendarID:   params.CalendarID,
+		}
+
+		events, err := c.getEventsBetweenDates(params.RemoteUserID, params.CalendarID, params.StartTime, params.EndTime)
+		if err != nil {
+			apiErr := &remote.APIError{Message: err.Error()}
+			var statusErr *statusError
+			if errors.As(err, &statusErr) {
+				apiErr.Code = http.StatusText(statusErr.StatusCode)
+			}
+			res.Error = apiErr
+		} else {
+			res.Events = events
+		}
+
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+// GetMailboxSettings returns the time zone of the user's default calendar.
+// CalDAV does not expose working hours.
+func (c *client) GetMailboxSettings(remoteUserID string) (*remote.MailboxSettings, error) {
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "caldav GetMailboxSettings")
+	}
+
+	ms, err := c.davRequest(methodPropfind, p.DefaultCalendar, "0", propfindBody("<c:calendar-timezone/>"))
+	if err != nil {
+		return nil, errors.Wrap(err, "caldav GetMailboxSettings")
+	}
+
+	settings := &remote.MailboxSettings{TimeZone: "UTC"}
+	for _, r := range ms.Responses {
+		data := r.prop().CalendarTimeZone
+		if data == "" {
+			continue
+		}
+		cal, err := parseICal(data)
+		if err != nil {
+			continue
+		}
+		for _, vtimezone := range cal.children("VTIMEZONE") {
+			if loc, err := loadLocation(vtimezone.text("TZID")); err == nil {
+				settings.TimeZone = loc.String()
+			}
+		}
+	}
+	return settings, nil
+}
+
+func (c *client) CreateCalendar(remoteUserID string, in *remote.Calendar) (*remote.Calendar, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav CreateCalendar")
+	}
+
+	// New calendars are created next to the default one, in the calendar home.
+	home := p.DefaultCalendar[:strings.LastIndex(strings.TrimSuffix(p.DefaultCalendar, "/"), "/")+1]
+	href := home + model.NewId() + "/"
+
+	b := &strings.Builder{}
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>`)
+	b.WriteString(`<c:mkcalendar xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:set><d:prop><d:displayname>`)
+	_ = xml.EscapeText(b, []byte(in.Name))
+	b.WriteString(`</d:displayname></d:prop></d:set></c:mkcalendar>`)
+
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/xml; charset=utf-8")
+	_, _, err = c.do("MKCALENDAR", href, headers, strings.NewReader(b.String()))
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav CreateCalendar")
+	}
+
+	out := &remote.Calendar{
+		ID:   href,
+		Name: in.Name,
+	}
+	c.Logger.With(bot.LogContext{
+		"v": out,
+	}).Infof("caldav: CreateCalendar created the following calendar.")
+	return out, nil
+}
+
+func (c *client) DeleteCalendar(_, calendarID string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	_, _, err := c.do(http.MethodDelete, calendarID, nil, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "caldav DeleteCalendar")
+	}
+
+	c.Logger.With(bot.LogContext{}).Infof("caldav: DeleteCalendar deleted calendar `%v`.", calendarID)
+	return nil
+}
+
+func (c *client) FindMeetingTimes(_ string, _ *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
+	return nil, remote.ErrNotImplemented
+}
+
+func (c *client) GetSchedule(_ []*remote.ScheduleUserInfo, _, _ *remote.DateTime, _ int) ([]*remote.ScheduleInformation, error) {
+	return nil, remote.ErrNotImplemented
+}
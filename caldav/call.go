@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// statusError is returned for non-successful responses of the CalDAV server.
+type statusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("status: %s. response: %s", e.Status, e.Body)
+}
+
+func isStatus(err error, statusCode int) bool {
+	var statusErr *statusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == statusCode
+}
+
+func (c *client) CallJSON(method, path string, in, out interface{}) (responseData []byte, err error) {
+	var body io.Reader
+	if in != nil {
+		buf := &bytes.Buffer{}
+		err = json.NewEncoder(buf).Encode(in)
+		if err != nil {
+			return nil, err
+		}
+		body = buf
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	responseData, _, err = c.do(method, path, headers, body)
+	if err != nil {
+		return responseData, err
+	}
+	if out != nil && len(responseData) > 0 {
+		err = json.Unmarshal(responseData, out)
+	}
+	return responseData, err
+}
+
+func (c *client) CallFormPost(method, path string, in url.Values, out interface{}) (responseData []byte, err error) {
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/x-www-form-urlencoded")
+	responseData, _, err = c.do(method, path, headers, strings.NewReader(in.Encode()))
+	if err != nil {
+		return responseData, err
+	}
+	if out != nil && len(responseData) > 0 {
+		err = json.Unmarshal(responseData, out)
+	}
+	return responseData, err
+}
+
+// do sends a request to the CalDAV server. Paths are resolved against the
+// server root, since hrefs returned by the server are absolute paths.
+func (c *client) do(method, path string, headers http.Header, inBody io.Reader) (responseData []byte, responseHeaders http.Header, err error) {
+	errContext := fmt.Sprintf("caldav: Call failed: method:%s, path:%s", method, path)
+	pathURL, err := url.Parse(path)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, errContext)
+	}
+
+	if pathURL.Scheme == "" || pathURL.Host == "" {
+		if path == "" || path[0] != '/' {
+			path = "/" + path
+		}
+		path = serverRoot(c.serverURL) + path
+	}
+
+	req, err := http.NewRequest(method, path, inBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.Body == nil {
+		return nil, resp.Header, nil
+	}
+	defer resp.Body.Close()
+
+	responseData, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return responseData, resp.Header, errors.WithMessage(&statusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       string(responseData),
+		}, errContext)
+	}
+
+	return responseData, resp.Header, nil
+}
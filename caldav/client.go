@@ -0,0 +1,31 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type client struct {
+	// caching the context here since it's a "single-use" client, usually used
+	// within a single API request
+	ctx context.Context
+
+	httpClient       *http.Client
+	serverURL        string
+	mattermostUserID string
+	conf             *config.Config
+	tokenHelpers     remote.UserTokenHelpers
+
+	// principals caches the discovered calendar home of each remote user.
+	principals map[string]*principal
+
+	bot.Logger
+	bot.Poster
+}
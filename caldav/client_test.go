@@ -0,0 +1,304 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const (
+	testPrincipal = "/dav/principals/user/"
+	testCalendar  = "/dav/calendars/user/personal/"
+	testEventHref = "/dav/calendars/user/personal/event.ics"
+
+	testNewHref     = "/dav/calendars/user/personal/new.ics"
+	testRemovedHref = "/dav/calendars/user/personal/removed.ics"
+)
+
+// testNewEventICS was never modified since its creation.
+var testNewEventICS = strings.Replace(testEventICS, "UID:event-uid\r\n",
+	"UID:new-uid\r\nCREATED:20240301T080000Z\r\nLAST-MODIFIED:20240301T080000Z\r\n", 1)
+
+type testTokenHelpers struct{}
+
+func (testTokenHelpers) CheckUserConnected(string) bool                   { return true }
+func (testTokenHelpers) DisconnectUserFromStoreIfNecessary(error, string) {}
+func (testTokenHelpers) RefreshAndStoreToken(token *oauth2.Token, _ *oauth2.Config, _ string) (*oauth2.Token, error) {
+	return token, nil
+}
+
+// testServer is a local stand-in for a CalDAV server with a single user.
+type testServer struct {
+	t      *testing.T
+	puts   map[string]string
+	putHdr map[string]http.Header
+}
+
+func multistatusResponse(responses ...string) string {
+	return `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+		strings.Join(responses, "") + `</d:multistatus>`
+}
+
+func propResponse(href, props string) string {
+	return `<d:response><d:href>` + href + `</d:href><d:propstat><d:prop>` + props +
+		`</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat></d:response>`
+}
+
+func escapeXML(t *testing.T, s string) string {
+	b := &strings.Builder{}
+	require.NoError(t, xml.EscapeText(b, []byte(s)))
+	return b.String()
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.Equal(s.t, "Bearer token", r.Header.Get("Authorization"))
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == methodPropfind && r.URL.Path == "/dav/":
+		fmt.Fprint(w, multistatusResponse(propResponse("/dav/",
+			`<d:current-user-principal><d:href>`+testPrincipal+`</d:href></d:current-user-principal>`)))
+
+	case r.Method == methodPropfind && r.URL.Path == testPrincipal:
+		fmt.Fprint(w, multistatusResponse(propResponse(testPrincipal,
+			`<d:displayname>Some User</d:displayname>`+
+				`<c:calendar-home-set><d:href>/dav/calendars/user/</d:href></c:calendar-home-set>`+
+				`<c:calendar-user-address-set><d:href>/dav/principals/user/</d:href><d:href>mailto:user@example.com</d:href></c:calendar-user-address-set>`)))
+
+	case r.Method == methodPropfind && r.URL.Path == "/dav/calendars/user/":
+		require.Equal(s.t, "1", r.Header.Get("Depth"))
+		fmt.Fprint(w, multistatusResponse(
+			propResponse("/dav/calendars/user/", `<d:resourcetype><d:collection/></d:resourcetype>`),
+			propResponse("/dav/calendars/user/tasks/", `<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>`+
+				`<d:displayname>Tasks</d:displayname><c:supported-calendar-component-set><c:comp name="VTODO"/></c:supported-calendar-component-set>`),
+			propResponse(testCalendar, `<d:resourcetype><d:collection/><c:calendar/></d:resourcetype>`+
+				`<d:displayname>Personal</d:displayname><c:supported-calendar-component-set><c:comp name="VEVENT"/></c:supported-calendar-component-set>`),
+		))
+
+	case r.Method == methodPropfind && r.URL.Path == testCalendar && strings.Contains(string(body), "calendar-timezone"):
+		vtimezone := "BEGIN:VCALENDAR\r\nBEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\nEND:VTIMEZONE\r\nEND:VCALENDAR\r\n"
+		fmt.Fprint(w, multistatusResponse(propResponse(testCalendar,
+			`<c:calendar-timezone>`+escapeXML(s.t, vtimezone)+`</c:calendar-timezone>`)))
+
+	case r.Method == methodReport && r.URL.Path == testCalendar && strings.Contains(string(body), "calendar-query"):
+		require.Contains(s.t, string(body), `<c:time-range start="20240304T000000Z" end="20240305T000000Z"/>`)
+		fmt.Fprint(w, multistatusResponse(propResponse(testEventHref,
+			`<d:getetag>"1"</d:getetag><c:calendar-data>`+escapeXML(s.t, testEventICS)+`</c:calendar-data>`)))
+
+	case r.Method == methodReport && r.URL.Path == testCalendar && strings.Contains(string(body), "<d:sync-token></d:sync-token>"):
+		require.NotContains(s.t, string(body), "calendar-data")
+		fmt.Fprint(w, `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`+
+			propResponse(testEventHref, `<d:getetag>"1"</d:getetag>`)+
+			propResponse(testRemovedHref, `<d:getetag>"1"</d:getetag>`)+
+			`<d:sync-token>token1</d:sync-token></d:multistatus>`)
+
+	case r.Method == methodReport && r.URL.Path == testCalendar && strings.Contains(string(body), "sync-collection"):
+		if strings.Contains(string(body), "expired") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		require.Contains(s.t, string(body), "<d:sync-token>token1</d:sync-token>")
+		fmt.Fprint(w, `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`+
+			propResponse(testEventHref, `<d:getetag>"2"</d:getetag><c:calendar-data>`+escapeXML(s.t, testEventICS)+`</c:calendar-data>`)+
+			propResponse(testNewHref, `<d:getetag>"1"</d:getetag><c:calendar-data>`+escapeXML(s.t, testNewEventICS)+`</c:calendar-data>`)+
+			`<d:response><d:href>`+testRemovedHref+`</d:href><d:status>HTTP/1.1 404 Not Found</d:status></d:response>`+
+			`<d:sync-token>token2</d:sync-token></d:multistatus>`)
+
+	case r.Method == http.MethodGet && r.URL.Path == testEventHref:
+		w.Header().Set("ETag", `"1"`)
+		fmt.Fprint(w, testEventICS)
+
+	case r.Method == http.MethodPut:
+		s.puts[r.URL.Path] = string(body)
+		s.putHdr[r.URL.Path] = r.Header
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodDelete && r.URL.Path == testEventHref:
+		s.puts[r.URL.Path] = ""
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T) (remote.Client, *testServer) {
+	s := &testServer{
+		t:      t,
+		puts:   map[string]string{},
+		putHdr: map[string]http.Header{},
+	}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	r := NewRemote(&config.Config{
+		StoredConfig: config.StoredConfig{OAuth2Authority: server.URL + "/dav/"},
+	}, &bot.NilLogger{})
+	token := &oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}
+	return r.MakeUserClient(context.Background(), token, "mm_user_id", nil, testTokenHelpers{}), s
+}
+
+func TestGetMe(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	user, err := c.GetMe()
+	require.NoError(t, err)
+	require.Equal(t, testPrincipal, user.ID)
+	require.Equal(t, "Some User", user.DisplayName)
+	require.Equal(t, "user@example.com", user.Mail)
+
+	calendars, err := c.GetCalendars(user.ID)
+	require.NoError(t, err)
+	require.Len(t, calendars, 1)
+	require.Equal(t, testCalendar, calendars[0].ID)
+	require.Equal(t, "Personal", calendars[0].Name)
+}
+
+func TestGetMailboxSettings(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	settings, err := c.GetMailboxSettings(testPrincipal)
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Berlin", settings.TimeZone)
+}
+
+func TestGetEventsBetweenDates(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	events, err := c.GetEventsBetweenDates(testPrincipal, start, start.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, testEventHref, events[0].ID)
+	require.Equal(t, "event-uid", events[0].ICalUID)
+
+	res, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: testPrincipal, StartTime: start, EndTime: start.Add(24 * time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Nil(t, res[0].Error)
+	require.Len(t, res[0].Events, 1)
+}
+
+func TestRespondToEvent(t *testing.T) {
+	c, s := newTestClient(t)
+
+	require.NoError(t, c.AcceptEvent(testPrincipal, testEventHref))
+	require.Equal(t, `"1"`, s.putHdr[testEventHref].Get("If-Match"))
+
+	cal, err := parseICal(s.puts[testEventHref])
+	require.NoError(t, err)
+	attendee := masterEvent(cal).props("ATTENDEE")[0]
+	require.Equal(t, PartstatAccepted, attendee.param("PARTSTAT"))
+	require.Equal(t, "kept", masterEvent(cal).prop("X-UNKNOWN").Value)
+
+	require.NoError(t, c.DeclineEvent(testPrincipal, testEventHref))
+	cal, err = parseICal(s.puts[testEventHref])
+	require.NoError(t, err)
+	require.Equal(t, PartstatDeclined, masterEvent(cal).props("ATTENDEE")[0].param("PARTSTAT"))
+}
+
+func TestCreateEvent(t *testing.T) {
+	c, s := newTestClient(t)
+
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	event, err := c.CreateEvent(testPrincipal, &remote.Event{
+		Subject: "Planning",
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(event.ID, testCalendar))
+	require.Equal(t, "Planning", event.Subject)
+	require.Equal(t, "*", s.putHdr[event.ID].Get("If-None-Match"))
+	require.Contains(t, s.puts[event.ID], "SUMMARY:Planning")
+}
+
+func TestUpdateEvent(t *testing.T) {
+	c, s := newTestClient(t)
+
+	start := time.Date(2024, 3, 4, 9, 15, 0, 0, time.UTC)
+	event, err := c.UpdateEvent(testPrincipal, testEventHref, &remote.Event{
+		Start: remote.NewDateTime(start, "UTC"),
+		End:   remote.NewDateTime(start.Add(time.Hour), "UTC"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Planning, Q2", event.Subject)
+	require.True(t, event.Start.Time().Equal(start))
+	require.Equal(t, `"1"`, s.putHdr[testEventHref].Get("If-Match"))
+
+	cal, err := parseICal(s.puts[testEventHref])
+	require.NoError(t, err)
+	vevent := masterEvent(cal)
+	require.Equal(t, "20240304T091500Z", vevent.prop("DTSTART").Value)
+	require.Len(t, vevent.props("DTSTART"), 1)
+	require.Equal(t, "1", vevent.text("SEQUENCE"))
+	require.Equal(t, "kept", vevent.prop("X-UNKNOWN").Value)
+	require.Len(t, vevent.props("ATTENDEE"), 2)
+}
+
+func TestCancelEvent(t *testing.T) {
+	c, s := newTestClient(t)
+
+	err := c.CancelEvent(testPrincipal, testEventHref, "Sorry")
+	require.Error(t, err)
+	require.Empty(t, s.puts[testEventHref])
+
+	cal, err := parseICal(testEventICS)
+	require.NoError(t, err)
+	require.False(t, isOrganizedBy(masterEvent(cal), "user@example.com"))
+	require.True(t, isOrganizedBy(masterEvent(cal), "Organizer@example.com"))
+
+	require.NoError(t, c.DeleteEvent(testPrincipal, testEventHref))
+}
+
+func TestPollSubscription(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	sub, err := c.CreateMySubscription("https://mattermost.example.com/notification", testPrincipal)
+	require.NoError(t, err)
+	require.Equal(t, "token1", sub.SyncToken)
+	require.Equal(t, testCalendar, sub.Resource)
+	require.Equal(t, testPrincipal, sub.CreatorID)
+
+	poller, ok := c.(remote.Poller)
+	require.True(t, ok)
+
+	notifications, polled, err := poller.PollSubscription(sub)
+	require.NoError(t, err)
+	require.Equal(t, "token2", polled.SyncToken)
+	require.Equal(t, sub.ID, polled.ID)
+	require.Len(t, notifications, 3)
+	require.Equal(t, sub.ID, notifications[0].SubscriptionID)
+	require.False(t, notifications[0].IsBare)
+	require.Equal(t, remote.ChangeTypeUpdated, notifications[0].ChangeType)
+	require.Equal(t, "event-uid", notifications[0].Event.ICalUID)
+	require.Equal(t, remote.ChangeTypeCreated, notifications[1].ChangeType)
+	require.Equal(t, testNewHref, notifications[1].Event.ID)
+	require.Equal(t, remote.ChangeTypeDeleted, notifications[2].ChangeType)
+	require.Equal(t, testRemovedHref, notifications[2].Event.ID)
+
+	sub.SyncToken = "expired"
+	notifications, polled, err = poller.PollSubscription(sub)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+	require.Equal(t, "token1", polled.SyncToken)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WebDAV (RFC 4918), CalDAV (RFC 4791) and WebDAV sync (RFC 6578) messages.
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+
+	methodPropfind = "PROPFIND"
+	methodReport   = "REPORT"
+)
+
+type hrefProp struct {
+	Href string `xml:"DAV: href"`
+}
+
+type davProp struct {
+	DisplayName  string `xml:"DAV: displayname"`
+	GetETag      string `xml:"DAV: getetag"`
+	SyncToken    string `xml:"DAV: sync-token"`
+	ResourceType struct {
+		Collection *struct{} `xml:"DAV: collection"`
+		Calendar   *struct{} `xml:"urn:ietf:params:xml:ns:caldav calendar"`
+	} `xml:"DAV: resourcetype"`
+	CurrentUserPrincipal   *hrefProp `xml:"DAV: current-user-principal"`
+	CalendarHomeSet        *hrefProp `xml:"urn:ietf:params:xml:ns:caldav calendar-home-set"`
+	CalendarUserAddressSet *struct {
+		Hrefs []string `xml:"DAV: href"`
+	} `xml:"urn:ietf:params:xml:ns:caldav calendar-user-address-set"`
+	SupportedComponentSet *struct {
+		Comps []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"urn:ietf:params:xml:ns:caldav comp"`
+	} `xml:"urn:ietf:params:xml:ns:caldav supported-calendar-component-set"`
+	CalendarTimeZone string `xml:"urn:ietf:params:xml:ns:caldav calendar-timezone"`
+	CalendarData     string `xml:"urn:ietf:params:xml:ns:caldav calendar-data"`
+}
+
+type davPropstat struct {
+	Prop   davProp `xml:"DAV: prop"`
+	Status string  `xml:"DAV: status"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"DAV: href"`
+	Status    string        `xml:"DAV: status"`
+	Propstats []davPropstat `xml:"DAV: propstat"`
+}
+
+type multistatus struct {
+	XMLName   xml.Name      `xml:"DAV: multistatus"`
+	Responses []davResponse `xml:"DAV: response"`
+	SyncToken string        `xml:"DAV: sync-token"`
+}
+
+// prop returns the merged successful properties of the response.
+func (r *davResponse) prop() *davProp {
+	for i := range r.Propstats {
+		if statusOK(r.Propstats[i].Status) {
+			return &r.Propstats[i].Prop
+		}
+	}
+	return &davProp{}
+}
+
+// found reports whether the resource itself still exists. Members removed
+// since the last sync are reported with a 404 status.
+func (r *davResponse) found() bool {
+	return r.Status == "" || statusOK(r.Status)
+}
+
+// notFound reports whether the member was removed since the last sync.
+func (r *davResponse) notFound() bool {
+	fields := strings.Fields(r.Status)
+	return len(fields) >= 2 && fields[1] == "404"
+}
+
+func (p *davProp) isCalendar() bool {
+	return p.ResourceType.Calendar != nil
+}
+
+func (p *davProp) supportsEvents() bool {
+	if p.SupportedComponentSet == nil {
+		return true
+	}
+	for _, comp := range p.SupportedComponentSet.Comps {
+		if strings.EqualFold(comp.Name, "VEVENT") {
+			return true
+		}
+	}
+	return false
+}
+
+func statusOK(status string) bool {
+	fields := strings.Fields(status)
+	return len(fields) >= 2 && strings.HasPrefix(fields[1], "2")
+}
+
+func propfindBody(props ...string) string {
+	return `<?xml version="1.0" encoding="utf-8"?>` +
+		`<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav"><d:prop>` +
+		strings.Join(props, "") +
+		`</d:prop></d:propfind>`
+}
+
+func calendarQueryBody(start, end time.Time) string {
+	s := start.UTC().Format(icalDateTimeUTCFormat)
+	e := end.UTC().Format(icalDateTimeUTCFormat)
+	return `<?xml version="1.0" encoding="utf-8"?>` +
+		`<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+		`<d:prop><d:getetag/><c:calendar-data>` +
+		fmt.Sprintf(`<c:expand start="%s" end="%s"/>`, s, e) +
+		`</c:calendar-data></d:prop>` +
+		`<c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT">` +
+		fmt.Sprintf(`<c:time-range start="%s" end="%s"/>`, s, e) +
+		`</c:comp-filter></c:comp-filter></c:filter>` +
+		`</c:calendar-query>`
+}
+
+// syncCollectionBody requests the members changed since the sync token, or
+// all the members without one, with their data if calendarData is set.
+func syncCollectionBody(syncToken string, calendarData bool) string {
+	b := &strings.Builder{}
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>`)
+	b.WriteString(`<d:sync-collection xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">`)
+	b.WriteString(`<d:sync-token>`)
+	_ = xml.EscapeText(b, []byte(syncToken))
+	b.WriteString(`</d:sync-token><d:sync-level>1</d:sync-level>`)
+	if calendarData {
+		b.WriteString(`<d:prop><d:getetag/><c:calendar-data/></d:prop>`)
+	} else {
+		b.WriteString(`<d:prop><d:getetag/></d:prop>`)
+	}
+	b.WriteString(`</d:sync-collection>`)
+	return b.String()
+}
+
+// davRequest sends a PROPFIND or REPORT request and decodes the multistatus response.
+func (c *client) davRequest(method, path, depth, body string) (*multistatus, error) {
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/xml; charset=utf-8")
+	headers.Set("Depth", depth)
+
+	data, _, err := c.do(method, path, headers, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &multistatus{}
+	err = xml.Unmarshal(data, ms)
+	if err != nil {
+		return nil, errors.Wrap(err, "caldav: invalid multistatus response")
+	}
+	return ms, nil
+}
@@ -0,0 +1,673 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	PartstatAccepted    = "ACCEPTED"
+	PartstatTentative   = "TENTATIVE"
+	PartstatDeclined    = "DECLINED"
+	PartstatNeedsAction = "NEEDS-ACTION"
+
+	prodID = "-//Mattermost//mattermost-plugin-mscalendar//EN"
+)
+
+var responseStatusConversion = map[string]string{
+	PartstatAccepted:    remote.EventResponseStatusAccepted,
+	PartstatTentative:   remote.EventResponseStatusTentative,
+	PartstatDeclined:    remote.EventResponseStatusDeclined,
+	PartstatNeedsAction: remote.EventResponseStatusNotAnswered,
+}
+
+// loadLocation resolves a TZID. Some servers prefix Olson names with a
+// vendor path, e.g. /freeassociation.sourceforge.net/Europe/Berlin.
+func loadLocation(tzid string) (*time.Location, error) {
+	loc, err := time.LoadLocation(tz.Go(tzid))
+	if err == nil {
+		return loc, nil
+	}
+
+	parts := strings.Split(strings.Trim(tzid, "/"), "/")
+	for i := 1; i < len(parts); i++ {
+		if loc, err = time.LoadLocation(strings.Join(parts[i:], "/")); err == nil {
+			return loc, nil
+		}
+	}
+	return nil, errors.Errorf("unknown time zone %s", tzid)
+}
+
+func toRemoteDateTime(t time.Time, timeZone string) *remote.DateTime {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		loc, timeZone = time.UTC, "UTC"
+	}
+	return &remote.DateTime{
+		DateTime: t.In(loc).Format(remote.RFC3339NanoNoTimezone),
+		TimeZone: timeZone,
+	}
+}
+
+func toRemoteResponseStatus(partstat string) *remote.EventResponseStatus {
+	response, ok := responseStatusConversion[strings.ToUpper(partstat)]
+	if !ok {
+		response = remote.EventResponseStatusNotAnswered
+	}
+	return &remote.EventResponseStatus{Response: response}
+}
+
+// toRemoteEvent converts a VEVENT stored at href into our representation of
+// fields, from the point of view of the user with the given address.
+func toRemoteEvent(vevent *icalComponent, href, userAddress string) *remote.Event {
+	out := &remote.Event{
+		ID:             href,
+		ICalUID:        vevent.text("UID"),
+		Subject:        vevent.text("SUMMARY"),
+		BodyPreview:    vevent.text("DESCRIPTION"),
+		Weblink:        vevent.text("URL"),
+		IsCancelled:    strings.EqualFold(vevent.text("STATUS"), "CANCELLED"),
+		ShowAs:         "busy",
+		Importance:     "normal",
+		ResponseStatus: toRemoteResponseStatus(PartstatNeedsAction),
+	}
+
+	if strings.EqualFold(vevent.text("TRANSP"), "TRANSPARENT") {
+		out.ShowAs = "free"
+	}
+	// PRIORITY 1 to 4 is high, 5 is normal and 6 to 9 is low, 0 is undefined.
+	if priority, err := strconv.Atoi(vevent.text("PRIORITY")); err == nil && priority > 0 {
+		switch {
+		case priority < 5:
+			out.Importance = "high"
+		case priority > 5:
+			out.Importance = "low"
+		}
+	}
+
+	if out.BodyPreview != "" {
+		out.Body = &remote.ItemBody{
+			Content:     out.BodyPreview,
+			ContentType: "text",
+		}
+	}
+
+	if location := vevent.text("LOCATION"); location != "" {
+		out.Location = &remote.Location{DisplayName: location}
+	}
+
+	if conference := vevent.prop("CONFERENCE"); conference != nil {
+		out.Conference = &remote.Conference{
+			Application: conference.param("LABEL"),
+			URL:         conference.Value,
+		}
+	}
+
+	var start time.Time
+	if p := vevent.prop("DTSTART"); p != nil {
+		t, timeZone, allDay, err := parseICalTime(p)
+		if err == nil {
+			start = t
+			out.Start = toRemoteDateTime(t, timeZone)
+			out.IsAllDay = allDay
+		}
+	}
+	if p := vevent.prop("DTEND"); p != nil {
+		if t, timeZone, _, err := parseICalTime(p); err == nil {
+			out.End = toRemoteDateTime(t, timeZone)
+		}
+	} else if p := vevent.prop("DURATION"); p != nil && out.Start != nil {
+		if d, err := parseICalDuration(p.Value); err == nil {
+			out.End = toRemoteDateTime(start.Add(d), out.Start.TimeZone)
+		}
+	} else if out.Start != nil {
+		d := time.Duration(0)
+		if out.IsAllDay {
+			d = 24 * time.Hour
+		}
+		out.End = toRemoteDateTime(start.Add(d), out.Start.TimeZone)
+	}
+
+	// Expanded instances of a recurring event carry the start they replace.
+	if p := vevent.prop("RECURRENCE-ID"); p != nil {
+		out.Type = remote.EventTypeOccurrence
+		out.SeriesMasterID = href
+		if t, _, _, err := parseICalTime(p); err == nil {
+			out.OriginalStart = t.UTC().Format(time.RFC3339)
+		}
+	} else if p := vevent.prop("RRULE"); p != nil && !start.IsZero() {
+		if recurrence, err := remote.ParseRRule(p.Value, start); err == nil {
+			out.Type = remote.EventTypeSeriesMaster
+			out.Recurrence = recurrence
+		}
+	}
+
+	if p := vevent.prop("ORGANIZER"); p != nil {
+		address := calAddress(p.Value)
+		out.Organizer = &remote.Attendee{
+			EmailAddress: &remote.EmailAddress{
+				Address: address,
+				Name:    p.param("CN"),
+			},
+		}
+		out.IsOrganizer = strings.EqualFold(address, userAddress)
+	}
+
+	attendees := vevent.props("ATTENDEE")
+	for _, p := range attendees {
+		address := calAddress(p.Value)
+		attendeeType := "required"
+		switch strings.ToUpper(p.param("ROLE")) {
+		case "OPT-PARTICIPANT", "NON-PARTICIPANT":
+			attendeeType = "optional"
+		}
+		if strings.EqualFold(p.param("CUTYPE"), "RESOURCE") || strings.EqualFold(p.param("CUTYPE"), "ROOM") {
+			attendeeType = "resource"
+		}
+
+		if strings.EqualFold(address, userAddress) {
+			out.ResponseStatus = toRemoteResponseStatus(p.param("PARTSTAT"))
+			out.ResponseRequested = strings.EqualFold(p.param("RSVP"), "TRUE")
+		}
+		out.Attendees = append(out.Attendees, &remote.Attendee{
+			Type:   attendeeType,
+			Status: toRemoteResponseStatus(p.param("PARTSTAT")),
+			EmailAddress: &remote.EmailAddress{
+				Address: address,
+				Name:    p.param("CN"),
+			},
+		})
+	}
+
+	// Organizers, and owners of events without attendees, own the event outright.
+	if out.IsOrganizer || len(attendees) == 0 {
+		out.ResponseStatus = toRemoteResponseStatus(PartstatAccepted)
+	}
+
+	for _, alarm := range vevent.children("VALARM") {
+		trigger := alarm.prop("TRIGGER")
+		if trigger == nil || strings.EqualFold(trigger.param("VALUE"), "DATE-TIME") {
+			continue
+		}
+		d, err := parseICalDuration(trigger.Value)
+		if err == nil && d <= 0 {
+			out.ReminderMinutesBeforeStart = int(-d.Minutes())
+			out.IsReminderOn = true
+			break
+		}
+	}
+
+	return out
+}
+
+// toRemoteEvents converts all VEVENTs of a calendar object resource.
+func toRemoteEvents(calendarData, href, userAddress string) ([]*remote.Event, error) {
+	cal, err := parseICal(calendarData)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*remote.Event{}
+	for _, vevent := range cal.children("VEVENT") {
+		events = append(events, toRemoteEvent(vevent, href, userAddress))
+	}
+	return events, nil
+}
+
+// masterEvent returns the VEVENT defining the event, as opposed to the
+// overridden instances of a recurring event.
+func masterEvent(cal *icalComponent) *icalComponent {
+	vevents := cal.children("VEVENT")
+	for _, vevent := range vevents {
+		if vevent.prop("RECURRENCE-ID") == nil {
+			return vevent
+		}
+	}
+	if len(vevents) > 0 {
+		return vevents[0]
+	}
+	return nil
+}
+
+func formatICalTime(dt *remote.DateTime, allDay bool) *icalProp {
+	t := dt.Time()
+	if allDay {
+		return &icalProp{
+			Params: []icalParam{{Name: "VALUE", Value: "DATE"}},
+			Value:  t.Format(icalDateFormat),
+		}
+	}
+	return &icalProp{Value: t.UTC().Format(icalDateTimeUTCFormat)}
+}
+
+// fromRemoteEvent converts our representation of an event into a calendar
+// object resource organized by the given principal.
+func fromRemoteEvent(in *remote.Event, uid string, organizer *principal) *icalComponent {
+	vevent := &icalComponent{Name: "VEVENT"}
+	vevent.add("UID", uid)
+	vevent.add("DTSTAMP", time.Now().UTC().Format(icalDateTimeUTCFormat))
+
+	if in.Start != nil {
+		p := formatICalTime(in.Start, in.IsAllDay)
+		vevent.add("DTSTART", p.Value, p.Params...)
+	}
+	if in.End != nil {
+		p := formatICalTime(in.End, in.IsAllDay)
+		vevent.add("DTEND", p.Value, p.Params...)
+	}
+	if in.Recurrence != nil {
+		if rrule, err := in.Recurrence.RRule(); err == nil {
+			vevent.add("RRULE", rrule)
+		}
+	}
+
+	vevent.addText("SUMMARY", in.Subject)
+	if in.Body != nil {
+		vevent.addText("DESCRIPTION", in.Body.Content)
+	}
+	if in.Location != nil {
+		vevent.addText("LOCATION", in.Location.DisplayName)
+	}
+	if in.ShowAs == "free" {
+		vevent.add("TRANSP", "TRANSPARENT")
+	}
+
+	if len(in.Attendees) > 0 {
+		params := []icalParam{}
+		if organizer.DisplayName != "" {
+			params = append(params, icalParam{Name: "CN", Value: organizer.DisplayName})
+		}
+		vevent.add("ORGANIZER", "mailto:"+organizer.Address, params...)
+	}
+	for _, a := range in.Attendees {
+		if a.EmailAddress == nil {
+			continue
+		}
+		role := "REQ-PARTICIPANT"
+		if a.Type == "optional" {
+			role = "OPT-PARTICIPANT"
+		}
+		params := []icalParam{
+			{Name: "ROLE", Value: role},
+			{Name: "PARTSTAT", Value: PartstatNeedsAction},
+			{Name: "RSVP", Value: "TRUE"},
+		}
+		if a.EmailAddress.Name != "" {
+			params = append(params, icalParam{Name: "CN", Value: a.EmailAddress.Name})
+		}
+		vevent.add("ATTENDEE", "mailto:"+a.EmailAddress.Address, params...)
+	}
+
+	if in.ReminderMinutesBeforeStart > 0 {
+		alarm := &icalComponent{Name: "VALARM"}
+		alarm.add("ACTION", "DISPLAY")
+		alarm.addText("DESCRIPTION", in.Subject)
+		alarm.add("TRIGGER", formatICalDuration(-time.Duration(in.ReminderMinutesBeforeStart)*time.Minute))
+		vevent.Children = append(vevent.Children, alarm)
+	}
+
+	cal := &icalComponent{Name: "VCALENDAR"}
+	cal.add("VERSION", "2.0")
+	cal.add("PRODID", prodID)
+	cal.Children = append(cal.Children, vevent)
+	return cal
+}
+
+// setPartstat updates the participation status of the attendee with the
+// given address in every VEVENT of the calendar object.
+func setPartstat(cal *icalComponent, address, partstat string) bool {
+	found := false
+	for _, vevent := range cal.children("VEVENT") {
+		for _, p := range vevent.props("ATTENDEE") {
+			if !strings.EqualFold(calAddress(p.Value), address) {
+				continue
+			}
+			p.setParam("PARTSTAT", partstat)
+			p.deleteParam("RSVP")
+			found = true
+		}
+	}
+	return found
+}
+
+func (c *client) getCalendarObject(href string) (*icalComponent, string, error) {
+	data, headers, err := c.do(http.MethodGet, href, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	cal, err := parseICal(string(data))
+	if err != nil {
+		return nil, "", err
+	}
+	return cal, headers.Get("ETag"), nil
+}
+
+func (c *client) putCalendarObject(href string, cal *icalComponent, etag string) error {
+	headers := http.Header{}
+	headers.Set("Content-Type", "text/calendar; charset=utf-8")
+	if etag != "" {
+		headers.Set("If-Match", etag)
+	} else {
+		headers.Set("If-None-Match", "*")
+	}
+	_, _, err := c.do(http.MethodPut, href, headers, strings.NewReader(cal.String()))
+	return err
+}
+
+func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav GetEvent")
+	}
+
+	cal, _, err := c.getCalendarObject(eventID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav GetEvent")
+	}
+
+	vevent := masterEvent(cal)
+	if vevent == nil {
+		return nil, errors.New("caldav GetEvent: calendar object has no event")
+	}
+	return toRemoteEvent(vevent, eventID, p.Address), nil
+}
+
+func (c *client) AcceptEvent(remoteUserID, eventID string) error {
+	return errors.Wrap(c.respondToEvent(remoteUserID, eventID, PartstatAccepted), "caldav AcceptEvent")
+}
+
+func (c *client) DeclineEvent(remoteUserID, eventID string) error {
+	return errors.Wrap(c.respondToEvent(remoteUserID, eventID, PartstatDeclined), "caldav DeclineEvent")
+}
+
+func (c *client) TentativelyAcceptEvent(remoteUserID, eventID string) error {
+	return errors.Wrap(c.respondToEvent(remoteUserID, eventID, PartstatTentative), "caldav TentativelyAcceptEvent")
+}
+
+// respondToEvent edits the PARTSTAT of the user's ATTENDEE property. The
+// server takes care of scheduling the reply to the organizer.
+func (c *client) respondToEvent(remoteUserID, eventID, partstat string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return err
+	}
+
+	cal, etag, err := c.getCalendarObject(eventID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return err
+	}
+
+	if !setPartstat(cal, p.Address, partstat) {
+		return errors.New("user is not an attendee of the event")
+	}
+
+	err = c.putCalendarObject(eventID, cal, etag)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return err
+	}
+	return nil
+}
+
+func (c *client) GetEventsBetweenDates(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	events, err := c.getEventsBetweenDates(remoteUserID, "", start, end)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav GetEventsBetweenDates")
+	}
+	return events, nil
+}
+
+// getEventsBetweenDates queries a calendar of the user, the default one when
+// calendarHref is empty, with recurring events expanded by the server.
+func (c *client) getEventsBetweenDates(remoteUserID, calendarHref string, start, end time.Time) ([]*remote.Event, error) {
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		return nil, err
+	}
+	if calendarHref == "" {
+		calendarHref = p.DefaultCalendar
+	}
+
+	ms, err := c.davRequest(methodReport, calendarHref, "1", calendarQueryBody(start, end))
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*remote.Event{}
+	for _, r := range ms.Responses {
+		prop := r.prop()
+		if prop.CalendarData == "" {
+			continue
+		}
+		objectEvents, err := toRemoteEvents(prop.CalendarData, r.Href, p.Address)
+		if err != nil {
+			c.Logger.Warnf("caldav: failed to parse calendar object %s: %v", r.Href, err)
+			continue
+		}
+		for _, e := range objectEvents {
+			if e.Start == nil || e.End == nil || !e.Start.Time().Before(end) || e.End.Time().Before(start) {
+				continue
+			}
+			events = append(events, e)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+	return events, nil
+}
+
+// CreateEvent creates a calendar event
+func (c *client) CreateEvent(remoteUserID string, in *remote.Event) (*remote.Event, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav CreateEvent")
+	}
+
+	uid := model.NewId()
+	href := strings.TrimSuffix(p.DefaultCalendar, "/") + "/" + uid + ".ics"
+	cal := fromRemoteEvent(in, uid, p)
+	err = c.putCalendarObject(href, cal, "")
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav CreateEvent")
+	}
+
+	return toRemoteEvent(masterEvent(cal), href, p.Address), nil
+}
+
+// applyUpdate sets the fields of the event that are set in the update, and
+// bumps the sequence so that attendees get the new version.
+func applyUpdate(vevent *icalComponent, in *remote.Event, organizer *principal) {
+	if in.Start != nil {
+		p := formatICalTime(in.Start, in.IsAllDay)
+		vevent.set("DTSTART", p.Value, p.Params...)
+	}
+	if in.End != nil {
+		p := formatICalTime(in.End, in.IsAllDay)
+		vevent.remove("DURATION")
+		vevent.set("DTEND", p.Value, p.Params...)
+	}
+	if in.Recurrence != nil {
+		if rrule, err := in.Recurrence.RRule(); err == nil {
+			vevent.set("RRULE", rrule)
+		}
+	}
+	if in.Subject != "" {
+		vevent.setText("SUMMARY", in.Subject)
+	}
+	if in.Body != nil {
+		vevent.setText("DESCRIPTION", in.Body.Content)
+	}
+	if in.Location != nil {
+		vevent.setText("LOCATION", in.Location.DisplayName)
+	}
+
+	if len(in.Attendees) > 0 {
+		existing := map[string]*icalProp{}
+		for _, p := range vevent.props("ATTENDEE") {
+			existing[strings.ToLower(calAddress(p.Value))] = p
+		}
+		vevent.remove("ATTENDEE")
+		if vevent.prop("ORGANIZER") == nil {
+			vevent.add("ORGANIZER", "mailto:"+organizer.Address)
+		}
+		for _, a := range in.Attendees {
+			if a.EmailAddress == nil {
+				continue
+			}
+			if p, ok := existing[strings.ToLower(a.EmailAddress.Address)]; ok {
+				vevent.Props = append(vevent.Props, p)
+				continue
+			}
+			vevent.add("ATTENDEE", "mailto:"+a.EmailAddress.Address,
+				icalParam{Name: "ROLE", Value: "REQ-PARTICIPANT"},
+				icalParam{Name: "PARTSTAT", Value: PartstatNeedsAction},
+				icalParam{Name: "RSVP", Value: "TRUE"})
+		}
+	}
+
+	bumpSequence(vevent)
+}
+
+func bumpSequence(vevent *icalComponent) {
+	sequence, _ := strconv.Atoi(vevent.text("SEQUENCE"))
+	vevent.set("SEQUENCE", strconv.Itoa(sequence+1))
+	vevent.set("DTSTAMP", time.Now().UTC().Format(icalDateTimeUTCFormat))
+}
+
+// isOrganizedBy tells whether the event is organized by the given address.
+// Events without attendees have no organizer and belong to their calendar.
+func isOrganizedBy(vevent *icalComponent, address string) bool {
+	p := vevent.prop("ORGANIZER")
+	return p == nil || strings.EqualFold(calAddress(p.Value), address)
+}
+
+// UpdateEvent updates the fields set in the event. The server takes care of
+// scheduling the update to the attendees.
+func (c *client) UpdateEvent(remoteUserID, eventID string, in *remote.Event) (*remote.Event, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav UpdateEvent")
+	}
+
+	cal, etag, err := c.getCalendarObject(eventID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav UpdateEvent")
+	}
+	vevent := masterEvent(cal)
+	if vevent == nil {
+		return nil, errors.New("caldav UpdateEvent: calendar object has no event")
+	}
+
+	applyUpdate(vevent, in, p)
+	err = c.putCalendarObject(eventID, cal, etag)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav UpdateEvent")
+	}
+
+	return toRemoteEvent(vevent, eventID, p.Address), nil
+}
+
+// DeleteEvent removes the calendar object of the event.
+func (c *client) DeleteEvent(_, eventID string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	_, _, err := c.do(http.MethodDelete, eventID, nil, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "caldav DeleteEvent")
+	}
+	return nil
+}
+
+// CancelEvent marks an event organized by the user as cancelled, with the
+// comment for the attendees. The server schedules the cancellation.
+func (c *client) CancelEvent(remoteUserID, eventID, comment string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "caldav CancelEvent")
+	}
+
+	cal, etag, err := c.getCalendarObject(eventID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "caldav CancelEvent")
+	}
+	vevent := masterEvent(cal)
+	if vevent == nil {
+		return errors.New("caldav CancelEvent: calendar object has no event")
+	}
+	if !isOrganizedBy(vevent, p.Address) {
+		return errors.New("caldav CancelEvent: only the organizer can cancel the event")
+	}
+
+	vevent.set("STATUS", "CANCELLED")
+	vevent.setText("COMMENT", comment)
+	bumpSequence(vevent)
+	err = c.putCalendarObject(eventID, cal, etag)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "caldav CancelEvent")
+	}
+	return nil
+}
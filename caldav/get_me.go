@@ -0,0 +1,135 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const (
+	ErrorUserInactive = "You have been marked inactive because your refresh token is expired. Please disconnect and reconnect your account again."
+	LogUserInactive   = "User %s is inactive. Please disconnect and reconnect your account."
+)
+
+// principal is a discovered CalDAV user. The principal href is used as the
+// remote user ID.
+type principal struct {
+	Href        string
+	DisplayName string
+	Address     string
+	Calendars   []*remote.Calendar
+
+	// DefaultCalendar is the href of the first calendar accepting events.
+	DefaultCalendar string
+}
+
+// GetMe discovers the current user principal of the server.
+func (c *client) GetMe() (*remote.User, error) {
+	ms, err := c.davRequest(methodPropfind, c.serverURL, "0", propfindBody("<d:current-user-principal/>"))
+	if err != nil {
+		return nil, errors.Wrap(err, "caldav GetMe")
+	}
+
+	href := ""
+	for _, r := range ms.Responses {
+		if p := r.prop(); p.CurrentUserPrincipal != nil {
+			href = p.CurrentUserPrincipal.Href
+		}
+	}
+	if href == "" {
+		return nil, errors.New("user has no principal")
+	}
+
+	p, err := c.getPrincipal(href)
+	if err != nil {
+		return nil, errors.Wrap(err, "caldav GetMe")
+	}
+	if p.Address == "" {
+		return nil, errors.New("user has no email address")
+	}
+
+	displayName := p.DisplayName
+	if displayName == "" {
+		displayName = p.Address
+	}
+
+	return &remote.User{
+		ID:                p.Href,
+		DisplayName:       displayName,
+		UserPrincipalName: p.Address,
+		Mail:              p.Address,
+	}, nil
+}
+
+func (c *client) GetSuperuserToken() (string, error) {
+	return "", remote.ErrSuperUserClientNotSupported
+}
+
+// getPrincipal looks up the address and calendars of a principal.
+func (c *client) getPrincipal(href string) (*principal, error) {
+	if p, ok := c.principals[href]; ok {
+		return p, nil
+	}
+
+	ms, err := c.davRequest(methodPropfind, href, "0", propfindBody(
+		"<d:displayname/>",
+		"<c:calendar-home-set/>",
+		"<c:calendar-user-address-set/>",
+	))
+	if err != nil {
+		return nil, err
+	}
+	if len(ms.Responses) == 0 {
+		return nil, errors.Errorf("principal %s not found", href)
+	}
+
+	prop := ms.Responses[0].prop()
+	p := &principal{
+		Href:        href,
+		DisplayName: prop.DisplayName,
+	}
+	if prop.CalendarUserAddressSet != nil {
+		for _, address := range prop.CalendarUserAddressSet.Hrefs {
+			if a := calAddress(address); a != address {
+				p.Address = a
+				break
+			}
+		}
+	}
+	if prop.CalendarHomeSet == nil || prop.CalendarHomeSet.Href == "" {
+		return nil, errors.Errorf("principal %s has no calendar home", href)
+	}
+
+	ms, err = c.davRequest(methodPropfind, prop.CalendarHomeSet.Href, "1", propfindBody(
+		"<d:resourcetype/>",
+		"<d:displayname/>",
+		"<c:supported-calendar-component-set/>",
+	))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range ms.Responses {
+		prop := r.prop()
+		if !prop.isCalendar() || !prop.supportsEvents() {
+			continue
+		}
+		p.Calendars = append(p.Calendars, &remote.Calendar{
+			ID:   r.Href,
+			Name: prop.DisplayName,
+		})
+		if p.DefaultCalendar == "" {
+			p.DefaultCalendar = r.Href
+		}
+	}
+	if p.DefaultCalendar == "" {
+		return nil, errors.Errorf("principal %s has no event calendar", href)
+	}
+
+	if c.principals != nil {
+		c.principals[href] = p
+	}
+	return p, nil
+}
@@ -0,0 +1,390 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Minimal iCalendar (RFC 5545) support: enough to read and write events while
+// preserving properties the plugin doesn't know about.
+
+const (
+	icalDateTimeFormat    = "20060102T150405"
+	icalDateTimeUTCFormat = "20060102T150405Z"
+	icalDateFormat        = "20060102"
+	icalLineLength        = 75
+)
+
+type icalParam struct {
+	Name  string
+	Value string
+}
+
+type icalProp struct {
+	Name   string
+	Params []icalParam
+	Value  string
+}
+
+type icalComponent struct {
+	Name     string
+	Props    []*icalProp
+	Children []*icalComponent
+}
+
+func (p *icalProp) param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+func (p *icalProp) setParam(name, value string) {
+	for i, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			p.Params[i].Value = value
+			return
+		}
+	}
+	p.Params = append(p.Params, icalParam{Name: name, Value: value})
+}
+
+func (p *icalProp) deleteParam(name string) {
+	params := p.Params[:0]
+	for _, param := range p.Params {
+		if !strings.EqualFold(param.Name, name) {
+			params = append(params, param)
+		}
+	}
+	p.Params = params
+}
+
+func (c *icalComponent) prop(name string) *icalProp {
+	for _, p := range c.Props {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (c *icalComponent) props(name string) []*icalProp {
+	var out []*icalProp
+	for _, p := range c.Props {
+		if p.Name == name {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// text returns the unescaped TEXT value of the named property.
+func (c *icalComponent) text(name string) string {
+	p := c.prop(name)
+	if p == nil {
+		return ""
+	}
+	return unescapeText(p.Value)
+}
+
+func (c *icalComponent) add(name, value string, params ...icalParam) {
+	c.Props = append(c.Props, &icalProp{Name: name, Params: params, Value: value})
+}
+
+func (c *icalComponent) addText(name, value string) {
+	if value == "" {
+		return
+	}
+	c.add(name, escapeText(value))
+}
+
+// set replaces the properties of the given name with a single one.
+func (c *icalComponent) set(name, value string, params ...icalParam) {
+	c.remove(name)
+	c.add(name, value, params...)
+}
+
+func (c *icalComponent) setText(name, value string) {
+	c.remove(name)
+	c.addText(name, value)
+}
+
+func (c *icalComponent) remove(name string) {
+	props := c.Props[:0]
+	for _, p := range c.Props {
+		if !strings.EqualFold(p.Name, name) {
+			props = append(props, p)
+		}
+	}
+	c.Props = props
+}
+
+func (c *icalComponent) children(name string) []*icalComponent {
+	var out []*icalComponent
+	for _, child := range c.Children {
+		if child.Name == name {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+// parseICal parses an iCalendar object into its root component.
+func parseICal(data string) (*icalComponent, error) {
+	var stack []*icalComponent
+	var root *icalComponent
+
+	for _, line := range unfoldLines(data) {
+		if line == "" {
+			continue
+		}
+		p, err := parseICalLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			c := &icalComponent{Name: strings.ToUpper(p.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return nil, errors.Errorf("ical: unexpected END:%s", p.Value)
+			}
+			c := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				root = c
+			}
+		default:
+			if len(stack) == 0 {
+				return nil, errors.Errorf("ical: property %s outside of a component", p.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Props = append(c.Props, p)
+		}
+	}
+
+	if root == nil || len(stack) > 0 {
+		return nil, errors.New("ical: incomplete calendar object")
+	}
+	return root, nil
+}
+
+func unfoldLines(data string) []string {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func parseICalLine(line string) (*icalProp, error) {
+	// The name and parameters end at the first colon outside of quotes.
+	inQuotes := false
+	end := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, errors.Errorf("ical: invalid content line %q", line)
+	}
+
+	p := &icalProp{Value: line[end+1:]}
+	parts := splitUnquoted(line[:end], ';')
+	p.Name = strings.ToUpper(parts[0])
+	for _, part := range parts[1:] {
+		kv := strings.SplitN(part, "=", 2)
+		param := icalParam{Name: strings.ToUpper(kv[0])}
+		if len(kv) == 2 {
+			param.Value = strings.Trim(kv[1], `"`)
+		}
+		p.Params = append(p.Params, param)
+	}
+	return p, nil
+}
+
+func splitUnquoted(s string, sep rune) []string {
+	var out []string
+	inQuotes := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == sep && !inQuotes:
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+// String serializes the component, folding lines as required by RFC 5545.
+func (c *icalComponent) String() string {
+	b := &strings.Builder{}
+	c.write(b)
+	return b.String()
+}
+
+func (c *icalComponent) write(b *strings.Builder) {
+	writeICalLine(b, "BEGIN:"+c.Name)
+	for _, p := range c.Props {
+		line := p.Name
+		for _, param := range p.Params {
+			value := param.Value
+			if strings.ContainsAny(value, ":;,") {
+				value = `"` + value + `"`
+			}
+			line += ";" + param.Name + "=" + value
+		}
+		writeICalLine(b, line+":"+p.Value)
+	}
+	for _, child := range c.Children {
+		child.write(b)
+	}
+	writeICalLine(b, "END:"+c.Name)
+}
+
+func writeICalLine(b *strings.Builder, line string) {
+	for len(line) > icalLineLength {
+		// Don't split multi-byte characters.
+		cut := icalLineLength
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func escapeText(s string) string {
+	return textEscaper.Replace(strings.ReplaceAll(s, "\r\n", "\n"))
+}
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
+
+// parseICalTime parses a DATE or DATE-TIME property. The returned time zone
+// name is empty for UTC and floating times.
+func parseICalTime(p *icalProp) (t time.Time, timeZone string, allDay bool, err error) {
+	if p.param("VALUE") == "DATE" || len(p.Value) == len(icalDateFormat) {
+		t, err = time.ParseInLocation(icalDateFormat, p.Value, time.UTC)
+		return t, "", true, err
+	}
+
+	if strings.HasSuffix(p.Value, "Z") {
+		t, err = time.Parse(icalDateTimeUTCFormat, p.Value)
+		return t, "", false, err
+	}
+
+	loc := time.UTC
+	if tzid := p.param("TZID"); tzid != "" {
+		if l, loadErr := loadLocation(tzid); loadErr == nil {
+			loc = l
+			timeZone = l.String()
+		}
+	}
+	t, err = time.ParseInLocation(icalDateTimeFormat, p.Value, loc)
+	return t, timeZone, false, err
+}
+
+// parseICalDuration parses an RFC 5545 duration such as "PT15M" or "-P1DT2H".
+func parseICalDuration(s string) (time.Duration, error) {
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") {
+		return 0, errors.Errorf("ical: invalid duration %q", s)
+	}
+	s = s[1:]
+
+	var d time.Duration
+	inTime := false
+	num := ""
+	for _, r := range s {
+		switch {
+		case r == 'T':
+			inTime = true
+		case r >= '0' && r <= '9':
+			num += string(r)
+		default:
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return 0, errors.Errorf("ical: invalid duration %q", s)
+			}
+			num = ""
+			switch {
+			case r == 'W':
+				d += time.Duration(n) * 7 * 24 * time.Hour
+			case r == 'D':
+				d += time.Duration(n) * 24 * time.Hour
+			case r == 'H' && inTime:
+				d += time.Duration(n) * time.Hour
+			case r == 'M' && inTime:
+				d += time.Duration(n) * time.Minute
+			case r == 'S' && inTime:
+				d += time.Duration(n) * time.Second
+			default:
+				return 0, errors.Errorf("ical: invalid duration %q", s)
+			}
+		}
+	}
+	return sign * d, nil
+}
+
+func formatICalDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	return fmt.Sprintf("%sPT%dM", sign, int(d.Minutes()))
+}
+
+// calAddress strips the mailto: scheme from a CAL-ADDRESS value.
+func calAddress(value string) string {
+	if len(value) > 7 && strings.EqualFold(value[:7], "mailto:") {
+		return value[7:]
+	}
+	return value
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const testEventICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Test//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:event-uid\r\n" +
+	"DTSTART;TZID=Europe/Berlin:20240304T100000\r\n" +
+	"DTEND;TZID=Europe/Berlin:20240304T110000\r\n" +
+	"SUMMARY:Planning\\, Q2\r\n" +
+	"DESCRIPTION:First line\\nSecond line that is long enough to be folded by th\r\n" +
+	" e server\r\n" +
+	"LOCATION:Room 1\r\n" +
+	"ORGANIZER;CN=Organizer:mailto:organizer@example.com\r\n" +
+	"ATTENDEE;CN=\"Doe, Jane\";PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:user@example.com\r\n" +
+	"ATTENDEE;ROLE=OPT-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:other@example.com\r\n" +
+	"X-UNKNOWN;X-PARAM=1:kept\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"ACTION:DISPLAY\r\n" +
+	"TRIGGER:-PT15M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICal(t *testing.T) {
+	cal, err := parseICal(testEventICS)
+	require.NoError(t, err)
+	require.Equal(t, "VCALENDAR", cal.Name)
+
+	vevent := masterEvent(cal)
+	require.NotNil(t, vevent)
+	require.Equal(t, "Planning, Q2", vevent.text("SUMMARY"))
+	require.Equal(t, "First line\nSecond line that is long enough to be folded by the server", vevent.text("DESCRIPTION"))
+
+	attendees := vevent.props("ATTENDEE")
+	require.Len(t, attendees, 2)
+	require.Equal(t, "Doe, Jane", attendees[0].param("CN"))
+	require.Equal(t, "mailto:user@example.com", attendees[0].Value)
+
+	_, err = parseICal("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n")
+	require.Error(t, err)
+}
+
+func TestICalRoundTrip(t *testing.T) {
+	cal, err := parseICal(testEventICS)
+	require.NoError(t, err)
+
+	out := cal.String()
+	for _, line := range strings.Split(out, "\r\n") {
+		require.LessOrEqual(t, len(line), icalLineLength+1)
+	}
+
+	reparsed, err := parseICal(out)
+	require.NoError(t, err)
+	require.Equal(t, cal, reparsed)
+	require.Equal(t, "kept", masterEvent(reparsed).prop("X-UNKNOWN").Value)
+}
+
+func TestParseICalDuration(t *testing.T) {
+	for in, expected := range map[string]time.Duration{
+		"PT15M":    15 * time.Minute,
+		"-PT15M":   -15 * time.Minute,
+		"P1D":      24 * time.Hour,
+		"P1W":      7 * 24 * time.Hour,
+		"-P1DT2H":  -26 * time.Hour,
+		"+PT1H30M": 90 * time.Minute,
+	} {
+		d, err := parseICalDuration(in)
+		require.NoError(t, err, in)
+		require.Equal(t, expected, d, in)
+	}
+
+	_, err := parseICalDuration("15M")
+	require.Error(t, err)
+}
+
+func TestToRemoteEvent(t *testing.T) {
+	events, err := toRemoteEvents(testEventICS, "/calendars/user/personal/event.ics", "user@example.com")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	e := events[0]
+	require.Equal(t, "/calendars/user/personal/event.ics", e.ID)
+	require.Equal(t, "event-uid", e.ICalUID)
+	require.Equal(t, "Planning, Q2", e.Subject)
+	require.Equal(t, "Room 1", e.Location.DisplayName)
+	require.Equal(t, "Europe/Berlin", e.Start.TimeZone)
+	require.Equal(t, "2024-03-04T10:00:00", e.Start.DateTime)
+	require.True(t, time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC).Equal(e.End.Time()))
+	require.Equal(t, remote.EventResponseStatusNotAnswered, e.ResponseStatus.Response)
+	require.True(t, e.ResponseRequested)
+	require.False(t, e.IsOrganizer)
+	require.Equal(t, "organizer@example.com", e.Organizer.EmailAddress.Address)
+	require.Equal(t, 15, e.ReminderMinutesBeforeStart)
+	require.Len(t, e.Attendees, 2)
+	require.Equal(t, "optional", e.Attendees[1].Type)
+	require.Equal(t, remote.EventResponseStatusAccepted, e.Attendees[1].Status.Response)
+}
+
+func TestSetPartstat(t *testing.T) {
+	cal, err := parseICal(testEventICS)
+	require.NoError(t, err)
+
+	require.True(t, setPartstat(cal, "USER@example.com", PartstatAccepted))
+	require.False(t, setPartstat(cal, "nobody@example.com", PartstatAccepted))
+
+	attendee := masterEvent(cal).props("ATTENDEE")[0]
+	require.Equal(t, PartstatAccepted, attendee.param("PARTSTAT"))
+	require.Empty(t, attendee.param("RSVP"))
+	require.Contains(t, cal.String(), `ATTENDEE;CN="Doe, Jane";PARTSTAT=ACCEPTED:mailto:user@example.com`)
+}
+
+func TestFromRemoteEvent(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	in := &remote.Event{
+		Subject:                    "Planning; Q2",
+		Body:                       &remote.ItemBody{Content: "Agenda"},
+		Start:                      remote.NewDateTime(start, "UTC"),
+		End:                        remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		ReminderMinutesBeforeStart: 10,
+		Attendees: []*remote.Attendee{
+			{EmailAddress: &remote.EmailAddress{Address: "other@example.com", Name: "Other"}},
+		},
+	}
+
+	cal := fromRemoteEvent(in, "event-uid", &principal{Address: "user@example.com", DisplayName: "User"})
+	vevent := masterEvent(cal)
+	require.Equal(t, "event-uid", vevent.text("UID"))
+	require.Equal(t, "Planning; Q2", vevent.text("SUMMARY"))
+	require.Equal(t, "20240304T090000Z", vevent.prop("DTSTART").Value)
+	require.Equal(t, "20240304T100000Z", vevent.prop("DTEND").Value)
+	require.Equal(t, "mailto:user@example.com", vevent.prop("ORGANIZER").Value)
+	require.Equal(t, PartstatNeedsAction, vevent.prop("ATTENDEE").param("PARTSTAT"))
+	require.Equal(t, "-PT10M", vevent.children("VALARM")[0].prop("TRIGGER").Value)
+
+	out := toRemoteEvent(vevent, "href", "user@example.com")
+	require.True(t, out.IsOrganizer)
+	require.Equal(t, remote.EventResponseStatusAccepted, out.ResponseStatus.Response)
+	require.Equal(t, 10, out.ReminderMinutesBeforeStart)
+
+	in.IsAllDay = true
+	vevent = masterEvent(fromRemoteEvent(in, "event-uid", &principal{Address: "user@example.com"}))
+	require.Equal(t, "20240304", vevent.prop("DTSTART").Value)
+	require.Equal(t, "DATE", vevent.prop("DTSTART").param("VALUE"))
+}
+
+func TestRecurringEvent(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	recurrence, err := remote.NewPatternedRecurrence(remote.FrequencyWeekdays, 1, start)
+	require.NoError(t, err)
+	recurrence.EndBy("2024-03-29")
+
+	cal := fromRemoteEvent(&remote.Event{
+		Subject:    "Standup",
+		Start:      remote.NewDateTime(start, "UTC"),
+		End:        remote.NewDateTime(start.Add(15*time.Minute), "UTC"),
+		Recurrence: recurrence,
+	}, "event-uid", &principal{Address: "user@example.com"})
+	require.Contains(t, cal.String(), "RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20240329T235959Z\r\n")
+
+	master := toRemoteEvent(masterEvent(cal), "href", "user@example.com")
+	require.Equal(t, remote.EventTypeSeriesMaster, master.Type)
+	require.Equal(t, recurrence, master.Recurrence)
+
+	// Instances expanded by the server have no RRULE.
+	instance := strings.Replace(testEventICS, "UID:event-uid\r\n", "UID:event-uid\r\nRECURRENCE-ID:20240304T090000Z\r\n", 1)
+	events, err := toRemoteEvents(instance, "href", "user@example.com")
+	require.NoError(t, err)
+	require.Equal(t, remote.EventTypeOccurrence, events[0].Type)
+	require.Equal(t, "href", events[0].SeriesMasterID)
+	require.Equal(t, "2024-03-04T09:00:00Z", events[0].OriginalStart)
+	require.Equal(t, "event-uid_20240304T090000Z", events[0].OccurrenceKey())
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const Kind = "caldav"
+
+// OAuth2 endpoints, relative to the server root. These follow the Nextcloud
+// layout; other servers need an OAuth2 proxy exposing the same paths.
+const (
+	authPath  = "/index.php/apps/oauth2/authorize"
+	tokenPath = "/index.php/apps/oauth2/api/v1/token"
+)
+
+// impl talks to the CalDAV server configured as the OAuth2 authority, e.g.
+// https://cloud.example.com/remote.php/dav. Requests are authorized with the
+// user's OAuth2 bearer token.
+type impl struct {
+	conf   *config.Config
+	logger bot.Logger
+}
+
+func init() {
+	remote.Makers[Kind] = NewRemote
+}
+
+func NewRemote(conf *config.Config, logger bot.Logger) remote.Remote {
+	return &impl{
+		conf:   conf,
+		logger: logger,
+	}
+}
+
+// makeClient creates a new client for user-delegated permissions.
+func (r *impl) makeClient(ctx context.Context, token *oauth2.Token, mattermostUserID string, poster bot.Poster, userTokenHelpers remote.UserTokenHelpers) remote.Client {
+	c := &client{
+		conf:             r.conf,
+		ctx:              ctx,
+		httpClient:       r.NewOAuth2Config().Client(ctx, token),
+		serverURL:        r.conf.OAuth2Authority,
+		Logger:           r.logger,
+		tokenHelpers:     userTokenHelpers,
+		mattermostUserID: mattermostUserID,
+		Poster:           poster,
+		principals:       map[string]*principal{},
+	}
+
+	return c
+}
+
+// MakeUserClient creates a new client having user-delegated permissions with refreshed token.
+func (r *impl) MakeUserClient(ctx context.Context, oauthToken *oauth2.Token, mattermostUserID string, poster bot.Poster, userTokenHelpers remote.UserTokenHelpers) remote.Client {
+	config := r.NewOAuth2Config()
+
+	token, err := userTokenHelpers.RefreshAndStoreToken(oauthToken, config, mattermostUserID)
+	if err != nil {
+		r.logger.Warnf("Not able to refresh or store the token", "error", err.Error())
+		return &client{}
+	}
+
+	return r.makeClient(ctx, token, mattermostUserID, poster, userTokenHelpers)
+}
+
+// MakeSuperuserClient is not supported: CalDAV has no notion of app-only access.
+func (r *impl) MakeSuperuserClient(_ context.Context) (remote.Client, error) {
+	return nil, remote.ErrSuperUserClientNotSupported
+}
+
+func (r *impl) NewOAuth2Config() *oauth2.Config {
+	root := serverRoot(r.conf.OAuth2Authority)
+	return &oauth2.Config{
+		ClientID:     r.conf.OAuth2ClientID,
+		ClientSecret: r.conf.OAuth2ClientSecret,
+		RedirectURL:  r.conf.PluginURL + config.FullPathOAuth2Redirect,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  root + authPath,
+			TokenURL: root + tokenPath,
+		},
+	}
+}
+
+// HandleWebhook is not used: CalDAV servers don't push notifications, changes
+// are fetched by polling the subscriptions instead.
+func (r *impl) HandleWebhook(w http.ResponseWriter, _ *http.Request) []*remote.Notification {
+	w.WriteHeader(http.StatusNotImplemented)
+	r.logger.Debugf("caldav: ignored webhook, notifications are polled.")
+	return nil
+}
+
+func (r *impl) CheckConfiguration(cfg config.StoredConfig) error {
+	if cfg.OAuth2ClientID == "" || cfg.OAuth2ClientSecret == "" || cfg.OAuth2Authority == "" {
+		return fmt.Errorf("OAuth2 credentials to be set in the config")
+	}
+
+	u, err := url.Parse(cfg.OAuth2Authority)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("OAuth2 authority must be the URL of the CalDAV server")
+	}
+
+	return nil
+}
+
+// serverRoot returns the scheme and host of the given URL.
+func serverRoot(serverURL string) string {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(serverURL, "/")
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package caldav
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// subscribeTTL only drives the regular renewal by the engine, polled
+// subscriptions don't expire on the server.
+const subscribeTTL = 48 * time.Hour
+
+// CreateMySubscription starts tracking changes of the user's default
+// calendar. Nothing is registered on the server: the subscription holds the
+// sync token of the calendar, and changes are fetched by PollSubscription.
+func (c *client) CreateMySubscription(notificationURL, remoteUserID string) (*remote.Subscription, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(remoteUserID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav CreateMySubscription")
+	}
+
+	syncToken, err := c.getSyncToken(p.DefaultCalendar)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "caldav CreateMySubscription")
+	}
+
+	sub := &remote.Subscription{
+		ID:                 model.NewId(),
+		Resource:           p.DefaultCalendar,
+		ChangeType:         "created,updated,deleted",
+		NotificationURL:    notificationURL,
+		ExpirationDateTime: time.Now().Add(subscribeTTL).Format(time.RFC3339),
+		CreatorID:          remoteUserID,
+		SyncToken:          syncToken,
+	}
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"resource":           sub.Resource,
+		"changeType":         sub.ChangeType,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("caldav: created subscription.")
+
+	return sub, nil
+}
+
+// DeleteSubscription has nothing to release on the server.
+func (c *client) DeleteSubscription(sub *remote.Subscription) error {
+	c.Logger.With(bot.LogContext{
+		"subscriptionID": sub.ID,
+	}).Debugf("caldav: deleted subscription.")
+
+	return nil
+}
+
+// RenewSubscription only moves the expiry of the subscription, the engine
+// stores it without touching the sync state of the stored subscription.
+func (c *client) RenewSubscription(_, _ string, oldSub *remote.Subscription) (*remote.Subscription, error) {
+	sub := *oldSub
+	sub.ExpirationDateTime = time.Now().Add(subscribeTTL).Format(time.RFC3339)
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("caldav: renewed subscription.")
+
+	return &sub, nil
+}
+
+// ListSubscriptions is not supported: subscriptions only exist in the plugin.
+func (c *client) ListSubscriptions() ([]*remote.Subscription, error) {
+	return nil, remote.ErrNotImplemented
+}
+
+// GetNotificationData returns the notification as is: polled notifications
+// already carry their event.
+func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notification, error) {
+	if orig.Event == nil {
+		return nil, errors.New("caldav GetNotificationData: notification has no event")
+	}
+	n := *orig
+	n.IsBare = false
+	return &n, nil
+}
+
+// PollSubscription fetches the events changed since the last poll, using a
+// WebDAV sync-collection report, which reports removed members as not found.
+// If the server no longer accepts the sync token, tracking restarts from the
+// current state without notifications.
+func (c *client) PollSubscription(sub *remote.Subscription) ([]*remote.Notification, *remote.Subscription, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, nil, errors.New(ErrorUserInactive)
+	}
+
+	p, err := c.getPrincipal(sub.CreatorID)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, nil, errors.Wrap(err, "caldav PollSubscription")
+	}
+
+	updated := *sub
+	ms, err := c.davRequest(methodReport, sub.Resource, "1", syncCollectionBody(sub.SyncToken, true))
+	if isStatus(err, http.StatusForbidden) || isStatus(err, http.StatusConflict) {
+		c.Logger.With(bot.LogContext{
+			"subscriptionID": sub.ID,
+		}).Infof("caldav: sync token expired, restarting sync.")
+		updated.SyncToken, err = c.getSyncToken(sub.Resource)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "caldav PollSubscription")
+		}
+		return nil, &updated, nil
+	}
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, nil, errors.Wrap(err, "caldav PollSubscription")
+	}
+	updated.SyncToken = ms.SyncToken
+
+	notifications := []*remote.Notification{}
+	for _, r := range ms.Responses {
+		if r.notFound() {
+			notifications = append(notifications, &remote.Notification{
+				SubscriptionID: sub.ID,
+				ChangeType:     remote.ChangeTypeDeleted,
+				ClientState:    sub.ClientState,
+				Event:          &remote.Event{ID: r.Href},
+			})
+			continue
+		}
+		if !r.found() {
+			continue
+		}
+
+		data := r.prop().CalendarData
+		if data == "" {
+			continue
+		}
+		cal, err := parseICal(data)
+		if err != nil {
+			c.Logger.Warnf("caldav: failed to parse calendar object %s: %v", r.Href, err)
+			continue
+		}
+		vevent := masterEvent(cal)
+		if vevent == nil {
+			continue
+		}
+
+		notifications = append(notifications, &remote.Notification{
+			SubscriptionID: sub.ID,
+			ChangeType:     changeType(vevent),
+			ClientState:    sub.ClientState,
+			Event:          toRemoteEvent(vevent, r.Href, p.Address),
+			WebhookRawData: []byte(data),
+		})
+	}
+
+	return notifications, &updated, nil
+}
+
+// changeType tells how the event changed. Sync reports don't tell created
+// members apart, an event whose creation is its last modification is taken
+// as created.
+func changeType(vevent *icalComponent) string {
+	created := vevent.text("CREATED")
+	if created != "" && created == vevent.text("LAST-MODIFIED") {
+		return remote.ChangeTypeCreated
+	}
+	return remote.ChangeTypeUpdated
+}
+
+// getSyncToken returns the current sync token of the calendar, with an
+// initial sync-collection report.
+func (c *client) getSyncToken(calendarHref string) (string, error) {
+	ms, err := c.davRequest(methodReport, calendarHref, "1", syncCollectionBody("", false))
+	if err != nil {
+		return "", err
+	}
+	if ms.SyncToken == "" {
+		return "", errors.Errorf("calendar %s does not support sync", calendarHref)
+	}
+	return ms.SyncToken, nil
+}
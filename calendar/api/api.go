@@ -28,6 +28,7 @@ func Init(h *httputils.Handler, env engine.Env, notificationProcessor engine.Not
 
 	notificationRouter := h.Router.PathPrefix(config.PathNotification).Subrouter()
 	notificationRouter.HandleFunc(config.PathEvent, api.notification).Methods(http.MethodPost)
+	notificationRouter.HandleFunc(config.PathLifecycle, api.notification).Methods(http.MethodPost)
 
 	postActionRouter := h.Router.PathPrefix(config.PathPostAction).Subrouter()
 	postActionRouter.HandleFunc(config.PathAccept, api.postActionAccept).Methods(http.MethodPost)
@@ -35,14 +36,28 @@ func Init(h *httputils.Handler, env engine.Env, notificationProcessor engine.Not
 	postActionRouter.HandleFunc(config.PathTentative, api.postActionTentative).Methods(http.MethodPost)
 	postActionRouter.HandleFunc(config.PathRespond, api.postActionRespond).Methods(http.MethodPost)
 	postActionRouter.HandleFunc(config.PathConfirmStatusChange, api.postActionConfirmStatusChange).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathFindTime, api.postActionFindTime).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathMeetingPollVote, api.postActionMeetingPollVote).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathMeetingPollFinalize, api.postActionMeetingPollFinalize).Methods(http.MethodPost)
+	postActionRouter.HandleFunc(config.PathReminder, api.postActionReminder).Methods(http.MethodPost)
 
 	dialogRouter := h.Router.PathPrefix(config.PathAutocomplete).Subrouter()
 	dialogRouter.HandleFunc(config.PathUsers, api.autocompleteConnectedUsers)
+	dialogRouter.HandleFunc(config.PathEvents, api.autocompleteUpcomingEvents).Methods(http.MethodGet)
+	dialogRouter.HandleFunc(config.PathEvents+config.PathLinked, api.autocompleteChannelLinkedEvents).Methods(http.MethodGet)
+
+	eventDialogRouter := h.Router.PathPrefix(config.PathDialogs + config.PathEvents).Subrouter()
+	eventDialogRouter.HandleFunc(config.PathCreate, api.submitCreateEventDialog).Methods(http.MethodPost)
 
 	apiRoutes := h.Router.PathPrefix(config.InternalAPIPath).Subrouter()
 	eventsRouter := apiRoutes.PathPrefix(config.PathEvents).Subrouter()
 	eventsRouter.HandleFunc(config.PathCreate, api.createEvent).Methods(http.MethodPost)
+	eventsRouter.HandleFunc(config.PathUpdate, api.updateEvent).Methods(http.MethodPost)
+	eventsRouter.HandleFunc(config.PathCancel, api.cancelEvent).Methods(http.MethodPost)
+	eventsRouter.HandleFunc(config.PathDelete, api.deleteEvent).Methods(http.MethodPost)
 	apiRoutes.HandleFunc(config.PathConnectedUser, api.connectedUserHandler)
+	apiRoutes.HandleFunc(config.PathSetAutoRespondMessage, api.setAutoRespondMessage).Methods(http.MethodPost)
+	apiRoutes.HandleFunc(config.PathAvailability, api.getAvailability).Methods(http.MethodPost)
 
 	// Returns provider information for the plugin to use
 	apiRoutes.HandleFunc(config.PathProvider, func(w http.ResponseWriter, r *http.Request) {
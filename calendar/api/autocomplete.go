@@ -7,13 +7,26 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
 )
 
+const (
+	// eventAutocompleteLookahead is how far ahead events are suggested.
+	eventAutocompleteLookahead = 14 * 24 * time.Hour
+
+	autocompleteEventTimeFormat = "2006-01-02 15:04"
+)
+
 func (api *api) autocompleteConnectedUsers(w http.ResponseWriter, r *http.Request) {
 	mattermostUserID := r.Header.Get("Mattermost-User-Id")
 	_, err := api.Store.LoadUser(mattermostUserID)
@@ -37,3 +50,65 @@ func (api *api) autocompleteConnectedUsers(w http.ResponseWriter, r *http.Reques
 		httputils.WriteInternalServerError(w, err)
 	}
 }
+
+// autocompleteUpcomingEvents lists the upcoming events of the user, for the
+// argument of the link command.
+func (api *api) autocompleteUpcomingEvents(w http.ResponseWriter, r *http.Request) {
+	api.autocompleteEvents(w, r, func(mscal engine.Engine, user *engine.User, from, to time.Time) ([]*remote.Event, error) {
+		return mscal.ViewCalendar(user, from, to)
+	})
+}
+
+// autocompleteChannelLinkedEvents lists the upcoming events of the user that
+// are linked to the channel the command is run in, for the argument of the
+// unlink command.
+func (api *api) autocompleteChannelLinkedEvents(w http.ResponseWriter, r *http.Request) {
+	channelID := r.URL.Query().Get("channel_id")
+	api.autocompleteEvents(w, r, func(mscal engine.Engine, user *engine.User, from, to time.Time) ([]*remote.Event, error) {
+		if channelID == "" {
+			return nil, nil
+		}
+		return mscal.GetChannelLinkedEvents(user, channelID, from, to)
+	})
+}
+
+func (api *api) autocompleteEvents(w http.ResponseWriter, r *http.Request, getEvents func(mscal engine.Engine, user *engine.User, from, to time.Time) ([]*remote.Event, error)) {
+	mattermostUserID := r.Header.Get("Mattermost-User-Id")
+	if mattermostUserID == "" {
+		httputils.WriteUnauthorizedError(w, fmt.Errorf("unauthorized"))
+		return
+	}
+
+	user := engine.NewUser(mattermostUserID)
+	mscal := engine.New(api.Env, mattermostUserID)
+	timezone, err := mscal.GetTimezone(user)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "userID": mattermostUserID}).Errorf("autocompleteEvents, error occurred while getting the timezone of the user")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	now := time.Now()
+	events, err := getEvents(mscal, user, now, now.Add(eventAutocompleteLookahead))
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "userID": mattermostUserID}).Errorf("autocompleteEvents, error occurred while loading the events of the user")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	items := []model.AutocompleteListItem{}
+	for _, event := range events {
+		if event.IsCancelled || !event.End.Time().After(now) {
+			continue
+		}
+		items = append(items, model.AutocompleteListItem{
+			Item:     event.ID,
+			Hint:     views.EnsureSubject(event.Subject),
+			HelpText: event.Start.In(timezone).Time().Format(autocompleteEventTimeFormat) + " - " + event.End.In(timezone).Time().Format("15:04"),
+		})
+	}
+
+	if err := httputils.WriteJSONResponse(w, items, http.StatusOK); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("error sending response to user")
+	}
+}
@@ -10,10 +10,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -81,3 +84,44 @@ func TestAutocompleteConnectedUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestAutocompleteUpcomingEvents(t *testing.T) {
+	api, mockStore, _, mockRemote, mockPluginAPI, _, _, mockClient := GetMockSetup(t)
+
+	t.Run("Unauthorized user", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/autocomplete/events", nil)
+		rec := httptest.NewRecorder()
+
+		api.autocompleteUpcomingEvents(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Result().StatusCode)
+	})
+
+	t.Run("Upcoming events", func(t *testing.T) {
+		start := time.Now().Add(time.Hour).UTC().Truncate(time.Minute)
+		mockStore.EXPECT().LoadUser(MockUserID).Return(&store.User{MattermostUserID: MockUserID, OAuth2Token: &oauth2.Token{}, Remote: &remote.User{ID: MockRemoteUserID}}, nil).AnyTimes()
+		mockPluginAPI.EXPECT().GetMattermostUser(MockUserID).Return(&model.User{Id: MockUserID}, nil).AnyTimes()
+		mockRemote.EXPECT().MakeUserClient(gomock.Any(), gomock.Any(), MockUserID, gomock.Any(), gomock.Any()).Return(mockClient).AnyTimes()
+		mockClient.EXPECT().GetMailboxSettings(MockRemoteUserID).Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil).Times(1)
+		mockClient.EXPECT().GetDefaultCalendarView(MockRemoteUserID, gomock.Any(), gomock.Any()).Return([]*remote.Event{
+			{ID: "cancelled_id", IsCancelled: true, Start: remote.NewDateTime(start, "UTC"), End: remote.NewDateTime(start.Add(time.Hour), "UTC")},
+			{ID: "event_id", Subject: "Design review", Start: remote.NewDateTime(start, "UTC"), End: remote.NewDateTime(start.Add(time.Hour), "UTC")},
+		}, nil).Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/autocomplete/events", nil)
+		req.Header.Set(MMUserIDHeader, MockUserID)
+		rec := httptest.NewRecorder()
+
+		api.autocompleteUpcomingEvents(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+		var items []model.AutocompleteListItem
+		err := json.NewDecoder(rec.Body).Decode(&items)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.AutocompleteListItem{{
+			Item:     "event_id",
+			Hint:     "Design review",
+			HelpText: start.Format("2006-01-02 15:04") + " - " + start.Add(time.Hour).Format("15:04"),
+		}}, items)
+	})
+}
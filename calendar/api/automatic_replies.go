@@ -0,0 +1,156 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+// autoRespondMessagePayload turns the automatic replies on or off. Without a
+// start and an end, enabled replies are sent until they are turned off.
+type autoRespondMessagePayload struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start,omitempty"`
+	End     string `json:"end,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (p autoRespondMessagePayload) IsValid(loc *time.Location) error {
+	if !p.Enabled {
+		return nil
+	}
+
+	if p.Message == "" {
+		return fmt.Errorf("message must not be empty")
+	}
+
+	if p.Start == "" && p.End == "" {
+		return nil
+	}
+	if p.Start == "" || p.End == "" {
+		return fmt.Errorf("start and end must be set together")
+	}
+
+	start, err := time.ParseInLocation(createEventDateTimeFormat, p.Start, loc)
+	if err != nil {
+		return fmt.Errorf("please use a valid start time")
+	}
+
+	end, err := time.ParseInLocation(createEventDateTimeFormat, p.End, loc)
+	if err != nil {
+		return fmt.Errorf("please use a valid end time")
+	}
+
+	if !end.After(start) {
+		return fmt.Errorf("end must be later than start")
+	}
+
+	if end.Before(time.Now()) {
+		return fmt.Errorf("please select an end date and time that is not prior to the current time")
+	}
+
+	return nil
+}
+
+func (p autoRespondMessagePayload) ToAutomaticRepliesSetting(loc *time.Location) (*remote.AutomaticRepliesSetting, error) {
+	if !p.Enabled {
+		return &remote.AutomaticRepliesSetting{Status: remote.AutomaticRepliesStatusDisabled}, nil
+	}
+
+	setting := &remote.AutomaticRepliesSetting{
+		Status:               remote.AutomaticRepliesStatusAlwaysEnabled,
+		InternalReplyMessage: p.Message,
+		ExternalReplyMessage: p.Message,
+	}
+	if p.Start == "" {
+		return setting, nil
+	}
+
+	start, err := time.ParseInLocation(createEventDateTimeFormat, p.Start, loc)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing start time")
+	}
+	end, err := time.ParseInLocation(createEventDateTimeFormat, p.End, loc)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing end time")
+	}
+	setting.Status = remote.AutomaticRepliesStatusScheduled
+	setting.ScheduledStartDateTime = remote.NewDateTime(start, loc.String())
+	setting.ScheduledEndDateTime = remote.NewDateTime(end, loc.String())
+
+	return setting, nil
+}
+
+func (api *api) setAutoRespondMessage(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := api.authenticateConnectedUser(w, r, "setAutoRespondMessage")
+	if mattermostUserID == "" {
+		return
+	}
+
+	var payload autoRespondMessagePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("setAutoRespondMessage, error occurred while decoding payload")
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	user := engine.NewUser(mattermostUserID)
+
+	timezone, err := mscal.GetTimezone(user)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("setAutoRespondMessage, error occurred while getting user timezone")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "timezone": timezone}).Errorf("setAutoRespondMessage, error occurred while loading timezone location")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	if err = payload.IsValid(loc); err != nil {
+		api.Logger.Errorf("setAutoRespondMessage, invalid payload")
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	setting, err := payload.ToAutomaticRepliesSetting(loc)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("setAutoRespondMessage, error occurred while creating automatic replies from payload")
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	err = mscal.SetAutomaticReplies(user, setting)
+	if errors.Is(err, remote.ErrAutomaticRepliesNotSupported) {
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+	if errors.Is(err, remote.ErrAutomaticRepliesForbidden) {
+		httputils.WriteForbiddenError(w, err)
+		return
+	}
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("setAutoRespondMessage, error occurred while setting automatic replies")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, setting, http.StatusOK)
+}
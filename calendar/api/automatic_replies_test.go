@@ -0,0 +1,98 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestAutoRespondMessagePayload(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	start := time.Now().In(loc).Add(24 * time.Hour).Truncate(time.Minute)
+	end := start.Add(72 * time.Hour)
+
+	tests := []struct {
+		name       string
+		payload    autoRespondMessagePayload
+		assertions func(t *testing.T, setting *remote.AutomaticRepliesSetting, err error)
+	}{
+		{
+			name:    "Disabled",
+			payload: autoRespondMessagePayload{Enabled: false, Message: "ignored"},
+			assertions: func(t *testing.T, setting *remote.AutomaticRepliesSetting, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, &remote.AutomaticRepliesSetting{Status: remote.AutomaticRepliesStatusDisabled}, setting)
+			},
+		},
+		{
+			name:    "Missing message",
+			payload: autoRespondMessagePayload{Enabled: true},
+			assertions: func(t *testing.T, _ *remote.AutomaticRepliesSetting, err error) {
+				assert.ErrorContains(t, err, "message must not be empty")
+			},
+		},
+		{
+			name:    "Always enabled",
+			payload: autoRespondMessagePayload{Enabled: true, Message: "On vacation"},
+			assertions: func(t *testing.T, setting *remote.AutomaticRepliesSetting, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, remote.AutomaticRepliesStatusAlwaysEnabled, setting.Status)
+				assert.Equal(t, "On vacation", setting.InternalReplyMessage)
+				assert.Equal(t, "On vacation", setting.ExternalReplyMessage)
+				assert.Nil(t, setting.ScheduledStartDateTime)
+			},
+		},
+		{
+			name:    "Missing end",
+			payload: autoRespondMessagePayload{Enabled: true, Message: "On vacation", Start: start.Format(createEventDateTimeFormat)},
+			assertions: func(t *testing.T, _ *remote.AutomaticRepliesSetting, err error) {
+				assert.ErrorContains(t, err, "start and end must be set together")
+			},
+		},
+		{
+			name:    "Invalid start",
+			payload: autoRespondMessagePayload{Enabled: true, Message: "On vacation", Start: "tomorrow", End: end.Format(createEventDateTimeFormat)},
+			assertions: func(t *testing.T, _ *remote.AutomaticRepliesSetting, err error) {
+				assert.ErrorContains(t, err, "please use a valid start time")
+			},
+		},
+		{
+			name:    "End before start",
+			payload: autoRespondMessagePayload{Enabled: true, Message: "On vacation", Start: end.Format(createEventDateTimeFormat), End: start.Format(createEventDateTimeFormat)},
+			assertions: func(t *testing.T, _ *remote.AutomaticRepliesSetting, err error) {
+				assert.ErrorContains(t, err, "end must be later than start")
+			},
+		},
+		{
+			name:    "Scheduled",
+			payload: autoRespondMessagePayload{Enabled: true, Message: "On vacation", Start: start.Format(createEventDateTimeFormat), End: end.Format(createEventDateTimeFormat)},
+			assertions: func(t *testing.T, setting *remote.AutomaticRepliesSetting, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, remote.AutomaticRepliesStatusScheduled, setting.Status)
+				assert.True(t, start.Equal(setting.ScheduledStartDateTime.Time()))
+				assert.True(t, end.Equal(setting.ScheduledEndDateTime.Time()))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.IsValid(loc)
+			if err != nil {
+				tt.assertions(t, nil, err)
+				return
+			}
+			setting, err := tt.payload.ToAutomaticRepliesSetting(loc)
+			tt.assertions(t, setting, err)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	availabilityTimeFormat = "15:04"
+
+	defaultAvailabilityStartTime = "08:00"
+	defaultAvailabilityEndTime   = "20:00"
+	defaultAvailabilityInterval  = 15
+
+	// Graph accepts availability intervals from 5 minutes to a day.
+	minAvailabilityInterval = 5
+	maxAvailabilityInterval = 1440
+
+	// maxAvailabilityUsers is the number of schedules Graph returns at once.
+	maxAvailabilityUsers = 20
+)
+
+// availabilityPayload asks for the availability of Mattermost users on a day,
+// today by default, in the time zone of the requesting user.
+type availabilityPayload struct {
+	UserIDs   []string `json:"user_ids"`
+	Date      string   `json:"date,omitempty"`
+	StartTime string   `json:"start_time,omitempty"`
+	EndTime   string   `json:"end_time,omitempty"`
+	Interval  int      `json:"interval,omitempty"`
+}
+
+type availabilityResponse struct {
+	Start     time.Time          `json:"start"`
+	End       time.Time          `json:"end"`
+	Interval  int                `json:"interval"`
+	TimeZone  string             `json:"time_zone"`
+	Schedules []*engine.Schedule `json:"schedules"`
+}
+
+func (p *availabilityPayload) IsValid() error {
+	if len(p.UserIDs) == 0 {
+		return fmt.Errorf("user_ids must not be empty")
+	}
+	if len(p.UserIDs) > maxAvailabilityUsers {
+		return fmt.Errorf("at most %d users can be looked up at once", maxAvailabilityUsers)
+	}
+	if p.Interval != 0 && (p.Interval < minAvailabilityInterval || p.Interval > maxAvailabilityInterval) {
+		return fmt.Errorf("interval must be between %d and %d minutes", minAvailabilityInterval, maxAvailabilityInterval)
+	}
+
+	return nil
+}
+
+// TimeRange returns the times the availability is looked up between, filling
+// in the defaults.
+func (p *availabilityPayload) TimeRange(loc *time.Location, now time.Time) (start, end time.Time, interval time.Duration, err error) {
+	day := now.In(loc)
+	if p.Date != "" {
+		day, err = time.ParseInLocation(createEventDateFormat, p.Date, loc)
+		if err != nil {
+			return start, end, 0, fmt.Errorf("please use a valid date")
+		}
+	}
+
+	startTime, endTime := p.StartTime, p.EndTime
+	if startTime == "" {
+		startTime = defaultAvailabilityStartTime
+	}
+	if endTime == "" {
+		endTime = defaultAvailabilityEndTime
+	}
+
+	startClock, err := time.Parse(availabilityTimeFormat, startTime)
+	if err != nil {
+		return start, end, 0, fmt.Errorf("please use a valid start time")
+	}
+	endClock, err := time.Parse(availabilityTimeFormat, endTime)
+	if err != nil {
+		return start, end, 0, fmt.Errorf("please use a valid end time")
+	}
+
+	start = time.Date(day.Year(), day.Month(), day.Day(), startClock.Hour(), startClock.Minute(), 0, 0, loc)
+	end = time.Date(day.Year(), day.Month(), day.Day(), endClock.Hour(), endClock.Minute(), 0, 0, loc)
+	if !end.After(start) {
+		return start, end, 0, fmt.Errorf("end time must be later than start time")
+	}
+
+	minutes := p.Interval
+	if minutes == 0 {
+		minutes = defaultAvailabilityInterval
+	}
+
+	return start, end, time.Duration(minutes) * time.Minute, nil
+}
+
+func (api *api) getAvailability(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := api.authenticateConnectedUser(w, r, "getAvailability")
+	if mattermostUserID == "" {
+		return
+	}
+
+	var payload availabilityPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("getAvailability, error occurred while decoding payload")
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if err := payload.IsValid(); err != nil {
+		api.Logger.Errorf("getAvailability, invalid payload")
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	user := engine.NewUser(mattermostUserID)
+
+	timezone, err := mscal.GetTimezone(user)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("getAvailability, error occurred while getting user timezone")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "timezone": timezone}).Errorf("getAvailability, error occurred while loading timezone location")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	start, end, interval, err := payload.TimeRange(loc, time.Now())
+	if err != nil {
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	schedules, err := mscal.GetSchedules(user, payload.UserIDs, start, end, interval)
+	if errors.Is(err, remote.ErrNotImplemented) {
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("getAvailability, error occurred while getting schedules")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, availabilityResponse{
+		Start:     start,
+		End:       end,
+		Interval:  int(interval.Minutes()),
+		TimeZone:  timezone,
+		Schedules: schedules,
+	}, http.StatusOK)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestAvailabilityPayloadTimeRange(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 2, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		payload          availabilityPayload
+		expectedStart    time.Time
+		expectedEnd      time.Time
+		expectedInterval time.Duration
+		expectedError    string
+	}{
+		{
+			name:             "Defaults to today in the user's time zone",
+			payload:          availabilityPayload{UserIDs: []string{"user1"}},
+			expectedStart:    time.Date(2024, 5, 3, 8, 0, 0, 0, loc),
+			expectedEnd:      time.Date(2024, 5, 3, 20, 0, 0, 0, loc),
+			expectedInterval: 15 * time.Minute,
+		},
+		{
+			name:             "Custom range",
+			payload:          availabilityPayload{UserIDs: []string{"user1"}, Date: "2024-05-10", StartTime: "09:30", EndTime: "12:00", Interval: 30},
+			expectedStart:    time.Date(2024, 5, 10, 9, 30, 0, 0, loc),
+			expectedEnd:      time.Date(2024, 5, 10, 12, 0, 0, 0, loc),
+			expectedInterval: 30 * time.Minute,
+		},
+		{
+			name:          "Invalid date",
+			payload:       availabilityPayload{UserIDs: []string{"user1"}, Date: "10/05/2024"},
+			expectedError: "please use a valid date",
+		},
+		{
+			name:          "End before start",
+			payload:       availabilityPayload{UserIDs: []string{"user1"}, StartTime: "12:00", EndTime: "09:00"},
+			expectedError: "end time must be later than start time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, interval, err := tt.payload.TimeRange(loc, now)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.expectedStart.Equal(start), "start %v", start)
+			assert.True(t, tt.expectedEnd.Equal(end), "end %v", end)
+			assert.Equal(t, tt.expectedInterval, interval)
+		})
+	}
+}
+
+func TestAvailabilityPayloadIsValid(t *testing.T) {
+	assert.EqualError(t, (&availabilityPayload{}).IsValid(), "user_ids must not be empty")
+	assert.EqualError(t, (&availabilityPayload{UserIDs: make([]string, 21)}).IsValid(), "at most 20 users can be looked up at once")
+	assert.EqualError(t, (&availabilityPayload{UserIDs: []string{"user1"}, Interval: -5}).IsValid(), "interval must be between 5 and 1440 minutes")
+	assert.EqualError(t, (&availabilityPayload{UserIDs: []string{"user1"}, Interval: 1}).IsValid(), "interval must be between 5 and 1440 minutes")
+	assert.EqualError(t, (&availabilityPayload{UserIDs: []string{"user1"}, Interval: 1441}).IsValid(), "interval must be between 5 and 1440 minutes")
+	assert.NoError(t, (&availabilityPayload{UserIDs: []string{"user1"}, Interval: 5}).IsValid())
+	assert.NoError(t, (&availabilityPayload{UserIDs: []string{"user1"}, Interval: 1440}).IsValid())
+	assert.NoError(t, (&availabilityPayload{UserIDs: []string{"user1"}}).IsValid())
+}
+
+func TestGetAvailabilityInvalidInterval(t *testing.T) {
+	api, mockStore, _, _, _, mockLogger, _, _ := GetMockSetup(t)
+	mockStore.EXPECT().LoadUser(MockUserID).Return(&store.User{MattermostUserID: MockUserID}, nil).Times(1)
+	mockLogger.EXPECT().Errorf("getAvailability, invalid payload").Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/availability", bytes.NewBufferString(`{"user_ids": ["user1"], "interval": 1}`))
+	req.Header.Set(MMUserIDHeader, MockUserID)
+	rec := httptest.NewRecorder()
+
+	api.getAvailability(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "interval must be between 5 and 1440 minutes")
+}
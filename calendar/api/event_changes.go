@@ -0,0 +1,260 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+type updateEventPayload struct {
+	EventID     string `json:"event_id"`
+	Subject     string `json:"subject,omitempty"`
+	Description string `json:"description,omitempty"`
+	Location    string `json:"location,omitempty"`
+	Date        string `json:"date,omitempty"`
+	StartTime   string `json:"start_time,omitempty"`
+	EndTime     string `json:"end_time,omitempty"`
+}
+
+type cancelEventPayload struct {
+	EventID string `json:"event_id"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type deleteEventPayload struct {
+	EventID string `json:"event_id"`
+}
+
+func (uep updateEventPayload) reschedules() bool {
+	return uep.Date != "" || uep.StartTime != "" || uep.EndTime != ""
+}
+
+func (uep updateEventPayload) IsValid(loc *time.Location) error {
+	if uep.EventID == "" {
+		return fmt.Errorf("event_id must not be empty")
+	}
+
+	if !uep.reschedules() {
+		if uep.Subject == "" && uep.Description == "" && uep.Location == "" {
+			return fmt.Errorf("nothing to update")
+		}
+		return nil
+	}
+
+	if uep.Date == "" || uep.StartTime == "" || uep.EndTime == "" {
+		return fmt.Errorf("date, start time and end time must be set together")
+	}
+
+	start, err := time.ParseInLocation(createEventDateTimeFormat, fmt.Sprintf("%s %s", uep.Date, uep.StartTime), loc)
+	if err != nil {
+		return fmt.Errorf("please use a valid start time")
+	}
+
+	end, err := time.ParseInLocation(createEventDateTimeFormat, fmt.Sprintf("%s %s", uep.Date, uep.EndTime), loc)
+	if err != nil {
+		return fmt.Errorf("please use a valid end time")
+	}
+
+	if start.Before(time.Now()) {
+		return fmt.Errorf("please select a start date and time that is not prior to the current time")
+	}
+
+	if start.After(end) {
+		return fmt.Errorf("end date cannot be earlier than start date")
+	}
+
+	return nil
+}
+
+// ToRemoteEvent returns an event holding only the fields to change.
+func (uep updateEventPayload) ToRemoteEvent(loc *time.Location) (*remote.Event, error) {
+	evt := &remote.Event{
+		Subject: uep.Subject,
+	}
+
+	if uep.reschedules() {
+		start, err := time.ParseInLocation(createEventDateTimeFormat, fmt.Sprintf("%s %s", uep.Date, uep.StartTime), loc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing start time")
+		}
+		end, err := time.ParseInLocation(createEventDateTimeFormat, fmt.Sprintf("%s %s", uep.Date, uep.EndTime), loc)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing end time")
+		}
+		evt.Start = &remote.DateTime{
+			DateTime: start.Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		}
+		evt.End = &remote.DateTime{
+			DateTime: end.Format(remote.RFC3339NanoNoTimezone),
+			TimeZone: loc.String(),
+		}
+	}
+
+	if uep.Description != "" {
+		evt.Body = &remote.ItemBody{
+			Content:     uep.Description,
+			ContentType: "text/plain",
+		}
+	}
+	if uep.Location != "" {
+		evt.Location = &remote.Location{
+			DisplayName: uep.Location,
+		}
+	}
+
+	return evt, nil
+}
+
+// authenticateConnectedUser returns the ID of the Mattermost user making the
+// request, who must be connected. Nothing is returned when an error has
+// already been written.
+func (api *api) authenticateConnectedUser(w http.ResponseWriter, r *http.Request, handler string) string {
+	mattermostUserID := r.Header.Get("Mattermost-User-Id")
+	if mattermostUserID == "" {
+		api.Logger.Errorf("%s, unauthorized user", handler)
+		httputils.WriteUnauthorizedError(w, fmt.Errorf("unauthorized"))
+		return ""
+	}
+
+	_, errStore := api.Store.LoadUser(mattermostUserID)
+	if errStore != nil && !errors.Is(errStore, store.ErrNotFound) {
+		api.Logger.With(bot.LogContext{"err": errStore}).Errorf("%s, error occurred while loading user from store", handler)
+		httputils.WriteInternalServerError(w, errStore)
+		return ""
+	}
+	if errors.Is(errStore, store.ErrNotFound) {
+		api.Logger.With(bot.LogContext{"err": errStore.Error()}).Errorf("%s, user not found in store", handler)
+		httputils.WriteUnauthorizedError(w, fmt.Errorf("unauthorized"))
+		return ""
+	}
+
+	return mattermostUserID
+}
+
+// preprocessEventChange authenticates the request, decodes its payload and
+// checks that the event is organized by the user. Nothing is returned when an
+// error has already been written.
+func (api *api) preprocessEventChange(w http.ResponseWriter, r *http.Request, handler string, payload any, eventID func() string) (engine.Engine, *engine.User, *remote.Event) {
+	mattermostUserID := api.authenticateConnectedUser(w, r, handler)
+	if mattermostUserID == "" {
+		return nil, nil, nil
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("%s, error occurred while decoding event payload", handler)
+		httputils.WriteBadRequestError(w, err)
+		return nil, nil, nil
+	}
+	defer r.Body.Close()
+
+	if eventID() == "" {
+		httputils.WriteBadRequestError(w, fmt.Errorf("event_id must not be empty"))
+		return nil, nil, nil
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	user := engine.NewUser(mattermostUserID)
+
+	event, err := mscal.GetEvent(user, eventID())
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "eventID": eventID()}).Errorf("%s, error occurred while getting event", handler)
+		httputils.WriteNotFoundError(w, err)
+		return nil, nil, nil
+	}
+	if !event.IsOrganizer {
+		httputils.WriteUnauthorizedError(w, fmt.Errorf("only the organizer can change this event"))
+		return nil, nil, nil
+	}
+
+	return mscal, user, event
+}
+
+func (api *api) updateEvent(w http.ResponseWriter, r *http.Request) {
+	var payload updateEventPayload
+	mscal, user, _ := api.preprocessEventChange(w, r, "updateEvent", &payload, func() string { return payload.EventID })
+	if mscal == nil {
+		return
+	}
+
+	timezone, err := mscal.GetTimezone(user)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("updateEvent, error occurred while getting user timezone")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "timezone": timezone}).Errorf("updateEvent, error occurred while loading timezone location")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	if err = payload.IsValid(loc); err != nil {
+		api.Logger.Errorf("updateEvent, invalid payload")
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	update, err := payload.ToRemoteEvent(loc)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("updateEvent, error occurred while creating remote event from payload")
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	event, err := mscal.UpdateEvent(user, payload.EventID, update)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("updateEvent, error occurred while updating event")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, event, http.StatusOK)
+}
+
+func (api *api) cancelEvent(w http.ResponseWriter, r *http.Request) {
+	var payload cancelEventPayload
+	mscal, user, _ := api.preprocessEventChange(w, r, "cancelEvent", &payload, func() string { return payload.EventID })
+	if mscal == nil {
+		return
+	}
+
+	if err := mscal.CancelEvent(user, payload.EventID, payload.Comment); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("cancelEvent, error occurred while cancelling event")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, `{"ok": true}`, http.StatusOK)
+}
+
+func (api *api) deleteEvent(w http.ResponseWriter, r *http.Request) {
+	var payload deleteEventPayload
+	mscal, user, _ := api.preprocessEventChange(w, r, "deleteEvent", &payload, func() string { return payload.EventID })
+	if mscal == nil {
+		return
+	}
+
+	if err := mscal.DeleteEvent(user, payload.EventID); err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("deleteEvent, error occurred while deleting event")
+		httputils.WriteInternalServerError(w, err)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, `{"ok": true}`, http.StatusOK)
+}
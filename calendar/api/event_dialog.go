@@ -0,0 +1,134 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+// submitCreateEventDialog creates the event submitted with the dialog opened
+// by `event create`. Errors are shown in the dialog, which stays open.
+func (api *api) submitCreateEventDialog(w http.ResponseWriter, r *http.Request) {
+	mattermostUserID := r.Header.Get("Mattermost-User-Id")
+	if mattermostUserID == "" {
+		httputils.WriteUnauthorizedError(w, fmt.Errorf("unauthorized"))
+		return
+	}
+
+	var request model.SubmitDialogRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if request.Cancelled {
+		httputils.WriteJSONResponse(w, model.SubmitDialogResponse{}, http.StatusOK)
+		return
+	}
+
+	payload := createEventPayload{
+		Subject:     dialogSubmissionString(request.Submission, "subject"),
+		Date:        dialogSubmissionString(request.Submission, "date"),
+		StartTime:   dialogSubmissionString(request.Submission, "start_time"),
+		EndTime:     dialogSubmissionString(request.Submission, "end_time"),
+		Location:    dialogSubmissionString(request.Submission, "location"),
+		ChannelID:   dialogSubmissionString(request.Submission, "channel_id"),
+		Description: dialogSubmissionString(request.Submission, "description"),
+		// The element is only in the dialog of providers supporting online
+		// meetings.
+		OnlineMeeting: dialogSubmissionBool(request.Submission, "online_meeting") && api.Provider.Features.OnlineMeetings,
+	}
+
+	var mattermostUserIDs []string
+	for _, attendee := range strings.Fields(strings.ReplaceAll(dialogSubmissionString(request.Submission, "attendees"), ",", " ")) {
+		if !strings.HasPrefix(attendee, "@") && strings.Contains(attendee, "@") {
+			payload.Attendees = append(payload.Attendees, attendee)
+			continue
+		}
+		mattermostUser, err := api.PluginAPI.GetMattermostUserByUsername(strings.TrimPrefix(attendee, "@"))
+		if err != nil {
+			httputils.WriteJSONResponse(w, model.SubmitDialogResponse{
+				Errors: map[string]string{"attendees": fmt.Sprintf("User %s not found", attendee)},
+			}, http.StatusOK)
+			return
+		}
+		mattermostUserIDs = append(mattermostUserIDs, mattermostUser.Id)
+	}
+
+	user := engine.NewUser(mattermostUserID)
+	mscal := engine.New(api.Env, mattermostUserID)
+	timezone, err := mscal.GetTimezone(user)
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "userID": mattermostUserID}).Errorf("submitCreateEventDialog, error occurred while getting the timezone of the user")
+		httputils.WriteJSONResponse(w, model.SubmitDialogResponse{Error: "Failed to get your timezone: " + err.Error()}, http.StatusOK)
+		return
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	if err = payload.IsValid(loc); err != nil {
+		httputils.WriteJSONResponse(w, model.SubmitDialogResponse{Error: err.Error()}, http.StatusOK)
+		return
+	}
+
+	event, err := payload.ToRemoteEvent(loc)
+	if err != nil {
+		httputils.WriteJSONResponse(w, model.SubmitDialogResponse{Error: err.Error()}, http.StatusOK)
+		return
+	}
+	for _, mail := range payload.Attendees {
+		event.Attendees = append(event.Attendees, &remote.Attendee{
+			EmailAddress: &remote.EmailAddress{Address: mail},
+		})
+	}
+
+	_, err = mscal.ScheduleEvent(user, event, mattermostUserIDs, payload.ChannelID)
+	if errors.Is(err, engine.ErrChannelLinkNotPermitted) {
+		httputils.WriteJSONResponse(w, model.SubmitDialogResponse{
+			Errors: map[string]string{"channel_id": "You don't have permission to link events in the selected channel"},
+		}, http.StatusOK)
+		return
+	}
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error(), "userID": mattermostUserID}).Errorf("submitCreateEventDialog, error occurred while creating event")
+		httputils.WriteJSONResponse(w, model.SubmitDialogResponse{Error: "Failed to create the event: " + err.Error()}, http.StatusOK)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, model.SubmitDialogResponse{}, http.StatusOK)
+}
+
+func dialogSubmissionString(submission map[string]any, name string) string {
+	value, _ := submission[name].(string)
+	return strings.TrimSpace(value)
+}
+
+// dialogSubmissionBool reads a bool element, submitted as a boolean or as
+// "true" depending on the client.
+func dialogSubmissionBool(submission map[string]any, name string) bool {
+	switch value := submission[name].(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSubmitCreateEventDialog(t *testing.T) {
+	api, mockStore, _, mockRemote, mockPluginAPI, mockLogger, mockLoggerWith, mockClient := GetMockSetup(t)
+	api.Config = &config.Config{Provider: config.ProviderConfig{Features: config.ProviderFeatures{OnlineMeetings: true}}}
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	submission := func() map[string]any {
+		return map[string]any{
+			"subject":    "Design review",
+			"date":       tomorrow,
+			"start_time": "14:00",
+			"end_time":   "15:00",
+			"attendees":  "@alice",
+			"channel_id": MockChannelID,
+		}
+	}
+	expectTimezone := func() {
+		mockOAuthToken := oauth2.Token{}
+		mockStore.EXPECT().LoadUser(MockUserID).Return(&store.User{MattermostUserID: MockUserID, OAuth2Token: &mockOAuthToken, Remote: &remote.User{ID: MockRemoteUserID}}, nil).AnyTimes()
+		mockPluginAPI.EXPECT().GetMattermostUser(MockUserID).Return(&model.User{Id: MockUserID}, nil).AnyTimes()
+		mockRemote.EXPECT().MakeUserClient(gomock.Any(), gomock.Any(), MockUserID, gomock.Any(), gomock.Any()).Return(mockClient).Times(1)
+		mockClient.EXPECT().GetMailboxSettings(MockRemoteUserID).Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil).Times(1)
+	}
+
+	tests := []struct {
+		name       string
+		submission func() map[string]any
+		cancelled  bool
+		setup      func()
+		expected   model.SubmitDialogResponse
+	}{
+		{
+			name:       "Cancelled",
+			submission: submission,
+			cancelled:  true,
+			setup:      func() {},
+			expected:   model.SubmitDialogResponse{},
+		},
+		{
+			name:       "Unknown attendee",
+			submission: submission,
+			setup: func() {
+				mockPluginAPI.EXPECT().GetMattermostUserByUsername("alice").Return(nil, errors.New("not found")).Times(1)
+			},
+			expected: model.SubmitDialogResponse{Errors: map[string]string{"attendees": "User @alice not found"}},
+		},
+		{
+			name: "Invalid payload",
+			submission: func() map[string]any {
+				s := submission()
+				s["subject"] = ""
+				s["attendees"] = "carol@example.com"
+				return s
+			},
+			setup:    expectTimezone,
+			expected: model.SubmitDialogResponse{Error: "subject must not be empty"},
+		},
+		{
+			name:       "Not permitted to link the channel",
+			submission: submission,
+			setup: func() {
+				expectTimezone()
+				mockPluginAPI.EXPECT().GetMattermostUserByUsername("alice").Return(&model.User{Id: "alice_id"}, nil).Times(1)
+				mockPluginAPI.EXPECT().CanLinkEventToChannel(MockChannelID, MockUserID).Return(false).Times(1)
+			},
+			expected: model.SubmitDialogResponse{Errors: map[string]string{"channel_id": "You don't have permission to link events in the selected channel"}},
+		},
+		{
+			name: "Online meeting",
+			submission: func() map[string]any {
+				s := submission()
+				s["attendees"] = "carol@example.com"
+				s["channel_id"] = ""
+				s["online_meeting"] = true
+				return s
+			},
+			setup: func() {
+				expectTimezone()
+				mockClient.EXPECT().CreateEvent(MockRemoteUserID, gomock.Any()).DoAndReturn(func(_ string, event *remote.Event) (*remote.Event, error) {
+					assert.True(t, event.IsOnlineMeeting)
+					assert.Equal(t, remote.OnlineMeetingProviderTeams, event.OnlineMeetingProvider)
+					return nil, errors.New("some error")
+				}).Times(1)
+				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
+				mockLoggerWith.EXPECT().Errorf(gomock.Any()).Times(1)
+			},
+			expected: model.SubmitDialogResponse{Error: "Failed to create the event: some error"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.setup()
+
+			body, _ := json.Marshal(model.SubmitDialogRequest{Submission: tc.submission(), Cancelled: tc.cancelled})
+			req := httptest.NewRequest(http.MethodPost, "/dialogs/events/create", io.NopCloser(bytes.NewBuffer(body)))
+			req.Header.Set(MMUserIDHeader, MockUserID)
+			rec := httptest.NewRecorder()
+
+			api.submitCreateEventDialog(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var response model.SubmitDialogResponse
+			err := json.NewDecoder(rec.Body).Decode(&response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
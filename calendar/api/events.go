@@ -11,9 +11,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/pkg/errors"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
@@ -37,6 +37,18 @@ type createEventPayload struct {
 	Subject     string `json:"subject"`
 	Location    string `json:"location,omitempty"`
 	ChannelID   string `json:"channel_id"`
+
+	// Recurrence is one of daily, weekdays, weekly, monthly or yearly. The
+	// series ends on RecurrenceEndDate or after RecurrenceCount occurrences
+	// if either is set.
+	Recurrence         string `json:"recurrence,omitempty"`
+	RecurrenceInterval int    `json:"recurrence_interval,omitempty"`
+	RecurrenceEndDate  string `json:"recurrence_end_date,omitempty"`
+	RecurrenceCount    int    `json:"recurrence_count,omitempty"`
+
+	// OnlineMeeting requests a Teams meeting for the event, on providers
+	// that support online meetings.
+	OnlineMeeting bool `json:"online_meeting,omitempty"`
 }
 
 func (cep createEventPayload) ToRemoteEvent(loc *time.Location) (*remote.Event, error) {
@@ -86,11 +98,22 @@ func (cep createEventPayload) ToRemoteEvent(loc *time.Location) (*remote.Event,
 		}
 	}
 	evt.Subject = cep.Subject
+
+	if cep.Recurrence != "" {
+		evt.Recurrence, err = cep.recurrence(start)
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing recurrence")
+		}
+	}
 	if cep.Location != "" {
 		evt.Location = &remote.Location{
 			DisplayName: cep.Location,
 		}
 	}
+	if cep.OnlineMeeting {
+		evt.IsOnlineMeeting = true
+		evt.OnlineMeetingProvider = remote.OnlineMeetingProviderTeams
+	}
 
 	return &evt, nil
 }
@@ -103,6 +126,24 @@ func (cep createEventPayload) parseEndTime(loc *time.Location) (time.Time, error
 	return time.ParseInLocation(createEventDateTimeFormat, fmt.Sprintf("%s %s", cep.Date, cep.EndTime), loc)
 }
 
+func (cep createEventPayload) recurrence(start time.Time) (*remote.PatternedRecurrence, error) {
+	recurrence, err := remote.NewPatternedRecurrence(cep.Recurrence, cep.RecurrenceInterval, start)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cep.RecurrenceEndDate != "" && cep.RecurrenceCount > 0:
+		return nil, fmt.Errorf("recurrence end date and count cannot both be set")
+	case cep.RecurrenceEndDate != "":
+		recurrence.EndBy(cep.RecurrenceEndDate)
+	case cep.RecurrenceCount > 0:
+		recurrence.EndAfter(cep.RecurrenceCount)
+	}
+
+	return recurrence, recurrence.Validate()
+}
+
 func (cep createEventPayload) parseDate(loc *time.Location) (time.Time, error) {
 	return time.ParseInLocation(createEventDateFormat, cep.Date, loc)
 }
@@ -147,6 +188,15 @@ func (cep createEventPayload) IsValid(loc *time.Location) error {
 		return fmt.Errorf("end date cannot be earlier than start date")
 	}
 
+	if cep.Recurrence != "" {
+		if _, err := cep.recurrence(start); err != nil {
+			return fmt.Errorf("invalid recurrence: %s", err.Error())
+		}
+		if cep.RecurrenceEndDate != "" && cep.RecurrenceEndDate < cep.Date {
+			return fmt.Errorf("recurrence end date cannot be earlier than start date")
+		}
+	}
+
 	return nil
 }
 
@@ -178,6 +228,12 @@ func (api *api) createEvent(w http.ResponseWriter, r *http.Request) {
 	}
 	defer r.Body.Close()
 
+	if payload.OnlineMeeting && !api.Provider.Features.OnlineMeetings {
+		api.Logger.With(bot.LogContext{"userID": mattermostUserID}).Errorf("createEvent, online meetings are not supported by the provider")
+		httputils.WriteBadRequestError(w, fmt.Errorf("online meetings are not supported by %s", api.Provider.DisplayName))
+		return
+	}
+
 	if payload.ChannelID != "" {
 		if !api.PluginAPI.CanLinkEventToChannel(payload.ChannelID, user.MattermostUserID) {
 			api.Logger.With(bot.LogContext{"userID": mattermostUserID, "channelID": payload.ChannelID}).Errorf("createEvent, user don't have permission to link events in the selected channel")
@@ -251,30 +307,12 @@ func (api *api) createEvent(w http.ResponseWriter, r *http.Request) {
 
 	// Event linking
 	if payload.ChannelID != "" {
-		if err := api.Store.StoreUserLinkedEvent(user.MattermostUserID, event.ICalUID, payload.ChannelID); err != nil {
-			api.Poster.DM(mattermostUserID, "Your event **%s** could not be linked to a channel. Please contact an administrator for more details.", event.Subject)
+		mscal := engine.New(api.Env, mattermostUserID)
+		if err := mscal.LinkEventToChannel(&engine.User{User: user, MattermostUserID: mattermostUserID}, event, payload.ChannelID, attachment); err != nil {
 			api.Logger.With(bot.LogContext{"err": err.Error(), "userID": user.MattermostUserID}).Errorf("createEvent, error occurred while storing user linked event")
 			httputils.WriteInternalServerError(w, err)
 			return
 		}
-
-		if err := api.Store.AddLinkedChannelToEvent(event.ICalUID, payload.ChannelID); err != nil {
-			api.Logger.With(bot.LogContext{"err": err}).Errorf("error linking event to channel")
-			defer func() {
-				api.Poster.DM(mattermostUserID, "You event **%s** could not be linked to a channel. Please contact an administrator for more details.", event.Subject)
-			}()
-		} else {
-			post := &model.Post{
-				Message:   fmt.Sprintf("The event **%s** was linked to this channel by @%s", event.Subject, user.MattermostUsername),
-				ChannelId: payload.ChannelID,
-			}
-			if attachment != nil {
-				model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
-			}
-			if err := api.Poster.CreatePost(post); err != nil {
-				api.Logger.With(bot.LogContext{"err": err}).Errorf("error sending post to channel about linked event")
-			}
-		}
 	} else {
 		if attachment == nil {
 			api.Poster.DM(mattermostUserID, "Your event: **%s** was created successfully.", event.Subject)
@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 
@@ -91,6 +92,72 @@ func TestToRemoteEvent(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			name: "Valid weekly event until a date",
+			payload: func() createEventPayload {
+				payload := GetMockCreateEventPayload(false, nil, "2024-10-18", "10:00", "12:00", "", "Weekly sync", "", "")
+				payload.Recurrence = remote.FrequencyWeekly
+				payload.RecurrenceEndDate = "2024-12-20"
+				return payload
+			}(),
+			assertions: func(t *testing.T, event *remote.Event, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, &remote.PatternedRecurrence{
+					Pattern: &remote.RecurrencePattern{
+						Type:       remote.RecurrencePatternWeekly,
+						Interval:   1,
+						DaysOfWeek: []string{"friday"},
+					},
+					Range: &remote.RecurrenceRange{
+						Type:      remote.RecurrenceRangeEndDate,
+						StartDate: "2024-10-18",
+						EndDate:   "2024-12-20",
+					},
+				}, event.Recurrence)
+			},
+		},
+		{
+			name: "Valid monthly event with count",
+			payload: func() createEventPayload {
+				payload := GetMockCreateEventPayload(false, nil, "2024-10-18", "10:00", "12:00", "", "Monthly review", "", "")
+				payload.Recurrence = remote.FrequencyMonthly
+				payload.RecurrenceInterval = 3
+				payload.RecurrenceCount = 4
+				return payload
+			}(),
+			assertions: func(t *testing.T, event *remote.Event, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, remote.RecurrencePatternAbsoluteMonthly, event.Recurrence.Pattern.Type)
+				assert.Equal(t, 18, event.Recurrence.Pattern.DayOfMonth)
+				assert.Equal(t, 3, event.Recurrence.Pattern.Interval)
+				assert.Equal(t, 4, event.Recurrence.Range.NumberOfOccurrences)
+			},
+		},
+		{
+			name: "Valid event with a Teams meeting",
+			payload: func() createEventPayload {
+				payload := GetMockCreateEventPayload(false, nil, "2024-10-18", "10:00", "12:00", "", "Design review", "", "")
+				payload.OnlineMeeting = true
+				return payload
+			}(),
+			assertions: func(t *testing.T, event *remote.Event, err error) {
+				assert.NoError(t, err)
+				assert.True(t, event.IsOnlineMeeting)
+				assert.Equal(t, remote.OnlineMeetingProviderTeams, event.OnlineMeetingProvider)
+			},
+		},
+		{
+			name: "Invalid recurrence",
+			payload: func() createEventPayload {
+				payload := GetMockCreateEventPayload(false, nil, "2024-10-18", "10:00", "12:00", "", "Sync", "", "")
+				payload.Recurrence = "hourly"
+				return payload
+			}(),
+			assertions: func(t *testing.T, event *remote.Event, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, event)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -274,6 +341,22 @@ func TestCreateEvent(t *testing.T) {
 				assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
 			},
 		},
+		{
+			name: "Online meetings not supported by the provider",
+			setup: func(req *http.Request) {
+				api.Config = &config.Config{Provider: config.ProviderConfig{DisplayName: "Google Calendar"}}
+				req.Header.Set(MMUserIDHeader, MockUserID)
+				req.Body = io.NopCloser(bytes.NewBufferString(`{"subject": "Design review", "date": "2024-10-18", "online_meeting": true}`))
+				mockStore.EXPECT().LoadUser(MockUserID).Return(&store.User{MattermostUserID: MockUserID}, nil).Times(1)
+				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
+				mockLoggerWith.EXPECT().Errorf("createEvent, online meetings are not supported by the provider").Times(1)
+			},
+			assertions: func(rec *httptest.ResponseRecorder) {
+				assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+				responseBody, _ := io.ReadAll(rec.Body)
+				assert.Contains(t, string(responseBody), "online meetings are not supported by Google Calendar")
+			},
+		},
 		{
 			name: "User doesn't have permission to link event to the channel",
 			setup: func(req *http.Request) {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,7 +17,10 @@ import (
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
 )
 
 func (api *api) preprocessAction(w http.ResponseWriter, req *http.Request) (mscal engine.Engine, user *engine.User, eventID string, option string, postID string) {
@@ -262,6 +266,313 @@ func getEventInfo(ctx map[string]interface{}) (string, error) {
 	return views.RenderEventWillStartLine(subject, weblink, startTime), nil
 }
 
+// postActionFindTime creates the meeting at the time suggested by findtime,
+// linked to the channel it was looked up for.
+func (api *api) postActionFindTime(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	subject, _ := request.Context["subject"].(string)
+	channelID, _ := request.Context["channel_id"].(string)
+	startTime, _ := request.Context["start"].(string)
+	endTime, _ := request.Context["end"].(string)
+	start, errStart := time.Parse(time.RFC3339, startTime)
+	end, errEnd := time.Parse(time.RFC3339, endTime)
+	if errStart != nil || errEnd != nil {
+		utils.SlackAttachmentError(w, "Error: invalid meeting time")
+		return
+	}
+
+	user, err := api.Store.LoadUser(mattermostUserID)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: cannot load user")
+		return
+	}
+
+	if channelID != "" && !api.PluginAPI.CanLinkEventToChannel(channelID, mattermostUserID) {
+		utils.SlackAttachmentError(w, "Error: you don't have permission to link events in the channel")
+		return
+	}
+
+	event := &remote.Event{
+		Subject: subject,
+		Start:   remote.NewDateTime(start.UTC(), "UTC"),
+		End:     remote.NewDateTime(end.UTC(), "UTC"),
+	}
+	for _, mail := range contextStrings(request.Context["attendees"]) {
+		event.Attendees = append(event.Attendees, &remote.Attendee{
+			EmailAddress: &remote.EmailAddress{Address: mail},
+		})
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	event, err = mscal.CreateEvent(engine.NewUser(mattermostUserID), event, contextStrings(request.Context["user_ids"]))
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("postActionFindTime, error occurred while creating event")
+		utils.SlackAttachmentError(w, "Error: Failed to create event: "+err.Error())
+		return
+	}
+
+	timezone, err := mscal.GetTimezone(engine.NewUser(mattermostUserID))
+	if err != nil {
+		timezone = event.Start.TimeZone
+	}
+	attachment, err := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("postActionFindTime, error rendering event as attachment")
+	}
+
+	if channelID != "" {
+		if err := mscal.LinkEventToChannel(&engine.User{User: user, MattermostUserID: mattermostUserID}, event, channelID, attachment); err != nil {
+			utils.SlackAttachmentError(w, "Error: Failed to link the event to the channel: "+err.Error())
+			return
+		}
+	}
+
+	text := fmt.Sprintf("Your event **%s** was created successfully.", event.Subject)
+	post := &model.Post{}
+	sa := &model.SlackAttachment{
+		Title:    "Meeting Time Suggestions",
+		Text:     text,
+		Fallback: "Meeting Time Suggestions: " + text,
+	}
+	if attachment != nil {
+		sa = attachment
+		post.Message = text
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{sa})
+
+	response := model.PostActionIntegrationResponse{Update: post}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		utils.SlackAttachmentError(w, "Error: unable to write response, "+err.Error())
+	}
+}
+
+// preprocessMeetingPollAction returns the poll post, the poll and the time
+// of a meeting poll action, or nothing when an error has already been
+// written.
+func (api *api) preprocessMeetingPollAction(w http.ResponseWriter, req *http.Request, slotKey string) (mattermostUserID, postID, pollID string, slot int) {
+	mattermostUserID = req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return "", "", "", 0
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return "", "", "", 0
+	}
+
+	pollID, _ = request.Context["poll_id"].(string)
+	if pollID == "" {
+		utils.SlackAttachmentError(w, "Error: missing poll ID")
+		return "", "", "", 0
+	}
+
+	slotValue, _ := request.Context[slotKey].(string)
+	slot, err := strconv.Atoi(slotValue)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid meeting time")
+		return "", "", "", 0
+	}
+
+	return mattermostUserID, request.PostId, pollID, slot
+}
+
+func (api *api) postActionMeetingPollVote(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, postID, pollID, slot := api.preprocessMeetingPollAction(w, req, "slot")
+	if pollID == "" {
+		return
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	poll, err := mscal.VoteMeetingPoll(mattermostUserID, pollID, slot)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to vote: "+err.Error())
+		return
+	}
+
+	api.writeMeetingPollUpdate(w, mscal, postID, poll, "")
+}
+
+func (api *api) postActionMeetingPollFinalize(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID, postID, pollID, slot := api.preprocessMeetingPollAction(w, req, "selected_option")
+	if pollID == "" {
+		return
+	}
+
+	user, err := api.Store.LoadUser(mattermostUserID)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: cannot load user")
+		return
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	poll, event, err := mscal.FinalizeMeetingPoll(engine.NewUser(mattermostUserID), pollID, slot)
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to finalize the poll: "+err.Error())
+		return
+	}
+
+	timezone := poll.TimeZone
+	if timezone == "" {
+		timezone = event.Start.TimeZone
+	}
+	attachment, err := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+	if err != nil {
+		api.Logger.With(bot.LogContext{"err": err.Error()}).Errorf("postActionMeetingPollFinalize, error rendering event as attachment")
+	}
+	if err := mscal.LinkEventToChannel(&engine.User{User: user, MattermostUserID: mattermostUserID}, event, poll.ChannelID, attachment); err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to link the event to the channel: "+err.Error())
+		return
+	}
+
+	api.writeMeetingPollUpdate(w, mscal, postID, poll, fmt.Sprintf("@%s 님이 **%s** 시간을 확정했습니다.", user.MattermostUsername, poll.Subject))
+}
+
+// writeMeetingPollUpdate renders the poll on its post, keeping the message of
+// the post, to which the optional note is added.
+func (api *api) writeMeetingPollUpdate(w http.ResponseWriter, mscal engine.Engine, postID string, poll *store.MeetingPoll, note string) {
+	post, appErr := api.PluginAPI.GetPost(postID)
+	if appErr != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to update the post: "+appErr.Error())
+		return
+	}
+	if note != "" {
+		post.Message = strings.TrimSpace(post.Message + "\n\n" + note)
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{mscal.RenderMeetingPoll(poll)})
+
+	response := model.PostActionIntegrationResponse{Update: post}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		utils.SlackAttachmentError(w, "Error: unable to write response, "+err.Error())
+	}
+}
+
+// postActionReminder snoozes or dismisses the reminder of an event, and
+// shows the chosen action on the reminder.
+func (api *api) postActionReminder(w http.ResponseWriter, req *http.Request) {
+	mattermostUserID := req.Header.Get("Mattermost-User-ID")
+	if mattermostUserID == "" {
+		utils.SlackAttachmentError(w, "Error: not authorized")
+		return
+	}
+
+	var request model.PostActionIntegrationRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		utils.SlackAttachmentError(w, "Error: invalid request")
+		return
+	}
+
+	action, _ := request.Context[views.ReminderContextAction].(string)
+	eventKey, _ := request.Context[views.ReminderContextEventKey].(string)
+	seriesKey, _ := request.Context[views.ReminderContextSeriesKey].(string)
+	startValue, _ := request.Context[views.ReminderContextStart].(string)
+	if seriesKey == "" {
+		utils.SlackAttachmentError(w, "Error: missing event")
+		return
+	}
+	var start time.Time
+	if action != views.ReminderActionRestore {
+		var err error
+		start, err = time.Parse(time.RFC3339, startValue)
+		if err != nil || eventKey == "" {
+			utils.SlackAttachmentError(w, "Error: missing event")
+			return
+		}
+	}
+
+	mscal := engine.New(api.Env, mattermostUserID)
+	user := engine.NewUser(mattermostUserID)
+	var text string
+	var actions []*model.PostAction
+	var err error
+	switch action {
+	case views.ReminderActionSnooze, views.ReminderActionAtStart:
+		var remindAt time.Time
+		remindAt, err = mscal.SnoozeReminder(user, eventKey, start, action == views.ReminderActionAtStart)
+		text = fmt.Sprintf("You will be reminded again in %d minutes.", int(time.Until(remindAt).Round(time.Minute).Minutes()))
+		if remindAt.Equal(start) {
+			text = "You will be reminded again when the event starts."
+		}
+	case views.ReminderActionDismiss:
+		err = mscal.DismissReminders(user, eventKey, seriesKey, start)
+		text = "You won't be reminded of this event anymore."
+		if seriesKey != eventKey {
+			text = "You won't be reminded of the events of this series anymore."
+			url := fmt.Sprintf("%s%s%s", api.Config.PluginURLPath, config.PathPostAction, config.PathReminder)
+			actions = []*model.PostAction{views.RenderReminderRestoreAction(url, seriesKey)}
+		}
+	case views.ReminderActionRestore:
+		err = mscal.RestoreReminders(user, seriesKey)
+		text = "You will be reminded of the events of this series again."
+	default:
+		utils.SlackAttachmentError(w, "Error: unknown reminder action")
+		return
+	}
+	if err != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to update the reminder: "+err.Error())
+		return
+	}
+
+	p, appErr := api.PluginAPI.GetPost(request.PostId)
+	if appErr != nil {
+		utils.SlackAttachmentError(w, "Error: Failed to update the post: "+appErr.Error())
+		return
+	}
+	sas := p.Attachments()
+	if len(sas) == 0 {
+		utils.SlackAttachmentError(w, "Error: Failed to update the post: No attachments found")
+		return
+	}
+
+	sa := sas[0]
+	fields := []*model.SlackAttachmentField{}
+	for _, field := range sa.Fields {
+		if field.Title != "Reminder" {
+			fields = append(fields, field)
+		}
+	}
+	sa.Fields = append(fields, &model.SlackAttachmentField{
+		Title: "Reminder",
+		Value: text,
+		Short: false,
+	})
+	sa.Actions = actions
+	model.ParseSlackAttachment(p, []*model.SlackAttachment{sa})
+
+	response := model.PostActionIntegrationResponse{Update: p}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		utils.SlackAttachmentError(w, "Error: unable to write response, "+err.Error())
+	}
+}
+
+// contextStrings returns the strings of a list decoded from a post action
+// context.
+func contextStrings(value interface{}) []string {
+	list, _ := value.([]interface{})
+	result := []string{}
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
 func isAcceptedError(err error) bool {
 	return strings.Contains(err.Error(), "202 Accepted")
 }
@@ -620,3 +620,308 @@ func TestPostActionConfirmStatusChange(t *testing.T) {
 		})
 	}
 }
+
+func TestPostActionFindTime(t *testing.T) {
+	api, mockStore, _, _, mockPluginAPI, _, _, _ := GetMockSetup(t)
+
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Minute)
+	validContext := func() map[string]interface{} {
+		return map[string]interface{}{
+			"subject":    "Planning",
+			"channel_id": MockChannelID,
+			"start":      start.Format(time.RFC3339),
+			"end":        start.Add(30 * time.Minute).Format(time.RFC3339),
+			"user_ids":   []string{"user1"},
+			"attendees":  []string{"user1@example.com"},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setup         func(*http.Request)
+		expectedError string
+	}{
+		{
+			name: "Missing Mattermost User ID",
+			setup: func(req *http.Request) {
+				bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: validContext()})
+				req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			},
+			expectedError: "Error: not authorized",
+		},
+		{
+			name: "Invalid meeting time",
+			setup: func(req *http.Request) {
+				req.Header.Set(MMUserIDHeader, MockUserID)
+				context := validContext()
+				context["start"] = "tomorrow"
+				bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: context})
+				req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			},
+			expectedError: "Error: invalid meeting time",
+		},
+		{
+			name: "Not permitted to link the channel",
+			setup: func(req *http.Request) {
+				mockStore.EXPECT().LoadUser(MockUserID).Return(&store.User{Remote: &remote.User{ID: MockRemoteUserID}}, nil).Times(1)
+				mockPluginAPI.EXPECT().CanLinkEventToChannel(MockChannelID, MockUserID).Return(false).Times(1)
+
+				req.Header.Set(MMUserIDHeader, MockUserID)
+				bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: validContext()})
+				req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			},
+			expectedError: "Error: you don't have permission to link events in the channel",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/postActionFindTime", nil)
+			rec := httptest.NewRecorder()
+
+			tc.setup(req)
+			api.postActionFindTime(rec, req)
+
+			var response model.PostActionIntegrationResponse
+			err := json.NewDecoder(rec.Body).Decode(&response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedError, response.EphemeralText)
+		})
+	}
+}
+
+func TestPostActionMeetingPoll(t *testing.T) {
+	api, mockStore, _, _, _, _, _, _ := GetMockSetup(t)
+
+	tests := []struct {
+		name          string
+		finalize      bool
+		setup         func(*http.Request)
+		expectedError string
+	}{
+		{
+			name: "Missing Mattermost User ID",
+			setup: func(req *http.Request) {
+				bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: map[string]interface{}{"poll_id": "poll_id", "slot": "0"}})
+				req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			},
+			expectedError: "Error: not authorized",
+		},
+		{
+			name: "Missing poll ID",
+			setup: func(req *http.Request) {
+				req.Header.Set(MMUserIDHeader, MockUserID)
+				bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: map[string]interface{}{"slot": "0"}})
+				req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			},
+			expectedError: "Error: missing poll ID",
+		},
+		{
+			name:     "Invalid selected time",
+			finalize: true,
+			setup: func(req *http.Request) {
+				req.Header.Set(MMUserIDHeader, MockUserID)
+				bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: map[string]interface{}{"poll_id": "poll_id", "selected_option": "first"}})
+				req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			},
+			expectedError: "Error: invalid meeting time",
+		},
+		{
+			name:     "Not the organizer",
+			finalize: true,
+			setup: func(req *http.Request) {
+				mockStore.EXPECT().LoadUser(MockUserID).Return(&store.User{Remote: &remote.User{ID: MockRemoteUserID}}, nil).Times(1)
+				mockStore.EXPECT().ModifyMeetingPoll("poll_id", gomock.Any()).DoAndReturn(func(_ string, modify func(*store.MeetingPoll) error) (*store.MeetingPoll, error) {
+					poll := &store.MeetingPoll{ID: "poll_id", OrganizerID: "organizer_id"}
+					if err := modify(poll); err != nil {
+						return nil, err
+					}
+					return poll, nil
+				}).Times(1)
+
+				req.Header.Set(MMUserIDHeader, MockUserID)
+				bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: map[string]interface{}{"poll_id": "poll_id", "selected_option": "0"}})
+				req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			},
+			expectedError: "Error: Failed to finalize the poll: 주최자만 회의 시간을 확정할 수 있습니다",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/postActionMeetingPoll", nil)
+			rec := httptest.NewRecorder()
+
+			tc.setup(req)
+			if tc.finalize {
+				api.postActionMeetingPollFinalize(rec, req)
+			} else {
+				api.postActionMeetingPollVote(rec, req)
+			}
+
+			var response model.PostActionIntegrationResponse
+			err := json.NewDecoder(rec.Body).Decode(&response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedError, response.EphemeralText)
+		})
+	}
+}
+
+func TestPostActionMeetingPollVoteKeepsMessage(t *testing.T) {
+	api, mockStore, _, _, mockPluginAPI, _, _, _ := GetMockSetup(t)
+	api.Config = &config.Config{PluginURLPath: "/plugins/mscalendar"}
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	message := "@organizer 님이 **Design review** 시간을 정하려고 합니다. 참석할 수 있는 시간에 모두 투표해주세요."
+
+	mockPluginAPI.EXPECT().GetMattermostUser(MockUserID).Return(&model.User{Id: MockUserID, Username: "voter"}, nil).Times(1)
+	mockStore.EXPECT().ModifyMeetingPoll("poll_id", gomock.Any()).DoAndReturn(func(_ string, modify func(*store.MeetingPoll) error) (*store.MeetingPoll, error) {
+		poll := &store.MeetingPoll{
+			ID:       "poll_id",
+			Subject:  "Design review",
+			TimeZone: "UTC",
+			Slots:    []*store.MeetingPollSlot{{Start: start, End: start.Add(time.Hour)}},
+		}
+		if err := modify(poll); err != nil {
+			return nil, err
+		}
+		return poll, nil
+	}).Times(1)
+	mockPluginAPI.EXPECT().GetPost(MockPostID).Return(&model.Post{Id: MockPostID, Message: message}, nil).Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/postActionMeetingPoll", nil)
+	req.Header.Set(MMUserIDHeader, MockUserID)
+	bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{PostId: MockPostID, Context: map[string]interface{}{"poll_id": "poll_id", "slot": "0"}})
+	req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	rec := httptest.NewRecorder()
+
+	api.postActionMeetingPollVote(rec, req)
+
+	var response model.PostActionIntegrationResponse
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+	if assert.NotNil(t, response.Update) {
+		assert.Equal(t, message, response.Update.Message)
+		attachments := response.Update.Attachments()
+		if assert.Len(t, attachments, 1) && assert.Len(t, attachments[0].Fields, 1) {
+			assert.Equal(t, "1표: @voter", attachments[0].Fields[0].Value)
+		}
+	}
+}
+
+func TestPostActionReminder(t *testing.T) {
+	api, mockStore, _, _, mockPluginAPI, _, _, _ := GetMockSetup(t)
+	api.Config = &config.Config{PluginURLPath: "/plugins/mscalendar"}
+	start := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+
+	reminderPost := func() *model.Post {
+		return &model.Post{
+			Id: MockPostID,
+			Props: map[string]interface{}{
+				"attachments": []*model.SlackAttachment{{
+					Title:   "Upcoming event",
+					Actions: []*model.PostAction{{Name: "5분 후 다시 알림"}},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		context         map[string]interface{}
+		setup           func()
+		expectedError   string
+		expectedText    string
+		expectedActions int
+	}{
+		{
+			name:          "Missing event",
+			context:       map[string]interface{}{"action": "snooze"},
+			setup:         func() {},
+			expectedError: "Error: missing event",
+		},
+		{
+			name:          "Unknown action",
+			context:       map[string]interface{}{"action": "other", "event_key": "event_key", "series_key": "event_key", "start": start.Format(time.RFC3339)},
+			setup:         func() {},
+			expectedError: "Error: unknown reminder action",
+		},
+		{
+			name:    "Snooze the reminder",
+			context: map[string]interface{}{"action": "snooze", "event_key": "event_key", "series_key": "event_key", "start": start.Format(time.RFC3339)},
+			setup: func() {
+				mockStore.EXPECT().StoreReminderSnooze(MockUserID, "event_key", gomock.Any()).Return(nil)
+				mockPluginAPI.EXPECT().GetPost("").Return(reminderPost(), nil)
+			},
+			expectedText: "You will be reminded again in 5 minutes.",
+		},
+		{
+			name:    "Remind at the start of the event",
+			context: map[string]interface{}{"action": "start", "event_key": "event_key", "series_key": "event_key", "start": start.Format(time.RFC3339)},
+			setup: func() {
+				mockStore.EXPECT().StoreReminderSnooze(MockUserID, "event_key", &store.ReminderSnooze{RemindAt: start, ExpiresAt: start.Add(time.Hour)}).Return(nil)
+				mockPluginAPI.EXPECT().GetPost("").Return(reminderPost(), nil)
+			},
+			expectedText: "You will be reminded again when the event starts.",
+		},
+		{
+			name:    "Dismiss the reminders of the series",
+			context: map[string]interface{}{"action": "dismiss", "event_key": "event_key", "series_key": "series_key", "start": start.Format(time.RFC3339)},
+			setup: func() {
+				mockStore.EXPECT().StoreReminderSnooze(MockUserID, "series_key", &store.ReminderSnooze{Dismissed: true}).Return(nil)
+				mockPluginAPI.EXPECT().GetPost("").Return(reminderPost(), nil)
+			},
+			expectedText:    "You won't be reminded of the events of this series anymore.",
+			expectedActions: 1,
+		},
+		{
+			name:    "Restore the reminders of the series",
+			context: map[string]interface{}{"action": "restore", "series_key": "series_key"},
+			setup: func() {
+				mockStore.EXPECT().StoreReminderSnooze(MockUserID, "series_key", nil).Return(nil)
+				mockPluginAPI.EXPECT().GetPost("").Return(reminderPost(), nil)
+			},
+			expectedText: "You will be reminded of the events of this series again.",
+		},
+		{
+			name:    "Error storing the snooze",
+			context: map[string]interface{}{"action": "snooze", "event_key": "event_key", "series_key": "event_key", "start": start.Format(time.RFC3339)},
+			setup: func() {
+				mockStore.EXPECT().StoreReminderSnooze(MockUserID, "event_key", gomock.Any()).Return(errors.New("store error"))
+			},
+			expectedError: "Error: Failed to update the reminder: store error",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/postActionReminder", nil)
+			rec := httptest.NewRecorder()
+			req.Header.Set(MMUserIDHeader, MockUserID)
+			bodyBytes, _ := json.Marshal(model.PostActionIntegrationRequest{Context: tc.context})
+			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+			tc.setup()
+			api.postActionReminder(rec, req)
+
+			var response model.PostActionIntegrationResponse
+			err := json.NewDecoder(rec.Body).Decode(&response)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedError, response.EphemeralText)
+			if tc.expectedError != "" {
+				return
+			}
+
+			sas := response.Update.Attachments()
+			assert.Len(t, sas, 1)
+			assert.Len(t, sas[0].Actions, tc.expectedActions)
+			assert.Equal(t, "Reminder", sas[0].Fields[len(sas[0].Fields)-1].Title)
+			assert.Equal(t, tc.expectedText, sas[0].Fields[len(sas[0].Fields)-1].Value)
+		})
+	}
+}
+
+func TestContextStrings(t *testing.T) {
+	var context map[string]interface{}
+	err := json.Unmarshal([]byte(`{"ids": ["a", "b"], "other": "c"}`), &context)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "b"}, contextStrings(context["ids"]))
+	assert.Equal(t, []string{}, contextStrings(context["other"]))
+	assert.Equal(t, []string{}, contextStrings(context["missing"]))
+}
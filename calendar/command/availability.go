@@ -3,6 +3,19 @@
 
 package command
 
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
 func (c *Command) debugAvailability(parameters ...string) (string, bool, error) {
 	switch {
 	case len(parameters) == 0:
@@ -23,3 +36,91 @@ func (c *Command) debugAvailability(parameters ...string) (string, bool, error)
 
 	return "bad syntax", false, nil
 }
+
+const (
+	// Availability is shown from scheduleStartHour to scheduleEndHour of the
+	// day, by blocks of scheduleInterval.
+	scheduleStartHour = 8
+	scheduleEndHour   = 20
+	scheduleInterval  = 15 * time.Minute
+
+	// maxScheduleUsers is the number of schedules Graph returns at once.
+	maxScheduleUsers = 20
+)
+
+func getAvailabilityHelp() string {
+	return "### 가용성 명령어:\n" +
+		fmt.Sprintf("`/%s availability @user1 @user2` - 오늘 동료들의 한가한 시간과 바쁜 시간 보기\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s availability @user1 @user2 2024-05-02` - 특정 날짜의 가용성 보기", config.Provider.CommandTrigger)
+}
+
+func (c *Command) availability(parameters ...string) (string, bool, error) {
+	usernames := []string{}
+	date := ""
+	for _, p := range parameters {
+		if strings.HasPrefix(p, "@") {
+			usernames = append(usernames, strings.TrimPrefix(p, "@"))
+			continue
+		}
+		if date != "" {
+			return getAvailabilityHelp(), false, nil
+		}
+		date = p
+	}
+	if len(usernames) == 0 {
+		return getAvailabilityHelp(), false, nil
+	}
+	if len(usernames) > maxScheduleUsers {
+		return fmt.Sprintf("한 번에 최대 %d명의 가용성을 볼 수 있습니다.", maxScheduleUsers), false, nil
+	}
+
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+
+	day := time.Now().In(loc)
+	if date != "" {
+		day, err = time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil {
+			return fmt.Sprintf("잘못된 날짜입니다: `%s`. 예시: `2024-05-02`", date), false, nil
+		}
+	}
+	start := time.Date(day.Year(), day.Month(), day.Day(), scheduleStartHour, 0, 0, 0, loc)
+	end := time.Date(day.Year(), day.Month(), day.Day(), scheduleEndHour, 0, 0, 0, loc)
+
+	mattermostUserIDs := []string{}
+	names := map[string]string{}
+	for _, username := range usernames {
+		mattermostUser, err := c.Engine.GetMattermostUserByUsername(username)
+		if err != nil {
+			return fmt.Sprintf("사용자를 찾을 수 없습니다: `@%s`", username), false, nil
+		}
+		mattermostUserIDs = append(mattermostUserIDs, mattermostUser.Id)
+		names[mattermostUser.Id] = "@" + mattermostUser.Username
+	}
+
+	schedules, err := c.Engine.GetSchedules(c.user(), mattermostUserIDs, start, end, scheduleInterval)
+	if errors.Is(err, remote.ErrNotImplemented) {
+		return fmt.Sprintf("%s에서는 가용성 조회를 지원하지 않습니다.", config.Provider.DisplayName), false, nil
+	}
+	if err != nil {
+		return c.userError("가용성을 불러오지 못했습니다", err)
+	}
+
+	rows := []views.ScheduleRow{}
+	for _, schedule := range schedules {
+		rows = append(rows, views.ScheduleRow{
+			Name:             names[schedule.MattermostUserID],
+			AvailabilityView: schedule.AvailabilityView,
+			Error:            schedule.Error,
+		})
+	}
+
+	return fmt.Sprintf("#### %s %02d:00 - %02d:00 (%s)\n", start.Format("2006-01-02"), scheduleStartHour, scheduleEndHour, timezone) +
+		views.RenderSchedules(rows, start, scheduleInterval), false, nil
+}
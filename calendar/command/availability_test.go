@@ -0,0 +1,132 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestAvailability(t *testing.T) {
+	start := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 2, 20, 0, 0, 0, time.UTC)
+	freeDay := strings.Repeat("0", 48)
+
+	testcase := []struct {
+		name       string
+		parameters []string
+		setup      func(engine.Engine)
+		assertions func(t *testing.T, output string, err error)
+	}{
+		{
+			name:       "no users",
+			parameters: []string{"2024-05-02"},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, getAvailabilityHelp(), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "invalid date",
+			parameters: []string{"@alice", "tomorrow"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "잘못된 날짜입니다: `tomorrow`. 예시: `2024-05-02`", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "unknown user",
+			parameters: []string{"@nobody"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetMattermostUserByUsername("nobody").Return(nil, errors.New("not found")).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "사용자를 찾을 수 없습니다: `@nobody`", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "schedules",
+			parameters: []string{"@alice", "@bob", "2024-05-02"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetMattermostUserByUsername("alice").Return(&model.User{Id: "alice_id", Username: "alice"}, nil).Times(1)
+				mscal.EXPECT().GetMattermostUserByUsername("bob").Return(&model.User{Id: "bob_id", Username: "bob"}, nil).Times(1)
+				mscal.EXPECT().GetSchedules(gomock.Any(), []string{"alice_id", "bob_id"}, start, end, 15*time.Minute).Return([]*engine.Schedule{
+					{MattermostUserID: "alice_id", AvailabilityView: remote.AvailabilityView(freeDay)},
+					{MattermostUserID: "bob_id", Error: "user not found"},
+				}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Nil(t, err)
+				require.True(t, strings.HasPrefix(output, "#### 2024-05-02 08:00 - 20:00 (UTC)\n```\n"), output)
+				require.Contains(t, output, "@alice  "+strings.Repeat(".", 48)+"\n")
+				require.Contains(t, output, "@bob    (user not found)\n")
+			},
+		},
+		{
+			name:       "not supported by the provider",
+			parameters: []string{"@alice"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetMattermostUserByUsername("alice").Return(&model.User{Id: "alice_id", Username: "alice"}, nil).Times(1)
+				mscal.EXPECT().GetSchedules(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, remote.ErrNotImplemented).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, fmt.Sprintf("%s에서는 가용성 조회를 지원하지 않습니다.", config.Provider.DisplayName), output)
+				require.Nil(t, err)
+			},
+		},
+	}
+	for _, tt := range testcase {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			conf := &config.Config{
+				PluginURL: "http://localhost",
+			}
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: fmt.Sprintf("/%s availability", config.Provider.CommandTrigger),
+					UserId:  "mockUserID",
+				},
+				ChannelID: "mockChannelID",
+				Config:    conf,
+				Engine:    mscal,
+			}
+
+			tt.setup(mscal)
+
+			out, _, err := command.availability(tt.parameters...)
+
+			tt.assertions(t, out, err)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func getChannelCalendarHelp() string {
+	return "### 채널 캘린더 명령어:\n" +
+		fmt.Sprintf("`/%s channelcal connect 캘린더 이름` - 내 캘린더 또는 내가 속한 Microsoft 365 그룹의 캘린더를 이 채널에 연결 (이름 없이 실행하면 연결할 수 있는 캘린더 목록 표시)\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s channelcal info` - 이 채널에 연결된 캘린더 보기\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s channelcal agenda 09:00` - 평일에 오늘의 일정을 게시할 시간 설정 (`off`는 끄기)\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s channelcal disconnect` - 이 채널과 캘린더의 연결 해제\n", config.Provider.CommandTrigger) +
+		"캘린더는 연결한 사용자의 계정으로 읽으며, 일정 알림과 변경 알림이 채널에 게시됩니다."
+}
+
+func (c *Command) channelCalendar(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return getChannelCalendarHelp(), false, nil
+	}
+
+	switch parameters[0] {
+	case "connect":
+		return c.connectChannelCalendar(strings.Join(parameters[1:], " "))
+	case "disconnect":
+		return c.channelCalendarResponse(c.Engine.DisconnectChannelCalendar(c.user(), c.ChannelID), "이 채널과 캘린더의 연결을 해제했습니다.")
+	case "info":
+		channelCalendar, err := c.Engine.GetChannelCalendar(c.ChannelID)
+		if err != nil {
+			return c.channelCalendarResponse(err, "")
+		}
+		agenda := "게시하지 않음"
+		if channelCalendar.AgendaTime != "" {
+			agenda = "평일 " + channelCalendar.AgendaTime
+		}
+		return fmt.Sprintf("이 채널에는 **%s** 캘린더가 연결되어 있습니다. 오늘의 일정: %s", channelCalendar.CalendarName, agenda), false, nil
+	case "agenda":
+		if len(parameters) != 2 {
+			return fmt.Sprintf("시간을 입력해주세요. 예시: `/%s channelcal agenda 09:00`", config.Provider.CommandTrigger), false, nil
+		}
+		agendaTime := parameters[1]
+		if agendaTime == "off" {
+			agendaTime = ""
+		}
+		channelCalendar, err := c.Engine.SetChannelAgendaTime(c.user(), c.ChannelID, agendaTime)
+		if err != nil {
+			if errors.Is(err, engine.ErrChannelLinkNotPermitted) || errors.Is(err, engine.ErrChannelCalendarNotFound) {
+				return c.channelCalendarResponse(err, "")
+			}
+			return fmt.Sprintf("잘못된 시간입니다: `%s`. 예시: `09:00`", parameters[1]), false, nil
+		}
+		if channelCalendar.AgendaTime == "" {
+			return "이 채널에 오늘의 일정을 게시하지 않습니다.", false, nil
+		}
+		return fmt.Sprintf("평일 %s에 이 채널에 오늘의 일정을 게시합니다.", channelCalendar.AgendaTime), false, nil
+	}
+
+	return "잘못된 명령어입니다. 다시 시도해주세요\n\n" + getChannelCalendarHelp(), false, nil
+}
+
+func (c *Command) connectChannelCalendar(calendar string) (string, bool, error) {
+	if calendar == "" {
+		calendars, err := c.Engine.GetCalendars(c.user())
+		if err != nil {
+			return c.userError("캘린더 목록을 불러오지 못했습니다", err)
+		}
+		groups, err := c.Engine.GetChannelCalendarGroups(c.user())
+		if err != nil {
+			return c.userError("그룹 목록을 불러오지 못했습니다", err)
+		}
+		lines := []string{"연결할 캘린더의 이름이나 ID를 입력해주세요:"}
+		for _, cal := range calendars {
+			lines = append(lines, fmt.Sprintf("- %s (`%s`)", cal.Name, cal.ID))
+		}
+		if len(groups) > 0 {
+			lines = append(lines, "", "Microsoft 365 그룹 캘린더:")
+			for _, g := range groups {
+				lines = append(lines, fmt.Sprintf("- %s (`%s`)", g.DisplayName, g.ID))
+			}
+		}
+		return strings.Join(lines, "\n"), false, nil
+	}
+
+	channelCalendar, err := c.Engine.ConnectChannelCalendar(c.user(), c.ChannelID, calendar)
+	if errors.Is(err, engine.ErrCalendarNotFound) {
+		return fmt.Sprintf("캘린더를 찾을 수 없습니다: `%s`. `/%s channelcal connect`로 연결할 수 있는 캘린더를 확인하세요.", calendar, config.Provider.CommandTrigger), false, nil
+	}
+	if err != nil {
+		return c.channelCalendarResponse(err, "")
+	}
+	return fmt.Sprintf("**%s** 캘린더를 이 채널에 연결했습니다.", channelCalendar.CalendarName), false, nil
+}
+
+// channelCalendarResponse returns the message for the outcome of a channel
+// calendar command: out when it succeeded, or the error to show.
+func (c *Command) channelCalendarResponse(err error, out string) (string, bool, error) {
+	switch {
+	case err == nil:
+		return out, false, nil
+	case errors.Is(err, engine.ErrChannelLinkNotPermitted):
+		return "이 채널의 캘린더를 관리할 권한이 없습니다.", false, nil
+	case errors.Is(err, engine.ErrChannelCalendarNotFound):
+		return fmt.Sprintf("이 채널에 연결된 캘린더가 없습니다. `/%s channelcal connect`로 캘린더를 연결할 수 있습니다.", config.Provider.CommandTrigger), false, nil
+	case errors.Is(err, engine.ErrChannelCalendarAlreadyConnected):
+		return fmt.Sprintf("이미 이 채널에 캘린더가 연결되어 있습니다. 다른 캘린더를 연결하려면 먼저 `/%s channelcal disconnect`를 실행하세요.", config.Provider.CommandTrigger), false, nil
+	case errors.Is(err, remote.ErrNotImplemented):
+		return fmt.Sprintf("%s에서는 채널 캘린더를 사용할 수 없습니다.", config.Provider.DisplayName), false, nil
+	}
+	return c.userError("채널 캘린더 명령을 처리하지 못했습니다", err)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestChannelCalendar(t *testing.T) {
+	channelCalendar := &store.ChannelCalendar{
+		ChannelID:    "mockChannelID",
+		CalendarID:   "team_id",
+		CalendarName: "Team Calendar",
+		AgendaTime:   "09:00",
+	}
+
+	testcase := []struct {
+		name       string
+		parameters []string
+		setup      func(*mock_engine.MockEngine)
+		expected   string
+	}{
+		{
+			name:       "list the calendars to connect",
+			parameters: []string{"connect"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetCalendars(gomock.Any()).Return([]*remote.Calendar{{ID: "team_id", Name: "Team Calendar"}}, nil).Times(1)
+				mscal.EXPECT().GetChannelCalendarGroups(gomock.Any()).Return(nil, nil).Times(1)
+			},
+			expected: "연결할 캘린더의 이름이나 ID를 입력해주세요:\n- Team Calendar (`team_id`)",
+		},
+		{
+			name:       "list the calendars and group calendars to connect",
+			parameters: []string{"connect"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetCalendars(gomock.Any()).Return([]*remote.Calendar{{ID: "team_id", Name: "Team Calendar"}}, nil).Times(1)
+				mscal.EXPECT().GetChannelCalendarGroups(gomock.Any()).Return([]*remote.Group{{ID: "group_id", DisplayName: "Design Team"}}, nil).Times(1)
+			},
+			expected: "연결할 캘린더의 이름이나 ID를 입력해주세요:\n- Team Calendar (`team_id`)\n\nMicrosoft 365 그룹 캘린더:\n- Design Team (`group_id`)",
+		},
+		{
+			name:       "connect a calendar by name",
+			parameters: []string{"connect", "Team", "Calendar"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().ConnectChannelCalendar(gomock.Any(), "mockChannelID", "Team Calendar").Return(channelCalendar, nil).Times(1)
+			},
+			expected: "**Team Calendar** 캘린더를 이 채널에 연결했습니다.",
+		},
+		{
+			name:       "connect an unknown calendar",
+			parameters: []string{"connect", "Unknown"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().ConnectChannelCalendar(gomock.Any(), "mockChannelID", "Unknown").Return(nil, engine.ErrCalendarNotFound).Times(1)
+			},
+			expected: fmt.Sprintf("캘린더를 찾을 수 없습니다: `Unknown`. `/%s channelcal connect`로 연결할 수 있는 캘린더를 확인하세요.", config.Provider.CommandTrigger),
+		},
+		{
+			name:       "connect without calendar subscriptions",
+			parameters: []string{"connect", "Team Calendar"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().ConnectChannelCalendar(gomock.Any(), "mockChannelID", "Team Calendar").Return(nil, remote.ErrNotImplemented).Times(1)
+			},
+			expected: fmt.Sprintf("%s에서는 채널 캘린더를 사용할 수 없습니다.", config.Provider.DisplayName),
+		},
+		{
+			name:       "info without a channel calendar",
+			parameters: []string{"info"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetChannelCalendar("mockChannelID").Return(nil, engine.ErrChannelCalendarNotFound).Times(1)
+			},
+			expected: fmt.Sprintf("이 채널에 연결된 캘린더가 없습니다. `/%s channelcal connect`로 캘린더를 연결할 수 있습니다.", config.Provider.CommandTrigger),
+		},
+		{
+			name:       "info",
+			parameters: []string{"info"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetChannelCalendar("mockChannelID").Return(channelCalendar, nil).Times(1)
+			},
+			expected: "이 채널에는 **Team Calendar** 캘린더가 연결되어 있습니다. 오늘의 일정: 평일 09:00",
+		},
+		{
+			name:       "agenda off",
+			parameters: []string{"agenda", "off"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().SetChannelAgendaTime(gomock.Any(), "mockChannelID", "").Return(&store.ChannelCalendar{}, nil).Times(1)
+			},
+			expected: "이 채널에 오늘의 일정을 게시하지 않습니다.",
+		},
+		{
+			name:       "agenda with an invalid time",
+			parameters: []string{"agenda", "25:00"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().SetChannelAgendaTime(gomock.Any(), "mockChannelID", "25:00").Return(nil, errors.New("invalid time")).Times(1)
+			},
+			expected: "잘못된 시간입니다: `25:00`. 예시: `09:00`",
+		},
+		{
+			name:       "disconnect not permitted",
+			parameters: []string{"disconnect"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().DisconnectChannelCalendar(gomock.Any(), "mockChannelID").Return(engine.ErrChannelLinkNotPermitted).Times(1)
+			},
+			expected: "이 채널의 캘린더를 관리할 권한이 없습니다.",
+		},
+		{
+			name:       "disconnect",
+			parameters: []string{"disconnect"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().DisconnectChannelCalendar(gomock.Any(), "mockChannelID").Return(nil).Times(1)
+			},
+			expected: "이 채널과 캘린더의 연결을 해제했습니다.",
+		},
+	}
+	for _, tt := range testcase {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: fmt.Sprintf("/%s channelcal", config.Provider.CommandTrigger),
+					UserId:  "mockUserID",
+				},
+				ChannelID: "mockChannelID",
+				Config:    &config.Config{PluginURL: "http://localhost"},
+				Engine:    mscal,
+			}
+
+			tt.setup(mscal)
+
+			out, _, err := command.channelCalendar(tt.parameters...)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// linkedEventsLookahead is how far ahead the events linked to a channel are
+// listed.
+const linkedEventsLookahead = 14 * 24 * time.Hour
+
+// newEventArgumentAutocompleteData suggests the events returned by the
+// plugin at fetchPath as the argument of the command.
+func newEventArgumentAutocompleteData(trigger, helpText, fetchPath string) *model.AutocompleteData {
+	data := model.NewAutocompleteData(trigger, "[next|일정 ID]", helpText)
+	data.AddDynamicListArgument("next 또는 일정 ID", config.PathAutocomplete+fetchPath, true)
+	return data
+}
+
+func (c *Command) link(parameters ...string) (string, bool, error) {
+	if len(parameters) != 1 {
+		return fmt.Sprintf("연결할 일정을 입력해주세요: `/%s link next` 또는 `/%s link 일정 ID`", config.Provider.CommandTrigger, config.Provider.CommandTrigger), false, nil
+	}
+
+	eventID := parameters[0]
+	if eventID == nextEventID {
+		now := time.Now()
+		events, err := c.Engine.ViewCalendar(c.user(), now, now.Add(nextEventLookahead))
+		if err != nil {
+			return c.userError("일정을 불러오지 못했습니다", err)
+		}
+		next := firstUpcomingEvent(events, now)
+		if next == nil {
+			return "진행 중이거나 예정된 일정이 없습니다.", false, nil
+		}
+		eventID = next.ID
+	}
+
+	event, err := c.Engine.LinkEvent(c.user(), eventID, c.ChannelID)
+	switch {
+	case errors.Is(err, engine.ErrChannelLinkNotPermitted):
+		return "이 채널에 일정을 연결할 권한이 없습니다.", false, nil
+	case errors.Is(err, engine.ErrEventAlreadyLinked):
+		return fmt.Sprintf("**%s** 일정은 이미 이 채널에 연결되어 있습니다.", views.EnsureSubject(event.Subject)), false, nil
+	case err != nil:
+		return c.userError("일정을 연결하지 못했습니다", err)
+	}
+
+	return fmt.Sprintf("**%s** 일정을 이 채널에 연결했습니다. 일정 알림이 이 채널에도 게시됩니다.", views.EnsureSubject(event.Subject)), false, nil
+}
+
+func (c *Command) links(_ ...string) (string, bool, error) {
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+
+	now := time.Now()
+	events, err := c.Engine.GetChannelLinkedEvents(c.user(), c.ChannelID, now, now.Add(linkedEventsLookahead))
+	if err != nil {
+		return c.userError("일정을 불러오지 못했습니다", err)
+	}
+	if len(events) == 0 {
+		return fmt.Sprintf("내 캘린더에 이 채널에 연결된 예정된 일정이 없습니다. `/%s link`로 일정을 연결할 수 있습니다.", config.Provider.CommandTrigger), false, nil
+	}
+
+	lines := []string{"### 이 채널에 연결된 일정"}
+	for _, event := range events {
+		lines = append(lines, fmt.Sprintf("- %s - %s **%s** `%s`",
+			event.Start.In(timezone).Time().Format(eventDateTimeFormat),
+			event.End.In(timezone).Time().Format("15:04"),
+			views.EnsureSubject(event.Subject),
+			event.ID,
+		))
+	}
+	lines = append(lines, fmt.Sprintf("연결을 해제하려면 `/%s unlink 일정 ID`를 사용하세요.", config.Provider.CommandTrigger))
+	return strings.Join(lines, "\n"), false, nil
+}
+
+func (c *Command) unlink(parameters ...string) (string, bool, error) {
+	if len(parameters) != 1 {
+		return fmt.Sprintf("연결을 해제할 일정을 입력해주세요: `/%s unlink next` 또는 `/%s unlink 일정 ID`", config.Provider.CommandTrigger, config.Provider.CommandTrigger), false, nil
+	}
+
+	eventID := parameters[0]
+	if eventID == nextEventID {
+		now := time.Now()
+		events, err := c.Engine.GetChannelLinkedEvents(c.user(), c.ChannelID, now, now.Add(linkedEventsLookahead))
+		if err != nil {
+			return c.userError("일정을 불러오지 못했습니다", err)
+		}
+		next := firstUpcomingEvent(events, now)
+		if next == nil {
+			return "이 채널에 연결된 예정된 일정이 없습니다.", false, nil
+		}
+		eventID = next.ID
+	}
+
+	event, err := c.Engine.UnlinkEvent(c.user(), eventID, c.ChannelID)
+	switch {
+	case errors.Is(err, engine.ErrChannelLinkNotPermitted):
+		return "이 채널의 일정 연결을 해제할 권한이 없습니다.", false, nil
+	case errors.Is(err, engine.ErrEventNotLinked):
+		return fmt.Sprintf("**%s** 일정은 이 채널에 연결되어 있지 않습니다.", views.EnsureSubject(event.Subject)), false, nil
+	case err != nil:
+		return c.userError("일정 연결을 해제하지 못했습니다", err)
+	}
+
+	return fmt.Sprintf("**%s** 일정과 이 채널의 연결을 해제했습니다.", views.EnsureSubject(event.Subject)), false, nil
+}
+
+// firstUpcomingEvent returns the first event that is not cancelled and has
+// not ended yet.
+func firstUpcomingEvent(events []*remote.Event, now time.Time) *remote.Event {
+	for _, event := range events {
+		if !event.IsCancelled && event.End.Time().After(now) {
+			return event
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestChannelLinks(t *testing.T) {
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Minute)
+	newEvent := func(id, subject string) *remote.Event {
+		return &remote.Event{
+			ID:      id,
+			Subject: subject,
+			Start:   remote.NewDateTime(start, "UTC"),
+			End:     remote.NewDateTime(start.Add(30*time.Minute), "UTC"),
+		}
+	}
+
+	testcase := []struct {
+		name       string
+		command    string
+		parameters []string
+		setup      func(*mock_engine.MockEngine)
+		expected   string
+	}{
+		{
+			name:       "link the next event",
+			command:    "link",
+			parameters: []string{"next"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				cancelled := newEvent("cancelled_id", "Cancelled")
+				cancelled.IsCancelled = true
+				mscal.EXPECT().ViewCalendar(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*remote.Event{cancelled, newEvent("event_id", "Design review")}, nil).Times(1)
+				mscal.EXPECT().LinkEvent(gomock.Any(), "event_id", "mockChannelID").Return(newEvent("event_id", "Design review"), nil).Times(1)
+			},
+			expected: "**Design review** 일정을 이 채널에 연결했습니다. 일정 알림이 이 채널에도 게시됩니다.",
+		},
+		{
+			name:       "link an event already linked",
+			command:    "link",
+			parameters: []string{"event_id"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().LinkEvent(gomock.Any(), "event_id", "mockChannelID").Return(newEvent("event_id", "Design review"), engine.ErrEventAlreadyLinked).Times(1)
+			},
+			expected: "**Design review** 일정은 이미 이 채널에 연결되어 있습니다.",
+		},
+		{
+			name:       "link not permitted",
+			command:    "link",
+			parameters: []string{"event_id"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().LinkEvent(gomock.Any(), "event_id", "mockChannelID").Return(nil, engine.ErrChannelLinkNotPermitted).Times(1)
+			},
+			expected: "이 채널에 일정을 연결할 권한이 없습니다.",
+		},
+		{
+			name:       "no linked events",
+			command:    "links",
+			parameters: []string{},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetChannelLinkedEvents(gomock.Any(), "mockChannelID", gomock.Any(), gomock.Any()).Return([]*remote.Event{}, nil).Times(1)
+			},
+			expected: fmt.Sprintf("내 캘린더에 이 채널에 연결된 예정된 일정이 없습니다. `/%s link`로 일정을 연결할 수 있습니다.", config.Provider.CommandTrigger),
+		},
+		{
+			name:       "linked events",
+			command:    "links",
+			parameters: []string{},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetChannelLinkedEvents(gomock.Any(), "mockChannelID", gomock.Any(), gomock.Any()).Return([]*remote.Event{newEvent("event_id", "Design review")}, nil).Times(1)
+			},
+			expected: fmt.Sprintf("### 이 채널에 연결된 일정\n- %s - %s **Design review** `event_id`\n연결을 해제하려면 `/%s unlink 일정 ID`를 사용하세요.",
+				start.Format(eventDateTimeFormat), start.Add(30*time.Minute).Format("15:04"), config.Provider.CommandTrigger),
+		},
+		{
+			name:       "unlink the next event without linked events",
+			command:    "unlink",
+			parameters: []string{"next"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().GetChannelLinkedEvents(gomock.Any(), "mockChannelID", gomock.Any(), gomock.Any()).Return([]*remote.Event{}, nil).Times(1)
+			},
+			expected: "이 채널에 연결된 예정된 일정이 없습니다.",
+		},
+		{
+			name:       "unlink an event not linked",
+			command:    "unlink",
+			parameters: []string{"event_id"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().UnlinkEvent(gomock.Any(), "event_id", "mockChannelID").Return(newEvent("event_id", ""), engine.ErrEventNotLinked).Times(1)
+			},
+			expected: "**(제목 없음)** 일정은 이 채널에 연결되어 있지 않습니다.",
+		},
+		{
+			name:       "unlink an event",
+			command:    "unlink",
+			parameters: []string{"event_id"},
+			setup: func(mscal *mock_engine.MockEngine) {
+				mscal.EXPECT().UnlinkEvent(gomock.Any(), "event_id", "mockChannelID").Return(newEvent("event_id", "Design review"), nil).Times(1)
+			},
+			expected: "**Design review** 일정과 이 채널의 연결을 해제했습니다.",
+		},
+	}
+	for _, tt := range testcase {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: fmt.Sprintf("/%s %s", config.Provider.CommandTrigger, tt.command),
+					UserId:  "mockUserID",
+				},
+				ChannelID: "mockChannelID",
+				Config:    &config.Config{PluginURL: "http://localhost"},
+				Engine:    mscal,
+			}
+
+			tt.setup(mscal)
+
+			handlers := map[string]handleFunc{
+				"link":   command.link,
+				"links":  command.links,
+				"unlink": command.unlink,
+			}
+			out, _, err := handlers[tt.command](tt.parameters...)
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
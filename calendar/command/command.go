@@ -59,7 +59,32 @@ var cmds = []*model.AutocompleteData{
 		Trigger:  "event",
 		HelpText: "일정 관리.",
 		SubCommands: []*model.AutocompleteData{
-			model.NewAutocompleteData("create", "", "새 일정 생성 (데스크톱 전용)."),
+			model.NewAutocompleteData("create", "[\"제목\" 날짜 HH:MM-HH:MM @user ~channel|from 게시물 링크]", "새 일정 생성. 인수 없이 실행하면 대화 상자가 열립니다."),
+			model.NewAutocompleteData("edit", "[next|일정 ID] [+15m|YYYY-MM-DD HH:MM|subject 제목|repeat 주기]", "내가 주최한 일정의 시간, 제목 또는 반복 변경."),
+			model.NewAutocompleteData("cancel", "[next|일정 ID] [메시지]", "내가 주최한 일정을 취소하고 참석자에게 알림."),
+		},
+	},
+	newEventArgumentAutocompleteData("link", "이 채널에 일정을 연결해 일정 알림을 채널에도 게시.", config.PathEvents),
+	model.NewAutocompleteData("links", "", "이 채널에 연결된 예정된 일정 보기."),
+	newEventArgumentAutocompleteData("unlink", "이 채널과 일정의 연결 해제.", config.PathEvents+config.PathLinked),
+	{ // Channel calendar
+		Trigger:  "channelcal",
+		HelpText: "내 캘린더 또는 Microsoft 365 그룹 캘린더를 이 채널에 연결.",
+		SubCommands: []*model.AutocompleteData{
+			model.NewAutocompleteData("connect", "[캘린더 이름]", "캘린더를 이 채널에 연결. 이름 없이 실행하면 캘린더 목록이 표시됩니다."),
+			model.NewAutocompleteData("info", "", "이 채널에 연결된 캘린더 보기."),
+			model.NewAutocompleteData("agenda", "[HH:MM|off]", "평일에 오늘의 일정을 게시할 시간 설정."),
+			model.NewAutocompleteData("disconnect", "", "이 채널과 캘린더의 연결 해제."),
+		},
+	},
+	model.NewAutocompleteData("availability", "@user1 @user2 [YYYY-MM-DD]", "동료들의 한가한 시간과 바쁜 시간 보기."),
+	model.NewAutocompleteData("findtime", "~channel|@user1 @user2 <duration> [within N days] [poll]", "모든 참석자가 참석할 수 있는 회의 시간 찾기."),
+	{ // Out of office
+		Trigger:  "ooo",
+		HelpText: "자동 회신(부재 중) 보기 및 설정.",
+		SubCommands: []*model.AutocompleteData{
+			model.NewAutocompleteData("set", "[시작] [종료] [메시지]", "기간 동안 자동 회신 설정. 날짜는 YYYY-MM-DD 또는 YYYY-MM-DDTHH:MM."),
+			model.NewAutocompleteData("off", "", "자동 회신 끄기."),
 		},
 	},
 	model.NewAutocompleteData("today", "", "오늘의 일정 표시."),
@@ -119,8 +144,22 @@ func (c *Command) Handle() (string, bool, error) {
 		handler = c.requireConnectedUser(c.viewCalendar)
 	case "settings":
 		handler = c.requireConnectedUser(c.settings)
-	case "events":
+	case "event", "events":
 		handler = c.requireConnectedUser(c.event)
+	case "link":
+		handler = c.requireConnectedUser(c.link)
+	case "links":
+		handler = c.requireConnectedUser(c.links)
+	case "unlink":
+		handler = c.requireConnectedUser(c.unlink)
+	case "channelcal":
+		handler = c.requireConnectedUser(c.channelCalendar)
+	case "availability":
+		handler = c.requireConnectedUser(c.availability)
+	case "findtime":
+		handler = c.requireConnectedUser(c.findTime)
+	case "ooo":
+		handler = c.requireConnectedUser(c.outOfOffice)
 	// Admin only
 	case "showcals":
 		handler = c.requireConnectedUser(c.requireAdminUser(c.showCalendars))
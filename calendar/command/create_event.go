@@ -0,0 +1,196 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const eventDateFormat = "2006-01-02"
+
+func getCreateEventUsage() string {
+	return fmt.Sprintf("`/%s event create \"디자인 리뷰\" tomorrow 14:00-15:00 @alice @bob ~design`", config.Provider.CommandTrigger)
+}
+
+// createEvent opens the dialog to create an event, optionally about a post,
+// or creates the event described on one line:
+// "<subject>" <date> <HH:MM-HH:MM> [@user...] [~channel].
+func (c *Command) createEvent(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 || (len(parameters) == 2 && parameters[0] == "from") {
+		if c.Args.TriggerId == "" {
+			return "이 클라이언트에서는 대화 상자를 열 수 없습니다. 한 줄로 일정을 만들어주세요: " + getCreateEventUsage(), false, nil
+		}
+
+		var err error
+		if len(parameters) == 0 {
+			err = c.Engine.OpenCreateEventDialog(c.user(), c.Args.TriggerId, views.CreateEventDefaults{ChannelID: c.ChannelID})
+		} else {
+			err = c.Engine.OpenCreateEventDialogFromPost(c.user(), c.Args.TriggerId, postIDFromLink(parameters[1]))
+		}
+		if errors.Is(err, engine.ErrPostNotAccessible) {
+			return fmt.Sprintf("게시물을 찾을 수 없습니다: `%s`. 게시물의 **링크 복사**로 얻은 링크를 입력해주세요.", parameters[1]), false, nil
+		}
+		if err != nil {
+			return c.userError("일정 만들기 창을 열지 못했습니다", err)
+		}
+		return "", false, nil
+	}
+
+	tokens := splitQuoted(strings.Join(parameters, " "))
+	if len(tokens) < 3 || tokens[0] == "" {
+		return "제목, 날짜와 시간을 입력해주세요. 예시: " + getCreateEventUsage(), false, nil
+	}
+	subject := tokens[0]
+
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+
+	now := time.Now().In(loc)
+	date, ok := parseEventDate(tokens[1], now)
+	if !ok {
+		return fmt.Sprintf("잘못된 날짜입니다: `%s`. 예시: `today`, `tomorrow`, `2024-05-02`", tokens[1]), false, nil
+	}
+	start, end, ok := parseEventTimeRange(tokens[2], date, loc)
+	if !ok {
+		return fmt.Sprintf("잘못된 시간입니다: `%s`. 예시: `14:00-15:00`", tokens[2]), false, nil
+	}
+	if !end.After(start) {
+		return "종료 시간은 시작 시간보다 늦어야 합니다.", false, nil
+	}
+	if start.Before(now) {
+		return "지난 시간에는 일정을 만들 수 없습니다.", false, nil
+	}
+
+	channelName := ""
+	mattermostUserIDs := []string{}
+	for _, p := range tokens[3:] {
+		switch {
+		case strings.HasPrefix(p, "~") && channelName == "":
+			channelName = strings.TrimPrefix(p, "~")
+		case strings.HasPrefix(p, "@"):
+			mattermostUser, err := c.Engine.GetMattermostUserByUsername(strings.TrimPrefix(p, "@"))
+			if err != nil {
+				return fmt.Sprintf("사용자를 찾을 수 없습니다: `%s`", p), false, nil
+			}
+			mattermostUserIDs = append(mattermostUserIDs, mattermostUser.Id)
+		default:
+			return fmt.Sprintf("알 수 없는 인수입니다: `%s`. 예시: %s", p, getCreateEventUsage()), false, nil
+		}
+	}
+
+	channelID := ""
+	if channelName != "" {
+		channel, err := c.Engine.GetMattermostChannelByName(c.Args.TeamId, channelName)
+		if err != nil {
+			return fmt.Sprintf("채널을 찾을 수 없습니다: `~%s`", channelName), false, nil
+		}
+		channelID = channel.Id
+	}
+
+	event := &remote.Event{
+		Subject: subject,
+		Start:   remote.NewDateTime(start, timezone),
+		End:     remote.NewDateTime(end, timezone),
+	}
+	_, err = c.Engine.ScheduleEvent(c.user(), event, mattermostUserIDs, channelID)
+	switch {
+	case errors.Is(err, engine.ErrChannelLinkNotPermitted):
+		return fmt.Sprintf("`~%s` 채널에 이벤트를 연결할 권한이 없습니다.", channelName), false, nil
+	case err != nil:
+		return c.userError("일정을 만들지 못했습니다", err)
+	}
+
+	out := fmt.Sprintf("**%s** 일정을 만들었습니다: %s - %s", subject, start.Format(eventDateTimeFormat), end.Format("15:04"))
+	if channelName != "" {
+		out += fmt.Sprintf(" (`~%s` 채널에 연결됨)", channelName)
+	}
+	return out, false, nil
+}
+
+// postIDFromLink returns the ID of the post from its permalink, which ends
+// with /pl/<post ID>. Anything else is taken as a post ID.
+func postIDFromLink(link string) string {
+	if i := strings.LastIndex(link, "/pl/"); i >= 0 {
+		link = link[i+len("/pl/"):]
+	}
+	return strings.Trim(link, "/<>")
+}
+
+// parseEventDate parses today, tomorrow or a YYYY-MM-DD date, relative to
+// now and in its location.
+func parseEventDate(value string, now time.Time) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch value {
+	case "today", "오늘":
+		return today, true
+	case "tomorrow", "내일":
+		return today.AddDate(0, 0, 1), true
+	}
+
+	date, err := time.ParseInLocation(eventDateFormat, value, now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// parseEventTimeRange parses HH:MM-HH:MM on the date.
+func parseEventTimeRange(value string, date time.Time, loc *time.Location) (start, end time.Time, ok bool) {
+	startValue, endValue, found := strings.Cut(value, "-")
+	if !found {
+		return time.Time{}, time.Time{}, false
+	}
+
+	day := date.Format(eventDateFormat)
+	start, errStart := time.ParseInLocation(eventDateTimeFormat, day+" "+startValue, loc)
+	end, errEnd := time.ParseInLocation(eventDateTimeFormat, day+" "+endValue, loc)
+	if errStart != nil || errEnd != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// splitQuoted splits s on spaces, keeping the words between double quotes
+// together. Curly quotes, which mobile keyboards insert, are also accepted.
+func splitQuoted(s string) []string {
+	tokens := []string{}
+	var current strings.Builder
+	quoted, inToken := false, false
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '“' || r == '”':
+			quoted = !quoted
+			inToken = true
+		case r == ' ' && !quoted:
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestCreateEvent(t *testing.T) {
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	tomorrowStart := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 14, 0, 0, 0, time.UTC)
+
+	testcase := []struct {
+		name       string
+		triggerID  string
+		parameters []string
+		setup      func(engine.Engine)
+		assertions func(t *testing.T, output string, err error)
+	}{
+		{
+			name:       "dialog",
+			triggerID:  "trigger_id",
+			parameters: []string{},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().OpenCreateEventDialog(gomock.Any(), "trigger_id", views.CreateEventDefaults{ChannelID: "mockChannelID"}).Return(nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Empty(t, output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "dialog from a post",
+			triggerID:  "trigger_id",
+			parameters: []string{"from", "https://chat.example.com/team/pl/post_id"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().OpenCreateEventDialogFromPost(gomock.Any(), "trigger_id", "post_id").Return(nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Empty(t, output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "post not found",
+			triggerID:  "trigger_id",
+			parameters: []string{"from", "post_id"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().OpenCreateEventDialogFromPost(gomock.Any(), "trigger_id", "post_id").Return(engine.ErrPostNotAccessible).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "게시물을 찾을 수 없습니다: `post_id`. 게시물의 **링크 복사**로 얻은 링크를 입력해주세요.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "no dialog without a trigger",
+			parameters: []string{},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "이 클라이언트에서는 대화 상자를 열 수 없습니다. 한 줄로 일정을 만들어주세요: "+getCreateEventUsage(), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "invalid time range",
+			parameters: []string{"Sync", "tomorrow", "14:00"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "잘못된 시간입니다: `14:00`. 예시: `14:00-15:00`", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "end before start",
+			parameters: []string{"Sync", "tomorrow", "15:00-14:00"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "종료 시간은 시작 시간보다 늦어야 합니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "one line with attendees and channel",
+			parameters: []string{"\"Design", "review\"", "tomorrow", "14:00-15:00", "@alice", "@bob", "~design"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetMattermostUserByUsername("alice").Return(&model.User{Id: "alice_id"}, nil).Times(1)
+				mscal.EXPECT().GetMattermostUserByUsername("bob").Return(&model.User{Id: "bob_id"}, nil).Times(1)
+				mscal.EXPECT().GetMattermostChannelByName("mockTeamID", "design").Return(&model.Channel{Id: "design_id"}, nil).Times(1)
+				mscal.EXPECT().ScheduleEvent(gomock.Any(), &remote.Event{
+					Subject: "Design review",
+					Start:   remote.NewDateTime(tomorrowStart, "UTC"),
+					End:     remote.NewDateTime(tomorrowStart.Add(time.Hour), "UTC"),
+				}, []string{"alice_id", "bob_id"}, "design_id").Return(&remote.Event{}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, fmt.Sprintf("**Design review** 일정을 만들었습니다: %s - 15:00 (`~design` 채널에 연결됨)", tomorrowStart.Format(eventDateTimeFormat)), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "not permitted to link the channel",
+			parameters: []string{"Sync", tomorrow.Format("2006-01-02"), "14:00-14:30", "~design"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetMattermostChannelByName("mockTeamID", "design").Return(&model.Channel{Id: "design_id"}, nil).Times(1)
+				mscal.EXPECT().ScheduleEvent(gomock.Any(), gomock.Any(), []string{}, "design_id").Return(nil, engine.ErrChannelLinkNotPermitted).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "`~design` 채널에 이벤트를 연결할 권한이 없습니다.", output)
+				require.Nil(t, err)
+			},
+		},
+	}
+	for _, tt := range testcase {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			conf := &config.Config{
+				PluginURL: "http://localhost",
+			}
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command:   fmt.Sprintf("/%s event create", config.Provider.CommandTrigger),
+					UserId:    "mockUserID",
+					TeamId:    "mockTeamID",
+					TriggerId: tt.triggerID,
+				},
+				ChannelID: "mockChannelID",
+				Config:    conf,
+				Engine:    mscal,
+			}
+
+			tt.setup(mscal)
+
+			out, _, err := command.createEvent(tt.parameters...)
+
+			tt.assertions(t, out, err)
+		})
+	}
+}
+
+func TestSplitQuoted(t *testing.T) {
+	require.Equal(t, []string{"Design review", "tomorrow", "@alice"}, splitQuoted(`"Design review" tomorrow @alice`))
+	require.Equal(t, []string{"디자인 리뷰", "today"}, splitQuoted("“디자인 리뷰” today"))
+	require.Equal(t, []string{"Sync"}, splitQuoted("Sync"))
+	require.Equal(t, []string{"", "today"}, splitQuoted(`"" today`))
+}
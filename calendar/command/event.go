@@ -3,14 +3,215 @@
 
 package command
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	// nextEventID refers to the ongoing or next event organized by the user.
+	nextEventID = "next"
+
+	// nextEventLookahead is how far ahead the next event is looked up.
+	nextEventLookahead = 7 * 24 * time.Hour
+
+	eventDateTimeFormat = "2006-01-02 15:04"
+)
+
+func getEventHelp() string {
+	return "### 일정 명령어:\n" +
+		fmt.Sprintf("`/%s event create` - 대화 상자에서 새 일정 생성\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s event create from 게시물 링크` - 게시물로 새 일정 생성 (제목, 게시물 링크, 작성자와 멘션된 사용자가 채워짐)\n", config.Provider.CommandTrigger) +
+		getCreateEventUsage() + " - 한 줄로 새 일정 생성 (날짜는 `today`, `tomorrow` 또는 `YYYY-MM-DD`, 채널을 지정하면 채널에 연결)\n" +
+		fmt.Sprintf("`/%s event edit next +15m` - 진행 중이거나 다음 일정을 15분 뒤로 미루기 (`-15m`은 앞당기기)\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s event edit next 2024-05-02 14:00` - 일정 시작 시간 변경 (길이는 유지)\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s event edit next subject 새 제목` - 일정 제목 변경\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s event edit next repeat weekly until 2024-12-31` - 일정을 반복 일정으로 변경 (`daily`, `weekdays`, `weekly`, `monthly`, `yearly`, 종료 조건은 `until 날짜` 또는 `count 횟수`)\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s event cancel next 참석자에게 보낼 메시지` - 일정을 취소하고 참석자에게 알림\n", config.Provider.CommandTrigger) +
+		"`next` 대신 일정 ID를 사용할 수 있습니다. 내가 주최한 일정만 변경할 수 있습니다."
+}
+
 func (c *Command) event(parameters ...string) (string, bool, error) {
 	if len(parameters) == 0 {
-		return getDailySummaryHelp(), false, nil
+		return getEventHelp(), false, nil
+	}
+
+	switch parameters[0] {
+	case "create":
+		return c.createEvent(parameters[1:]...)
+	case "edit":
+		return c.editEvent(parameters[1:]...)
+	case "cancel":
+		return c.cancelEvent(parameters[1:]...)
+	}
+
+	return "잘못된 명령어입니다. 다시 시도해주세요\n\n" + getEventHelp(), false, nil
+}
+
+func (c *Command) editEvent(parameters ...string) (string, bool, error) {
+	if len(parameters) < 2 {
+		return getEventHelp(), false, nil
+	}
+
+	event, out, err := c.findEvent(parameters[0])
+	if event == nil {
+		return out, false, err
+	}
+
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+
+	update := &remote.Event{}
+	switch {
+	case parameters[1] == "subject":
+		update.Subject = strings.Join(parameters[2:], " ")
+		if update.Subject == "" {
+			return "새 제목을 입력해주세요.", false, nil
+		}
+
+	case parameters[1] == "repeat":
+		if event.IsRecurring() {
+			return "이미 반복 일정입니다.", false, nil
+		}
+		recurrence, out := parseRecurrence(event.Start.In(timezone).Time(), parameters[2:]...)
+		if recurrence == nil {
+			return out, false, nil
+		}
+		update.Recurrence = recurrence
+
+	case strings.HasPrefix(parameters[1], "+") || strings.HasPrefix(parameters[1], "-"):
+		d, parseErr := time.ParseDuration(parameters[1])
+		if parseErr != nil {
+			return fmt.Sprintf("잘못된 시간 간격입니다: `%s`. 예시: `+15m`, `-1h30m`", parameters[1]), false, nil
+		}
+		update.Start = remote.NewDateTime(event.Start.Time().Add(d), event.Start.TimeZone)
+		update.End = remote.NewDateTime(event.End.Time().Add(d), event.End.TimeZone)
+
+	default:
+		loc, locErr := time.LoadLocation(tz.Go(timezone))
+		if locErr != nil {
+			return c.userError("오류: 시간대를 찾을 수 없습니다", locErr)
+		}
+		start, parseErr := time.ParseInLocation(eventDateTimeFormat, strings.Join(parameters[1:], " "), loc)
+		if parseErr != nil {
+			return fmt.Sprintf("잘못된 날짜 및 시간입니다: `%s`. 예시: `2024-05-02 14:00`", strings.Join(parameters[1:], " ")), false, nil
+		}
+		duration := event.End.Time().Sub(event.Start.Time())
+		update.Start = remote.NewDateTime(start, timezone)
+		update.End = remote.NewDateTime(start.Add(duration), timezone)
+	}
+
+	updated, err := c.Engine.UpdateEvent(c.user(), event.ID, update)
+	if err != nil {
+		return c.userError("일정을 변경하지 못했습니다", err)
+	}
+
+	out = fmt.Sprintf("**%s** 일정이 변경되었습니다: %s - %s",
+		updated.Subject,
+		updated.Start.In(timezone).Time().Format(eventDateTimeFormat),
+		updated.End.In(timezone).Time().Format(eventDateTimeFormat),
+	)
+	if updated.Recurrence != nil {
+		out += fmt.Sprintf(" (반복: %s)", views.RenderRecurrence(updated.Recurrence))
+	}
+	return out, false, nil
+}
+
+// parseRecurrence parses "<frequency> [until YYYY-MM-DD|count N]" into the
+// recurrence of a series starting at start. When no recurrence is returned,
+// the message is to be shown to the user.
+func parseRecurrence(start time.Time, parameters ...string) (*remote.PatternedRecurrence, string) {
+	if len(parameters) == 0 {
+		return nil, "반복 주기를 입력해주세요: `daily`, `weekdays`, `weekly`, `monthly`, `yearly`"
+	}
+
+	recurrence, err := remote.NewPatternedRecurrence(parameters[0], 1, start)
+	if err != nil {
+		return nil, fmt.Sprintf("잘못된 반복 주기입니다: `%s`. 예시: `daily`, `weekdays`, `weekly`, `monthly`, `yearly`", parameters[0])
+	}
+
+	switch {
+	case len(parameters) == 1:
+	case len(parameters) == 3 && parameters[1] == "until":
+		_, parseErr := time.Parse("2006-01-02", parameters[2])
+		if parseErr != nil || parameters[2] < start.Format("2006-01-02") {
+			return nil, fmt.Sprintf("잘못된 종료 날짜입니다: `%s`. 예시: `until 2024-12-31`", parameters[2])
+		}
+		recurrence.EndBy(parameters[2])
+	case len(parameters) == 3 && parameters[1] == "count":
+		count, parseErr := strconv.Atoi(parameters[2])
+		if parseErr != nil || count < 1 {
+			return nil, fmt.Sprintf("잘못된 반복 횟수입니다: `%s`. 예시: `count 10`", parameters[2])
+		}
+		recurrence.EndAfter(count)
+	default:
+		return nil, "종료 조건은 `until YYYY-MM-DD` 또는 `count 횟수` 형식으로 입력해주세요."
+	}
+
+	return recurrence, ""
+}
+
+func (c *Command) cancelEvent(parameters ...string) (string, bool, error) {
+	if len(parameters) < 1 {
+		return getEventHelp(), false, nil
 	}
 
-	if parameters[0] == "create" {
-		return "이벤트 생성은 데스크톱에서만 지원됩니다.", false, nil
+	event, out, err := c.findEvent(parameters[0])
+	if event == nil {
+		return out, false, err
 	}
 
-	return "", false, nil
+	err = c.Engine.CancelEvent(c.user(), event.ID, strings.Join(parameters[1:], " "))
+	if err != nil {
+		return c.userError("일정을 취소하지 못했습니다", err)
+	}
+
+	return fmt.Sprintf("**%s** 일정이 취소되었습니다. 참석자에게 취소 알림이 전송됩니다.", event.Subject), false, nil
+}
+
+// findEvent loads the event to change, which must be organized by the user.
+// When no event is returned, the message is to be shown to the user.
+func (c *Command) findEvent(eventID string) (*remote.Event, string, error) {
+	if eventID != nextEventID {
+		event, err := c.Engine.GetEvent(c.user(), eventID)
+		if err != nil {
+			out, _, err := c.userError("일정을 찾을 수 없습니다", err)
+			return nil, out, err
+		}
+		if !event.IsOrganizer {
+			return nil, "내가 주최한 일정만 변경할 수 있습니다.", nil
+		}
+		return event, "", nil
+	}
+
+	now := time.Now()
+	events, err := c.Engine.ViewCalendar(c.user(), now, now.Add(nextEventLookahead))
+	if err != nil {
+		out, _, err := c.userError("일정을 불러오지 못했습니다", err)
+		return nil, out, err
+	}
+	for _, event := range events {
+		if event.IsOrganizer && !event.IsCancelled && event.End.Time().After(now) {
+			return event, "", nil
+		}
+	}
+
+	return nil, "진행 중이거나 예정된 내가 주최한 일정이 없습니다.", nil
+}
+
+func (c *Command) userError(message string, err error) (string, bool, error) {
+	if strings.Contains(err.Error(), store.ErrorRefreshTokenNotSet) || strings.Contains(err.Error(), store.ErrorUserInactive) {
+		return store.ErrorUserInactive, false, nil
+	}
+	return message, false, err
 }
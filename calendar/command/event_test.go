@@ -0,0 +1,232 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestEvent(t *testing.T) {
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Minute)
+	organized := &remote.Event{
+		ID:          "event_id",
+		Subject:     "Planning",
+		IsOrganizer: true,
+		Start:       remote.NewDateTime(start, "UTC"),
+		End:         remote.NewDateTime(start.Add(30*time.Minute), "UTC"),
+	}
+	invited := &remote.Event{
+		ID:      "other_event_id",
+		Subject: "Review",
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(30*time.Minute), "UTC"),
+	}
+
+	testcase := []struct {
+		name       string
+		parameters []string
+		setup      func(engine.Engine)
+		assertions func(t *testing.T, output string, err error)
+	}{
+		{
+			name:       "no parameters",
+			parameters: []string{},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, getEventHelp(), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "shift next event",
+			parameters: []string{"edit", "next", "+15m"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().ViewCalendar(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*remote.Event{invited, organized}, nil).Times(1)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().UpdateEvent(gomock.Any(), "event_id", &remote.Event{
+					Start: remote.NewDateTime(start.Add(15*time.Minute), "UTC"),
+					End:   remote.NewDateTime(start.Add(45*time.Minute), "UTC"),
+				}).Return(&remote.Event{
+					Subject: "Planning",
+					Start:   remote.NewDateTime(start.Add(15*time.Minute), "UTC"),
+					End:     remote.NewDateTime(start.Add(45*time.Minute), "UTC"),
+				}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, fmt.Sprintf("**Planning** 일정이 변경되었습니다: %s - %s",
+					start.Add(15*time.Minute).Format(eventDateTimeFormat),
+					start.Add(45*time.Minute).Format(eventDateTimeFormat)), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "rename event by ID",
+			parameters: []string{"edit", "event_id", "subject", "New", "name"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetEvent(gomock.Any(), "event_id").Return(organized, nil).Times(1)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().UpdateEvent(gomock.Any(), "event_id", &remote.Event{Subject: "New name"}).Return(&remote.Event{
+					Subject: "New name",
+					Start:   organized.Start,
+					End:     organized.End,
+				}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Contains(t, output, "**New name** 일정이 변경되었습니다")
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "invalid shift",
+			parameters: []string{"edit", "event_id", "+soon"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetEvent(gomock.Any(), "event_id").Return(organized, nil).Times(1)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "잘못된 시간 간격입니다: `+soon`. 예시: `+15m`, `-1h30m`", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "repeat event weekly",
+			parameters: []string{"edit", "event_id", "repeat", "weekly", "count", "4"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetEvent(gomock.Any(), "event_id").Return(organized, nil).Times(1)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				recurrence, err := remote.NewPatternedRecurrence(remote.FrequencyWeekly, 1, start)
+				require.NoError(t, err)
+				recurrence.EndAfter(4)
+				mscal.EXPECT().UpdateEvent(gomock.Any(), "event_id", &remote.Event{Recurrence: recurrence}).Return(&remote.Event{
+					Subject:    "Planning",
+					Start:      organized.Start,
+					End:        organized.End,
+					Recurrence: recurrence,
+				}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Contains(t, output, "**Planning** 일정이 변경되었습니다")
+				require.Contains(t, output, "(4회)")
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "repeat with invalid frequency",
+			parameters: []string{"edit", "event_id", "repeat", "hourly"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetEvent(gomock.Any(), "event_id").Return(organized, nil).Times(1)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "잘못된 반복 주기입니다: `hourly`. 예시: `daily`, `weekdays`, `weekly`, `monthly`, `yearly`", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "edit event not organized by the user",
+			parameters: []string{"edit", "other_event_id", "+15m"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetEvent(gomock.Any(), "other_event_id").Return(invited, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "내가 주최한 일정만 변경할 수 있습니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "no next event",
+			parameters: []string{"cancel", "next"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().ViewCalendar(gomock.Any(), gomock.Any(), gomock.Any()).Return([]*remote.Event{invited}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "진행 중이거나 예정된 내가 주최한 일정이 없습니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "cancel event with message",
+			parameters: []string{"cancel", "event_id", "Moved", "to", "Friday"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetEvent(gomock.Any(), "event_id").Return(organized, nil).Times(1)
+				mscal.EXPECT().CancelEvent(gomock.Any(), "event_id", "Moved to Friday").Return(nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "**Planning** 일정이 취소되었습니다. 참석자에게 취소 알림이 전송됩니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "error cancelling event",
+			parameters: []string{"cancel", "event_id"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetEvent(gomock.Any(), "event_id").Return(organized, nil).Times(1)
+				mscal.EXPECT().CancelEvent(gomock.Any(), "event_id", "").Return(errors.New("cancel error")).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "일정을 취소하지 못했습니다", output)
+				require.Equal(t, "cancel error", err.Error())
+			},
+		},
+		{
+			name:       "invalid command",
+			parameters: []string{"invalid"},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "잘못된 명령어입니다. 다시 시도해주세요\n\n"+getEventHelp(), output)
+				require.Nil(t, err)
+			},
+		},
+	}
+	for _, tt := range testcase {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			conf := &config.Config{
+				PluginURL: "http://localhost",
+			}
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: fmt.Sprintf("/%s event", config.Provider.CommandTrigger),
+					UserId:  "mockUserID",
+				},
+				ChannelID: "mockChannelID",
+				Config:    conf,
+				Engine:    mscal,
+			}
+
+			tt.setup(mscal)
+
+			out, _, err := command.event(tt.parameters...)
+
+			tt.assertions(t, out, err)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const (
+	defaultFindTimeDays = 7
+	maxFindTimeDays     = 30
+	maxFindTimeDuration = 8 * time.Hour
+
+	// Meeting times are looked up from the next findTimeStep.
+	findTimeStep = 30 * time.Minute
+)
+
+func getFindTimeHelp() string {
+	return "### 회의 시간 찾기 명령어:\n" +
+		fmt.Sprintf("`/%s findtime ~channel 30m` - 채널 멤버 모두가 참석할 수 있는 30분짜리 회의 시간 찾기\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s findtime @user1 @user2 1h within 3 days` - 3일 안에 동료들과의 1시간짜리 회의 시간 찾기\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s findtime ~channel 1h poll` - 찾은 시간들을 채널에 올려 투표하기. 주최자가 시간을 확정하면 투표한 사람들이 초대됩니다\n", config.Provider.CommandTrigger) +
+		"제안된 시간을 선택하면 이벤트가 만들어지고, 채널을 지정한 경우 채널에 연결됩니다."
+}
+
+func (c *Command) findTime(parameters ...string) (string, bool, error) {
+	channelName := ""
+	usernames := []string{}
+	var duration time.Duration
+	days := defaultFindTimeDays
+	poll := false
+	for i := 0; i < len(parameters); i++ {
+		p := parameters[i]
+		switch {
+		case strings.HasPrefix(p, "~"):
+			if channelName != "" {
+				return getFindTimeHelp(), false, nil
+			}
+			channelName = strings.TrimPrefix(p, "~")
+		case strings.HasPrefix(p, "@"):
+			usernames = append(usernames, strings.TrimPrefix(p, "@"))
+		case p == "poll":
+			poll = true
+		case p == "within":
+			if i+1 >= len(parameters) {
+				return getFindTimeHelp(), false, nil
+			}
+			n, err := strconv.Atoi(parameters[i+1])
+			if err != nil || n < 1 || n > maxFindTimeDays {
+				return fmt.Sprintf("기간은 1일에서 %d일 사이여야 합니다.", maxFindTimeDays), false, nil
+			}
+			days = n
+			i++
+			if i+1 < len(parameters) && (parameters[i+1] == "days" || parameters[i+1] == "day") {
+				i++
+			}
+		default:
+			d, err := time.ParseDuration(p)
+			if err != nil || duration != 0 {
+				return getFindTimeHelp(), false, nil
+			}
+			if d <= 0 || d > maxFindTimeDuration {
+				return fmt.Sprintf("회의 시간은 %s 이하여야 합니다. 예시: `30m`, `1h`, `1h30m`", maxFindTimeDuration), false, nil
+			}
+			duration = d
+		}
+	}
+	if duration == 0 || (channelName == "" && len(usernames) == 0) {
+		return getFindTimeHelp(), false, nil
+	}
+	if poll && channelName == "" {
+		return "투표는 `~channel`과 함께 사용해야 합니다.", false, nil
+	}
+
+	req := &engine.FindTimeRequest{
+		Subject:  "회의",
+		Duration: duration,
+	}
+	if channelName != "" {
+		channel, err := c.Engine.GetMattermostChannelByName(c.Args.TeamId, channelName)
+		if err != nil {
+			return fmt.Sprintf("채널을 찾을 수 없습니다: `~%s`", channelName), false, nil
+		}
+		req.ChannelID = channel.Id
+		req.Subject = channel.DisplayName + " 회의"
+	}
+	for _, username := range usernames {
+		mattermostUser, err := c.Engine.GetMattermostUserByUsername(username)
+		if err != nil {
+			return fmt.Sprintf("사용자를 찾을 수 없습니다: `@%s`", username), false, nil
+		}
+		req.MattermostUserIDs = append(req.MattermostUserIDs, mattermostUser.Id)
+	}
+
+	req.From = time.Now().Truncate(findTimeStep).Add(findTimeStep)
+	req.To = req.From.AddDate(0, 0, days)
+
+	var n int
+	var err error
+	if poll {
+		n, err = c.Engine.CreateMeetingPoll(c.user(), req)
+	} else {
+		n, err = c.Engine.FindTime(c.user(), req)
+	}
+	switch {
+	case errors.Is(err, engine.ErrNoMeetingAttendees):
+		return "초대할 참석자가 없습니다.", false, nil
+	case errors.Is(err, engine.ErrTooManyMeetingAttendees):
+		return fmt.Sprintf("참석자는 최대 %d명까지 지정할 수 있습니다.", engine.MaxMeetingAttendees), false, nil
+	case errors.Is(err, engine.ErrChannelLinkNotPermitted):
+		return fmt.Sprintf("`~%s` 채널에 이벤트를 연결할 권한이 없습니다.", channelName), false, nil
+	case errors.Is(err, remote.ErrNotImplemented):
+		return fmt.Sprintf("%s에서는 회의 시간 찾기를 지원하지 않습니다.", config.Provider.DisplayName), false, nil
+	case err != nil:
+		return c.userError("회의 시간을 찾지 못했습니다", err)
+	}
+
+	if n == 0 {
+		return fmt.Sprintf("%d일 안에 모든 참석자가 참석할 수 있는 시간을 찾지 못했습니다.", days), false, nil
+	}
+	if poll {
+		return fmt.Sprintf("`~%s` 채널에 회의 시간 후보 %d개로 투표를 올렸습니다.", channelName, n), false, nil
+	}
+	return fmt.Sprintf("회의 시간 제안 %d개를 DM으로 보냈습니다.", n), false, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestFindTime(t *testing.T) {
+	testcase := []struct {
+		name       string
+		parameters []string
+		setup      func(engine.Engine)
+		assertions func(t *testing.T, output string, err error)
+	}{
+		{
+			name:       "no duration",
+			parameters: []string{"~town-square"},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, getFindTimeHelp(), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "no attendees",
+			parameters: []string{"30m"},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, getFindTimeHelp(), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "too many days",
+			parameters: []string{"@alice", "30m", "within", "60", "days"},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "기간은 1일에서 30일 사이여야 합니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "unknown channel",
+			parameters: []string{"~nowhere", "30m"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetMattermostChannelByName("mockTeamID", "nowhere").Return(nil, errors.New("not found")).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "채널을 찾을 수 없습니다: `~nowhere`", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "channel meeting",
+			parameters: []string{"~town-square", "1h", "within", "3", "days"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetMattermostChannelByName("mockTeamID", "town-square").Return(&model.Channel{Id: "channel_id", DisplayName: "Town Square"}, nil).Times(1)
+				mscal.EXPECT().FindTime(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *engine.User, req *engine.FindTimeRequest) (int, error) {
+					require.Equal(t, "Town Square 회의", req.Subject)
+					require.Equal(t, "channel_id", req.ChannelID)
+					require.Equal(t, time.Hour, req.Duration)
+					require.Equal(t, req.From.AddDate(0, 0, 3), req.To)
+					require.True(t, req.From.After(time.Now()))
+					return 3, nil
+				}).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "회의 시간 제안 3개를 DM으로 보냈습니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "no time found",
+			parameters: []string{"@alice", "@bob", "30m"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetMattermostUserByUsername("alice").Return(&model.User{Id: "alice_id"}, nil).Times(1)
+				mscal.EXPECT().GetMattermostUserByUsername("bob").Return(&model.User{Id: "bob_id"}, nil).Times(1)
+				mscal.EXPECT().FindTime(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *engine.User, req *engine.FindTimeRequest) (int, error) {
+					require.Equal(t, []string{"alice_id", "bob_id"}, req.MattermostUserIDs)
+					require.Empty(t, req.ChannelID)
+					return 0, nil
+				}).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "7일 안에 모든 참석자가 참석할 수 있는 시간을 찾지 못했습니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "poll without channel",
+			parameters: []string{"@alice", "30m", "poll"},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "투표는 `~channel`과 함께 사용해야 합니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "channel poll",
+			parameters: []string{"~town-square", "1h", "poll"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetMattermostChannelByName("mockTeamID", "town-square").Return(&model.Channel{Id: "channel_id", DisplayName: "Town Square"}, nil).Times(1)
+				mscal.EXPECT().CreateMeetingPoll(gomock.Any(), gomock.Any()).DoAndReturn(func(_ *engine.User, req *engine.FindTimeRequest) (int, error) {
+					require.Equal(t, "channel_id", req.ChannelID)
+					require.Equal(t, time.Hour, req.Duration)
+					return 4, nil
+				}).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "`~town-square` 채널에 회의 시간 후보 4개로 투표를 올렸습니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "not supported by the provider",
+			parameters: []string{"@alice", "30m"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetMattermostUserByUsername("alice").Return(&model.User{Id: "alice_id"}, nil).Times(1)
+				mscal.EXPECT().FindTime(gomock.Any(), gomock.Any()).Return(0, remote.ErrNotImplemented).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, fmt.Sprintf("%s에서는 회의 시간 찾기를 지원하지 않습니다.", config.Provider.DisplayName), output)
+				require.Nil(t, err)
+			},
+		},
+	}
+	for _, tt := range testcase {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			conf := &config.Config{
+				PluginURL: "http://localhost",
+			}
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: fmt.Sprintf("/%s findtime", config.Provider.CommandTrigger),
+					UserId:  "mockUserID",
+					TeamId:  "mockTeamID",
+				},
+				ChannelID: "mockChannelID",
+				Config:    conf,
+				Engine:    mscal,
+			}
+
+			tt.setup(mscal)
+
+			out, _, err := command.findTime(tt.parameters...)
+
+			tt.assertions(t, out, err)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	oooDateFormat     = "2006-01-02"
+	oooDateTimeFormat = "2006-01-02T15:04"
+)
+
+func getOutOfOfficeHelp() string {
+	return "### 부재 중 명령어:\n" +
+		fmt.Sprintf("`/%s ooo` - 현재 자동 회신 상태 보기\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s ooo set 2024-07-01 2024-07-05 휴가 중입니다` - 기간 동안 자동 회신 설정 (날짜만 입력하면 종료일 하루 전체 포함)\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s ooo set 2024-07-01T13:00 2024-07-01T18:00 외근 중입니다` - 시간까지 지정해 자동 회신 설정\n", config.Provider.CommandTrigger) +
+		fmt.Sprintf("`/%s ooo off` - 자동 회신 끄기", config.Provider.CommandTrigger)
+}
+
+func (c *Command) outOfOffice(parameters ...string) (string, bool, error) {
+	if len(parameters) == 0 {
+		return c.showOutOfOffice()
+	}
+
+	switch parameters[0] {
+	case "set":
+		return c.setOutOfOffice(parameters[1:]...)
+	case "off":
+		return c.setAutomaticReplies(&remote.AutomaticRepliesSetting{
+			Status: remote.AutomaticRepliesStatusDisabled,
+		}, "자동 회신이 꺼졌습니다.")
+	}
+
+	return "잘못된 명령어입니다. 다시 시도해주세요\n\n" + getOutOfOfficeHelp(), false, nil
+}
+
+func (c *Command) showOutOfOffice() (string, bool, error) {
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+
+	setting, err := c.Engine.GetAutomaticReplies(c.user())
+	if err != nil {
+		return c.userError("자동 회신 설정을 불러오지 못했습니다", err)
+	}
+
+	out := fmt.Sprintf("**자동 회신:** %s", views.RenderAutomaticReplies(setting, timezone))
+	if setting.Status != remote.AutomaticRepliesStatusDisabled && setting.InternalReplyMessage != "" {
+		out += fmt.Sprintf("\n**메시지:** %s", setting.InternalReplyMessage)
+	}
+	return out + "\n\n" + getOutOfOfficeHelp(), false, nil
+}
+
+func (c *Command) setOutOfOffice(parameters ...string) (string, bool, error) {
+	if len(parameters) < 3 {
+		return getOutOfOfficeHelp(), false, nil
+	}
+
+	timezone, err := c.Engine.GetTimezone(c.user())
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		return c.userError("오류: 시간대를 찾을 수 없습니다", err)
+	}
+
+	start, _, ok := parseOutOfOfficeTime(parameters[0], loc)
+	if !ok {
+		return fmt.Sprintf("잘못된 시작 날짜입니다: `%s`. 예시: `2024-07-01` 또는 `2024-07-01T13:00`", parameters[0]), false, nil
+	}
+	end, isDate, ok := parseOutOfOfficeTime(parameters[1], loc)
+	if !ok {
+		return fmt.Sprintf("잘못된 종료 날짜입니다: `%s`. 예시: `2024-07-05` 또는 `2024-07-05T18:00`", parameters[1]), false, nil
+	}
+	if isDate {
+		// The end date is included.
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return "종료 시간은 시작 시간 이후여야 합니다.", false, nil
+	}
+	if !end.After(time.Now()) {
+		return "종료 시간이 이미 지났습니다.", false, nil
+	}
+
+	message := strings.Join(parameters[2:], " ")
+	setting := &remote.AutomaticRepliesSetting{
+		Status:                 remote.AutomaticRepliesStatusScheduled,
+		ScheduledStartDateTime: remote.NewDateTime(start, timezone),
+		ScheduledEndDateTime:   remote.NewDateTime(end, timezone),
+		InternalReplyMessage:   message,
+		ExternalReplyMessage:   message,
+	}
+
+	return c.setAutomaticReplies(setting, fmt.Sprintf("자동 회신이 설정되었습니다: %s", views.RenderAutomaticReplies(setting, timezone)))
+}
+
+func (c *Command) setAutomaticReplies(setting *remote.AutomaticRepliesSetting, out string) (string, bool, error) {
+	err := c.Engine.SetAutomaticReplies(c.user(), setting)
+	if errors.Is(err, remote.ErrAutomaticRepliesNotSupported) {
+		return fmt.Sprintf("%s에서는 자동 회신 설정을 지원하지 않습니다.", config.Provider.DisplayName), false, nil
+	}
+	if errors.Is(err, remote.ErrAutomaticRepliesForbidden) {
+		return fmt.Sprintf("자동 회신을 설정할 권한이 없습니다. `/%[1]s disconnect`를 실행한 뒤 `/%[1]s connect`로 계정을 다시 연결하세요.", config.Provider.CommandTrigger), false, nil
+	}
+	if err != nil {
+		return c.userError("자동 회신을 설정하지 못했습니다", err)
+	}
+	return out, false, nil
+}
+
+// parseOutOfOfficeTime parses a date, or a date and time, in loc. It returns
+// whether only a date was given.
+func parseOutOfOfficeTime(value string, loc *time.Location) (time.Time, bool, bool) {
+	if t, err := time.ParseInLocation(oooDateTimeFormat, value, loc); err == nil {
+		return t, false, true
+	}
+	if t, err := time.ParseInLocation(oooDateFormat, value, loc); err == nil {
+		return t, true, true
+	}
+	return time.Time{}, false, false
+}
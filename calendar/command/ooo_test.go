@@ -0,0 +1,181 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package command
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestOutOfOffice(t *testing.T) {
+	start := time.Now().UTC().AddDate(0, 0, 1).Truncate(24 * time.Hour)
+	end := start.AddDate(0, 0, 3)
+
+	testcase := []struct {
+		name       string
+		parameters []string
+		setup      func(engine.Engine)
+		assertions func(t *testing.T, output string, err error)
+	}{
+		{
+			name:       "show automatic replies",
+			parameters: []string{},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().GetAutomaticReplies(gomock.Any()).Return(&remote.AutomaticRepliesSetting{
+					Status:               remote.AutomaticRepliesStatusAlwaysEnabled,
+					InternalReplyMessage: "On vacation",
+				}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "**자동 회신:** 켜짐\n**메시지:** On vacation\n\n"+getOutOfOfficeHelp(), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "set with dates",
+			parameters: []string{"set", start.Format(oooDateFormat), end.Format(oooDateFormat), "On", "vacation"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+				mscal.EXPECT().SetAutomaticReplies(gomock.Any(), &remote.AutomaticRepliesSetting{
+					Status:                 remote.AutomaticRepliesStatusScheduled,
+					ScheduledStartDateTime: remote.NewDateTime(start, "UTC"),
+					ScheduledEndDateTime:   remote.NewDateTime(end.AddDate(0, 0, 1), "UTC"),
+					InternalReplyMessage:   "On vacation",
+					ExternalReplyMessage:   "On vacation",
+				}).Return(nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, fmt.Sprintf("자동 회신이 설정되었습니다: 예약됨 (%s 00:00 - %s 00:00)",
+					start.Format(oooDateFormat), end.AddDate(0, 0, 1).Format(oooDateFormat)), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "set with end before start",
+			parameters: []string{"set", end.Format(oooDateTimeFormat), start.Format(oooDateTimeFormat), "On", "vacation"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "종료 시간은 시작 시간 이후여야 합니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "set with invalid date",
+			parameters: []string{"set", "tomorrow", end.Format(oooDateFormat), "On", "vacation"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().GetTimezone(gomock.Any()).Return("UTC", nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "잘못된 시작 날짜입니다: `tomorrow`. 예시: `2024-07-01` 또는 `2024-07-01T13:00`", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "set without message",
+			parameters: []string{"set", start.Format(oooDateFormat), end.Format(oooDateFormat)},
+			setup:      func(_ engine.Engine) {},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, getOutOfOfficeHelp(), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "turn off",
+			parameters: []string{"off"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().SetAutomaticReplies(gomock.Any(), &remote.AutomaticRepliesSetting{
+					Status: remote.AutomaticRepliesStatusDisabled,
+				}).Return(nil).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "자동 회신이 꺼졌습니다.", output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "not supported by the provider",
+			parameters: []string{"off"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().SetAutomaticReplies(gomock.Any(), gomock.Any()).Return(remote.ErrAutomaticRepliesNotSupported).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, fmt.Sprintf("%s에서는 자동 회신 설정을 지원하지 않습니다.", config.Provider.DisplayName), output)
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "account connected before automatic replies could be set",
+			parameters: []string{"off"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().SetAutomaticReplies(gomock.Any(), gomock.Any()).Return(remote.ErrAutomaticRepliesForbidden).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Contains(t, output, fmt.Sprintf("`/%s connect`", config.Provider.CommandTrigger))
+				require.Nil(t, err)
+			},
+		},
+		{
+			name:       "error turning off",
+			parameters: []string{"off"},
+			setup: func(m engine.Engine) {
+				mscal := m.(*mock_engine.MockEngine)
+				mscal.EXPECT().SetAutomaticReplies(gomock.Any(), gomock.Any()).Return(errors.New("patch error")).Times(1)
+			},
+			assertions: func(t *testing.T, output string, err error) {
+				require.Equal(t, "자동 회신을 설정하지 못했습니다", output)
+				require.Equal(t, "patch error", err.Error())
+			},
+		},
+	}
+	for _, tt := range testcase {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			conf := &config.Config{
+				PluginURL: "http://localhost",
+			}
+
+			mscal := mock_engine.NewMockEngine(ctrl)
+			command := Command{
+				Context: &plugin.Context{},
+				Args: &model.CommandArgs{
+					Command: fmt.Sprintf("/%s ooo", config.Provider.CommandTrigger),
+					UserId:  "mockUserID",
+				},
+				ChannelID: "mockChannelID",
+				Config:    conf,
+				Engine:    mscal,
+			}
+
+			tt.setup(mscal)
+
+			out, _, err := command.outOfOffice(tt.parameters...)
+
+			tt.assertions(t, out, err)
+		})
+	}
+}
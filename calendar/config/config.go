@@ -13,11 +13,26 @@ type StoredConfig struct {
 	OAuth2Authority    string
 	OAuth2ClientID     string
 	OAuth2ClientSecret string
+
+	// GraphBaseURL and IdentityAuthorityURL point the plugin at a national
+	// cloud, or at a stand-in for Graph. Empty values stand for the public
+	// cloud.
+	GraphBaseURL         string
+	IdentityAuthorityURL string
+
 	bot.Config
 	EnableStatusSync   bool
 	EnableDailySummary bool
 
 	EncryptionKey string
+
+	// MaxResultPages bounds the number of pages read for a single collection
+	// of the remote, such as a calendar view. Zero stands for the default.
+	MaxResultPages int
+
+	// LocalFixturesPath is the directory of the fixtures of the local remote.
+	// When set, the local remote is used instead of the provider's.
+	LocalFixturesPath string
 }
 
 func (c *StoredConfig) IsOAuthConfigured() bool {
@@ -27,6 +42,15 @@ func (c *StoredConfig) IsOAuthConfigured() bool {
 type ProviderFeatures struct {
 	EncryptedStore     bool
 	EventNotifications bool
+	// EventPolling is set when event notifications are fetched by polling
+	// the remote instead of being pushed to the webhook.
+	EventPolling bool
+	// OnlineMeetings is set when events can be created with an online
+	// meeting, such as a Teams meeting.
+	OnlineMeetings bool
+	// AutomaticReplies is set when the automatic replies, or out of office
+	// message, of users can be read and changed.
+	AutomaticReplies bool
 }
 
 // ProviderConfig represents the specific configuration that changes when building for different
@@ -61,3 +85,7 @@ type Config struct {
 func (c *Config) GetNotificationURL() string {
 	return c.PluginURL + FullPathEventNotification
 }
+
+func (c *Config) GetLifecycleNotificationURL() string {
+	return c.PluginURL + FullPathLifecycleNotification
+}
@@ -17,22 +17,34 @@ const (
 	PathDecline               = "/decline"
 	PathTentative             = "/tentative"
 	PathConfirmStatusChange   = "/confirm"
+	PathFindTime              = "/findtime"
+	PathMeetingPollVote       = "/poll-vote"
+	PathMeetingPollFinalize   = "/poll-finalize"
+	PathReminder              = "/reminder"
 	PathNotification          = "/notification/v1"
 	PathEvent                 = "/event"
+	PathLifecycle             = "/lifecycle"
 	PathVerifyDomain          = "/verify"
+	PathLocal                 = "/local"
 
 	PathAutocomplete = "/autocomplete"
 	PathUsers        = "/users"
 	PathChannels     = "/channels"
+	PathLinked       = "/linked"
 
 	InternalAPIPath   = "/api/v1"
 	PathEvents        = "/events"
 	PathCreate        = "/create"
+	PathUpdate        = "/update"
+	PathCancel        = "/cancel"
+	PathDelete        = "/delete"
 	PathProvider      = "/provider"
 	PathConnectedUser = "/me"
+	PathAvailability  = "/availability"
 
-	FullPathEventNotification = PathNotification + PathEvent
-	FullPathOAuth2Redirect    = PathOAuth2 + PathComplete
+	FullPathEventNotification     = PathNotification + PathEvent
+	FullPathLifecycleNotification = PathNotification + PathLifecycle
+	FullPathOAuth2Redirect        = PathOAuth2 + PathComplete
 
 	EventIDKey = "EventID"
 )
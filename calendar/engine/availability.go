@@ -89,7 +89,6 @@ func (m *mscalendar) SyncAll() (string, *StatusSyncJobSummary, error) {
 
 func (m *mscalendar) retrieveUsersToSync(userIndex store.UserIndex, syncJobSummary *StatusSyncJobSummary, fetchIndividually bool) ([]*store.User, []*remote.ViewCalendarResponse, error) {
 	start := time.Now().UTC()
-	end := time.Now().UTC().Add(calendarViewTimeWindowSize)
 
 	numberOfLogs := 0
 	users := []*store.User{}
@@ -111,7 +110,8 @@ func (m *mscalendar) retrieveUsersToSync(userIndex store.UserIndex, syncJobSumma
 		}
 
 		// If user does not have the proper features enabled, just go to the next one
-		if !(user.IsConfiguredForStatusUpdates() || user.IsConfiguredForCustomStatusUpdates() || user.Settings.ReceiveReminders) {
+		if !(user.IsConfiguredForStatusUpdates() || user.IsConfiguredForCustomStatusUpdates() || user.IsConfiguredForOutOfOfficeSync() || user.OutOfOffice != nil || user.Settings.ReceiveReminders ||
+			user.IsConfiguredForWorkingHours() || user.AfterHours != nil) {
 			continue
 		}
 
@@ -123,7 +123,7 @@ func (m *mscalendar) retrieveUsersToSync(userIndex store.UserIndex, syncJobSumma
 			}
 
 			calendarUser := newUserFromStoredUser(user)
-			calendarEvents, err := engine.GetCalendarEvents(calendarUser, start, end, true)
+			calendarEvents, err := engine.GetCalendarEvents(calendarUser, start, start.Add(calendarViewTimeWindow(user)), true)
 			if err != nil {
 				syncJobSummary.NumberOfUsersFailedStatusChanged++
 				m.Logger.With(bot.LogContext{
@@ -172,14 +172,18 @@ func (m *mscalendar) syncUsers(userIndex store.UserIndex, fetchIndividually bool
 		return "연결된 사용자를 찾을 수 없습니다", syncJobSummary, nil
 	}
 	syncJobSummary.NumberOfUsersProcessed = len(userIndex)
+	m.syncedMailboxSettings = map[string]*remote.MailboxSettings{}
 
 	users, calendarViews, err := m.retrieveUsersToSync(userIndex, syncJobSummary, fetchIndividually)
 	if err != nil {
 		return err.Error(), syncJobSummary, errors.Wrapf(err, "동기화할 사용자를 검색하는 중 오류 발생 (individually=%v)", fetchIndividually)
 	}
 
+	m.syncWorkingHours(users)
 	m.deliverReminders(users, calendarViews, fetchIndividually)
-	out, numberOfUsersStatusChanged, numberOfUsersFailedStatusChanged, err := m.setUserStatuses(users, calendarViews)
+	// The views look further ahead for the reminders than the statuses do.
+	statusViews := limitCalendarViews(calendarViews, time.Now().Add(calendarViewTimeWindowSize))
+	out, numberOfUsersStatusChanged, numberOfUsersFailedStatusChanged, err := m.setUserStatuses(users, statusViews)
 	if err != nil {
 		return "", syncJobSummary, errors.Wrap(err, "사용자 상태를 설정하는 중 오류 발생")
 	}
@@ -222,16 +226,15 @@ func (m *mscalendar) deliverReminders(users []*store.User, calendarViews []*remo
 			continue
 		}
 
-		mattermostUserID := usersByRemoteID[view.RemoteUserID].MattermostUserID
 		if fetchIndividually {
 			engine, err := m.FilterCopy(withActingUser(user.MattermostUserID))
 			if err != nil {
 				m.Logger.With(bot.LogContext{"err": err}).Errorf("사용자 엔진을 가져오는 중 오류 발생")
 				continue
 			}
-			engine.notifyUpcomingEvents(mattermostUserID, view.Events)
+			engine.notifyUpcomingEvents(user, view.Events)
 		} else {
-			m.notifyUpcomingEvents(mattermostUserID, view.Events)
+			m.notifyUpcomingEvents(user, view.Events)
 		}
 	}
 }
@@ -240,7 +243,7 @@ func (m *mscalendar) setUserStatuses(users []*store.User, calendarViews []*remot
 	numberOfLogs, numberOfUserStatusChange, numberOfUserErrorInStatusChange := 0, 0, 0
 	toUpdate := []*store.User{}
 	for _, u := range users {
-		if u.IsConfiguredForStatusUpdates() || u.IsConfiguredForCustomStatusUpdates() {
+		if u.IsConfiguredForStatusUpdates() || u.IsConfiguredForCustomStatusUpdates() || u.IsConfiguredForOutOfOfficeSync() || u.OutOfOffice != nil {
 			toUpdate = append(toUpdate, u)
 		}
 	}
@@ -291,7 +294,24 @@ func (m *mscalendar) setUserStatuses(users []*store.User, calendarViews []*remot
 		events := filterBusyAndAttendeeEvents(view.Events)
 		events = getMergedEvents(events)
 
-		var err error
+		// Out of office takes precedence over the calendar.
+		isOutOfOffice, isStatusChanged, err := m.setStatusFromOutOfOffice(user, status)
+		if err != nil {
+			if numberOfLogs < logTruncateLimit {
+				m.Logger.Warnf("사용자 %s 부재 중 상태 설정 중 오류 발생. err=%v", user.MattermostUserID, err)
+			} else if numberOfLogs == logTruncateLimit {
+				m.Logger.Warnf(logTruncateMsg)
+			}
+			numberOfLogs++
+			numberOfUserErrorInStatusChange++
+		}
+		if isStatusChanged {
+			numberOfUserStatusChange++
+		}
+		if isOutOfOffice || isAfterHours(user) {
+			continue
+		}
+
 		if user.IsConfiguredForStatusUpdates() {
 			res, isStatusChanged, err = m.setStatusFromCalendarView(user, status, events)
 			if err != nil {
@@ -546,7 +566,14 @@ func (m *mscalendar) GetCalendarEvents(user *User, start, end time.Time, exclude
 		return nil, errors.Wrap(err, "GetCalendarEvents에서 withClient 오류")
 	}
 
-	events, err := m.client.GetEventsBetweenDates(user.Remote.ID, start, end)
+	var events []*remote.Event
+	if _, ok := m.client.(remote.DeltaSyncer); ok && user.User != nil {
+		events, err = m.getSyncedCalendarView(user, start, end)
+	} else if user.User != nil && len(user.Settings.Calendars) > 0 {
+		events, err = m.getSelectedCalendarsView(user.Remote.ID, user.Settings.Calendars, start, end)
+	} else {
+		events, err = m.client.GetEventsBetweenDates(user.Remote.ID, start, end)
+	}
 	if err != nil {
 		return nil, errors.Wrapf(err, "사용자 %s의 이벤트를 가져오는 중 오류 발생", user.MattermostUserID)
 	}
@@ -568,43 +595,68 @@ func (m *mscalendar) GetCalendarViews(users []*store.User) ([]*remote.ViewCalend
 	}
 
 	start := time.Now().UTC()
-	end := time.Now().UTC().Add(calendarViewTimeWindowSize)
 
 	params := []*remote.ViewCalendarParams{}
+	byRemoteID := map[string]*store.User{}
 	for _, u := range users {
-		params = append(params, &remote.ViewCalendarParams{
-			RemoteUserID: u.Remote.ID,
-			StartTime:    start,
-			EndTime:      end,
-		})
+		params = append(params, calendarViewParams(u, start, start.Add(calendarViewTimeWindow(u)))...)
+		byRemoteID[u.Remote.ID] = u
 	}
 
-	return m.client.DoBatchViewCalendarRequests(params)
+	responses, err := m.viewCalendars(params)
+	if err != nil {
+		return nil, err
+	}
+	return mergeCalendarViews(byRemoteID, responses), nil
 }
 
-func (m *mscalendar) notifyUpcomingEvents(mattermostUserID string, events []*remote.Event) {
+// notifyUpcomingEvents reminds the user of their events due at their lead
+// times, or as they snoozed them, and posts the reminders of the events
+// linked to channels there.
+func (m *mscalendar) notifyUpcomingEvents(user *store.User, events []*remote.Event) {
+	mattermostUserID := user.MattermostUserID
+	now := time.Now()
+	url := fmt.Sprintf("%s%s%s", m.Config.PluginURLPath, config.PathPostAction, config.PathReminder)
 	var timezone string
+	var snoozes store.ReminderSnoozes
+	// Users are left alone after hours, but the channels linked to their
+	// events are still reminded.
+	afterHours := isAfterHours(user)
 	for _, event := range events {
 		if event.IsCancelled {
 			continue
 		}
-		upcomingTime := time.Now().Add(upcomingEventNotificationTime)
-		start := event.Start.Time()
-		diff := start.Sub(upcomingTime)
-
-		if (diff < upcomingEventNotificationWindow) && (diff > -upcomingEventNotificationWindow) {
-			var err error
-			if timezone == "" {
-				timezone, err = m.GetTimezoneByID(mattermostUserID)
-				if err != nil {
-					m.Logger.Warnf("notifyUpcomingEvents 시간대 가져오기 오류. err=%v", err)
-					return
-				}
+
+		var err error
+		if snoozes == nil {
+			snoozes, err = m.Store.LoadReminderSnoozes(mattermostUserID)
+			if err != nil {
+				m.Logger.Warnf("notifyUpcomingEvents 미룬 알림 로드 오류. err=%v", err)
+				snoozes = store.ReminderSnoozes{}
 			}
+		}
 
-			_, attachment, err := views.RenderUpcomingEventAsAttachment(event, timezone)
+		until := event.Start.Time().Sub(now)
+		leadTime, isDue := dueUserReminder(user.Settings.Reminders, snoozes, event, now)
+		isDue = isDue && !afterHours
+		isChannelReminderDue := isReminderDue(upcomingEventNotificationTime, until)
+		if !isDue && !isChannelReminderDue {
+			continue
+		}
+
+		if timezone == "" {
+			timezone, err = m.GetTimezoneByID(mattermostUserID)
 			if err != nil {
-				m.Logger.Warnf("notifyUpcomingEvent 일정 항목 렌더링 오류. err=%v", err)
+				m.Logger.Warnf("notifyUpcomingEvents 시간대 가져오기 오류. err=%v", err)
+				return
+			}
+		}
+
+		if isDue && m.markReminderSent(mattermostUserID, event, leadTime) {
+			_, attachment, errRender := views.RenderUpcomingEventAsAttachment(event, timezone,
+				views.ReminderActionsOption(url, reminderEventKey(event), reminderSeriesKey(event)))
+			if errRender != nil {
+				m.Logger.Warnf("notifyUpcomingEvent 일정 항목 렌더링 오류. err=%v", errRender)
 				continue
 			}
 
@@ -613,38 +665,88 @@ func (m *mscalendar) notifyUpcomingEvents(mattermostUserID string, events []*rem
 				m.Logger.Warnf("notifyUpcomingEvents DM 생성 오류. err=%v", err)
 				continue
 			}
+		}
 
-			// Process channel reminders
-			eventMetadata, errMetadata := m.Store.LoadEventMetadata(event.ICalUID)
-			if errMetadata != nil && !errors.Is(errMetadata, store.ErrNotFound) {
-				m.Logger.With(bot.LogContext{
-					"eventID": event.ID,
-					"err":     errMetadata.Error(),
-				}).Warnf("notifyUpcomingEvents 채널 알림을 위한 스토어 확인 오류")
+		// Channels are reminded of their linked events at the default lead
+		// time, once whichever of their members is reminded first.
+		if !isChannelReminderDue {
+			continue
+		}
+		linkedChannelIDs, errMetadata := loadLinkedChannelIDs(m.Store, event)
+		if errMetadata != nil {
+			m.Logger.With(bot.LogContext{
+				"eventID": event.ID,
+				"err":     errMetadata.Error(),
+			}).Warnf("notifyUpcomingEvents 채널 알림을 위한 스토어 확인 오류")
+			continue
+		}
+
+		for channelID := range linkedChannelIDs {
+			if !m.markReminderSent(channelReminderOwner(channelID), event, upcomingEventNotificationTime) {
 				continue
 			}
+			post := &model.Post{
+				ChannelId: channelID,
+				Message:   "예정된 이벤트",
+			}
+			attachment, errRender := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+			if errRender != nil {
+				m.Logger.With(bot.LogContext{"err": errRender}).Errorf("notifyUpcomingEvents 채널 게시물 렌더링 오류")
+				continue
+			}
+			model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+			errPoster := m.Poster.CreatePost(post)
+			if errPoster != nil {
+				m.Logger.With(bot.LogContext{"err": errPoster}).Warnf("notifyUpcomingEvents 채널에 게시물 생성 오류")
+				continue
+			}
+		}
+	}
+}
 
-			if eventMetadata != nil {
-				for channelID := range eventMetadata.LinkedChannelIDs {
-					post := &model.Post{
-						ChannelId: channelID,
-						Message:   "예정된 이벤트",
-					}
-					attachment, errRender := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
-					if errRender != nil {
-						m.Logger.With(bot.LogContext{"err": errRender}).Errorf("notifyUpcomingEvents 채널 게시물 렌더링 오류")
-						continue
-					}
-					model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
-					errPoster := m.Poster.CreatePost(post)
-					if errPoster != nil {
-						m.Logger.With(bot.LogContext{"err": errPoster}).Warnf("notifyUpcomingEvents 채널에 게시물 생성 오류")
-						continue
-					}
-				}
+// loadLinkedChannelIDs returns the channels linked to the event. Channels
+// may be linked to a single occurrence, to the event or to its whole series.
+func loadLinkedChannelIDs(s store.Store, event *remote.Event) (map[string]struct{}, error) {
+	channelIDs := map[string]struct{}{}
+	seen := map[string]bool{}
+	for _, key := range []string{event.OccurrenceKey(), event.ICalUID, event.SeriesMasterID} {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		eventMetadata, err := s.LoadEventMetadata(key)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if eventMetadata == nil {
+			continue
+		}
+		for channelID := range eventMetadata.LinkedChannelIDs {
+			channelIDs[channelID] = struct{}{}
+		}
+	}
+	return channelIDs, nil
+}
+
+// limitCalendarViews returns the views with only the events starting before
+// end.
+func limitCalendarViews(calendarViews []*remote.ViewCalendarResponse, end time.Time) []*remote.ViewCalendarResponse {
+	limited := []*remote.ViewCalendarResponse{}
+	for _, view := range calendarViews {
+		limitedView := *view
+		limitedView.Events = []*remote.Event{}
+		for _, event := range view.Events {
+			if event.Start.Time().Before(end) {
+				limitedView.Events = append(limitedView.Events, event)
 			}
 		}
+		limited = append(limited, &limitedView)
 	}
+	return limited
 }
 
 func filterBusyAndAttendeeEvents(events []*remote.Event) []*remote.Event {
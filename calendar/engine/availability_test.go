@@ -789,9 +789,11 @@ func TestReminders(t *testing.T) {
 			c.EXPECT().DoBatchViewCalendarRequests(gomock.Any()).Return([]*remote.ViewCalendarResponse{
 				{Events: tc.remoteEvents, RemoteUserID: "user_remote_id", Error: tc.apiError},
 			}, nil)
+			s.EXPECT().LoadReminderSnoozes("user_mm_id").Return(store.ReminderSnoozes{}, nil).AnyTimes()
 
 			if tc.numReminders > 0 {
 				poster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Times(tc.numReminders)
+				s.EXPECT().MarkReminderSent("user_mm_id", gomock.Any(), gomock.Any()).Return(true, nil).Times(tc.numReminders)
 				loadUser.Times(2)
 				c.EXPECT().GetMailboxSettings("user_remote_id").Times(1).Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
 
@@ -799,6 +801,7 @@ func TestReminders(t *testing.T) {
 				for eventID, metadata := range tc.eventMetadata {
 					s.EXPECT().LoadEventMetadata(eventID).Return(metadata, nil).Times(1)
 					for channelID := range metadata.LinkedChannelIDs {
+						s.EXPECT().MarkReminderSent("channel_"+channelID, gomock.Any(), gomock.Any()).Return(true, nil)
 						poster.EXPECT().CreatePost(test.DoMatch(func(v *model.Post) bool {
 							return v.ChannelId == channelID
 						})).Return(nil)
@@ -6,12 +6,19 @@ package engine
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 )
 
 type Calendar interface {
 	CreateCalendar(user *User, calendar *remote.Calendar) (*remote.Calendar, error)
 	CreateEvent(user *User, event *remote.Event, mattermostUserIDs []string) (*remote.Event, error)
+	GetEvent(user *User, eventID string) (*remote.Event, error)
+	UpdateEvent(user *User, eventID string, event *remote.Event) (*remote.Event, error)
+	DeleteEvent(user *User, eventID string) error
+	CancelEvent(user *User, eventID, comment string) error
 	DeleteCalendar(user *User, calendarID string) error
 	FindMeetingTimes(user *User, meetingParams *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error)
 	GetCalendars(user *User) ([]*remote.Calendar, error)
@@ -26,7 +33,7 @@ func (m *mscalendar) ViewCalendar(user *User, from, to time.Time) ([]*remote.Eve
 	if err != nil {
 		return nil, err
 	}
-	return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	return m.getCalendarView(user, from, to)
 }
 
 func (m *mscalendar) getTodayCalendarEvents(user *User, now time.Time, timezone string) ([]*remote.Event, error) {
@@ -43,7 +50,102 @@ func (m *mscalendar) getTodayCalendarEvents(user *User, now time.Time, timezone
 	}
 
 	from, to := getTodayHoursForTimezone(now, timezone)
-	return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	return m.getSyncedCalendarView(user, from, to)
+}
+
+// getCalendarView returns the events of the calendars the user follows, or of
+// the default calendar when the user did not choose any.
+func (m *mscalendar) getCalendarView(user *User, from, to time.Time) ([]*remote.Event, error) {
+	if len(user.Settings.Calendars) == 0 {
+		return m.client.GetDefaultCalendarView(user.Remote.ID, from, to)
+	}
+	return m.getSelectedCalendarsView(user.Remote.ID, user.Settings.Calendars, from, to)
+}
+
+// getSelectedCalendarsView aggregates the events of the calendars, labelled
+// with the name of their calendar and sorted by start time.
+func (m *mscalendar) getSelectedCalendarsView(remoteUserID string, calendars []store.SelectedCalendar, from, to time.Time) ([]*remote.Event, error) {
+	events := []*remote.Event{}
+	for _, cal := range calendars {
+		calendarEvents, err := m.client.GetCalendarView(remoteUserID, cal.ID, from, to)
+		if err != nil {
+			return nil, errors.Wrapf(err, "캘린더 %s의 이벤트를 가져오는 중 오류 발생", cal.Name)
+		}
+		events = append(events, labelEvents(calendarEvents, cal.Name)...)
+	}
+
+	sortEventsByStart(events)
+	return events, nil
+}
+
+// calendarViewParams returns one request per calendar the user follows, or a
+// request for the default calendar when the user did not choose any.
+func calendarViewParams(user *store.User, start, end time.Time) []*remote.ViewCalendarParams {
+	if len(user.Settings.Calendars) == 0 {
+		return []*remote.ViewCalendarParams{{
+			RemoteUserID: user.Remote.ID,
+			StartTime:    start,
+			EndTime:      end,
+		}}
+	}
+
+	params := []*remote.ViewCalendarParams{}
+	for _, cal := range user.Settings.Calendars {
+		params = append(params, &remote.ViewCalendarParams{
+			RemoteUserID: user.Remote.ID,
+			CalendarID:   cal.ID,
+			StartTime:    start,
+			EndTime:      end,
+		})
+	}
+	return params
+}
+
+// mergeCalendarViews merges the responses for the calendars of each user into
+// a single response per user, with the events labelled with the name of their
+// calendar and sorted by start time. The response keeps the first error of its calendars, so that a
+// user is not considered free when one of their calendars failed to load.
+func mergeCalendarViews(users map[string]*store.User, responses []*remote.ViewCalendarResponse) []*remote.ViewCalendarResponse {
+	result := []*remote.ViewCalendarResponse{}
+	byRemoteID := map[string]*remote.ViewCalendarResponse{}
+	for _, res := range responses {
+		if res.CalendarID != "" {
+			if u, ok := users[res.RemoteUserID]; ok {
+				for _, cal := range u.Settings.Calendars {
+					if cal.ID == res.CalendarID {
+						res.Events = labelEvents(res.Events, cal.Name)
+					}
+				}
+			}
+		}
+
+		merged, ok := byRemoteID[res.RemoteUserID]
+		if !ok {
+			merged = &remote.ViewCalendarResponse{
+				RemoteUserID: res.RemoteUserID,
+				Error:        res.Error,
+				Events:       res.Events,
+			}
+			byRemoteID[res.RemoteUserID] = merged
+			result = append(result, merged)
+			continue
+		}
+		if merged.Error == nil {
+			merged.Error = res.Error
+		}
+		merged.Events = append(merged.Events, res.Events...)
+	}
+	for _, merged := range result {
+		sortEventsByStart(merged.Events)
+	}
+	return result
+}
+
+func labelEvents(events []*remote.Event, calendarName string) []*remote.Event {
+	for _, e := range events {
+		e.CalendarName = calendarName
+	}
+	return events
 }
 
 func (m *mscalendar) excludeDeclinedEvents(events []*remote.Event) (result []*remote.Event) {
@@ -94,6 +196,54 @@ func (m *mscalendar) CreateEvent(user *User, event *remote.Event, mattermostUser
 	return m.client.CreateEvent(user.Remote.ID, event)
 }
 
+func (m *mscalendar) GetEvent(user *User, eventID string) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.GetEvent(user.Remote.ID, eventID)
+}
+
+func (m *mscalendar) UpdateEvent(user *User, eventID string, event *remote.Event) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.client.UpdateEvent(user.Remote.ID, eventID, event)
+}
+
+func (m *mscalendar) DeleteEvent(user *User, eventID string) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.client.DeleteEvent(user.Remote.ID, eventID)
+}
+
+func (m *mscalendar) CancelEvent(user *User, eventID, comment string) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.client.CancelEvent(user.Remote.ID, eventID, comment)
+}
+
 func (m *mscalendar) DeleteCalendar(user *User, calendarID string) error {
 	err := m.Filter(
 		withClient,
@@ -0,0 +1,208 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// Calendars are cached by UTC day, for the day of the request and the next
+// one, so that the status sync, the reminders and the daily summary of a user
+// share the same cache until it expires.
+const calendarCacheWindowSize = 48 * time.Hour
+
+// getSyncedCalendarView returns the events of the calendars the user follows
+// between from and to, read from the cache when the remote can track the
+// changes of the calendars, and from the remote otherwise.
+func (m *mscalendar) getSyncedCalendarView(user *User, from, to time.Time) ([]*remote.Event, error) {
+	if _, ok := m.client.(remote.DeltaSyncer); !ok {
+		return m.getCalendarView(user, from, to)
+	}
+
+	if len(user.Settings.Calendars) == 0 {
+		return m.getCachedCalendarView(user.Remote.ID, "", from, to)
+	}
+
+	events := []*remote.Event{}
+	for _, cal := range user.Settings.Calendars {
+		calendarEvents, err := m.getCachedCalendarView(user.Remote.ID, cal.ID, from, to)
+		if err != nil {
+			return nil, errors.Wrapf(err, "캘린더 %s의 이벤트를 가져오는 중 오류 발생", cal.Name)
+		}
+		events = append(events, labelEvents(calendarEvents, cal.Name)...)
+	}
+	sortEventsByStart(events)
+	return events, nil
+}
+
+// viewCalendars runs the requests from the cache when the remote can track
+// the changes of the calendars, and as a batch otherwise. The changes of the
+// cached calendars are requested as a batch too.
+func (m *mscalendar) viewCalendars(params []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+	syncer, ok := m.client.(remote.DeltaSyncer)
+	if !ok {
+		return m.client.DoBatchViewCalendarRequests(params)
+	}
+
+	caches := []*store.CalendarCache{}
+	deltaParams := []*remote.CalendarViewDeltaParams{}
+	for _, p := range params {
+		cache := m.loadCalendarCache(p.RemoteUserID, p.CalendarID, p.StartTime, p.EndTime)
+		caches = append(caches, cache)
+		deltaParams = append(deltaParams, &remote.CalendarViewDeltaParams{
+			StartTime:    cache.WindowStart,
+			EndTime:      cache.WindowEnd,
+			RemoteUserID: p.RemoteUserID,
+			CalendarID:   p.CalendarID,
+			DeltaLink:    cache.DeltaLink,
+		})
+	}
+
+	deltas, err := syncer.DoBatchCalendarViewDeltaRequests(deltaParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(deltas) != len(params) {
+		return nil, errors.Errorf("%d개의 캘린더 변경 사항 요청에 %d개의 응답을 받았습니다", len(params), len(deltas))
+	}
+
+	responses := []*remote.ViewCalendarResponse{}
+	for i, p := range params {
+		res := &remote.ViewCalendarResponse{
+			RemoteUserID: p.RemoteUserID,
+			CalendarID:   p.CalendarID,
+		}
+		events, err := m.updateCalendarCache(syncer, p.RemoteUserID, p.CalendarID, p.StartTime, p.EndTime, caches[i], deltas[i].Delta, deltas[i].Error)
+		if err != nil {
+			res.Error = &remote.APIError{Message: err.Error()}
+		}
+		res.Events = events
+		responses = append(responses, res)
+	}
+	return responses, nil
+}
+
+// getCachedCalendarView brings the cache of the calendar up to date with the
+// changes made since the last synchronization, and returns its events between
+// start and end.
+func (m *mscalendar) getCachedCalendarView(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	syncer, ok := m.client.(remote.DeltaSyncer)
+	if !ok {
+		return nil, remote.ErrNotImplemented
+	}
+
+	cache := m.loadCalendarCache(remoteUserID, calendarID, start, end)
+	delta, err := syncer.GetCalendarViewDelta(remoteUserID, calendarID, cache.WindowStart, cache.WindowEnd, cache.DeltaLink)
+	return m.updateCalendarCache(syncer, remoteUserID, calendarID, start, end, cache, delta, err)
+}
+
+// loadCalendarCache returns the cache of the calendar, or a new one to be
+// filled with a full read of its window when it does not cover the request.
+func (m *mscalendar) loadCalendarCache(remoteUserID, calendarID string, start, end time.Time) *store.CalendarCache {
+	cache, err := m.Store.LoadCalendarCache(remoteUserID, calendarID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		m.Logger.With(bot.LogContext{
+			"remote_id":   remoteUserID,
+			"calendar_id": calendarID,
+			"err":         err,
+		}).Warnf("캘린더 캐시를 불러올 수 없어 다시 만듭니다")
+	}
+	if cache == nil || cache.DeltaLink == "" || !cache.Covers(start, end) {
+		cache = newCalendarCache(start, end)
+	}
+	return cache
+}
+
+// updateCalendarCache applies the changes read with the delta link of the
+// cache, or the error of their request, and returns the events of the cache
+// between start and end. The cache is rebuilt with a full read of its window
+// when the remote no longer accepts its delta link.
+func (m *mscalendar) updateCalendarCache(syncer remote.DeltaSyncer, remoteUserID, calendarID string, start, end time.Time, cache *store.CalendarCache, delta *remote.CalendarViewDelta, err error) ([]*remote.Event, error) {
+	if errors.Is(err, remote.ErrDeltaLinkExpired) && cache.DeltaLink != "" {
+		cache = newCalendarCache(start, end)
+		delta, err = syncer.GetCalendarViewDelta(remoteUserID, calendarID, cache.WindowStart, cache.WindowEnd, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	applyCalendarDelta(cache, delta)
+	err = m.Store.StoreCalendarCache(remoteUserID, calendarID, cache)
+	if err != nil {
+		// The events are still accurate, the next synchronization will read
+		// the window again.
+		m.Logger.With(bot.LogContext{
+			"remote_id":   remoteUserID,
+			"calendar_id": calendarID,
+			"err":         err,
+		}).Warnf("캘린더 캐시를 저장할 수 없습니다")
+	}
+
+	return cachedEventsBetween(cache, start, end), nil
+}
+
+func newCalendarCache(start, end time.Time) *store.CalendarCache {
+	windowStart := start.UTC().Truncate(24 * time.Hour)
+	windowEnd := windowStart.Add(calendarCacheWindowSize)
+	if end.After(windowEnd) {
+		windowEnd = end.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	}
+	return &store.CalendarCache{
+		WindowStart: windowStart,
+		WindowEnd:   windowEnd,
+		Events:      map[string]*remote.Event{},
+	}
+}
+
+func applyCalendarDelta(cache *store.CalendarCache, delta *remote.CalendarViewDelta) {
+	for _, e := range delta.Events {
+		if e == nil || e.ID == "" {
+			continue
+		}
+		cache.Events[e.ID] = e
+	}
+	for _, id := range delta.RemovedIDs {
+		delete(cache.Events, id)
+	}
+	cache.DeltaLink = delta.DeltaLink
+}
+
+// cachedEventsBetween returns the cached events that overlap [start, end),
+// sorted by start time.
+func cachedEventsBetween(cache *store.CalendarCache, start, end time.Time) []*remote.Event {
+	events := []*remote.Event{}
+	for _, e := range cache.Events {
+		if e.Start == nil || e.End == nil {
+			continue
+		}
+		if e.Start.Time().Before(end) && e.End.Time().After(start) {
+			events = append(events, e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Start.Time().Equal(events[j].Start.Time()) {
+			return events[i].ID < events[j].ID
+		}
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+	return events
+}
+
+// sortEventsByStart sorts the events by start time, the ones without a start
+// last.
+func sortEventsByStart(events []*remote.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Start == nil || events[j].Start == nil {
+			return events[j].Start == nil && events[i].Start != nil
+		}
+		return events[i].Start.Time().Before(events[j].Start.Time())
+	})
+}
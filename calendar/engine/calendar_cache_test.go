@@ -0,0 +1,226 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+type deltaRequest struct {
+	calendarID  string
+	windowStart time.Time
+	deltaLink   string
+}
+
+// mockDeltaClient serves the calendar view deltas queued by the tests.
+type mockDeltaClient struct {
+	*mock_remote.MockClient
+	deltas   []*remote.CalendarViewDelta
+	errors   []error
+	requests []deltaRequest
+	batches  int
+}
+
+func (c *mockDeltaClient) GetCalendarViewDelta(_, calendarID string, start, _ time.Time, deltaLink string) (*remote.CalendarViewDelta, error) {
+	c.requests = append(c.requests, deltaRequest{calendarID: calendarID, windowStart: start, deltaLink: deltaLink})
+	delta, err := c.deltas[0], c.errors[0]
+	c.deltas, c.errors = c.deltas[1:], c.errors[1:]
+	return delta, err
+}
+
+func (c *mockDeltaClient) DoBatchCalendarViewDeltaRequests(params []*remote.CalendarViewDeltaParams) ([]*remote.CalendarViewDeltaResponse, error) {
+	c.batches++
+	responses := []*remote.CalendarViewDeltaResponse{}
+	for _, p := range params {
+		delta, err := c.GetCalendarViewDelta(p.RemoteUserID, p.CalendarID, p.StartTime, p.EndTime, p.DeltaLink)
+		responses = append(responses, &remote.CalendarViewDeltaResponse{Delta: delta, Error: err})
+	}
+	return responses, nil
+}
+
+func mockCacheEvent(id string, start time.Time) *remote.Event {
+	return &remote.Event{
+		ID:    id,
+		Start: remote.NewDateTime(start, "UTC"),
+		End:   remote.NewDateTime(start.Add(30*time.Minute), "UTC"),
+	}
+}
+
+func TestGetCachedCalendarView(t *testing.T) {
+	day := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	start := day.Add(9 * time.Hour)
+	end := start.Add(calendarViewTimeWindowSize)
+
+	tests := []struct {
+		name       string
+		cache      *store.CalendarCache
+		deltas     []*remote.CalendarViewDelta
+		errors     []error
+		assertions func(*testing.T, []*remote.Event, error, []deltaRequest, *store.CalendarCache)
+	}{
+		{
+			name: "Full read without cache",
+			deltas: []*remote.CalendarViewDelta{{
+				Events:    []*remote.Event{mockCacheEvent("later", start.Add(2*time.Hour)), mockCacheEvent("now", start)},
+				DeltaLink: "link1",
+			}},
+			errors: []error{nil},
+			assertions: func(t *testing.T, events []*remote.Event, err error, requests []deltaRequest, stored *store.CalendarCache) {
+				require.NoError(t, err)
+				require.Len(t, events, 1)
+				require.Equal(t, "now", events[0].ID)
+				require.Equal(t, []deltaRequest{{windowStart: day}}, requests)
+				require.Equal(t, "link1", stored.DeltaLink)
+				require.Len(t, stored.Events, 2)
+				require.Equal(t, day.Add(calendarCacheWindowSize), stored.WindowEnd)
+			},
+		},
+		{
+			name: "Apply changes to the cache",
+			cache: &store.CalendarCache{
+				WindowStart: day,
+				WindowEnd:   day.Add(calendarCacheWindowSize),
+				DeltaLink:   "link1",
+				Events: map[string]*remote.Event{
+					"now":       mockCacheEvent("now", start),
+					"cancelled": mockCacheEvent("cancelled", start),
+				},
+			},
+			deltas: []*remote.CalendarViewDelta{{
+				Events:     []*remote.Event{mockCacheEvent("added", start.Add(time.Minute))},
+				RemovedIDs: []string{"cancelled"},
+				DeltaLink:  "link2",
+			}},
+			errors: []error{nil},
+			assertions: func(t *testing.T, events []*remote.Event, err error, requests []deltaRequest, stored *store.CalendarCache) {
+				require.NoError(t, err)
+				require.Len(t, events, 2)
+				require.Equal(t, "now", events[0].ID)
+				require.Equal(t, "added", events[1].ID)
+				require.Equal(t, []deltaRequest{{windowStart: day, deltaLink: "link1"}}, requests)
+				require.Equal(t, "link2", stored.DeltaLink)
+			},
+		},
+		{
+			name: "Read the window again when the delta link expired",
+			cache: &store.CalendarCache{
+				WindowStart: day,
+				WindowEnd:   day.Add(calendarCacheWindowSize),
+				DeltaLink:   "link1",
+				Events: map[string]*remote.Event{
+					"stale": mockCacheEvent("stale", start),
+				},
+			},
+			deltas: []*remote.CalendarViewDelta{nil, {
+				Events:    []*remote.Event{mockCacheEvent("now", start)},
+				DeltaLink: "link2",
+			}},
+			errors: []error{remote.ErrDeltaLinkExpired, nil},
+			assertions: func(t *testing.T, events []*remote.Event, err error, requests []deltaRequest, stored *store.CalendarCache) {
+				require.NoError(t, err)
+				require.Len(t, events, 1)
+				require.Equal(t, "now", events[0].ID)
+				require.Equal(t, []deltaRequest{{windowStart: day, deltaLink: "link1"}, {windowStart: day}}, requests)
+				require.Equal(t, "link2", stored.DeltaLink)
+			},
+		},
+		{
+			name: "Read the window again when the cache does not cover the request",
+			cache: &store.CalendarCache{
+				WindowStart: day.Add(-calendarCacheWindowSize),
+				WindowEnd:   day,
+				DeltaLink:   "link1",
+				Events:      map[string]*remote.Event{},
+			},
+			deltas: []*remote.CalendarViewDelta{{
+				Events:    []*remote.Event{mockCacheEvent("now", start)},
+				DeltaLink: "link2",
+			}},
+			errors: []error{nil},
+			assertions: func(t *testing.T, events []*remote.Event, err error, requests []deltaRequest, stored *store.CalendarCache) {
+				require.NoError(t, err)
+				require.Len(t, events, 1)
+				require.Equal(t, []deltaRequest{{windowStart: day}}, requests)
+				require.Equal(t, day, stored.WindowStart)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+			client := &mockDeltaClient{MockClient: mockClient, deltas: tt.deltas, errors: tt.errors}
+			mscalendar.client = client
+
+			if tt.cache != nil {
+				mockStore.EXPECT().LoadCalendarCache(MockRemoteUserID, "").Return(tt.cache, nil).Times(1)
+			} else {
+				mockStore.EXPECT().LoadCalendarCache(MockRemoteUserID, "").Return(nil, store.ErrNotFound).Times(1)
+			}
+			var stored *store.CalendarCache
+			mockStore.EXPECT().StoreCalendarCache(MockRemoteUserID, "", gomock.Any()).DoAndReturn(func(_, _ string, cache *store.CalendarCache) error {
+				stored = cache
+				return nil
+			}).Times(1)
+
+			events, err := mscalendar.getCachedCalendarView(MockRemoteUserID, "", start, end)
+
+			tt.assertions(t, events, err, client.requests, stored)
+		})
+	}
+}
+
+func TestViewCalendarsFromCache(t *testing.T) {
+	day := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	start := day.Add(9 * time.Hour)
+	end := start.Add(calendarViewTimeWindowSize)
+
+	mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+	client := &mockDeltaClient{
+		MockClient: mockClient,
+		deltas: []*remote.CalendarViewDelta{
+			{Events: []*remote.Event{mockCacheEvent("now", start)}, DeltaLink: "link1"},
+			nil,
+			nil,
+			{Events: []*remote.Event{mockCacheEvent("again", start)}, DeltaLink: "link4"},
+		},
+		errors: []error{nil, remote.ErrNotImplemented, remote.ErrDeltaLinkExpired, nil},
+	}
+	mscalendar.client = client
+
+	mockStore.EXPECT().LoadCalendarCache(MockRemoteUserID, "").Return(nil, store.ErrNotFound).Times(1)
+	mockStore.EXPECT().LoadCalendarCache(MockRemoteUserID, MockCalendarID).Return(nil, store.ErrNotFound).Times(1)
+	mockStore.EXPECT().LoadCalendarCache("other_remote_id", "").Return(&store.CalendarCache{
+		WindowStart: day,
+		WindowEnd:   day.Add(calendarCacheWindowSize),
+		DeltaLink:   "link3",
+		Events:      map[string]*remote.Event{},
+	}, nil).Times(1)
+	mockStore.EXPECT().StoreCalendarCache(MockRemoteUserID, "", gomock.Any()).Return(nil).Times(1)
+	mockStore.EXPECT().StoreCalendarCache("other_remote_id", "", gomock.Any()).Return(nil).Times(1)
+
+	responses, err := mscalendar.viewCalendars([]*remote.ViewCalendarParams{
+		{RemoteUserID: MockRemoteUserID, StartTime: start, EndTime: end},
+		{RemoteUserID: MockRemoteUserID, CalendarID: MockCalendarID, StartTime: start, EndTime: end},
+		{RemoteUserID: "other_remote_id", StartTime: start, EndTime: end},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, client.batches)
+	require.Len(t, responses, 3)
+	require.Nil(t, responses[0].Error)
+	require.Len(t, responses[0].Events, 1)
+	require.Equal(t, MockCalendarID, responses[1].CalendarID)
+	require.Equal(t, remote.ErrNotImplemented.Error(), responses[1].Error.Message)
+	// The expired delta link is replaced with a full read of the window.
+	require.Nil(t, responses[2].Error)
+	require.Equal(t, "again", responses[2].Events[0].ID)
+	require.Equal(t, deltaRequest{windowStart: day}, client.requests[3])
+}
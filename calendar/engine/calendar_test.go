@@ -63,6 +63,40 @@ func TestViewCalendar(t *testing.T) {
 				require.Equal(t, MockEventName, events[0].Subject, "Expected first event's subject to be %s, but got %s", MockEventName, events[0].Subject)
 			},
 		},
+		{
+			name: "successful view of the selected calendars",
+			user: GetMockUser(model.NewPointer(MockRemoteUserID), model.NewPointer(MockMMModelUserID), MockMMUserID, &store.Settings{
+				Calendars: []store.SelectedCalendar{{ID: "work", Name: "Work"}, {ID: "team", Name: "Team"}},
+			}),
+			setupMock: func() {
+				mockClient.EXPECT().GetCalendarView(MockRemoteUserID, "work", from, to).Return([]*remote.Event{
+					{Subject: "Review", Start: remote.NewDateTime(now, "UTC")},
+				}, nil).Times(1)
+				mockClient.EXPECT().GetCalendarView(MockRemoteUserID, "team", from, to).Return([]*remote.Event{
+					{Subject: "Standup", Start: remote.NewDateTime(from, "UTC")},
+				}, nil).Times(1)
+			},
+			assertions: func(t *testing.T, events []*remote.Event, err error) {
+				require.NoError(t, err)
+				require.Len(t, events, 2)
+				require.Equal(t, "Standup", events[0].Subject)
+				require.Equal(t, "Team", events[0].CalendarName)
+				require.Equal(t, "Review", events[1].Subject)
+				require.Equal(t, "Work", events[1].CalendarName)
+			},
+		},
+		{
+			name: "error getting a selected calendar",
+			user: GetMockUser(model.NewPointer(MockRemoteUserID), model.NewPointer(MockMMModelUserID), MockMMUserID, &store.Settings{
+				Calendars: []store.SelectedCalendar{{ID: "work", Name: "Work"}},
+			}),
+			setupMock: func() {
+				mockClient.EXPECT().GetCalendarView(MockRemoteUserID, "work", from, to).Return(nil, fmt.Errorf("error getting calendar view")).Times(1)
+			},
+			assertions: func(t *testing.T, _ []*remote.Event, err error) {
+				require.EqualError(t, err, "캘린더 Work의 이벤트를 가져오는 중 오류 발생: error getting calendar view")
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -440,3 +474,38 @@ func TestGetCalendars(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeCalendarViews(t *testing.T) {
+	users := map[string]*store.User{
+		"alice": {Remote: &remote.User{ID: "alice"}, Settings: store.Settings{
+			Calendars: []store.SelectedCalendar{{ID: "work", Name: "Work"}, {ID: "team", Name: "Team"}},
+		}},
+		"bob": {Remote: &remote.User{ID: "bob"}},
+	}
+
+	at := func(hour int) *remote.DateTime {
+		return remote.NewDateTime(time.Date(2024, 5, 6, hour, 0, 0, 0, time.UTC), "UTC")
+	}
+
+	merged := mergeCalendarViews(users, []*remote.ViewCalendarResponse{
+		{RemoteUserID: "alice", CalendarID: "work", Events: []*remote.Event{{Subject: "Review", Start: at(10)}, {Subject: "Retro", Start: at(16)}}},
+		{RemoteUserID: "bob", Events: []*remote.Event{{Subject: "Lunch"}}},
+		{RemoteUserID: "alice", CalendarID: "team", Events: []*remote.Event{{Subject: "Standup", Start: at(9)}, {Subject: "Planning", Start: at(14)}}},
+		{RemoteUserID: "alice", CalendarID: "missing", Error: &remote.APIError{Code: "ErrorItemNotFound"}},
+	})
+
+	require.Len(t, merged, 2)
+	require.Equal(t, "alice", merged[0].RemoteUserID)
+	require.Len(t, merged[0].Events, 4)
+	subjects := []string{}
+	for _, e := range merged[0].Events {
+		subjects = append(subjects, e.Subject)
+	}
+	require.Equal(t, []string{"Standup", "Review", "Planning", "Retro"}, subjects)
+	require.Equal(t, "Team", merged[0].Events[0].CalendarName)
+	require.Equal(t, "Work", merged[0].Events[1].CalendarName)
+	require.NotNil(t, merged[0].Error)
+	require.Equal(t, "bob", merged[1].RemoteUserID)
+	require.Nil(t, merged[1].Error)
+	require.Empty(t, merged[1].Events[0].CalendarName)
+}
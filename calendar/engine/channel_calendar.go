@@ -0,0 +1,496 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+// ChannelCalendarJobInterval is how often the agendas and reminders of
+// channel calendars are posted.
+const ChannelCalendarJobInterval = StatusSyncJobInterval
+
+const (
+	defaultChannelAgendaTime = "09:00"
+	channelAgendaTimeFormat  = "15:04"
+
+	// channelAgendaTimeWindow is how late the agenda of the day may still be
+	// posted, when the job didn't run at its time.
+	channelAgendaTimeWindow = time.Hour
+)
+
+var (
+	ErrCalendarNotFound                = errors.New("캘린더를 찾을 수 없습니다")
+	ErrChannelCalendarNotFound         = errors.New("이 채널에 연결된 캘린더가 없습니다")
+	ErrChannelCalendarAlreadyConnected = errors.New("이미 이 채널에 캘린더가 연결되어 있습니다")
+)
+
+type ChannelCalendars interface {
+	ConnectChannelCalendar(user *User, channelID, calendar string) (*store.ChannelCalendar, error)
+	DisconnectChannelCalendar(user *User, channelID string) error
+	GetChannelCalendar(channelID string) (*store.ChannelCalendar, error)
+	GetChannelCalendarGroups(user *User) ([]*remote.Group, error)
+	SetChannelAgendaTime(user *User, channelID, agendaTime string) (*store.ChannelCalendar, error)
+	RenewChannelCalendarSubscription(channelID string) error
+	ProcessAllChannelCalendars(now time.Time) error
+}
+
+// ConnectChannelCalendar binds one of the user's calendars, or else the
+// calendar of one of their groups, found by ID or name, to the channel. The
+// user owns the subscription to its changes.
+func (m *mscalendar) ConnectChannelCalendar(user *User, channelID, calendar string) (*store.ChannelCalendar, error) {
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelLinkNotPermitted
+	}
+
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := m.client.(remote.CalendarSubscriber); !ok {
+		return nil, remote.ErrNotImplemented
+	}
+
+	_, err = m.Store.LoadChannelCalendar(channelID)
+	if err == nil {
+		return nil, ErrChannelCalendarAlreadyConnected
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, err
+	}
+
+	channelCalendar := &store.ChannelCalendar{
+		PluginVersion:       m.Config.PluginVersion,
+		ChannelID:           channelID,
+		MattermostCreatorID: user.MattermostUserID,
+		AgendaTime:          defaultChannelAgendaTime,
+	}
+
+	calendars, err := m.client.GetCalendars(user.Remote.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range calendars {
+		if c.ID == calendar || strings.EqualFold(c.Name, calendar) {
+			channelCalendar.CalendarID = c.ID
+			channelCalendar.CalendarName = c.Name
+			break
+		}
+	}
+
+	if channelCalendar.CalendarID == "" {
+		groups, errGroups := m.GetChannelCalendarGroups(user)
+		if errGroups != nil {
+			return nil, errGroups
+		}
+		for _, g := range groups {
+			if g.ID == calendar || strings.EqualFold(g.DisplayName, calendar) {
+				channelCalendar.GroupID = g.ID
+				channelCalendar.CalendarName = g.DisplayName
+				break
+			}
+		}
+		if channelCalendar.GroupID == "" {
+			return nil, ErrCalendarNotFound
+		}
+	}
+
+	err = m.subscribeChannelCalendar(user, channelCalendar)
+	if err != nil {
+		return nil, err
+	}
+
+	m.postToChannel(channelID, fmt.Sprintf("@%s님이 **%s** 캘린더를 이 채널에 연결했습니다. 평일 %s에 오늘의 일정을 게시하고, 일정이 시작되기 전과 일정이 변경될 때 알립니다.",
+		user.MattermostUsername, channelCalendar.CalendarName, defaultChannelAgendaTime))
+	return channelCalendar, nil
+}
+
+// GetChannelCalendarGroups returns the groups of the user whose calendar can
+// be connected to a channel, none if the remote has no group calendars.
+func (m *mscalendar) GetChannelCalendarGroups(user *User) ([]*remote.Group, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, ok := m.client.(remote.GroupCalendarClient)
+	if !ok {
+		return nil, nil
+	}
+	return groups.GetGroups(user.Remote.ID)
+}
+
+// subscribeChannelCalendar creates the subscription to the changes of the
+// channel calendar, and stores the channel calendar with it.
+func (m *mscalendar) subscribeChannelCalendar(creator *User, channelCalendar *store.ChannelCalendar) error {
+	sub, err := createChannelCalendarSubscription(m.client, m.Config.GetNotificationURL(), creator.Remote.ID, channelCalendar)
+	if err != nil {
+		return err
+	}
+	err = m.Store.StoreChannelSubscription(&store.Subscription{
+		PluginVersion:       m.Config.PluginVersion,
+		Remote:              sub,
+		MattermostCreatorID: creator.MattermostUserID,
+		ChannelID:           channelCalendar.ChannelID,
+	})
+	if err != nil {
+		return err
+	}
+
+	channelCalendar.SubscriptionID = sub.ID
+	return m.Store.StoreChannelCalendar(channelCalendar)
+}
+
+// createChannelCalendarSubscription subscribes the creator of the channel
+// calendar to its changes, with their client.
+func createChannelCalendarSubscription(client remote.Client, notificationURL, creatorRemoteID string, channelCalendar *store.ChannelCalendar) (*remote.Subscription, error) {
+	if channelCalendar.GroupID != "" {
+		groups, ok := client.(remote.GroupCalendarClient)
+		if !ok {
+			return nil, remote.ErrNotImplemented
+		}
+		return groups.CreateGroupCalendarSubscription(notificationURL, channelCalendar.GroupID)
+	}
+
+	subscriber, ok := client.(remote.CalendarSubscriber)
+	if !ok {
+		return nil, remote.ErrNotImplemented
+	}
+	return subscriber.CreateCalendarSubscription(notificationURL, creatorRemoteID, channelCalendar.CalendarID)
+}
+
+// getChannelCalendarView returns the events of the channel calendar between
+// start and end, read with the client of its creator.
+func getChannelCalendarView(client remote.Client, creatorRemoteID string, channelCalendar *store.ChannelCalendar, start, end time.Time) ([]*remote.Event, error) {
+	if channelCalendar.GroupID != "" {
+		groups, ok := client.(remote.GroupCalendarClient)
+		if !ok {
+			return nil, remote.ErrNotImplemented
+		}
+		return groups.GetGroupCalendarView(channelCalendar.GroupID, start, end)
+	}
+	return client.GetCalendarView(creatorRemoteID, channelCalendar.CalendarID, start, end)
+}
+
+// DisconnectChannelCalendar removes the calendar of the channel, and the
+// subscription of its creator to its changes.
+func (m *mscalendar) DisconnectChannelCalendar(user *User, channelID string) error {
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return ErrChannelLinkNotPermitted
+	}
+
+	err := m.Filter(withRemoteUser(user))
+	if err != nil {
+		return err
+	}
+
+	channelCalendar, err := m.GetChannelCalendar(channelID)
+	if err != nil {
+		return err
+	}
+
+	if sub, errLoad := m.Store.LoadSubscription(channelCalendar.SubscriptionID); errLoad == nil {
+		// The creator may have disconnected their account since, in which
+		// case the remote subscription expires by itself.
+		creatorEngine, errCopy := m.FilterCopy(withActingUser(channelCalendar.MattermostCreatorID), withClient)
+		if errCopy == nil {
+			errCopy = creatorEngine.client.DeleteSubscription(sub.Remote)
+		}
+		if errCopy != nil {
+			m.Logger.With(bot.LogContext{
+				"err":            errCopy,
+				"channelID":      channelID,
+				"subscriptionID": channelCalendar.SubscriptionID,
+			}).Warnf("채널 캘린더 구독 삭제 중 오류 발생")
+		}
+	}
+
+	err = m.Store.DeleteUserSubscription(nil, channelCalendar.SubscriptionID)
+	if err != nil {
+		return err
+	}
+	err = m.Store.DeleteChannelCalendar(channelID)
+	if err != nil {
+		return err
+	}
+
+	m.postToChannel(channelID, fmt.Sprintf("@%s님이 **%s** 캘린더와 이 채널의 연결을 해제했습니다.", user.MattermostUsername, channelCalendar.CalendarName))
+	return nil
+}
+
+// disconnectUserChannelCalendars removes the channel calendars the user
+// connected, since their events can't be read anymore once the user has
+// disconnected. client is the user's client, to delete the subscriptions.
+func (m *mscalendar) disconnectUserChannelCalendars(mattermostUserID string, client remote.Client) {
+	index, err := m.Store.LoadChannelCalendarIndex()
+	if err != nil {
+		if !errors.Is(err, store.ErrNotFound) {
+			m.Logger.Warnf("채널 캘린더 목록 로드 오류. err=%v", err)
+		}
+		return
+	}
+
+	for _, channelID := range index {
+		channelCalendar, err := m.Store.LoadChannelCalendar(channelID)
+		if err != nil || channelCalendar.MattermostCreatorID != mattermostUserID {
+			continue
+		}
+		warn := func(message string, err error) {
+			m.Logger.With(bot.LogContext{
+				"channelID":      channelID,
+				"subscriptionID": channelCalendar.SubscriptionID,
+				"err":            err,
+			}).Warnf(message)
+		}
+
+		if sub, errLoad := m.Store.LoadSubscription(channelCalendar.SubscriptionID); errLoad == nil {
+			if errDelete := client.DeleteSubscription(sub.Remote); errDelete != nil {
+				warn("채널 캘린더 구독 삭제 중 오류 발생", errDelete)
+			}
+		}
+		err = m.Store.DeleteUserSubscription(nil, channelCalendar.SubscriptionID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			warn("채널 캘린더 구독 저장소 삭제 중 오류 발생", err)
+		}
+		err = m.Store.DeleteChannelCalendar(channelID)
+		if err != nil {
+			warn("채널 캘린더 삭제 중 오류 발생", err)
+			continue
+		}
+
+		m.postToChannel(channelID, fmt.Sprintf("**%s** 캘린더를 연결한 사용자가 계정 연결을 해제하여 이 채널과 캘린더의 연결이 해제되었습니다.", channelCalendar.CalendarName))
+	}
+}
+
+func (m *mscalendar) GetChannelCalendar(channelID string) (*store.ChannelCalendar, error) {
+	channelCalendar, err := m.Store.LoadChannelCalendar(channelID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrChannelCalendarNotFound
+	}
+	return channelCalendar, err
+}
+
+// SetChannelAgendaTime sets the time of day, as HH:MM in the timezone of the
+// creator of the channel calendar, its agenda is posted. An empty time turns
+// the agenda off.
+func (m *mscalendar) SetChannelAgendaTime(user *User, channelID, agendaTime string) (*store.ChannelCalendar, error) {
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelLinkNotPermitted
+	}
+
+	if agendaTime != "" {
+		t, err := time.Parse(channelAgendaTimeFormat, agendaTime)
+		if err != nil {
+			return nil, errors.Wrapf(err, "잘못된 시간입니다: %s", agendaTime)
+		}
+		agendaTime = t.Format(channelAgendaTimeFormat)
+	}
+
+	channelCalendar, err := m.Store.ModifyChannelCalendar(channelID, func(stored *store.ChannelCalendar) error {
+		stored.AgendaTime = agendaTime
+		return nil
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrChannelCalendarNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return channelCalendar, nil
+}
+
+// RenewChannelCalendarSubscription renews the subscription of the acting
+// user, the creator of the channel calendar, to its changes. A subscription
+// the remote doesn't know anymore is created again.
+func (m *mscalendar) RenewChannelCalendarSubscription(channelID string) error {
+	err := m.Filter(
+		withClient,
+		withActingUserExpanded,
+	)
+	if err != nil {
+		return err
+	}
+
+	channelCalendar, err := m.GetChannelCalendar(channelID)
+	if err != nil {
+		return err
+	}
+
+	sub, err := m.Store.LoadSubscription(channelCalendar.SubscriptionID)
+	if err != nil {
+		return errors.Wrap(err, "구독 로드 오류")
+	}
+
+	renewed, err := m.client.RenewSubscription(m.Config.GetNotificationURL(), m.actingUser.Remote.ID, sub.Remote)
+	if err != nil {
+		if !strings.Contains(err.Error(), "The object was not found") {
+			return err
+		}
+		err = m.Store.DeleteUserSubscription(nil, sub.Remote.ID)
+		if err != nil {
+			return err
+		}
+
+		m.Logger.Infof("채널 %s의 캘린더 구독 %s가 만료되었습니다. 새 구독을 생성합니다.", channelID, sub.Remote.ID)
+		return m.subscribeChannelCalendar(m.actingUser, channelCalendar)
+	}
+
+	_, err = storeRenewedSubscription(m.Store, nil, sub, renewed)
+	return err
+}
+
+// ProcessAllChannelCalendars posts the agenda of the day and the reminders of
+// the upcoming events of each channel calendar in its channel.
+func (m *mscalendar) ProcessAllChannelCalendars(now time.Time) error {
+	index, err := m.Store.LoadChannelCalendarIndex()
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, channelID := range index {
+		channelCalendar, err := m.Store.LoadChannelCalendar(channelID)
+		if err != nil {
+			m.Logger.Warnf("채널 %s의 캘린더 로드 오류. err=%v", channelID, err)
+			continue
+		}
+
+		err = m.processChannelCalendar(channelCalendar, now)
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"channelID":  channelID,
+				"calendarID": channelCalendar.CalendarID,
+				"groupID":    channelCalendar.GroupID,
+				"err":        err,
+			}).Warnf("채널 캘린더 처리 오류")
+		}
+	}
+	return nil
+}
+
+func (m *mscalendar) processChannelCalendar(channelCalendar *store.ChannelCalendar, now time.Time) error {
+	engine, err := m.FilterCopy(
+		withActingUser(channelCalendar.MattermostCreatorID),
+		withClient,
+		withActingUserExpanded,
+	)
+	if err != nil {
+		return err
+	}
+	creator := engine.actingUser
+
+	timezone, err := engine.GetTimezone(creator)
+	if err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	upcoming, err := getChannelCalendarView(engine.client, creator.Remote.ID, channelCalendar, now, now.Add(upcomingEventNotificationTime+upcomingEventNotificationWindow))
+	if err != nil {
+		return err
+	}
+	for _, event := range upcoming {
+		if event.IsCancelled || event.IsAllDay {
+			continue
+		}
+		if !isReminderDue(upcomingEventNotificationTime, event.Start.Time().Sub(now)) ||
+			!m.markReminderSent(channelReminderOwner(channelCalendar.ChannelID), event, upcomingEventNotificationTime) {
+			continue
+		}
+		m.postEventToChannel(channelCalendar.ChannelID, "예정된 이벤트", event, timezone)
+	}
+
+	if !shouldPostChannelAgenda(channelCalendar, now.In(loc)) {
+		return nil
+	}
+	start, end := getTodayHoursForTimezone(now, timezone)
+	events, err := getChannelCalendarView(engine.client, creator.Remote.ID, channelCalendar, start, end)
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		agenda, err := views.RenderCalendarView(events, timezone)
+		if err != nil {
+			return err
+		}
+		m.postToChannel(channelCalendar.ChannelID, fmt.Sprintf("#### **%s** 캘린더의 오늘 일정\n%s", channelCalendar.CalendarName, agenda))
+	}
+
+	// The channel calendar may have been changed since it was loaded, e.g.
+	// its subscription renewed, so only the date of the agenda is updated.
+	lastAgendaDate := now.In(loc).Format("2006-01-02")
+	_, err = m.Store.ModifyChannelCalendar(channelCalendar.ChannelID, func(stored *store.ChannelCalendar) error {
+		stored.LastAgendaDate = lastAgendaDate
+		return nil
+	})
+	return err
+}
+
+// shouldPostChannelAgenda tells whether the agenda of the day is due in the
+// channel of the calendar: once on weekdays, within the window after its
+// time. now is in the timezone of the creator of the channel calendar.
+func shouldPostChannelAgenda(channelCalendar *store.ChannelCalendar, now time.Time) bool {
+	if channelCalendar.AgendaTime == "" || channelCalendar.LastAgendaDate == now.Format("2006-01-02") {
+		return false
+	}
+	if now.Weekday() == time.Saturday || now.Weekday() == time.Sunday {
+		return false
+	}
+
+	t, err := time.ParseInLocation(channelAgendaTimeFormat, channelCalendar.AgendaTime, now.Location())
+	if err != nil {
+		return false
+	}
+	t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	diff := now.Sub(t)
+	return diff >= 0 && diff < channelAgendaTimeWindow
+}
+
+func (m *mscalendar) postToChannel(channelID, message string) {
+	err := m.Poster.CreatePost(&model.Post{
+		ChannelId: channelID,
+		Message:   message,
+	})
+	if err != nil {
+		m.Logger.With(bot.LogContext{"channelID": channelID, "err": err}).Warnf("채널에 게시물 생성 오류")
+	}
+}
+
+func (m *mscalendar) postEventToChannel(channelID, message string, event *remote.Event, timezone string) {
+	post := &model.Post{
+		ChannelId: channelID,
+		Message:   message,
+	}
+	attachment, err := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+	if err == nil {
+		model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	}
+	err = m.Poster.CreatePost(post)
+	if err != nil {
+		m.Logger.With(bot.LogContext{"channelID": channelID, "err": err}).Warnf("채널에 게시물 생성 오류")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// processChannelCalendarNotification posts the changes of a channel calendar
+// in its channel, rather than sending them to the creator of its
+// subscription.
+func (processor *notificationProcessor) processChannelCalendarNotification(n *remote.Notification, sub *store.Subscription, creator *store.User, client remote.Client) error {
+	channelCalendar, err := processor.Store.LoadChannelCalendar(sub.ChannelID)
+	if err != nil {
+		return err
+	}
+	if channelCalendar.SubscriptionID != sub.Remote.ID {
+		return errors.New("구독이 관리되지않고 방치 상태입니다")
+	}
+
+	logger := processor.Logger.With(bot.LogContext{
+		"ChannelID":      sub.ChannelID,
+		"SubscriptionID": n.SubscriptionID,
+		"ChangeType":     n.ChangeType,
+	})
+
+	if n.LifecycleEvent != "" {
+		return processor.processChannelCalendarLifecycleNotification(n, sub, channelCalendar, creator, client, logger)
+	}
+
+	if n.RecommendRenew {
+		err = processor.renewChannelCalendarSubscription(n, sub, client)
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.IsBare {
+		n, err = client.GetNotificationData(n)
+		if err != nil {
+			return err
+		}
+	}
+
+	mailSettings, err := client.GetMailboxSettings(creator.Remote.ID)
+	if err != nil {
+		return err
+	}
+	timezone := mailSettings.TimeZone
+
+	var prior *store.Event
+	if n.Event.ICalUID != "" {
+		prior, err = processor.Store.LoadChannelCalendarEvent(sub.ChannelID, n.Event.OccurrenceKey())
+	} else {
+		prior, err = processor.Store.LoadChannelCalendarEventByRemoteID(sub.ChannelID, n.Event.ID)
+	}
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+
+	if n.ChangeType == remote.ChangeTypeDeleted || n.Event.IsCancelled {
+		if prior == nil || prior.Remote == nil {
+			logger.Debugf("웹훅 알림: 알 수 없는 이벤트가 취소되었습니다.")
+			return nil
+		}
+
+		cancelled := *n
+		if n.ChangeType == remote.ChangeTypeDeleted {
+			cancelled.Event = prior.Remote
+		}
+		sa := processor.cancelledEventSlackAttachment(&cancelled, timezone)
+		processor.postChannelCalendarChange(sub.ChannelID, fmt.Sprintf("**%s** 일정이 취소되었습니다.", views.EnsureSubject(cancelled.Event.Subject)), sa, logger)
+		return processor.Store.DeleteChannelCalendarEvent(sub.ChannelID, prior.Remote.OccurrenceKey())
+	}
+
+	var sa *model.SlackAttachment
+	if prior != nil && prior.Remote != nil {
+		var changed bool
+		changed, sa = processor.updatedEventSlackAttachment(n, prior.Remote, timezone)
+		if !changed {
+			logger.Debugf("웹훅 알림: 이벤트에서 변경사항이 감지되지 않았습니다.")
+			return nil
+		}
+	} else {
+		sa = processor.newEventSlackAttachment(n, timezone)
+		prior = &store.Event{}
+	}
+	// Responses to the event are the creator's, not the channel members'.
+	sa.Actions = nil
+	processor.postChannelCalendarChange(sub.ChannelID, "", sa, logger)
+
+	prior.Remote = n.Event
+	return processor.Store.StoreChannelCalendarEvent(sub.ChannelID, prior)
+}
+
+func (processor *notificationProcessor) postChannelCalendarChange(channelID, message string, sa *model.SlackAttachment, logger bot.Logger) {
+	post := &model.Post{
+		ChannelId: channelID,
+		Message:   message,
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{sa})
+	if err := processor.Poster.CreatePost(post); err != nil {
+		logger.With(bot.LogContext{"err": err}).Warnf("웹훅 알림: 채널 캘린더 게시물 생성 오류")
+	}
+}
+
+func (processor *notificationProcessor) renewChannelCalendarSubscription(n *remote.Notification, sub *store.Subscription, client remote.Client) error {
+	renewed, err := client.RenewSubscription(processor.Config.GetNotificationURL(), n.SubscriptionCreator.ID, n.Subscription)
+	if err != nil {
+		return err
+	}
+	_, err = storeRenewedSubscription(processor.Store, nil, sub, renewed)
+	return err
+}
+
+// processChannelCalendarLifecycleNotification keeps the subscription to the
+// changes of a channel calendar alive.
+func (processor *notificationProcessor) processChannelCalendarLifecycleNotification(n *remote.Notification, sub *store.Subscription, channelCalendar *store.ChannelCalendar, creator *store.User, client remote.Client, logger bot.Logger) error {
+	switch n.LifecycleEvent {
+	case remote.LifecycleEventReauthorizationRequired:
+		return processor.renewChannelCalendarSubscription(n, sub, client)
+
+	case remote.LifecycleEventSubscriptionRemoved:
+		err := processor.Store.DeleteUserSubscription(nil, sub.Remote.ID)
+		if err != nil {
+			return err
+		}
+
+		created, err := createChannelCalendarSubscription(client, processor.Config.GetNotificationURL(), creator.Remote.ID, channelCalendar)
+		if err != nil {
+			processor.postChannelCalendarChange(channelCalendar.ChannelID, "", &model.SlackAttachment{
+				Text: fmt.Sprintf("%s에서 **%s** 캘린더의 변경 알림 구독을 제거했으며 다시 만들 수 없습니다. `/%s channelcal disconnect` 후 다시 연결해주세요.",
+					processor.Provider.DisplayName, channelCalendar.CalendarName, processor.Provider.CommandTrigger),
+			}, logger)
+			return errors.Wrap(err, "제거된 채널 캘린더 구독을 다시 만드는 중 오류 발생")
+		}
+		sub.Remote = created
+		err = processor.Store.StoreChannelSubscription(sub)
+		if err != nil {
+			return err
+		}
+		channelCalendar.SubscriptionID = created.ID
+		err = processor.Store.StoreChannelCalendar(channelCalendar)
+		if err != nil {
+			return err
+		}
+		logger.Infof("웹훅 알림: 제거된 채널 캘린더 구독을 다시 만들었습니다. 새 구독: %s", created.ID)
+		return nil
+	}
+
+	logger.Debugf("웹훅 알림: 처리하지 않는 수명 주기 이벤트입니다: %s", n.LifecycleEvent)
+	return nil
+}
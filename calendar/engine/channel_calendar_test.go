@@ -0,0 +1,292 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+// mockSubscriberClient subscribes to the changes of single calendars.
+type mockSubscriberClient struct {
+	*mock_remote.MockClient
+	calendarIDs []string
+}
+
+func (c *mockSubscriberClient) CreateCalendarSubscription(_, _, calendarID string) (*remote.Subscription, error) {
+	c.calendarIDs = append(c.calendarIDs, calendarID)
+	return &remote.Subscription{ID: "sub_id"}, nil
+}
+
+// mockGroupCalendarClient also reads and subscribes to the calendars of
+// groups.
+type mockGroupCalendarClient struct {
+	*mockSubscriberClient
+	groups   []*remote.Group
+	groupIDs []string
+	events   []*remote.Event
+}
+
+func (c *mockGroupCalendarClient) GetGroups(string) ([]*remote.Group, error) {
+	return c.groups, nil
+}
+
+func (c *mockGroupCalendarClient) GetGroupCalendarView(groupID string, _, _ time.Time) ([]*remote.Event, error) {
+	c.groupIDs = append(c.groupIDs, groupID)
+	return c.events, nil
+}
+
+func (c *mockGroupCalendarClient) CreateGroupCalendarSubscription(_, groupID string) (*remote.Subscription, error) {
+	c.groupIDs = append(c.groupIDs, groupID)
+	return &remote.Subscription{ID: "group_sub_id"}, nil
+}
+
+func TestConnectChannelCalendar(t *testing.T) {
+	newUser := func() *User {
+		return &User{
+			MattermostUserID: "user_mm_id",
+			User: &store.User{
+				MattermostUserID:   "user_mm_id",
+				MattermostUsername: "alice",
+				Remote:             &remote.User{ID: "user_remote_id"},
+			},
+			MattermostUser: &model.User{Id: "user_mm_id"},
+		}
+	}
+	calendars := []*remote.Calendar{
+		{ID: "personal_id", Name: "Calendar"},
+		{ID: "team_id", Name: "Team Calendar"},
+	}
+
+	t.Run("connect not permitted", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(false)
+
+		_, err := mscalendar.ConnectChannelCalendar(newUser(), "channel_id", "Team Calendar")
+		require.ErrorIs(t, err, ErrChannelLinkNotPermitted)
+	})
+
+	t.Run("remote without calendar subscriptions", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+
+		_, err := mscalendar.ConnectChannelCalendar(newUser(), "channel_id", "Team Calendar")
+		require.ErrorIs(t, err, remote.ErrNotImplemented)
+	})
+
+	t.Run("channel already connected", func(t *testing.T) {
+		mscalendar, mockStore, _, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mscalendar.client = &mockSubscriberClient{MockClient: mockClient}
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockStore.EXPECT().LoadChannelCalendar("channel_id").Return(&store.ChannelCalendar{ChannelID: "channel_id"}, nil)
+
+		_, err := mscalendar.ConnectChannelCalendar(newUser(), "channel_id", "Team Calendar")
+		require.ErrorIs(t, err, ErrChannelCalendarAlreadyConnected)
+	})
+
+	t.Run("calendar not found", func(t *testing.T) {
+		mscalendar, mockStore, _, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mscalendar.client = &mockSubscriberClient{MockClient: mockClient}
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockStore.EXPECT().LoadChannelCalendar("channel_id").Return(nil, store.ErrNotFound)
+		mockClient.EXPECT().GetCalendars("user_remote_id").Return(calendars, nil)
+
+		_, err := mscalendar.ConnectChannelCalendar(newUser(), "channel_id", "Unknown")
+		require.ErrorIs(t, err, ErrCalendarNotFound)
+	})
+
+	t.Run("calendar is connected by name", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		client := &mockSubscriberClient{MockClient: mockClient}
+		mscalendar.client = client
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockStore.EXPECT().LoadChannelCalendar("channel_id").Return(nil, store.ErrNotFound)
+		mockClient.EXPECT().GetCalendars("user_remote_id").Return(calendars, nil)
+		mockStore.EXPECT().StoreChannelSubscription(gomock.Any()).DoAndReturn(func(sub *store.Subscription) error {
+			require.Equal(t, "channel_id", sub.ChannelID)
+			require.Equal(t, "user_mm_id", sub.MattermostCreatorID)
+			require.Equal(t, "sub_id", sub.Remote.ID)
+			return nil
+		})
+		mockStore.EXPECT().StoreChannelCalendar(gomock.Any()).Return(nil)
+		mockPoster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+			require.Equal(t, "channel_id", post.ChannelId)
+			require.Contains(t, post.Message, "@alice님이 **Team Calendar** 캘린더를 이 채널에 연결했습니다.")
+			return nil
+		})
+
+		channelCalendar, err := mscalendar.ConnectChannelCalendar(newUser(), "channel_id", "team calendar")
+		require.NoError(t, err)
+		require.Equal(t, []string{"team_id"}, client.calendarIDs)
+		require.Equal(t, &store.ChannelCalendar{
+			PluginVersion:       mscalendar.Config.PluginVersion,
+			ChannelID:           "channel_id",
+			CalendarID:          "team_id",
+			CalendarName:        "Team Calendar",
+			MattermostCreatorID: "user_mm_id",
+			SubscriptionID:      "sub_id",
+			AgendaTime:          defaultChannelAgendaTime,
+		}, channelCalendar)
+	})
+	t.Run("group calendar is connected by name", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		client := &mockGroupCalendarClient{
+			mockSubscriberClient: &mockSubscriberClient{MockClient: mockClient},
+			groups:               []*remote.Group{{ID: "group_id", DisplayName: "Design Team"}},
+		}
+		mscalendar.client = client
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockStore.EXPECT().LoadChannelCalendar("channel_id").Return(nil, store.ErrNotFound)
+		mockClient.EXPECT().GetCalendars("user_remote_id").Return(calendars, nil)
+		mockStore.EXPECT().StoreChannelSubscription(gomock.Any()).DoAndReturn(func(sub *store.Subscription) error {
+			require.Equal(t, "group_sub_id", sub.Remote.ID)
+			return nil
+		})
+		mockStore.EXPECT().StoreChannelCalendar(gomock.Any()).Return(nil)
+		mockPoster.EXPECT().CreatePost(gomock.Any()).Return(nil)
+
+		channelCalendar, err := mscalendar.ConnectChannelCalendar(newUser(), "channel_id", "design team")
+		require.NoError(t, err)
+		require.Empty(t, client.calendarIDs)
+		require.Equal(t, []string{"group_id"}, client.groupIDs)
+		require.Equal(t, "group_id", channelCalendar.GroupID)
+		require.Empty(t, channelCalendar.CalendarID)
+		require.Equal(t, "Design Team", channelCalendar.CalendarName)
+		require.Equal(t, "group_sub_id", channelCalendar.SubscriptionID)
+	})
+}
+
+func TestGetChannelCalendarView(t *testing.T) {
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	events := []*remote.Event{{ID: "event_id"}}
+
+	t.Run("calendar of the creator", func(t *testing.T) {
+		_, _, _, _, _, mockClient, _ := GetMockSetup(t)
+		mockClient.EXPECT().GetCalendarView("creator_remote_id", "calendar_id", start, end).Return(events, nil)
+
+		got, err := getChannelCalendarView(mockClient, "creator_remote_id", &store.ChannelCalendar{CalendarID: "calendar_id"}, start, end)
+		require.NoError(t, err)
+		require.Equal(t, events, got)
+	})
+
+	t.Run("calendar of a group", func(t *testing.T) {
+		_, _, _, _, _, mockClient, _ := GetMockSetup(t)
+		client := &mockGroupCalendarClient{mockSubscriberClient: &mockSubscriberClient{MockClient: mockClient}, events: events}
+
+		got, err := getChannelCalendarView(client, "creator_remote_id", &store.ChannelCalendar{GroupID: "group_id"}, start, end)
+		require.NoError(t, err)
+		require.Equal(t, events, got)
+		require.Equal(t, []string{"group_id"}, client.groupIDs)
+	})
+
+	t.Run("remote without group calendars", func(t *testing.T) {
+		_, _, _, _, _, mockClient, _ := GetMockSetup(t)
+
+		_, err := getChannelCalendarView(mockClient, "creator_remote_id", &store.ChannelCalendar{GroupID: "group_id"}, start, end)
+		require.ErrorIs(t, err, remote.ErrNotImplemented)
+	})
+}
+
+func TestSetChannelAgendaTime(t *testing.T) {
+	user := &User{MattermostUserID: "user_mm_id"}
+
+	t.Run("invalid time", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+
+		_, err := mscalendar.SetChannelAgendaTime(user, "channel_id", "25:00")
+		require.Error(t, err)
+	})
+
+	t.Run("channel not connected", func(t *testing.T) {
+		mscalendar, mockStore, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockStore.EXPECT().ModifyChannelCalendar("channel_id", gomock.Any()).Return(nil, store.ErrNotFound)
+
+		_, err := mscalendar.SetChannelAgendaTime(user, "channel_id", "9:30")
+		require.ErrorIs(t, err, ErrChannelCalendarNotFound)
+	})
+
+	t.Run("time is normalized", func(t *testing.T) {
+		mscalendar, mockStore, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockStore.EXPECT().ModifyChannelCalendar("channel_id", gomock.Any()).DoAndReturn(func(_ string, modify func(*store.ChannelCalendar) error) (*store.ChannelCalendar, error) {
+			channelCalendar := &store.ChannelCalendar{ChannelID: "channel_id", SubscriptionID: "renewed_subscription_id", AgendaTime: "09:00"}
+			if err := modify(channelCalendar); err != nil {
+				return nil, err
+			}
+			return channelCalendar, nil
+		})
+
+		channelCalendar, err := mscalendar.SetChannelAgendaTime(user, "channel_id", "9:30")
+		require.NoError(t, err)
+		require.Equal(t, "09:30", channelCalendar.AgendaTime)
+		require.Equal(t, "renewed_subscription_id", channelCalendar.SubscriptionID)
+	})
+}
+
+func TestDisconnectUserChannelCalendars(t *testing.T) {
+	mscalendar, mockStore, mockPoster, _, _, mockClient, _ := GetMockSetup(t)
+	sub := &store.Subscription{Remote: &remote.Subscription{ID: "subscription_id"}}
+	mockStore.EXPECT().LoadChannelCalendarIndex().Return(store.ChannelCalendarIndex{"own_channel_id", "other_channel_id"}, nil)
+	mockStore.EXPECT().LoadChannelCalendar("own_channel_id").Return(&store.ChannelCalendar{
+		ChannelID:           "own_channel_id",
+		CalendarName:        "Team",
+		MattermostCreatorID: "user_mm_id",
+		SubscriptionID:      "subscription_id",
+	}, nil)
+	mockStore.EXPECT().LoadChannelCalendar("other_channel_id").Return(&store.ChannelCalendar{
+		ChannelID:           "other_channel_id",
+		MattermostCreatorID: "other_mm_id",
+		SubscriptionID:      "other_subscription_id",
+	}, nil)
+	mockStore.EXPECT().LoadSubscription("subscription_id").Return(sub, nil)
+	mockClient.EXPECT().DeleteSubscription(sub.Remote).Return(nil)
+	mockStore.EXPECT().DeleteUserSubscription(nil, "subscription_id").Return(nil)
+	mockStore.EXPECT().DeleteChannelCalendar("own_channel_id").Return(nil)
+	mockPoster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+		require.Equal(t, "own_channel_id", post.ChannelId)
+		return nil
+	})
+
+	mscalendar.disconnectUserChannelCalendars("user_mm_id", mockClient)
+}
+
+func TestShouldPostChannelAgenda(t *testing.T) {
+	// 2024-03-04 is a Monday.
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, 3, 4, hour, minute, 0, 0, time.UTC)
+	}
+
+	for _, tt := range []struct {
+		name           string
+		agendaTime     string
+		lastAgendaDate string
+		now            time.Time
+		expected       bool
+	}{
+		{name: "agenda off", agendaTime: "", now: monday(9, 0), expected: false},
+		{name: "before the agenda time", agendaTime: "09:00", now: monday(8, 55), expected: false},
+		{name: "at the agenda time", agendaTime: "09:00", now: monday(9, 0), expected: true},
+		{name: "late within the window", agendaTime: "09:00", now: monday(9, 50), expected: true},
+		{name: "after the window", agendaTime: "09:00", now: monday(10, 0), expected: false},
+		{name: "already posted today", agendaTime: "09:00", lastAgendaDate: "2024-03-04", now: monday(9, 5), expected: false},
+		{name: "posted the day before", agendaTime: "09:00", lastAgendaDate: "2024-03-01", now: monday(9, 5), expected: true},
+		{name: "weekend", agendaTime: "09:00", now: time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC), expected: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			channelCalendar := &store.ChannelCalendar{AgendaTime: tt.agendaTime, LastAgendaDate: tt.lastAgendaDate}
+			require.Equal(t, tt.expected, shouldPostChannelAgenda(channelCalendar, tt.now))
+		})
+	}
+}
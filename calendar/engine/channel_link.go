@@ -0,0 +1,144 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+var (
+	ErrEventAlreadyLinked = errors.New("이미 이 채널에 연결된 일정입니다")
+	ErrEventNotLinked     = errors.New("이 채널에 연결되지 않은 일정입니다")
+)
+
+type ChannelLinks interface {
+	LinkEvent(user *User, eventID, channelID string) (*remote.Event, error)
+	UnlinkEvent(user *User, eventID, channelID string) (*remote.Event, error)
+	GetChannelLinkedEvents(user *User, channelID string, from, to time.Time) ([]*remote.Event, error)
+}
+
+// LinkEvent links an existing event of the user's calendar to the channel,
+// so that its reminders are also posted there.
+func (m *mscalendar) LinkEvent(user *User, eventID, channelID string) (*remote.Event, error) {
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelLinkNotPermitted
+	}
+
+	event, err := m.GetEvent(user, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	linkedChannelIDs, err := loadLinkedChannelIDs(m.Store, event)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := linkedChannelIDs[channelID]; ok {
+		return event, ErrEventAlreadyLinked
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		timezone = event.Start.TimeZone
+	}
+	attachment, err := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+	if err != nil {
+		m.Logger.Warnf("일정을 표시하지 못했습니다. err=%v", err)
+	}
+
+	return event, m.LinkEventToChannel(user, event, channelID, attachment)
+}
+
+// UnlinkEvent removes the link between the event and the channel. Links are
+// removed for every key the event is looked up by, so unlinking an occurrence
+// of a linked series unlinks the whole series.
+func (m *mscalendar) UnlinkEvent(user *User, eventID, channelID string) (*remote.Event, error) {
+	if !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelLinkNotPermitted
+	}
+
+	event, err := m.GetEvent(user, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	linkedChannelIDs, err := loadLinkedChannelIDs(m.Store, event)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := linkedChannelIDs[channelID]; !ok {
+		return event, ErrEventNotLinked
+	}
+
+	for _, key := range linkedEventKeys(event) {
+		if err = m.Store.DeleteLinkedChannelFromEvent(key, channelID); err != nil {
+			return event, err
+		}
+		if user.ChannelEvents[key] == channelID {
+			if err = m.Store.DeleteUserLinkedEvent(user.MattermostUserID, key); err != nil {
+				m.Logger.With(bot.LogContext{
+					"err":        err,
+					"mm_user_id": user.MattermostUserID,
+					"event_id":   key,
+				}).Warnf("사용자의 연결된 일정 삭제 중 오류 발생")
+			}
+		}
+	}
+
+	post := &model.Post{
+		Message:   fmt.Sprintf("@%s님이 **%s** 일정과 이 채널의 연결을 해제했습니다.", user.MattermostUsername, event.Subject),
+		ChannelId: channelID,
+	}
+	if err = m.Poster.CreatePost(post); err != nil {
+		m.Logger.With(bot.LogContext{"err": err}).Errorf("채널에 일정 연결 해제 게시물 생성 오류")
+	}
+	return event, nil
+}
+
+// GetChannelLinkedEvents returns the events of the user's calendar between
+// from and to that are linked to the channel.
+func (m *mscalendar) GetChannelLinkedEvents(user *User, channelID string, from, to time.Time) ([]*remote.Event, error) {
+	events, err := m.ViewCalendar(user, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	linked := []*remote.Event{}
+	for _, event := range events {
+		if event.IsCancelled {
+			continue
+		}
+		linkedChannelIDs, err := loadLinkedChannelIDs(m.Store, event)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := linkedChannelIDs[channelID]; ok {
+			linked = append(linked, event)
+		}
+	}
+	return linked, nil
+}
+
+// linkedEventKeys returns the keys channels may be linked to the event by:
+// the ones loadLinkedChannelIDs looks up, and the ID of a series master.
+func linkedEventKeys(event *remote.Event) []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, key := range []string{event.OccurrenceKey(), event.ICalUID, event.SeriesMasterID, event.ID} {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
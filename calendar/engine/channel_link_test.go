@@ -0,0 +1,133 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestChannelLinks(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Hour)
+	newUser := func() *User {
+		return &User{
+			MattermostUserID: "user_mm_id",
+			User: &store.User{
+				MattermostUserID:   "user_mm_id",
+				MattermostUsername: "alice",
+				Remote:             &remote.User{ID: "user_remote_id"},
+				ChannelEvents:      store.ChannelEventLink{"ical_uid": "channel_id"},
+			},
+			MattermostUser: &model.User{Id: "user_mm_id"},
+		}
+	}
+	newEvent := func() *remote.Event {
+		return &remote.Event{
+			ID:      "event_id",
+			ICalUID: "ical_uid",
+			Subject: "Design review",
+			Start:   remote.NewDateTime(start, "UTC"),
+			End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		}
+	}
+	linked := &store.EventMetadata{LinkedChannelIDs: map[string]struct{}{"channel_id": {}}}
+
+	t.Run("link not permitted", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(false)
+
+		_, err := mscalendar.LinkEvent(newUser(), "event_id", "channel_id")
+		require.ErrorIs(t, err, ErrChannelLinkNotPermitted)
+	})
+
+	t.Run("event already linked", func(t *testing.T) {
+		mscalendar, mockStore, _, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(newEvent(), nil)
+		mockStore.EXPECT().LoadEventMetadata("ical_uid").Return(linked, nil)
+
+		event, err := mscalendar.LinkEvent(newUser(), "event_id", "channel_id")
+		require.ErrorIs(t, err, ErrEventAlreadyLinked)
+		require.Equal(t, "Design review", event.Subject)
+	})
+
+	t.Run("event is linked", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(newEvent(), nil)
+		mockStore.EXPECT().LoadEventMetadata("ical_uid").Return(nil, store.ErrNotFound)
+		mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+		mockStore.EXPECT().StoreUserLinkedEvent("user_mm_id", "ical_uid", "channel_id").Return(nil)
+		mockStore.EXPECT().AddLinkedChannelToEvent("ical_uid", "channel_id").Return(nil)
+		mockPoster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+			require.Equal(t, "channel_id", post.ChannelId)
+			require.Equal(t, "The event **Design review** was linked to this channel by @alice", post.Message)
+			return nil
+		})
+
+		event, err := mscalendar.LinkEvent(newUser(), "event_id", "channel_id")
+		require.NoError(t, err)
+		require.Equal(t, "event_id", event.ID)
+	})
+
+	t.Run("event not linked", func(t *testing.T) {
+		mscalendar, mockStore, _, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(newEvent(), nil)
+		mockStore.EXPECT().LoadEventMetadata("ical_uid").Return(nil, store.ErrNotFound)
+
+		_, err := mscalendar.UnlinkEvent(newUser(), "event_id", "channel_id")
+		require.ErrorIs(t, err, ErrEventNotLinked)
+	})
+
+	t.Run("event is unlinked", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockClient.EXPECT().GetEvent("user_remote_id", "event_id").Return(newEvent(), nil)
+		mockStore.EXPECT().LoadEventMetadata("ical_uid").Return(linked, nil)
+		mockStore.EXPECT().DeleteLinkedChannelFromEvent("ical_uid", "channel_id").Return(nil)
+		mockStore.EXPECT().DeleteLinkedChannelFromEvent("event_id", "channel_id").Return(nil)
+		mockStore.EXPECT().DeleteUserLinkedEvent("user_mm_id", "ical_uid").Return(nil)
+		mockPoster.EXPECT().CreatePost(gomock.Any()).Return(nil)
+
+		event, err := mscalendar.UnlinkEvent(newUser(), "event_id", "channel_id")
+		require.NoError(t, err)
+		require.Equal(t, "event_id", event.ID)
+	})
+
+	t.Run("events linked to the channel", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+		other := newEvent()
+		other.ID, other.ICalUID = "other_id", "other_uid"
+		cancelled := newEvent()
+		cancelled.ID, cancelled.ICalUID, cancelled.IsCancelled = "cancelled_id", "cancelled_uid", true
+		mockClient.EXPECT().GetDefaultCalendarView("user_remote_id", start, start.Add(time.Hour)).Return([]*remote.Event{newEvent(), other, cancelled}, nil)
+		mockStore.EXPECT().LoadEventMetadata("ical_uid").Return(linked, nil)
+		mockStore.EXPECT().LoadEventMetadata("other_uid").Return(nil, store.ErrNotFound)
+
+		events, err := mscalendar.GetChannelLinkedEvents(newUser(), "channel_id", start, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, "event_id", events[0].ID)
+	})
+}
+
+func TestLinkedEventKeys(t *testing.T) {
+	require.Equal(t, []string{"ical_uid", "event_id"}, linkedEventKeys(&remote.Event{ID: "event_id", ICalUID: "ical_uid"}))
+
+	occurrence := &remote.Event{
+		ID:             "occurrence_id",
+		ICalUID:        "ical_uid",
+		SeriesMasterID: "series_id",
+		OriginalStart:  "2024-05-02T14:00:00Z",
+	}
+	require.Equal(t, []string{occurrence.OccurrenceKey(), "ical_uid", "series_id", "occurrence_id"}, linkedEventKeys(occurrence))
+}
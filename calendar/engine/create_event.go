@@ -0,0 +1,214 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+var ErrPostNotAccessible = errors.New("게시물을 찾을 수 없거나 볼 권한이 없습니다")
+
+// mentionPattern matches the @mentions of a message, as Mattermost usernames.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([a-z0-9][a-z0-9._-]*[a-z0-9_]|[a-z0-9])`)
+
+const maxEventSubjectLength = 255
+
+type EventCreator interface {
+	OpenCreateEventDialog(user *User, triggerID string, defaults views.CreateEventDefaults) error
+	OpenCreateEventDialogFromPost(user *User, triggerID, postID string) error
+	ScheduleEvent(user *User, event *remote.Event, mattermostUserIDs []string, channelID string) (*remote.Event, error)
+	LinkEventToChannel(user *User, event *remote.Event, channelID string, attachment *model.SlackAttachment) error
+}
+
+// OpenCreateEventDialog opens the dialog to create an event, which is
+// submitted to the plugin.
+func (m *mscalendar) OpenCreateEventDialog(user *User, triggerID string, defaults views.CreateEventDefaults) error {
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	return m.PluginAPI.OpenInteractiveDialog(model.OpenDialogRequest{
+		TriggerId: triggerID,
+		URL:       fmt.Sprintf("%s%s%s%s", m.Config.PluginURLPath, config.PathDialogs, config.PathEvents, config.PathCreate),
+		Dialog:    views.RenderCreateEventDialog(time.Now().In(loc), timezone, defaults, m.Provider.Features.OnlineMeetings),
+	})
+}
+
+// OpenCreateEventDialogFromPost opens the dialog to create an event about the
+// post: the subject is its first line, the description links to it, and its
+// author and the users it mentions are invited. The event is linked to the
+// channel of the post if the user can link events there.
+func (m *mscalendar) OpenCreateEventDialogFromPost(user *User, triggerID, postID string) error {
+	post, err := m.PluginAPI.GetPost(postID)
+	if err != nil || !m.PluginAPI.CanReadChannel(post.ChannelId, user.MattermostUserID) {
+		return ErrPostNotAccessible
+	}
+
+	defaults := views.CreateEventDefaults{
+		Subject:     postSubject(post.Message),
+		Description: fmt.Sprintf("%s/_redirect/pl/%s", strings.TrimRight(m.Config.MattermostSiteURL, "/"), post.Id),
+	}
+	if m.PluginAPI.CanLinkEventToChannel(post.ChannelId, user.MattermostUserID) {
+		defaults.ChannelID = post.ChannelId
+	}
+
+	attendees := []string{}
+	seen := map[string]bool{user.MattermostUserID: true}
+	addAttendee := func(u *model.User) {
+		if u == nil || u.IsBot || u.DeleteAt != 0 || seen[u.Id] {
+			return
+		}
+		seen[u.Id] = true
+		attendees = append(attendees, "@"+u.Username)
+	}
+	if author, err := m.PluginAPI.GetMattermostUser(post.UserId); err == nil {
+		addAttendee(author)
+	}
+	for _, match := range mentionPattern.FindAllStringSubmatch(strings.ToLower(post.Message), -1) {
+		// Mentions of @channel, @here or @all, and of unknown users, are
+		// not found as users.
+		if mentioned, err := m.PluginAPI.GetMattermostUserByUsername(match[1]); err == nil {
+			addAttendee(mentioned)
+		}
+	}
+	defaults.Attendees = strings.Join(attendees, " ")
+
+	return m.OpenCreateEventDialog(user, triggerID, defaults)
+}
+
+// postSubject returns the first line of the message, without markdown
+// heading or quote marks, as the subject of an event.
+func postSubject(message string) string {
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#>"))
+		if line == "" {
+			continue
+		}
+		if utf8.RuneCountInString(line) > maxEventSubjectLength {
+			line = string([]rune(line)[:maxEventSubjectLength])
+		}
+		return line
+	}
+	return ""
+}
+
+// ScheduleEvent creates the event with the users as attendees, and links it
+// to the channel if one is given. Without a channel, the event is sent to the
+// user as a DM.
+func (m *mscalendar) ScheduleEvent(user *User, event *remote.Event, mattermostUserIDs []string, channelID string) (*remote.Event, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if channelID != "" && !m.PluginAPI.CanLinkEventToChannel(channelID, user.MattermostUserID) {
+		return nil, ErrChannelLinkNotPermitted
+	}
+
+	for _, mattermostUserID := range mattermostUserIDs {
+		mail, err := m.getScheduleMail(mattermostUserID)
+		if err != nil {
+			m.Logger.Warnf("참석자 %s의 메일 주소를 찾을 수 없습니다. err=%v", mattermostUserID, err)
+			continue
+		}
+		event.Attendees = append(event.Attendees, &remote.Attendee{
+			Type:         meetingAttendeeTypeRequired,
+			EmailAddress: &remote.EmailAddress{Address: mail},
+		})
+	}
+
+	event, err = m.CreateEvent(user, event, mattermostUserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		timezone = event.Start.TimeZone
+	}
+	attachment, err := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+	if err != nil {
+		m.Logger.Warnf("일정을 표시하지 못했습니다. err=%v", err)
+	}
+
+	if channelID != "" {
+		return event, m.LinkEventToChannel(user, event, channelID, attachment)
+	}
+
+	if attachment == nil {
+		_, err = m.Poster.DM(user.MattermostUserID, "**%s** 일정이 생성되었습니다.", event.Subject)
+	} else {
+		_, err = m.Poster.DMWithMessageAndAttachments(user.MattermostUserID, "일정이 생성되었습니다.", attachment)
+	}
+	if err != nil {
+		m.Logger.Warnf("일정 생성 DM을 보내지 못했습니다. err=%v", err)
+	}
+	return event, nil
+}
+
+// LinkEventToChannel links the event to the channel and announces it there.
+// An error is returned when the link couldn't be stored for the user.
+func (m *mscalendar) LinkEventToChannel(user *User, event *remote.Event, channelID string, attachment *model.SlackAttachment) error {
+	err := m.Filter(withRemoteUser(user))
+	if err != nil {
+		return err
+	}
+
+	// Occurrences of a series don't share the ICalUID of the series with
+	// every provider, so recurring events are also linked by series ID.
+	linkedEventIDs := []string{event.ICalUID}
+	if event.Recurrence != nil && event.ID != event.ICalUID {
+		linkedEventIDs = append(linkedEventIDs, event.ID)
+	}
+
+	var errLink error
+	for _, linkedEventID := range linkedEventIDs {
+		if err := m.Store.StoreUserLinkedEvent(user.MattermostUserID, linkedEventID, channelID); err != nil {
+			m.Poster.DM(user.MattermostUserID, "Your event **%s** could not be linked to a channel. Please contact an administrator for more details.", event.Subject)
+			return err
+		}
+		if err := m.Store.AddLinkedChannelToEvent(linkedEventID, channelID); err != nil {
+			errLink = err
+		}
+	}
+
+	if errLink != nil {
+		m.Logger.With(bot.LogContext{"err": errLink}).Errorf("error linking event to channel")
+		m.Poster.DM(user.MattermostUserID, "You event **%s** could not be linked to a channel. Please contact an administrator for more details.", event.Subject)
+		return nil
+	}
+
+	post := &model.Post{
+		Message:   fmt.Sprintf("The event **%s** was linked to this channel by @%s", event.Subject, user.MattermostUsername),
+		ChannelId: channelID,
+	}
+	if attachment != nil {
+		model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+	}
+	if err := m.Poster.CreatePost(post); err != nil {
+		m.Logger.With(bot.LogContext{"err": err}).Errorf("error sending post to channel about linked event")
+	}
+	return nil
+}
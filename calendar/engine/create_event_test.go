@@ -0,0 +1,123 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestScheduleEvent(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Hour)
+	newUser := func() *User {
+		return &User{
+			MattermostUserID: "user_mm_id",
+			User:             &store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}},
+			MattermostUser:   &model.User{Id: "user_mm_id"},
+		}
+	}
+	newEvent := func() *remote.Event {
+		return &remote.Event{
+			Subject: "Design review",
+			Start:   remote.NewDateTime(start, "UTC"),
+			End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		}
+	}
+
+	t.Run("not permitted to link the channel", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(false)
+
+		_, err := mscalendar.ScheduleEvent(newUser(), newEvent(), nil, "channel_id")
+		require.ErrorIs(t, err, ErrChannelLinkNotPermitted)
+	})
+
+	t.Run("event is sent as a DM without a channel", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, _, mockClient, _ := GetMockSetup(t)
+		mockStore.EXPECT().LoadUser("alice_mm_id").Return(&store.User{Remote: &remote.User{Mail: "alice@example.com"}}, nil).Times(2)
+
+		expected := newEvent()
+		expected.Attendees = []*remote.Attendee{{
+			Type:         "required",
+			EmailAddress: &remote.EmailAddress{Address: "alice@example.com"},
+		}}
+		mockClient.EXPECT().CreateEvent("user_remote_id", expected).Return(expected, nil)
+		mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+		mockPoster.EXPECT().DMWithMessageAndAttachments("user_mm_id", "일정이 생성되었습니다.", gomock.Any()).Return("post_id", nil)
+
+		event, err := mscalendar.ScheduleEvent(newUser(), newEvent(), []string{"alice_mm_id"}, "")
+		require.NoError(t, err)
+		require.Equal(t, expected, event)
+	})
+}
+
+func TestOpenCreateEventDialogFromPost(t *testing.T) {
+	user := &User{
+		MattermostUserID: "user_mm_id",
+		User:             &store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}},
+		MattermostUser:   &model.User{Id: "user_mm_id"},
+	}
+
+	t.Run("post not accessible", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().GetPost("post_id").Return(&model.Post{Id: "post_id", ChannelId: "channel_id"}, nil)
+		mockPluginAPI.EXPECT().CanReadChannel("channel_id", "user_mm_id").Return(false)
+
+		err := mscalendar.OpenCreateEventDialogFromPost(user, "trigger_id", "post_id")
+		require.ErrorIs(t, err, ErrPostNotAccessible)
+	})
+
+	t.Run("dialog is filled from the post", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mscalendar.Config.MattermostSiteURL = "https://chat.example.com/"
+		mscalendar.Config.PluginURLPath = "/plugins/mscalendar"
+		mockPluginAPI.EXPECT().GetPost("post_id").Return(&model.Post{
+			Id:        "post_id",
+			ChannelId: "channel_id",
+			UserId:    "author_mm_id",
+			Message:   "## Release planning\nLet's sync with @bob and @carol. @channel @user_mm",
+		}, nil)
+		mockPluginAPI.EXPECT().CanReadChannel("channel_id", "user_mm_id").Return(true)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockPluginAPI.EXPECT().GetMattermostUser("author_mm_id").Return(&model.User{Id: "author_mm_id", Username: "alice"}, nil)
+		mockPluginAPI.EXPECT().GetMattermostUserByUsername("bob").Return(&model.User{Id: "bob_mm_id", Username: "bob"}, nil)
+		mockPluginAPI.EXPECT().GetMattermostUserByUsername("carol").Return(&model.User{Id: "carol_mm_id", Username: "carol", IsBot: true}, nil)
+		mockPluginAPI.EXPECT().GetMattermostUserByUsername("channel").Return(nil, errors.New("not found"))
+		mockPluginAPI.EXPECT().GetMattermostUserByUsername("user_mm").Return(&model.User{Id: "user_mm_id", Username: "user_mm"}, nil)
+		mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+		mockPluginAPI.EXPECT().OpenInteractiveDialog(gomock.Any()).DoAndReturn(func(request model.OpenDialogRequest) error {
+			require.Equal(t, "trigger_id", request.TriggerId)
+			require.Equal(t, "/plugins/mscalendar/dialogs/events/create", request.URL)
+
+			values := map[string]string{}
+			for _, element := range request.Dialog.Elements {
+				values[element.Name] = element.Default
+			}
+			require.Equal(t, "Release planning", values["subject"])
+			require.Equal(t, "https://chat.example.com/_redirect/pl/post_id", values["description"])
+			require.Equal(t, "@alice @bob", values["attendees"])
+			require.Equal(t, "channel_id", values["channel_id"])
+			return nil
+		})
+
+		err := mscalendar.OpenCreateEventDialogFromPost(user, "trigger_id", "post_id")
+		require.NoError(t, err)
+	})
+}
+
+func TestPostSubject(t *testing.T) {
+	require.Equal(t, "Release planning", postSubject("\n## Release planning\nDetails"))
+	require.Equal(t, "Quoted", postSubject("> Quoted"))
+	require.Equal(t, "", postSubject(" \n "))
+	require.Len(t, []rune(postSubject(strings.Repeat("가", 300))), maxEventSubjectLength)
+}
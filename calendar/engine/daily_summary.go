@@ -178,21 +178,16 @@ func (m *mscalendar) ProcessAllDailySummary(now time.Time) error {
 			})
 		} else {
 			start, end := getTodayHoursForTimezone(now, dsum.Timezone)
-			req := &remote.ViewCalendarParams{
-				RemoteUserID: storeUser.Remote.ID,
-				StartTime:    start,
-				EndTime:      end,
-			}
-			requests = append(requests, req)
+			requests = append(requests, calendarViewParams(storeUser, start, end)...)
 		}
 	}
 
 	if !fetchIndividually {
-		var err error
-		calendarViews, err = m.client.DoBatchViewCalendarRequests(requests)
+		responses, err := m.viewCalendars(requests)
 		if err != nil {
 			return err
 		}
+		calendarViews = mergeCalendarViews(byRemoteID, responses)
 	}
 
 	for _, res := range calendarViews {
@@ -0,0 +1,188 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const (
+	// MaxMeetingAttendees is the number of attendees a meeting time is looked
+	// up for.
+	MaxMeetingAttendees = 20
+
+	maxMeetingTimeSuggestions   = 5
+	meetingAttendeeTypeRequired = "required"
+)
+
+var (
+	ErrNoMeetingAttendees      = errors.New("참석자가 없습니다")
+	ErrTooManyMeetingAttendees = errors.New("참석자가 너무 많습니다")
+	ErrChannelLinkNotPermitted = errors.New("이 채널에 이벤트를 연결할 권한이 없습니다")
+)
+
+type MeetingTimes interface {
+	GetMattermostChannelByName(teamID, channelName string) (*model.Channel, error)
+	FindTime(user *User, req *FindTimeRequest) (int, error)
+}
+
+// FindTimeRequest asks for the times a meeting of the duration could take
+// place between From and To. The members of the channel are invited along
+// with the users, and the meeting is linked to the channel.
+type FindTimeRequest struct {
+	Subject           string
+	ChannelID         string
+	MattermostUserIDs []string
+	Duration          time.Duration
+	From              time.Time
+	To                time.Time
+}
+
+func (m *mscalendar) GetMattermostChannelByName(teamID, channelName string) (*model.Channel, error) {
+	return m.PluginAPI.GetMattermostChannelByName(teamID, channelName)
+}
+
+// FindTime looks up the times all the attendees are free during working
+// hours, and sends them to the user as buttons that create the meeting. It
+// returns the number of times found.
+func (m *mscalendar) FindTime(user *User, req *FindTimeRequest) (int, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	suggestions, attendees, err := m.findMeetingTimes(user, req)
+	if err != nil || len(suggestions) == 0 {
+		return 0, err
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return 0, err
+	}
+
+	url := fmt.Sprintf("%s%s%s", m.Config.PluginURLPath, config.PathPostAction, config.PathFindTime)
+	sa := views.RenderMeetingTimeSuggestions(req.Subject, suggestions, timezone, url, map[string]interface{}{
+		"subject":    req.Subject,
+		"channel_id": req.ChannelID,
+		"user_ids":   attendees.MattermostUserIDs,
+		"attendees":  attendees.Mails,
+	})
+	_, err = m.Poster.DMWithAttachments(user.MattermostUserID, sa)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(suggestions), nil
+}
+
+// meetingAttendees are the attendees a meeting time was found for.
+type meetingAttendees struct {
+	MattermostUserIDs []string
+	Mails             []string
+}
+
+// findMeetingTimes returns the best times for the meeting, and the attendees
+// they were found for. Attendees without a mail address are left out.
+func (m *mscalendar) findMeetingTimes(user *User, req *FindTimeRequest) ([]*remote.MeetingTimeSuggestion, *meetingAttendees, error) {
+	if req.ChannelID != "" && !m.PluginAPI.CanLinkEventToChannel(req.ChannelID, user.MattermostUserID) {
+		return nil, nil, ErrChannelLinkNotPermitted
+	}
+
+	attendeeIDs, err := m.getMeetingAttendeeIDs(user, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := &meetingAttendees{}
+	attendees := []remote.Attendee{}
+	for _, mattermostUserID := range attendeeIDs {
+		mail, err := m.getScheduleMail(mattermostUserID)
+		if err != nil {
+			m.Logger.Warnf("참석자 %s의 메일 주소를 찾을 수 없습니다. err=%v", mattermostUserID, err)
+			continue
+		}
+		found.MattermostUserIDs = append(found.MattermostUserIDs, mattermostUserID)
+		found.Mails = append(found.Mails, mail)
+		attendees = append(attendees, remote.Attendee{
+			Type:         meetingAttendeeTypeRequired,
+			EmailAddress: &remote.EmailAddress{Address: mail},
+		})
+	}
+	if len(attendees) == 0 {
+		return nil, nil, ErrNoMeetingAttendees
+	}
+
+	duration := req.Duration
+	maxCandidates := maxMeetingTimeSuggestions
+	results, err := m.client.FindMeetingTimes(user.Remote.ID, &remote.FindMeetingTimesParameters{
+		Attendees:       attendees,
+		MeetingDuration: &duration,
+		MaxCandidates:   &maxCandidates,
+		TimeConstraint: &remote.TimeConstraint{
+			ActivityDomain: "work",
+			TimeSlots: []remote.TimeSlot{{
+				Start: remote.NewDateTime(req.From.UTC(), "UTC"),
+				End:   remote.NewDateTime(req.To.UTC(), "UTC"),
+			}},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if results == nil || len(results.MeetingTimeSuggestions) == 0 {
+		return nil, found, nil
+	}
+
+	suggestions := results.MeetingTimeSuggestions
+	if len(suggestions) > maxMeetingTimeSuggestions {
+		suggestions = suggestions[:maxMeetingTimeSuggestions]
+	}
+	return suggestions, found, nil
+}
+
+// getMeetingAttendeeIDs returns the users and the members of the channel of
+// the request, without the organizer.
+func (m *mscalendar) getMeetingAttendeeIDs(user *User, req *FindTimeRequest) ([]string, error) {
+	ids := []string{}
+	seen := map[string]bool{user.MattermostUserID: true}
+	add := func(mattermostUserID string) {
+		if !seen[mattermostUserID] {
+			seen[mattermostUserID] = true
+			ids = append(ids, mattermostUserID)
+		}
+	}
+
+	for _, mattermostUserID := range req.MattermostUserIDs {
+		add(mattermostUserID)
+	}
+	if req.ChannelID != "" {
+		members, err := m.PluginAPI.GetMattermostChannelUsers(req.ChannelID)
+		if err != nil {
+			return nil, errors.Wrap(err, "채널 멤버를 가져오는 중 오류 발생")
+		}
+		for _, member := range members {
+			add(member.Id)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, ErrNoMeetingAttendees
+	}
+	if len(ids) > MaxMeetingAttendees {
+		return nil, ErrTooManyMeetingAttendees
+	}
+	return ids, nil
+}
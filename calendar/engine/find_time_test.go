@@ -0,0 +1,99 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestFindTime(t *testing.T) {
+	from := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	req := &FindTimeRequest{
+		Subject:   "Planning 회의",
+		ChannelID: "channel_id",
+		Duration:  30 * time.Minute,
+		From:      from,
+		To:        from.AddDate(0, 0, 7),
+	}
+	newUser := func() *User {
+		return &User{
+			MattermostUserID: "user_mm_id",
+			User:             &store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}},
+			MattermostUser:   &model.User{Id: "user_mm_id"},
+		}
+	}
+
+	t.Run("not permitted to link the channel", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(false)
+
+		_, err := mscalendar.FindTime(newUser(), req)
+		require.ErrorIs(t, err, ErrChannelLinkNotPermitted)
+	})
+
+	t.Run("alone in the channel", func(t *testing.T) {
+		mscalendar, _, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockPluginAPI.EXPECT().GetMattermostChannelUsers("channel_id").Return([]*model.User{{Id: "user_mm_id"}}, nil)
+
+		_, err := mscalendar.FindTime(newUser(), req)
+		require.ErrorIs(t, err, ErrNoMeetingAttendees)
+	})
+
+	t.Run("suggestions are sent as buttons", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+		mockPluginAPI.EXPECT().CanLinkEventToChannel("channel_id", "user_mm_id").Return(true)
+		mockPluginAPI.EXPECT().GetMattermostChannelUsers("channel_id").Return([]*model.User{{Id: "user_mm_id"}, {Id: "alice_mm_id"}}, nil)
+		mockStore.EXPECT().LoadUser("alice_mm_id").Return(&store.User{Remote: &remote.User{Mail: "alice@example.com"}}, nil)
+
+		duration := 30 * time.Minute
+		maxCandidates := 5
+		slot := &remote.TimeSlot{
+			Start: remote.NewDateTime(from.Add(time.Hour), "UTC"),
+			End:   remote.NewDateTime(from.Add(90*time.Minute), "UTC"),
+		}
+		mockClient.EXPECT().FindMeetingTimes("user_remote_id", &remote.FindMeetingTimesParameters{
+			Attendees: []remote.Attendee{{
+				Type:         "required",
+				EmailAddress: &remote.EmailAddress{Address: "alice@example.com"},
+			}},
+			MeetingDuration: &duration,
+			MaxCandidates:   &maxCandidates,
+			TimeConstraint: &remote.TimeConstraint{
+				ActivityDomain: "work",
+				TimeSlots: []remote.TimeSlot{{
+					Start: remote.NewDateTime(req.From, "UTC"),
+					End:   remote.NewDateTime(req.To, "UTC"),
+				}},
+			},
+		}).Return(&remote.MeetingTimeSuggestionResults{
+			MeetingTimeSuggestions: []*remote.MeetingTimeSuggestion{{MeetingTimeSlot: slot}},
+		}, nil)
+		mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+		mockPoster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).DoAndReturn(
+			func(_ string, attachments ...*model.SlackAttachment) (string, error) {
+				require.Len(t, attachments, 1)
+				require.Len(t, attachments[0].Actions, 1)
+				action := attachments[0].Actions[0]
+				require.Equal(t, "Thu 2024-05-02 10:00 - 10:30", action.Name)
+				require.Equal(t, "2024-05-02T10:00:00Z", action.Integration.Context["start"])
+				require.Equal(t, "channel_id", action.Integration.Context["channel_id"])
+				require.Equal(t, []string{"alice_mm_id"}, action.Integration.Context["user_ids"])
+				require.Equal(t, []string{"alice@example.com"}, action.Integration.Context["attendees"])
+				return "post_id", nil
+			})
+
+		n, err := mscalendar.FindTime(newUser(), req)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	})
+}
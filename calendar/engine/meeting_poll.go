@@ -0,0 +1,261 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const meetingPollSlotFormat = "Mon 2006-01-02 15:04"
+
+var (
+	ErrNotMeetingPollOrganizer = errors.New("주최자만 회의 시간을 확정할 수 있습니다")
+	ErrMeetingPollFinalized    = errors.New("이미 확정된 투표입니다")
+	ErrInvalidMeetingPollSlot  = errors.New("잘못된 회의 시간입니다")
+)
+
+type MeetingPolls interface {
+	CreateMeetingPoll(user *User, req *FindTimeRequest) (int, error)
+	VoteMeetingPoll(mattermostUserID, pollID string, slot int) (*store.MeetingPoll, error)
+	FinalizeMeetingPoll(user *User, pollID string, slot int) (*store.MeetingPoll, *remote.Event, error)
+	RenderMeetingPoll(poll *store.MeetingPoll) *model.SlackAttachment
+}
+
+// CreateMeetingPoll posts the times suggested for the meeting to the channel
+// of the request, for its members to vote. It returns the number of times
+// found.
+func (m *mscalendar) CreateMeetingPoll(user *User, req *FindTimeRequest) (int, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	suggestions, _, err := m.findMeetingTimes(user, req)
+	if err != nil || len(suggestions) == 0 {
+		return 0, err
+	}
+
+	timezone, err := m.GetTimezone(user)
+	if err != nil {
+		return 0, err
+	}
+
+	poll := &store.MeetingPoll{
+		ID:          model.NewId(),
+		OrganizerID: user.MattermostUserID,
+		ChannelID:   req.ChannelID,
+		Subject:     req.Subject,
+		TimeZone:    timezone,
+	}
+	for _, s := range suggestions {
+		if s.MeetingTimeSlot == nil || s.MeetingTimeSlot.Start == nil || s.MeetingTimeSlot.End == nil {
+			continue
+		}
+		poll.Slots = append(poll.Slots, &store.MeetingPollSlot{
+			Start: s.MeetingTimeSlot.Start.Time(),
+			End:   s.MeetingTimeSlot.End.Time(),
+		})
+	}
+	if len(poll.Slots) == 0 {
+		return 0, nil
+	}
+
+	err = m.Store.StoreMeetingPoll(poll)
+	if err != nil {
+		return 0, err
+	}
+
+	post := &model.Post{
+		ChannelId: req.ChannelID,
+		Message:   fmt.Sprintf("@%s 님이 **%s** 시간을 정하려고 합니다. 참석할 수 있는 시간에 모두 투표해주세요.", user.MattermostUser.Username, req.Subject),
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{m.RenderMeetingPoll(poll)})
+	err = m.Poster.CreatePost(post)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(poll.Slots), nil
+}
+
+// VoteMeetingPoll adds the vote of the user for the time, or removes it if
+// the user already voted for it.
+func (m *mscalendar) VoteMeetingPoll(mattermostUserID, pollID string, slot int) (*store.MeetingPoll, error) {
+	mattermostUser, err := m.PluginAPI.GetMattermostUser(mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Store.ModifyMeetingPoll(pollID, func(poll *store.MeetingPoll) error {
+		if poll.FinalizedSlot != nil {
+			return ErrMeetingPollFinalized
+		}
+		if slot < 0 || slot >= len(poll.Slots) {
+			return ErrInvalidMeetingPollSlot
+		}
+
+		s := poll.Slots[slot]
+		for i, voter := range s.Voters {
+			if voter.MattermostUserID == mattermostUserID {
+				s.Voters = append(s.Voters[:i], s.Voters[i+1:]...)
+				return nil
+			}
+		}
+		s.Voters = append(s.Voters, &store.MeetingPollVoter{
+			MattermostUserID: mattermostUserID,
+			Username:         mattermostUser.Username,
+		})
+		return nil
+	})
+}
+
+// FinalizeMeetingPoll creates the meeting at the time chosen by the
+// organizer, with those who voted for it as attendees. The slot is claimed
+// before the event is created, so that the meeting is created once however
+// many times the poll is finalized, and released if the creation fails.
+func (m *mscalendar) FinalizeMeetingPoll(user *User, pollID string, slot int) (*store.MeetingPoll, *remote.Event, error) {
+	poll, err := m.Store.ModifyMeetingPoll(pollID, func(poll *store.MeetingPoll) error {
+		switch {
+		case poll.OrganizerID != user.MattermostUserID:
+			return ErrNotMeetingPollOrganizer
+		case poll.FinalizedSlot != nil:
+			return ErrMeetingPollFinalized
+		case slot < 0 || slot >= len(poll.Slots):
+			return ErrInvalidMeetingPollSlot
+		}
+		poll.FinalizedSlot = &slot
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chosen := poll.Slots[slot]
+	event := &remote.Event{
+		Subject: poll.Subject,
+		Start:   remote.NewDateTime(chosen.Start.UTC(), "UTC"),
+		End:     remote.NewDateTime(chosen.End.UTC(), "UTC"),
+	}
+	voterIDs := []string{}
+	for _, voter := range chosen.Voters {
+		if voter.MattermostUserID == poll.OrganizerID {
+			continue
+		}
+		mail, err := m.getScheduleMail(voter.MattermostUserID)
+		if err != nil {
+			m.Logger.Warnf("참석자 %s의 메일 주소를 찾을 수 없습니다. err=%v", voter.MattermostUserID, err)
+			continue
+		}
+		voterIDs = append(voterIDs, voter.MattermostUserID)
+		event.Attendees = append(event.Attendees, &remote.Attendee{
+			Type:         meetingAttendeeTypeRequired,
+			EmailAddress: &remote.EmailAddress{Address: mail},
+		})
+	}
+
+	event, err = m.CreateEvent(user, event, voterIDs)
+	if err != nil {
+		_, releaseErr := m.Store.ModifyMeetingPoll(pollID, func(poll *store.MeetingPoll) error {
+			if poll.FinalizedSlot != nil && *poll.FinalizedSlot == slot {
+				poll.FinalizedSlot = nil
+			}
+			return nil
+		})
+		if releaseErr != nil {
+			m.Logger.Warnf("투표 %s의 확정을 취소할 수 없습니다. err=%v", pollID, releaseErr)
+		}
+		return nil, nil, err
+	}
+
+	return poll, event, nil
+}
+
+// RenderMeetingPoll renders the times of the poll with their votes. Until
+// the poll is finalized, it has a button to vote for each time and a menu
+// for the organizer to choose one.
+func (m *mscalendar) RenderMeetingPoll(poll *store.MeetingPoll) *model.SlackAttachment {
+	loc, err := time.LoadLocation(tz.Go(poll.TimeZone))
+	if err != nil || tz.Go(poll.TimeZone) == "" {
+		loc = time.UTC
+	}
+
+	fields := []*model.SlackAttachmentField{}
+	actions := []*model.PostAction{}
+	options := []*model.PostActionOptions{}
+	voteURL := fmt.Sprintf("%s%s%s", m.Config.PluginURLPath, config.PathPostAction, config.PathMeetingPollVote)
+	for i, s := range poll.Slots {
+		label := fmt.Sprintf("%d. %s - %s", i+1, s.Start.In(loc).Format(meetingPollSlotFormat), s.End.In(loc).Format("15:04"))
+
+		votes := "아직 투표가 없습니다"
+		if len(s.Voters) > 0 {
+			names := []string{}
+			for _, voter := range s.Voters {
+				names = append(names, "@"+voter.Username)
+			}
+			votes = fmt.Sprintf("%d표: %s", len(s.Voters), strings.Join(names, ", "))
+		}
+		fields = append(fields, &model.SlackAttachmentField{
+			Title: label,
+			Value: votes,
+		})
+
+		actions = append(actions, &model.PostAction{
+			Name: fmt.Sprintf("%d번 투표", i+1),
+			Integration: &model.PostActionIntegration{
+				URL: voteURL,
+				Context: map[string]interface{}{
+					"poll_id": poll.ID,
+					"slot":    strconv.Itoa(i),
+				},
+			},
+		})
+		options = append(options, &model.PostActionOptions{
+			Text:  label,
+			Value: strconv.Itoa(i),
+		})
+	}
+
+	title := fmt.Sprintf("회의 시간 투표: %s", poll.Subject)
+	text := fmt.Sprintf("참석할 수 있는 시간에 모두 투표해주세요. 다시 누르면 투표가 취소됩니다.\n시간은 %s로 표시됩니다.", poll.TimeZone)
+	if poll.FinalizedSlot != nil && *poll.FinalizedSlot < len(poll.Slots) {
+		chosen := poll.Slots[*poll.FinalizedSlot]
+		text = fmt.Sprintf("**%s - %s**로 확정되었습니다.\n시간은 %s로 표시됩니다.",
+			chosen.Start.In(loc).Format(meetingPollSlotFormat), chosen.End.In(loc).Format("15:04"), poll.TimeZone)
+		actions = nil
+	} else {
+		actions = append(actions, &model.PostAction{
+			Name:    "시간 확정 (주최자)",
+			Type:    model.PostActionTypeSelect,
+			Options: options,
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s%s", m.Config.PluginURLPath, config.PathPostAction, config.PathMeetingPollFinalize),
+				Context: map[string]interface{}{
+					"poll_id": poll.ID,
+				},
+			},
+		})
+	}
+
+	return &model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Fields:   fields,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+)
+
+func TestVoteMeetingPoll(t *testing.T) {
+	newPoll := func() *store.MeetingPoll {
+		start := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+		return &store.MeetingPoll{
+			ID:          "poll_id",
+			OrganizerID: "organizer_mm_id",
+			Slots: []*store.MeetingPollSlot{
+				{Start: start, End: start.Add(time.Hour)},
+				{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour), Voters: []*store.MeetingPollVoter{{MattermostUserID: "alice_mm_id", Username: "alice"}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		poll       func() *store.MeetingPoll
+		slot       int
+		assertions func(*testing.T, *store.MeetingPoll, error)
+	}{
+		{
+			name: "vote is added",
+			poll: newPoll,
+			slot: 0,
+			assertions: func(t *testing.T, poll *store.MeetingPoll, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []*store.MeetingPollVoter{{MattermostUserID: "alice_mm_id", Username: "alice"}}, poll.Slots[0].Voters)
+			},
+		},
+		{
+			name: "vote is removed when voting again",
+			poll: newPoll,
+			slot: 1,
+			assertions: func(t *testing.T, poll *store.MeetingPoll, err error) {
+				require.NoError(t, err)
+				require.Empty(t, poll.Slots[1].Voters)
+			},
+		},
+		{
+			name: "invalid slot",
+			poll: newPoll,
+			slot: 2,
+			assertions: func(t *testing.T, poll *store.MeetingPoll, err error) {
+				require.ErrorIs(t, err, ErrInvalidMeetingPollSlot)
+			},
+		},
+		{
+			name: "finalized poll",
+			poll: func() *store.MeetingPoll {
+				poll := newPoll()
+				finalized := 0
+				poll.FinalizedSlot = &finalized
+				return poll
+			},
+			slot: 0,
+			assertions: func(t *testing.T, poll *store.MeetingPoll, err error) {
+				require.ErrorIs(t, err, ErrMeetingPollFinalized)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mscalendar, mockStore, _, _, mockPluginAPI, _, _ := GetMockSetup(t)
+			mockPluginAPI.EXPECT().GetMattermostUser("alice_mm_id").Return(&model.User{Id: "alice_mm_id", Username: "alice"}, nil)
+			mockStore.EXPECT().ModifyMeetingPoll("poll_id", gomock.Any()).DoAndReturn(func(_ string, modify func(*store.MeetingPoll) error) (*store.MeetingPoll, error) {
+				poll := tt.poll()
+				if err := modify(poll); err != nil {
+					return nil, err
+				}
+				return poll, nil
+			})
+
+			poll, err := mscalendar.VoteMeetingPoll("alice_mm_id", "poll_id", tt.slot)
+			tt.assertions(t, poll, err)
+		})
+	}
+}
+
+func TestFinalizeMeetingPoll(t *testing.T) {
+	start := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	newUser := func() *User {
+		return &User{
+			User:             &store.User{MattermostUserID: "alice_mm_id", Remote: &remote.User{ID: "alice_remote_id"}},
+			MattermostUser:   &model.User{Id: "alice_mm_id"},
+			MattermostUserID: "alice_mm_id",
+		}
+	}
+	// storedPoll mocks the atomic modifications of the stored poll.
+	storedPoll := func(mockStore *mock_store.MockStore, poll *store.MeetingPoll) {
+		mockStore.EXPECT().ModifyMeetingPoll("poll_id", gomock.Any()).DoAndReturn(func(_ string, modify func(*store.MeetingPoll) error) (*store.MeetingPoll, error) {
+			modified := *poll
+			if err := modify(&modified); err != nil {
+				return nil, err
+			}
+			*poll = modified
+			return &modified, nil
+		}).AnyTimes()
+	}
+
+	t.Run("only the organizer can finalize", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		poll := &store.MeetingPoll{
+			ID:          "poll_id",
+			OrganizerID: "organizer_mm_id",
+			Slots:       []*store.MeetingPollSlot{{Start: start, End: start.Add(time.Hour)}},
+		}
+		storedPoll(mockStore, poll)
+
+		_, _, err := mscalendar.FinalizeMeetingPoll(newUser(), "poll_id", 0)
+		require.ErrorIs(t, err, ErrNotMeetingPollOrganizer)
+		require.Nil(t, poll.FinalizedSlot)
+	})
+
+	t.Run("finalized poll", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		finalized := 0
+		storedPoll(mockStore, &store.MeetingPoll{
+			ID:            "poll_id",
+			OrganizerID:   "alice_mm_id",
+			Slots:         []*store.MeetingPollSlot{{Start: start, End: start.Add(time.Hour)}},
+			FinalizedSlot: &finalized,
+		})
+
+		_, _, err := mscalendar.FinalizeMeetingPoll(newUser(), "poll_id", 0)
+		require.ErrorIs(t, err, ErrMeetingPollFinalized)
+	})
+
+	t.Run("meeting is created once", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+		mscalendar.client = mockClient
+		poll := &store.MeetingPoll{
+			ID:          "poll_id",
+			OrganizerID: "alice_mm_id",
+			Subject:     "Planning",
+			Slots:       []*store.MeetingPollSlot{{Start: start, End: start.Add(time.Hour)}},
+		}
+		storedPoll(mockStore, poll)
+		mockClient.EXPECT().CreateEvent("alice_remote_id", gomock.Any()).Return(&remote.Event{ID: "event_id"}, nil).Times(1)
+
+		finalized, event, err := mscalendar.FinalizeMeetingPoll(newUser(), "poll_id", 0)
+		require.NoError(t, err)
+		require.Equal(t, "event_id", event.ID)
+		require.Equal(t, 0, *finalized.FinalizedSlot)
+
+		_, _, err = mscalendar.FinalizeMeetingPoll(newUser(), "poll_id", 0)
+		require.ErrorIs(t, err, ErrMeetingPollFinalized)
+	})
+
+	t.Run("slot is released when the meeting can't be created", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+		mscalendar.client = mockClient
+		poll := &store.MeetingPoll{
+			ID:          "poll_id",
+			OrganizerID: "alice_mm_id",
+			Slots:       []*store.MeetingPollSlot{{Start: start, End: start.Add(time.Hour)}},
+		}
+		storedPoll(mockStore, poll)
+		mockClient.EXPECT().CreateEvent("alice_remote_id", gomock.Any()).Return(nil, errors.New("error creating event"))
+
+		_, _, err := mscalendar.FinalizeMeetingPoll(newUser(), "poll_id", 0)
+		require.EqualError(t, err, "error creating event")
+		require.Nil(t, poll.FinalizedSlot)
+	})
+}
+
+func TestRenderMeetingPoll(t *testing.T) {
+	mscalendar, _, _, _, _, _, _ := GetMockSetup(t)
+	start := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	poll := &store.MeetingPoll{
+		ID:       "poll_id",
+		Subject:  "Planning 회의",
+		TimeZone: "UTC",
+		Slots: []*store.MeetingPollSlot{
+			{Start: start, End: start.Add(time.Hour), Voters: []*store.MeetingPollVoter{{MattermostUserID: "alice_mm_id", Username: "alice"}}},
+			{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+		},
+	}
+
+	sa := mscalendar.RenderMeetingPoll(poll)
+	require.Len(t, sa.Fields, 2)
+	require.Equal(t, "1. Thu 2024-05-02 09:00 - 10:00", sa.Fields[0].Title)
+	require.Equal(t, "1표: @alice", sa.Fields[0].Value)
+	require.Equal(t, "아직 투표가 없습니다", sa.Fields[1].Value)
+	require.Len(t, sa.Actions, 3)
+	require.Equal(t, "1", sa.Actions[1].Integration.Context["slot"])
+
+	finalized := 1
+	poll.FinalizedSlot = &finalized
+	sa = mscalendar.RenderMeetingPoll(poll)
+	require.Empty(t, sa.Actions)
+	require.Contains(t, sa.Text, "**Thu 2024-05-02 11:00 - 12:00**로 확정되었습니다.")
+}
@@ -10,8 +10,10 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	engine "github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	views "github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
 	remote "github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	store "github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	model "github.com/mattermost/mattermost/server/public/model"
 )
 
 // MockEngine is a mock of Engine interface.
@@ -79,6 +81,20 @@ func (mr *MockEngineMockRecorder) AfterSuccessfullyConnect(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterSuccessfullyConnect", reflect.TypeOf((*MockEngine)(nil).AfterSuccessfullyConnect), arg0, arg1)
 }
 
+// CancelEvent mocks base method.
+func (m *MockEngine) CancelEvent(arg0 *engine.User, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEvent indicates an expected call of CancelEvent.
+func (mr *MockEngineMockRecorder) CancelEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockEngine)(nil).CancelEvent), arg0, arg1, arg2)
+}
+
 // ClearSettingsPosts mocks base method.
 func (m *MockEngine) ClearSettingsPosts(arg0 string) {
 	m.ctrl.T.Helper()
@@ -91,6 +107,21 @@ func (mr *MockEngineMockRecorder) ClearSettingsPosts(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSettingsPosts", reflect.TypeOf((*MockEngine)(nil).ClearSettingsPosts), arg0)
 }
 
+// ConnectChannelCalendar mocks base method.
+func (m *MockEngine) ConnectChannelCalendar(arg0 *engine.User, arg1, arg2 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectChannelCalendar", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConnectChannelCalendar indicates an expected call of ConnectChannelCalendar.
+func (mr *MockEngineMockRecorder) ConnectChannelCalendar(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectChannelCalendar", reflect.TypeOf((*MockEngine)(nil).ConnectChannelCalendar), arg0, arg1, arg2)
+}
+
 // CreateCalendar mocks base method.
 func (m *MockEngine) CreateCalendar(arg0 *engine.User, arg1 *remote.Calendar) (*remote.Calendar, error) {
 	m.ctrl.T.Helper()
@@ -121,6 +152,21 @@ func (mr *MockEngineMockRecorder) CreateEvent(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvent", reflect.TypeOf((*MockEngine)(nil).CreateEvent), arg0, arg1, arg2)
 }
 
+// CreateMeetingPoll mocks base method.
+func (m *MockEngine) CreateMeetingPoll(arg0 *engine.User, arg1 *engine.FindTimeRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMeetingPoll", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMeetingPoll indicates an expected call of CreateMeetingPoll.
+func (mr *MockEngineMockRecorder) CreateMeetingPoll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeetingPoll", reflect.TypeOf((*MockEngine)(nil).CreateMeetingPoll), arg0, arg1)
+}
+
 // CreateMyEventSubscription mocks base method.
 func (m *MockEngine) CreateMyEventSubscription() (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -164,6 +210,20 @@ func (mr *MockEngineMockRecorder) DeleteCalendar(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendar", reflect.TypeOf((*MockEngine)(nil).DeleteCalendar), arg0, arg1)
 }
 
+// DeleteEvent mocks base method.
+func (m *MockEngine) DeleteEvent(arg0 *engine.User, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockEngineMockRecorder) DeleteEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockEngine)(nil).DeleteEvent), arg0, arg1)
+}
+
 // DeleteMyEventSubscription mocks base method.
 func (m *MockEngine) DeleteMyEventSubscription() error {
 	m.ctrl.T.Helper()
@@ -192,6 +252,20 @@ func (mr *MockEngineMockRecorder) DeleteOrphanedSubscription(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedSubscription", reflect.TypeOf((*MockEngine)(nil).DeleteOrphanedSubscription), arg0)
 }
 
+// DisconnectChannelCalendar mocks base method.
+func (m *MockEngine) DisconnectChannelCalendar(arg0 *engine.User, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisconnectChannelCalendar", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisconnectChannelCalendar indicates an expected call of DisconnectChannelCalendar.
+func (mr *MockEngineMockRecorder) DisconnectChannelCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectChannelCalendar", reflect.TypeOf((*MockEngine)(nil).DisconnectChannelCalendar), arg0, arg1)
+}
+
 // DisconnectUser mocks base method.
 func (m *MockEngine) DisconnectUser(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -206,6 +280,36 @@ func (mr *MockEngineMockRecorder) DisconnectUser(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisconnectUser", reflect.TypeOf((*MockEngine)(nil).DisconnectUser), arg0)
 }
 
+// DismissReminders mocks base method.
+func (m *MockEngine) DismissReminders(arg0 *engine.User, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissReminders", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DismissReminders indicates an expected call of DismissReminders.
+func (mr *MockEngineMockRecorder) DismissReminders(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissReminders", reflect.TypeOf((*MockEngine)(nil).DismissReminders), arg0, arg1, arg2, arg3)
+}
+
+// FinalizeMeetingPoll mocks base method.
+func (m *MockEngine) FinalizeMeetingPoll(arg0 *engine.User, arg1 string, arg2 int) (*store.MeetingPoll, *remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinalizeMeetingPoll", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*store.MeetingPoll)
+	ret1, _ := ret[1].(*remote.Event)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FinalizeMeetingPoll indicates an expected call of FinalizeMeetingPoll.
+func (mr *MockEngineMockRecorder) FinalizeMeetingPoll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinalizeMeetingPoll", reflect.TypeOf((*MockEngine)(nil).FinalizeMeetingPoll), arg0, arg1, arg2)
+}
+
 // FindMeetingTimes mocks base method.
 func (m *MockEngine) FindMeetingTimes(arg0 *engine.User, arg1 *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
 	m.ctrl.T.Helper()
@@ -221,6 +325,21 @@ func (mr *MockEngineMockRecorder) FindMeetingTimes(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMeetingTimes", reflect.TypeOf((*MockEngine)(nil).FindMeetingTimes), arg0, arg1)
 }
 
+// FindTime mocks base method.
+func (m *MockEngine) FindTime(arg0 *engine.User, arg1 *engine.FindTimeRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTime", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTime indicates an expected call of FindTime.
+func (mr *MockEngineMockRecorder) FindTime(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTime", reflect.TypeOf((*MockEngine)(nil).FindTime), arg0, arg1)
+}
+
 // GetActingUser mocks base method.
 func (m *MockEngine) GetActingUser() *engine.User {
 	m.ctrl.T.Helper()
@@ -235,6 +354,21 @@ func (mr *MockEngineMockRecorder) GetActingUser() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActingUser", reflect.TypeOf((*MockEngine)(nil).GetActingUser))
 }
 
+// GetAutomaticReplies mocks base method.
+func (m *MockEngine) GetAutomaticReplies(arg0 *engine.User) (*remote.AutomaticRepliesSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutomaticReplies", arg0)
+	ret0, _ := ret[0].(*remote.AutomaticRepliesSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutomaticReplies indicates an expected call of GetAutomaticReplies.
+func (mr *MockEngineMockRecorder) GetAutomaticReplies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutomaticReplies", reflect.TypeOf((*MockEngine)(nil).GetAutomaticReplies), arg0)
+}
+
 // GetCalendarViews mocks base method.
 func (m *MockEngine) GetCalendarViews(arg0 []*store.User) ([]*remote.ViewCalendarResponse, error) {
 	m.ctrl.T.Helper()
@@ -265,6 +399,51 @@ func (mr *MockEngineMockRecorder) GetCalendars(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendars", reflect.TypeOf((*MockEngine)(nil).GetCalendars), arg0)
 }
 
+// GetChannelCalendar mocks base method.
+func (m *MockEngine) GetChannelCalendar(arg0 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelCalendar", arg0)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelCalendar indicates an expected call of GetChannelCalendar.
+func (mr *MockEngineMockRecorder) GetChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelCalendar", reflect.TypeOf((*MockEngine)(nil).GetChannelCalendar), arg0)
+}
+
+// GetChannelCalendarGroups mocks base method.
+func (m *MockEngine) GetChannelCalendarGroups(arg0 *engine.User) ([]*remote.Group, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelCalendarGroups", arg0)
+	ret0, _ := ret[0].([]*remote.Group)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelCalendarGroups indicates an expected call of GetChannelCalendarGroups.
+func (mr *MockEngineMockRecorder) GetChannelCalendarGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelCalendarGroups", reflect.TypeOf((*MockEngine)(nil).GetChannelCalendarGroups), arg0)
+}
+
+// GetChannelLinkedEvents mocks base method.
+func (m *MockEngine) GetChannelLinkedEvents(arg0 *engine.User, arg1 string, arg2, arg3 time.Time) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelLinkedEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelLinkedEvents indicates an expected call of GetChannelLinkedEvents.
+func (mr *MockEngineMockRecorder) GetChannelLinkedEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelLinkedEvents", reflect.TypeOf((*MockEngine)(nil).GetChannelLinkedEvents), arg0, arg1, arg2, arg3)
+}
+
 // GetDailySummarySettingsForUser mocks base method.
 func (m *MockEngine) GetDailySummarySettingsForUser(arg0 *engine.User) (*store.DailySummaryUserSettings, error) {
 	m.ctrl.T.Helper()
@@ -295,6 +474,51 @@ func (mr *MockEngineMockRecorder) GetDaySummaryForUser(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDaySummaryForUser", reflect.TypeOf((*MockEngine)(nil).GetDaySummaryForUser), arg0, arg1)
 }
 
+// GetEvent mocks base method.
+func (m *MockEngine) GetEvent(arg0 *engine.User, arg1 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvent", arg0, arg1)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvent indicates an expected call of GetEvent.
+func (mr *MockEngineMockRecorder) GetEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvent", reflect.TypeOf((*MockEngine)(nil).GetEvent), arg0, arg1)
+}
+
+// GetMattermostChannelByName mocks base method.
+func (m *MockEngine) GetMattermostChannelByName(arg0, arg1 string) (*model.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostChannelByName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostChannelByName indicates an expected call of GetMattermostChannelByName.
+func (mr *MockEngineMockRecorder) GetMattermostChannelByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostChannelByName", reflect.TypeOf((*MockEngine)(nil).GetMattermostChannelByName), arg0, arg1)
+}
+
+// GetMattermostUserByUsername mocks base method.
+func (m *MockEngine) GetMattermostUserByUsername(arg0 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostUserByUsername", arg0)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostUserByUsername indicates an expected call of GetMattermostUserByUsername.
+func (mr *MockEngineMockRecorder) GetMattermostUserByUsername(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostUserByUsername", reflect.TypeOf((*MockEngine)(nil).GetMattermostUserByUsername), arg0)
+}
+
 // GetRemoteUser mocks base method.
 func (m *MockEngine) GetRemoteUser(arg0 string) (*remote.User, error) {
 	m.ctrl.T.Helper()
@@ -310,6 +534,21 @@ func (mr *MockEngineMockRecorder) GetRemoteUser(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteUser", reflect.TypeOf((*MockEngine)(nil).GetRemoteUser), arg0)
 }
 
+// GetSchedules mocks base method.
+func (m *MockEngine) GetSchedules(arg0 *engine.User, arg1 []string, arg2, arg3 time.Time, arg4 time.Duration) ([]*engine.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*engine.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedules indicates an expected call of GetSchedules.
+func (mr *MockEngineMockRecorder) GetSchedules(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*MockEngine)(nil).GetSchedules), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetTimezone mocks base method.
 func (m *MockEngine) GetTimezone(arg0 *engine.User) (string, error) {
 	m.ctrl.T.Helper()
@@ -355,6 +594,35 @@ func (mr *MockEngineMockRecorder) IsAuthorizedAdmin(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAuthorizedAdmin", reflect.TypeOf((*MockEngine)(nil).IsAuthorizedAdmin), arg0)
 }
 
+// LinkEvent mocks base method.
+func (m *MockEngine) LinkEvent(arg0 *engine.User, arg1, arg2 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkEvent indicates an expected call of LinkEvent.
+func (mr *MockEngineMockRecorder) LinkEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkEvent", reflect.TypeOf((*MockEngine)(nil).LinkEvent), arg0, arg1, arg2)
+}
+
+// LinkEventToChannel mocks base method.
+func (m *MockEngine) LinkEventToChannel(arg0 *engine.User, arg1 *remote.Event, arg2 string, arg3 *model.SlackAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkEventToChannel", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkEventToChannel indicates an expected call of LinkEventToChannel.
+func (mr *MockEngineMockRecorder) LinkEventToChannel(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkEventToChannel", reflect.TypeOf((*MockEngine)(nil).LinkEventToChannel), arg0, arg1, arg2, arg3)
+}
+
 // ListRemoteSubscriptions mocks base method.
 func (m *MockEngine) ListRemoteSubscriptions() ([]*remote.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -385,6 +653,49 @@ func (mr *MockEngineMockRecorder) LoadMyEventSubscription() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMyEventSubscription", reflect.TypeOf((*MockEngine)(nil).LoadMyEventSubscription))
 }
 
+// OpenCreateEventDialog mocks base method.
+func (m *MockEngine) OpenCreateEventDialog(arg0 *engine.User, arg1 string, arg2 views.CreateEventDefaults) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCreateEventDialog", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenCreateEventDialog indicates an expected call of OpenCreateEventDialog.
+func (mr *MockEngineMockRecorder) OpenCreateEventDialog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCreateEventDialog", reflect.TypeOf((*MockEngine)(nil).OpenCreateEventDialog), arg0, arg1, arg2)
+}
+
+// OpenCreateEventDialogFromPost mocks base method.
+func (m *MockEngine) OpenCreateEventDialogFromPost(arg0 *engine.User, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenCreateEventDialogFromPost", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenCreateEventDialogFromPost indicates an expected call of OpenCreateEventDialogFromPost.
+func (mr *MockEngineMockRecorder) OpenCreateEventDialogFromPost(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenCreateEventDialogFromPost", reflect.TypeOf((*MockEngine)(nil).OpenCreateEventDialogFromPost), arg0, arg1, arg2)
+}
+
+// PollMyEventSubscription mocks base method.
+func (m *MockEngine) PollMyEventSubscription() ([]*remote.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PollMyEventSubscription")
+	ret0, _ := ret[0].([]*remote.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PollMyEventSubscription indicates an expected call of PollMyEventSubscription.
+func (mr *MockEngineMockRecorder) PollMyEventSubscription() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PollMyEventSubscription", reflect.TypeOf((*MockEngine)(nil).PollMyEventSubscription))
+}
+
 // PrintSettings mocks base method.
 func (m *MockEngine) PrintSettings(arg0 string) {
 	m.ctrl.T.Helper()
@@ -397,6 +708,20 @@ func (mr *MockEngineMockRecorder) PrintSettings(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrintSettings", reflect.TypeOf((*MockEngine)(nil).PrintSettings), arg0)
 }
 
+// ProcessAllChannelCalendars mocks base method.
+func (m *MockEngine) ProcessAllChannelCalendars(arg0 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAllChannelCalendars", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessAllChannelCalendars indicates an expected call of ProcessAllChannelCalendars.
+func (mr *MockEngineMockRecorder) ProcessAllChannelCalendars(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllChannelCalendars", reflect.TypeOf((*MockEngine)(nil).ProcessAllChannelCalendars), arg0)
+}
+
 // ProcessAllDailySummary mocks base method.
 func (m *MockEngine) ProcessAllDailySummary(arg0 time.Time) error {
 	m.ctrl.T.Helper()
@@ -411,6 +736,34 @@ func (mr *MockEngineMockRecorder) ProcessAllDailySummary(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAllDailySummary", reflect.TypeOf((*MockEngine)(nil).ProcessAllDailySummary), arg0)
 }
 
+// RenderMeetingPoll mocks base method.
+func (m *MockEngine) RenderMeetingPoll(arg0 *store.MeetingPoll) *model.SlackAttachment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderMeetingPoll", arg0)
+	ret0, _ := ret[0].(*model.SlackAttachment)
+	return ret0
+}
+
+// RenderMeetingPoll indicates an expected call of RenderMeetingPoll.
+func (mr *MockEngineMockRecorder) RenderMeetingPoll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderMeetingPoll", reflect.TypeOf((*MockEngine)(nil).RenderMeetingPoll), arg0)
+}
+
+// RenewChannelCalendarSubscription mocks base method.
+func (m *MockEngine) RenewChannelCalendarSubscription(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewChannelCalendarSubscription", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewChannelCalendarSubscription indicates an expected call of RenewChannelCalendarSubscription.
+func (mr *MockEngineMockRecorder) RenewChannelCalendarSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewChannelCalendarSubscription", reflect.TypeOf((*MockEngine)(nil).RenewChannelCalendarSubscription), arg0)
+}
+
 // RenewMyEventSubscription mocks base method.
 func (m *MockEngine) RenewMyEventSubscription() (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -440,6 +793,64 @@ func (mr *MockEngineMockRecorder) RespondToEvent(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondToEvent", reflect.TypeOf((*MockEngine)(nil).RespondToEvent), arg0, arg1, arg2)
 }
 
+// RestoreReminders mocks base method.
+func (m *MockEngine) RestoreReminders(arg0 *engine.User, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreReminders", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreReminders indicates an expected call of RestoreReminders.
+func (mr *MockEngineMockRecorder) RestoreReminders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReminders", reflect.TypeOf((*MockEngine)(nil).RestoreReminders), arg0, arg1)
+}
+
+// ScheduleEvent mocks base method.
+func (m *MockEngine) ScheduleEvent(arg0 *engine.User, arg1 *remote.Event, arg2 []string, arg3 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleEvent", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleEvent indicates an expected call of ScheduleEvent.
+func (mr *MockEngineMockRecorder) ScheduleEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleEvent", reflect.TypeOf((*MockEngine)(nil).ScheduleEvent), arg0, arg1, arg2, arg3)
+}
+
+// SetAutomaticReplies mocks base method.
+func (m *MockEngine) SetAutomaticReplies(arg0 *engine.User, arg1 *remote.AutomaticRepliesSetting) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutomaticReplies", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAutomaticReplies indicates an expected call of SetAutomaticReplies.
+func (mr *MockEngineMockRecorder) SetAutomaticReplies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutomaticReplies", reflect.TypeOf((*MockEngine)(nil).SetAutomaticReplies), arg0, arg1)
+}
+
+// SetChannelAgendaTime mocks base method.
+func (m *MockEngine) SetChannelAgendaTime(arg0 *engine.User, arg1, arg2 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannelAgendaTime", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChannelAgendaTime indicates an expected call of SetChannelAgendaTime.
+func (mr *MockEngineMockRecorder) SetChannelAgendaTime(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannelAgendaTime", reflect.TypeOf((*MockEngine)(nil).SetChannelAgendaTime), arg0, arg1, arg2)
+}
+
 // SetDailySummaryEnabled mocks base method.
 func (m *MockEngine) SetDailySummaryEnabled(arg0 *engine.User, arg1 bool) (*store.DailySummaryUserSettings, error) {
 	m.ctrl.T.Helper()
@@ -470,6 +881,21 @@ func (mr *MockEngineMockRecorder) SetDailySummaryPostTime(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDailySummaryPostTime", reflect.TypeOf((*MockEngine)(nil).SetDailySummaryPostTime), arg0, arg1)
 }
 
+// SnoozeReminder mocks base method.
+func (m *MockEngine) SnoozeReminder(arg0 *engine.User, arg1 string, arg2 time.Time, arg3 bool) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnoozeReminder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnoozeReminder indicates an expected call of SnoozeReminder.
+func (mr *MockEngineMockRecorder) SnoozeReminder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnoozeReminder", reflect.TypeOf((*MockEngine)(nil).SnoozeReminder), arg0, arg1, arg2, arg3)
+}
+
 // Sync mocks base method.
 func (m *MockEngine) Sync(arg0 string) (string, *engine.StatusSyncJobSummary, error) {
 	m.ctrl.T.Helper()
@@ -516,6 +942,36 @@ func (mr *MockEngineMockRecorder) TentativelyAcceptEvent(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TentativelyAcceptEvent", reflect.TypeOf((*MockEngine)(nil).TentativelyAcceptEvent), arg0, arg1)
 }
 
+// UnlinkEvent mocks base method.
+func (m *MockEngine) UnlinkEvent(arg0 *engine.User, arg1, arg2 string) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlinkEvent indicates an expected call of UnlinkEvent.
+func (mr *MockEngineMockRecorder) UnlinkEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkEvent", reflect.TypeOf((*MockEngine)(nil).UnlinkEvent), arg0, arg1, arg2)
+}
+
+// UpdateEvent mocks base method.
+func (m *MockEngine) UpdateEvent(arg0 *engine.User, arg1 string, arg2 *remote.Event) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent.
+func (mr *MockEngineMockRecorder) UpdateEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockEngine)(nil).UpdateEvent), arg0, arg1, arg2)
+}
+
 // ViewCalendar mocks base method.
 func (m *MockEngine) ViewCalendar(arg0 *engine.User, arg1, arg2 time.Time) ([]*remote.Event, error) {
 	m.ctrl.T.Helper()
@@ -531,6 +987,21 @@ func (mr *MockEngineMockRecorder) ViewCalendar(arg0, arg1, arg2 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewCalendar", reflect.TypeOf((*MockEngine)(nil).ViewCalendar), arg0, arg1, arg2)
 }
 
+// VoteMeetingPoll mocks base method.
+func (m *MockEngine) VoteMeetingPoll(arg0, arg1 string, arg2 int) (*store.MeetingPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteMeetingPoll", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*store.MeetingPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoteMeetingPoll indicates an expected call of VoteMeetingPoll.
+func (mr *MockEngineMockRecorder) VoteMeetingPoll(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteMeetingPoll", reflect.TypeOf((*MockEngine)(nil).VoteMeetingPoll), arg0, arg1, arg2)
+}
+
 // Welcome mocks base method.
 func (m *MockEngine) Welcome(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -48,6 +48,50 @@ func (mr *MockPluginAPIMockRecorder) CanLinkEventToChannel(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanLinkEventToChannel", reflect.TypeOf((*MockPluginAPI)(nil).CanLinkEventToChannel), arg0, arg1)
 }
 
+// CanReadChannel mocks base method.
+func (m *MockPluginAPI) CanReadChannel(arg0, arg1 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanReadChannel", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// CanReadChannel indicates an expected call of CanReadChannel.
+func (mr *MockPluginAPIMockRecorder) CanReadChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanReadChannel", reflect.TypeOf((*MockPluginAPI)(nil).CanReadChannel), arg0, arg1)
+}
+
+// GetMattermostChannelByName mocks base method.
+func (m *MockPluginAPI) GetMattermostChannelByName(arg0, arg1 string) (*model.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostChannelByName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostChannelByName indicates an expected call of GetMattermostChannelByName.
+func (mr *MockPluginAPIMockRecorder) GetMattermostChannelByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostChannelByName", reflect.TypeOf((*MockPluginAPI)(nil).GetMattermostChannelByName), arg0, arg1)
+}
+
+// GetMattermostChannelUsers mocks base method.
+func (m *MockPluginAPI) GetMattermostChannelUsers(arg0 string) ([]*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMattermostChannelUsers", arg0)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMattermostChannelUsers indicates an expected call of GetMattermostChannelUsers.
+func (mr *MockPluginAPIMockRecorder) GetMattermostChannelUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMattermostChannelUsers", reflect.TypeOf((*MockPluginAPI)(nil).GetMattermostChannelUsers), arg0)
+}
+
 // GetMattermostUser mocks base method.
 func (m *MockPluginAPI) GetMattermostUser(arg0 string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +197,20 @@ func (mr *MockPluginAPIMockRecorder) IsSysAdmin(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSysAdmin", reflect.TypeOf((*MockPluginAPI)(nil).IsSysAdmin), arg0)
 }
 
+// OpenInteractiveDialog mocks base method.
+func (m *MockPluginAPI) OpenInteractiveDialog(arg0 model.OpenDialogRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenInteractiveDialog", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OpenInteractiveDialog indicates an expected call of OpenInteractiveDialog.
+func (mr *MockPluginAPIMockRecorder) OpenInteractiveDialog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenInteractiveDialog", reflect.TypeOf((*MockPluginAPI)(nil).OpenInteractiveDialog), arg0)
+}
+
 // PublishWebsocketEvent mocks base method.
 func (m *MockPluginAPI) PublishWebsocketEvent(arg0, arg1 string, arg2 map[string]interface{}) {
 	m.ctrl.T.Helper()
@@ -23,6 +23,14 @@ type Engine interface {
 	Welcomer
 	Settings
 	DailySummary
+	OutOfOffice
+	Schedules
+	MeetingTimes
+	MeetingPolls
+	EventCreator
+	ChannelLinks
+	ChannelCalendars
+	Reminders
 }
 
 // Dependencies contains all API dependencies
@@ -48,7 +56,11 @@ type PluginAPI interface {
 	UpdateMattermostUserCustomStatus(mattermostUserID string, customStatus *model.CustomStatus) *model.AppError
 	RemoveMattermostUserCustomStatus(mattermostUserID string) *model.AppError
 	GetPost(postID string) (*model.Post, error)
+	GetMattermostChannelByName(teamID, channelName string) (*model.Channel, error)
+	GetMattermostChannelUsers(channelID string) ([]*model.User, error)
 	CanLinkEventToChannel(channelID, userID string) bool
+	CanReadChannel(channelID, userID string) bool
+	OpenInteractiveDialog(request model.OpenDialogRequest) error
 	SearchLinkableChannelForUser(teamID, mattermostUserID, search string) ([]*model.Channel, error)
 	GetMattermostUserTeams(mattermostUserID string) ([]*model.Team, error)
 	PublishWebsocketEvent(mattermostUserID, event string, payload map[string]any)
@@ -64,6 +76,10 @@ type mscalendar struct {
 
 	actingUser *User
 	client     remote.Client
+
+	// syncedMailboxSettings caches the mailbox settings of the users during
+	// a sync, by Mattermost user ID.
+	syncedMailboxSettings map[string]*remote.MailboxSettings
 }
 
 // copy returns a copy of the calendar engine
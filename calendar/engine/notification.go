@@ -6,11 +6,13 @@ package engine
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
@@ -18,6 +20,15 @@ import (
 
 const maxQueueSize = 1024
 
+// When the remote reports missed notifications, the events starting within
+// missedNotificationsWindow and changed within missedNotificationsLookback
+// are read again. Graph keeps retrying a notification for up to 4 hours
+// before reporting it missed.
+const (
+	missedNotificationsWindow   = 7 * 24 * time.Hour
+	missedNotificationsLookback = 24 * time.Hour
+)
+
 const (
 	FieldSubject        = "Subject"
 	FieldBodyPreview    = "BodyPreview"
@@ -28,6 +39,7 @@ const (
 	FieldAttendees      = "Attendees"
 	FieldOrganizer      = "Organizer"
 	FieldResponseStatus = "ResponseStatus"
+	FieldRecurrence     = "Recurrence"
 )
 
 const (
@@ -44,10 +56,11 @@ const (
 	ResponseNone  = "notResponded"
 )
 
-var importantNotificationChanges = []string{FieldSubject, FieldWhen}
+var importantNotificationChanges = []string{FieldSubject, FieldWhen, FieldRecurrence}
 
 var notificationFieldOrder = []string{
 	FieldWhen,
+	FieldRecurrence,
 	FieldLocation,
 	FieldAttendees,
 	FieldImportance,
@@ -126,7 +139,7 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 	if err != nil {
 		return err
 	}
-	if sub.Remote.ID != creator.Settings.EventSubscriptionID {
+	if sub.ChannelID == "" && sub.Remote.ID != creator.Settings.EventSubscriptionID {
 		return errors.New("구독이 관리되지않고 방치 상태입니다")
 	}
 	if sub.Remote.ClientState != "" && sub.Remote.ClientState != n.ClientState {
@@ -138,37 +151,37 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 
 	client := processor.Remote.MakeUserClient(context.Background(), creator.OAuth2Token, sub.MattermostCreatorID, processor.Poster, processor.Store)
 
-	if n.RecommendRenew {
-		var renewed *remote.Subscription
-		renewed, err = client.RenewSubscription(processor.Config.GetNotificationURL(), sub.Remote.CreatorID, n.Subscription)
-		if err != nil {
-			return err
-		}
+	if sub.ChannelID != "" {
+		return processor.processChannelCalendarNotification(n, sub, creator, client)
+	}
 
-		storedSub := &store.Subscription{
-			Remote:              renewed,
-			MattermostCreatorID: creator.MattermostUserID,
-			PluginVersion:       processor.Config.PluginVersion,
-		}
-		err = processor.Store.StoreUserSubscription(creator, storedSub)
+	if n.LifecycleEvent != "" {
+		return processor.processLifecycleNotification(n, creator, client)
+	}
+
+	if n.RecommendRenew {
+		err = processor.renewSubscription(n, creator, client)
 		if err != nil {
 			return err
 		}
-		processor.Logger.With(bot.LogContext{
-			"MattermostUserID": creator.MattermostUserID,
-			"SubscriptionID":   n.SubscriptionID,
-		}).Debugf("웹훅 알림: 사용자 구독이 갱신되었습니다.")
 	}
 
 	if n.IsBare {
+		if poller, ok := client.(remote.Poller); ok {
+			return processor.pollSubscription(creator, poller)
+		}
 		n, err = client.GetNotificationData(n)
 		if err != nil {
 			return err
 		}
 	}
 
+	if n.ChangeType == remote.ChangeTypeDeleted || n.Event.IsCancelled {
+		return processor.processCancelledEvent(n, creator, client)
+	}
+
 	var sa *model.SlackAttachment
-	prior, err := processor.Store.LoadUserEvent(creator.MattermostUserID, n.Event.ICalUID)
+	prior, err := processor.Store.LoadUserEvent(creator.MattermostUserID, n.Event.OccurrenceKey())
 	if err != nil && err != store.ErrNotFound {
 		return err
 	}
@@ -197,7 +210,7 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 		prior = &store.Event{}
 	}
 
-	_, err = processor.Poster.DMWithAttachments(creator.MattermostUserID, sa)
+	err = processor.dmWithinWorkingHours(creator, mailSettings, sa)
 	if err != nil {
 		return err
 	}
@@ -215,3 +228,256 @@ func (processor *notificationProcessor) processNotification(n *remote.Notificati
 
 	return nil
 }
+
+// dmWithinWorkingHours sends the attachment to the user, or queues it until
+// their working hours start if they asked not to be disturbed after hours.
+func (processor *notificationProcessor) dmWithinWorkingHours(user *store.User, mailSettings *remote.MailboxSettings, sa *model.SlackAttachment) error {
+	if !isWorkingTime(user, mailSettings, time.Now()) {
+		return processor.Store.QueueUserDM(user.MattermostUserID, sa)
+	}
+
+	_, err := processor.Poster.DMWithAttachments(user.MattermostUserID, sa)
+	return err
+}
+
+// processCancelledEvent lets the creator know that an event they were
+// notified about is off, tells the channels linked to it, and forgets about
+// the event. Events never seen before are ignored.
+func (processor *notificationProcessor) processCancelledEvent(n *remote.Notification, creator *store.User, client remote.Client) error {
+	logger := processor.Logger.With(bot.LogContext{
+		"MattermostUserID": creator.MattermostUserID,
+		"SubscriptionID":   n.SubscriptionID,
+		"ChangeType":       n.ChangeType,
+		"EventID":          n.Event.ID,
+	})
+
+	// Notifications of deleted events may only carry the remote ID.
+	var prior *store.Event
+	var err error
+	if n.Event.ICalUID != "" {
+		prior, err = processor.Store.LoadUserEvent(creator.MattermostUserID, n.Event.OccurrenceKey())
+	} else {
+		prior, err = processor.Store.LoadUserEventByRemoteID(creator.MattermostUserID, n.Event.ID)
+	}
+	if errors.Is(err, store.ErrNotFound) || (err == nil && prior.Remote == nil) {
+		logger.Debugf("웹훅 알림: 알 수 없는 이벤트가 취소되었습니다.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	event := prior.Remote
+	if n.ChangeType != remote.ChangeTypeDeleted {
+		event = n.Event
+	}
+
+	mailSettings, err := client.GetMailboxSettings(n.Subscription.CreatorID)
+	if err != nil {
+		return err
+	}
+	timezone := mailSettings.TimeZone
+
+	cancelled := *n
+	cancelled.Event = event
+	sa := processor.cancelledEventSlackAttachment(&cancelled, timezone)
+	err = processor.dmWithinWorkingHours(creator, mailSettings, sa)
+	if err != nil {
+		return err
+	}
+
+	linkedChannelIDs, err := loadLinkedChannelIDs(processor.Store, event)
+	if err != nil {
+		return err
+	}
+	for channelID := range linkedChannelIDs {
+		post := &model.Post{
+			ChannelId: channelID,
+			Message:   fmt.Sprintf("**%s** 이벤트가 취소되었습니다.", views.EnsureSubject(event.Subject)),
+		}
+		attachment, errRender := views.RenderEventAsAttachment(event, timezone, views.ShowTimezoneOption(timezone))
+		if errRender == nil {
+			model.ParseSlackAttachment(post, []*model.SlackAttachment{attachment})
+		}
+		if errPoster := processor.Poster.CreatePost(post); errPoster != nil {
+			logger.With(bot.LogContext{
+				"channelID": channelID,
+				"err":       errPoster,
+			}).Warnf("웹훅 알림: 연결된 채널에 취소 게시물 생성 오류")
+		}
+	}
+
+	// Series are also linked by their ID. A cancelled occurrence leaves the
+	// links of its series in place.
+	metadataKeys := []string{event.OccurrenceKey()}
+	if event.Recurrence != nil && event.ID != event.ICalUID {
+		metadataKeys = append(metadataKeys, event.ID)
+	}
+	for _, key := range metadataKeys {
+		if key == "" {
+			continue
+		}
+		err = processor.Store.DeleteEventMetadata(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = processor.Store.DeleteUserEvent(creator.MattermostUserID, prior.Remote.OccurrenceKey())
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("알림 전송됨: %s.", sa.Title)
+	return nil
+}
+
+func (processor *notificationProcessor) renewSubscription(n *remote.Notification, creator *store.User, client remote.Client) error {
+	renewed, err := client.RenewSubscription(processor.Config.GetNotificationURL(), n.Subscription.CreatorID, n.Subscription)
+	if err != nil {
+		return err
+	}
+
+	storedSub := &store.Subscription{
+		Remote:              n.Subscription,
+		MattermostCreatorID: creator.MattermostUserID,
+		PluginVersion:       processor.Config.PluginVersion,
+	}
+	_, err = storeRenewedSubscription(processor.Store, creator, storedSub, renewed)
+	if err != nil {
+		return err
+	}
+	processor.Logger.With(bot.LogContext{
+		"MattermostUserID": creator.MattermostUserID,
+		"SubscriptionID":   n.SubscriptionID,
+	}).Debugf("웹훅 알림: 사용자 구독이 갱신되었습니다.")
+	return nil
+}
+
+// pollSubscription handles a notification that doesn't identify the changed
+// events: the changes since the last sync of the creator's subscription are
+// fetched, the new sync state is stored, and a notification per changed event
+// is queued. The subscription is loaded again since it may have been renewed.
+func (processor *notificationProcessor) pollSubscription(creator *store.User, poller remote.Poller) error {
+	sub, err := processor.Store.LoadSubscription(creator.Settings.EventSubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	notifications, polled, err := poller.PollSubscription(sub.Remote)
+	if err != nil {
+		return err
+	}
+
+	sub.Remote = polled
+	err = processor.Store.StoreUserSubscription(creator, sub)
+	if err != nil {
+		return err
+	}
+
+	processor.Logger.With(bot.LogContext{
+		"MattermostUserID": creator.MattermostUserID,
+		"SubscriptionID":   sub.Remote.ID,
+	}).Debugf("웹훅 알림: 변경된 이벤트 %d개를 가져왔습니다.", len(notifications))
+
+	return processor.Enqueue(notifications...)
+}
+
+// processLifecycleNotification keeps the subscription of the creator alive
+// when the remote reports a problem with it.
+func (processor *notificationProcessor) processLifecycleNotification(n *remote.Notification, creator *store.User, client remote.Client) error {
+	logger := processor.Logger.With(bot.LogContext{
+		"MattermostUserID": creator.MattermostUserID,
+		"SubscriptionID":   n.SubscriptionID,
+		"LifecycleEvent":   n.LifecycleEvent,
+	})
+
+	switch n.LifecycleEvent {
+	case remote.LifecycleEventReauthorizationRequired:
+		// Renewing the subscription also reauthorizes it.
+		return processor.renewSubscription(n, creator, client)
+
+	case remote.LifecycleEventSubscriptionRemoved:
+		err := processor.Store.DeleteUserSubscription(creator, n.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		sub, err := client.CreateMySubscription(processor.Config.GetNotificationURL(), creator.Remote.ID)
+		if err != nil {
+			_, _ = processor.Poster.DM(creator.MattermostUserID, "%s에서 캘린더 이벤트 알림 구독을 제거했으며 다시 만들 수 없습니다. 이벤트 알림을 계속 받으려면 `/%s connect`로 계정을 다시 연결하세요.", processor.Provider.DisplayName, processor.Provider.CommandTrigger)
+			return errors.Wrap(err, "제거된 구독을 다시 만드는 중 오류 발생")
+		}
+		err = processor.Store.StoreUserSubscription(creator, &store.Subscription{
+			Remote:              sub,
+			MattermostCreatorID: creator.MattermostUserID,
+			PluginVersion:       processor.Config.PluginVersion,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = processor.Poster.DM(creator.MattermostUserID, "%s에서 캘린더 이벤트 알림 구독을 제거하여 다시 만들었습니다. 그 사이에 변경된 이벤트의 알림은 받지 못했을 수 있습니다.", processor.Provider.DisplayName)
+		if err != nil {
+			return err
+		}
+		logger.Infof("웹훅 알림: 제거된 구독을 다시 만들었습니다. 새 구독: %s", sub.ID)
+		return nil
+
+	case remote.LifecycleEventMissed:
+		// Drop the cached calendars of the creator, so that the next
+		// synchronization reads them again in full.
+		calendarIDs := []string{""}
+		for _, cal := range creator.Settings.Calendars {
+			calendarIDs = append(calendarIDs, cal.ID)
+		}
+		for _, calendarID := range calendarIDs {
+			err := processor.Store.DeleteCalendarCache(creator.Remote.ID, calendarID)
+			if err != nil && !errors.Is(err, store.ErrNotFound) {
+				return err
+			}
+		}
+
+		count, err := processor.resyncMissedNotifications(n, creator, client, time.Now())
+		if err != nil {
+			return err
+		}
+		logger.Infof("웹훅 알림: 놓친 알림이 있어 캘린더를 다시 동기화합니다. 최근 변경된 이벤트: %d개", count)
+
+		_, err = processor.Poster.DM(creator.MattermostUserID, "%s에서 일부 캘린더 이벤트 알림을 전달하지 못해 캘린더를 다시 동기화했습니다. 최근 변경된 이벤트를 다시 알려드리며, 그 사이에 삭제된 이벤트는 알리지 못할 수 있습니다.", processor.Provider.DisplayName)
+		return err
+	}
+
+	logger.Debugf("웹훅 알림: 알 수 없는 수명 주기 이벤트입니다.")
+	return nil
+}
+
+// resyncMissedNotifications makes up for the notifications the remote failed
+// to deliver: the upcoming events of the creator changed within
+// missedNotificationsLookback are queued as updated, so that the notification
+// path tells the creator about the ones that actually changed. Events deleted
+// in the meantime aren't in the calendar view, and can't be found. Returns the
+// number of events queued.
+func (processor *notificationProcessor) resyncMissedNotifications(n *remote.Notification, creator *store.User, client remote.Client, now time.Time) (int, error) {
+	events, err := client.GetDefaultCalendarView(creator.Remote.ID, now, now.Add(missedNotificationsWindow))
+	if err != nil {
+		return 0, errors.Wrap(err, "놓친 알림의 이벤트를 불러오는 중 오류 발생")
+	}
+
+	since := now.Add(-missedNotificationsLookback)
+	notifications := []*remote.Notification{}
+	for _, event := range events {
+		modified, errParse := time.Parse(time.RFC3339, event.LastModifiedDateTime)
+		if errParse != nil || modified.Before(since) {
+			continue
+		}
+		notifications = append(notifications, &remote.Notification{
+			SubscriptionID: n.SubscriptionID,
+			ChangeType:     remote.ChangeTypeUpdated,
+			ClientState:    n.Subscription.ClientState,
+			Event:          event,
+		})
+	}
+
+	return len(notifications), processor.Enqueue(notifications...)
+}
@@ -36,7 +36,10 @@ func (processor *notificationProcessor) newEventSlackAttachment(n *remote.Notifi
 
 	fields := eventToFields(n.Event, timezone)
 	for _, k := range notificationFieldOrder {
-		v := fields[k]
+		v, ok := fields[k]
+		if !ok {
+			continue
+		}
 
 		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
 			Title: k,
@@ -116,6 +119,26 @@ func (processor *notificationProcessor) updatedEventSlackAttachment(n *remote.No
 	return true, sa
 }
 
+func (processor *notificationProcessor) cancelledEventSlackAttachment(n *remote.Notification, timezone string) *model.SlackAttachment {
+	sa := processor.newSlackAttachment(n)
+	sa.Title = "(취소됨) " + sa.Title
+
+	fields := eventToFields(n.Event, timezone)
+	for _, k := range []string{FieldWhen, FieldLocation} {
+		v, ok := fields[k]
+		if !ok {
+			continue
+		}
+
+		sa.Fields = append(sa.Fields, &model.SlackAttachmentField{
+			Title: k,
+			Value: fmt.Sprintf("~~%s~~", views.MarkdownToHTMLEntities(strings.Join(v.Strings(), ", "))),
+			Short: true,
+		})
+	}
+	return sa
+}
+
 func isImportantChange(fieldName string) bool {
 	for _, ic := range importantNotificationChanges {
 		if ic == fieldName {
@@ -236,6 +259,10 @@ func eventToFields(e *remote.Event, timezone string) fields.Fields {
 		FieldAttendees:      fields.NewMultiValue(attendees...),
 	}
 
+	if e.Recurrence != nil {
+		ff[FieldRecurrence] = fields.NewStringValue(views.RenderRecurrence(e.Recurrence))
+	}
+
 	return ff
 }
 
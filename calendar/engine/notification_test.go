@@ -7,12 +7,15 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/golang/mock/gomock"
 	"golang.org/x/oauth2"
 
+	"github.com/mattermost/mattermost/server/public/model"
+
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
@@ -216,3 +219,373 @@ func TestProcessNotificationOverflow(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestProcessLifecycleNotification(t *testing.T) {
+	tcs := []struct {
+		name           string
+		lifecycleEvent string
+		setup          func(*mock_store.MockStore, *mock_remote.MockClient, *mock_bot.MockPoster, *store.User)
+		expectedError  string
+	}{
+		{
+			name:           "reauthorization required renews the subscription",
+			lifecycleEvent: remote.LifecycleEventReauthorizationRequired,
+			setup: func(mockStore *mock_store.MockStore, mockClient *mock_remote.MockClient, _ *mock_bot.MockPoster, user *store.User) {
+				renewed := &remote.Subscription{ID: "remote_subscription_id", CreatorID: "remote_user_id", ExpirationDateTime: "2030-01-02T00:00:00Z"}
+				mockClient.EXPECT().RenewSubscription("https://mm.example.com/plugins/mscalendar/notification/v1/event", "remote_user_id", gomock.Any()).Return(renewed, nil).Times(1)
+				mockStore.EXPECT().StoreSubscriptionExpiration("remote_subscription_id", "2030-01-02T00:00:00Z").Return(&store.Subscription{Remote: renewed}, nil).Times(1)
+			},
+		},
+		{
+			name:           "removed subscription is created again",
+			lifecycleEvent: remote.LifecycleEventSubscriptionRemoved,
+			setup: func(mockStore *mock_store.MockStore, mockClient *mock_remote.MockClient, mockPoster *mock_bot.MockPoster, user *store.User) {
+				created := &remote.Subscription{ID: "new_subscription_id", CreatorID: "remote_user_id"}
+				mockStore.EXPECT().DeleteUserSubscription(user, "remote_subscription_id").Return(nil).Times(1)
+				mockClient.EXPECT().CreateMySubscription("https://mm.example.com/plugins/mscalendar/notification/v1/event", "remote_user_id").Return(created, nil).Times(1)
+				mockStore.EXPECT().StoreUserSubscription(user, &store.Subscription{
+					Remote:              created,
+					MattermostCreatorID: "creator_mm_id",
+					PluginVersion:       "x.x.x",
+				}).Return(nil).Times(1)
+				mockPoster.EXPECT().DM("creator_mm_id", gomock.Any(), gomock.Any()).Return("", nil).Times(1)
+			},
+		},
+		{
+			name:           "removed subscription that can't be created again",
+			lifecycleEvent: remote.LifecycleEventSubscriptionRemoved,
+			setup: func(mockStore *mock_store.MockStore, mockClient *mock_remote.MockClient, mockPoster *mock_bot.MockPoster, user *store.User) {
+				mockStore.EXPECT().DeleteUserSubscription(user, "remote_subscription_id").Return(nil).Times(1)
+				mockClient.EXPECT().CreateMySubscription("https://mm.example.com/plugins/mscalendar/notification/v1/event", "remote_user_id").Return(nil, fmt.Errorf("forbidden")).Times(1)
+				mockPoster.EXPECT().DM("creator_mm_id", gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).Times(1)
+			},
+			expectedError: "제거된 구독을 다시 만드는 중 오류 발생: forbidden",
+		},
+		{
+			name:           "missed notifications drop the calendar caches and resync",
+			lifecycleEvent: remote.LifecycleEventMissed,
+			setup: func(mockStore *mock_store.MockStore, mockClient *mock_remote.MockClient, mockPoster *mock_bot.MockPoster, user *store.User) {
+				user.Settings.Calendars = []store.SelectedCalendar{{ID: "team_calendar_id", Name: "Team"}}
+				mockStore.EXPECT().DeleteCalendarCache("remote_user_id", "").Return(nil).Times(1)
+				mockStore.EXPECT().DeleteCalendarCache("remote_user_id", "team_calendar_id").Return(store.ErrNotFound).Times(1)
+				mockClient.EXPECT().GetDefaultCalendarView("remote_user_id", gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				mockPoster.EXPECT().DM("creator_mm_id", gomock.Any(), "testDisplayName").Return("", nil).Times(1)
+			},
+		},
+		{
+			name:           "missed notifications that can't be resynced",
+			lifecycleEvent: remote.LifecycleEventMissed,
+			setup: func(mockStore *mock_store.MockStore, mockClient *mock_remote.MockClient, _ *mock_bot.MockPoster, _ *store.User) {
+				mockStore.EXPECT().DeleteCalendarCache("remote_user_id", "").Return(nil).Times(1)
+				mockClient.EXPECT().GetDefaultCalendarView("remote_user_id", gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("throttled")).Times(1)
+			},
+			expectedError: "놓친 알림의 이벤트를 불러오는 중 오류 발생: throttled",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mock_store.NewMockStore(ctrl)
+			mockPoster := mock_bot.NewMockPoster(ctrl)
+			mockRemote := mock_remote.NewMockRemote(ctrl)
+			mockClient := mock_remote.NewMockClient(ctrl)
+
+			conf := &config.Config{
+				PluginVersion: "x.x.x",
+				PluginURL:     "https://mm.example.com/plugins/mscalendar",
+				Provider: config.ProviderConfig{
+					DisplayName:    "testDisplayName",
+					CommandTrigger: "testCommandTrigger",
+				},
+			}
+			env := Env{
+				Config: conf,
+				Dependencies: &Dependencies{
+					Store:  mockStore,
+					Logger: &bot.NilLogger{},
+					Poster: mockPoster,
+					Remote: mockRemote,
+				},
+			}
+
+			subscription := newTestSubscription()
+			user := &store.User{
+				Settings:         store.Settings{EventSubscriptionID: "remote_subscription_id"},
+				Remote:           &remote.User{ID: "remote_user_id"},
+				OAuth2Token:      &oauth2.Token{AccessToken: "creator_oauth_token"},
+				MattermostUserID: "creator_mm_id",
+			}
+
+			mockStore.EXPECT().LoadSubscription("remote_subscription_id").Return(subscription, nil).Times(1)
+			mockStore.EXPECT().LoadUser("creator_mm_id").Return(user, nil).Times(1)
+			mockRemote.EXPECT().MakeUserClient(context.Background(), user.OAuth2Token, "creator_mm_id", mockPoster, gomock.Any()).Return(mockClient).Times(1)
+			tc.setup(mockStore, mockClient, mockPoster, user)
+
+			processor := &notificationProcessor{
+				Env:   env,
+				queue: make(chan *remote.Notification, maxQueueSize),
+			}
+			err := processor.processNotification(&remote.Notification{
+				SubscriptionID: "remote_subscription_id",
+				ClientState:    "stored_client_state",
+				LifecycleEvent: tc.lifecycleEvent,
+			})
+
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMissedNotificationIsDelivered(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockRemote := mock_remote.NewMockRemote(ctrl)
+	mockClient := mock_remote.NewMockClient(ctrl)
+	env := Env{
+		Config: &config.Config{
+			PluginVersion: "x.x.x",
+			Provider:      config.ProviderConfig{DisplayName: "testDisplayName"},
+		},
+		Dependencies: &Dependencies{
+			Store:  mockStore,
+			Logger: &bot.NilLogger{},
+			Poster: mockPoster,
+			Remote: mockRemote,
+		},
+	}
+
+	subscription := newTestSubscription()
+	user := &store.User{
+		Settings:         store.Settings{EventSubscriptionID: "remote_subscription_id"},
+		Remote:           &remote.User{ID: "remote_user_id"},
+		OAuth2Token:      &oauth2.Token{AccessToken: "creator_oauth_token"},
+		MattermostUserID: "creator_mm_id",
+	}
+
+	now := time.Now()
+	changed := newTestEvent("1", "", "moved while notifications were missed")
+	changed.LastModifiedDateTime = now.Add(-time.Hour).UTC().Format(time.RFC3339)
+	unchanged := newTestEvent("2", "", "changed long ago")
+	unchanged.LastModifiedDateTime = now.Add(-72 * time.Hour).UTC().Format(time.RFC3339)
+
+	mockStore.EXPECT().LoadSubscription("remote_subscription_id").Return(subscription, nil).Times(2)
+	mockStore.EXPECT().LoadUser("creator_mm_id").Return(user, nil).Times(2)
+	mockRemote.EXPECT().MakeUserClient(context.Background(), user.OAuth2Token, "creator_mm_id", mockPoster, gomock.Any()).Return(mockClient).Times(2)
+	mockStore.EXPECT().DeleteCalendarCache("remote_user_id", "").Return(nil).Times(1)
+	mockClient.EXPECT().GetDefaultCalendarView("remote_user_id", gomock.Any(), gomock.Any()).Return([]*remote.Event{changed, unchanged}, nil).Times(1)
+	mockPoster.EXPECT().DM("creator_mm_id", gomock.Any(), "testDisplayName").Return("", nil).Times(1)
+
+	processor := &notificationProcessor{
+		Env:   env,
+		queue: make(chan *remote.Notification, maxQueueSize),
+	}
+	err := processor.processNotification(&remote.Notification{
+		SubscriptionID: "remote_subscription_id",
+		ClientState:    "stored_client_state",
+		LifecycleEvent: remote.LifecycleEventMissed,
+	})
+	require.NoError(t, err)
+	require.Len(t, processor.queue, 1)
+
+	// The queued change goes through the notification path like any other.
+	mockStore.EXPECT().LoadUserEvent("creator_mm_id", changed.OccurrenceKey()).Return(nil, store.ErrNotFound).Times(1)
+	mockClient.EXPECT().GetMailboxSettings("remote_user_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil).Times(1)
+	mockPoster.EXPECT().DMWithAttachments("creator_mm_id", gomock.Any()).DoAndReturn(func(_ string, attachments ...*model.SlackAttachment) (string, error) {
+		require.Len(t, attachments, 1)
+		require.Contains(t, attachments[0].Title, "moved while notifications were missed")
+		return "", nil
+	}).Times(1)
+	mockStore.EXPECT().StoreUserEvent("creator_mm_id", gomock.Any()).Return(nil).Times(1)
+
+	err = processor.processNotification(<-processor.queue)
+	require.NoError(t, err)
+}
+
+func TestProcessBareNotificationByPolling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockPoster := mock_bot.NewMockPoster(ctrl)
+	mockRemote := mock_remote.NewMockRemote(ctrl)
+	env := Env{
+		Config: &config.Config{PluginVersion: "x.x.x"},
+		Dependencies: &Dependencies{
+			Store:  mockStore,
+			Logger: &bot.NilLogger{},
+			Poster: mockPoster,
+			Remote: mockRemote,
+		},
+	}
+
+	subscription := newTestSubscription()
+	subscription.Remote.SyncToken = "sync_token"
+	user := &store.User{
+		Settings:         store.Settings{EventSubscriptionID: "remote_subscription_id"},
+		Remote:           &remote.User{ID: "remote_user_id"},
+		OAuth2Token:      &oauth2.Token{AccessToken: "creator_oauth_token"},
+		MattermostUserID: "creator_mm_id",
+	}
+	polledSub := &remote.Subscription{ID: "remote_subscription_id", CreatorID: "remote_user_id", SyncToken: "next_sync_token"}
+	changes := []*remote.Notification{
+		{SubscriptionID: "remote_subscription_id", ChangeType: remote.ChangeTypeCreated, Event: newTestEvent("1", "", "created")},
+		{SubscriptionID: "remote_subscription_id", ChangeType: remote.ChangeTypeUpdated, Event: newTestEvent("2", "", "updated")},
+	}
+	client := &mockPollerClient{
+		MockClient: mock_remote.NewMockClient(ctrl),
+		poll: func(sub *remote.Subscription) ([]*remote.Notification, *remote.Subscription, error) {
+			require.Equal(t, "sync_token", sub.SyncToken)
+			return changes, polledSub, nil
+		},
+	}
+
+	mockStore.EXPECT().LoadSubscription("remote_subscription_id").Return(subscription, nil).Times(2)
+	mockStore.EXPECT().LoadUser("creator_mm_id").Return(user, nil).Times(1)
+	mockRemote.EXPECT().MakeUserClient(context.Background(), user.OAuth2Token, "creator_mm_id", mockPoster, gomock.Any()).Return(client).Times(1)
+	mockStore.EXPECT().StoreUserSubscription(user, gomock.Any()).DoAndReturn(func(_ *store.User, sub *store.Subscription) error {
+		require.Equal(t, polledSub, sub.Remote)
+		return nil
+	}).Times(1)
+
+	processor := &notificationProcessor{
+		Env:   env,
+		queue: make(chan *remote.Notification, maxQueueSize),
+	}
+	err := processor.processNotification(&remote.Notification{
+		SubscriptionID: "remote_subscription_id",
+		ClientState:    "stored_client_state",
+		ChangeType:     remote.ChangeTypeUpdated,
+		IsBare:         true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, processor.queue, 2)
+	require.Equal(t, changes[0], <-processor.queue)
+	require.Equal(t, changes[1], <-processor.queue)
+}
+
+func TestProcessCancelledNotification(t *testing.T) {
+	tcs := []struct {
+		name         string
+		notification *remote.Notification
+		setup        func(*mock_store.MockStore, *mock_remote.MockClient, *mock_bot.MockPoster)
+	}{
+		{
+			name: "deleted event is looked up by ID and unlinked",
+			notification: &remote.Notification{
+				ChangeType: remote.ChangeTypeDeleted,
+				Event:      &remote.Event{ID: "remote_event_id_1"},
+			},
+			setup: func(mockStore *mock_store.MockStore, mockClient *mock_remote.MockClient, mockPoster *mock_bot.MockPoster) {
+				prior := newTestEvent("1", "event_location_display_name", "event_subject")
+				prior.Start = remote.NewDateTime(time.Date(2030, 5, 2, 14, 0, 0, 0, time.UTC), "UTC")
+				prior.End = remote.NewDateTime(time.Date(2030, 5, 2, 15, 0, 0, 0, time.UTC), "UTC")
+				mockStore.EXPECT().LoadUserEventByRemoteID("creator_mm_id", "remote_event_id_1").Return(&store.Event{Remote: prior}, nil).Times(1)
+				mockClient.EXPECT().GetMailboxSettings("remote_user_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil).Times(1)
+				mockPoster.EXPECT().DMWithAttachments("creator_mm_id", gomock.Any()).DoAndReturn(func(_ string, attachments ...*model.SlackAttachment) (string, error) {
+					require.Equal(t, "(취소됨) event_subject", attachments[0].Title)
+					require.Nil(t, attachments[0].Actions)
+					return "", nil
+				}).Times(1)
+				mockStore.EXPECT().LoadEventMetadata("remote_event_uid_1").Return(&store.EventMetadata{
+					LinkedChannelIDs: map[string]struct{}{"linked_channel_id": {}},
+				}, nil).Times(1)
+				mockPoster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+					require.Equal(t, "linked_channel_id", post.ChannelId)
+					require.Equal(t, "**event_subject** 이벤트가 취소되었습니다.", post.Message)
+					return nil
+				}).Times(1)
+				mockStore.EXPECT().DeleteEventMetadata("remote_event_uid_1").Return(nil).Times(1)
+				mockStore.EXPECT().DeleteUserEvent("creator_mm_id", "remote_event_uid_1").Return(nil).Times(1)
+			},
+		},
+		{
+			name: "cancelled occurrence leaves the links of its series",
+			notification: &remote.Notification{
+				ChangeType: remote.ChangeTypeUpdated,
+				Event: func() *remote.Event {
+					e := newTestEvent("2", "event_location_display_name", "Canceled: event_subject")
+					e.IsCancelled = true
+					e.Type = remote.EventTypeOccurrence
+					e.SeriesMasterID = "series_master_id"
+					e.OriginalStart = "2030-05-02T14:00:00Z"
+					return e
+				}(),
+			},
+			setup: func(mockStore *mock_store.MockStore, mockClient *mock_remote.MockClient, mockPoster *mock_bot.MockPoster) {
+				prior := newTestEvent("2", "event_location_display_name", "event_subject")
+				prior.Type = remote.EventTypeOccurrence
+				prior.SeriesMasterID = "series_master_id"
+				prior.OriginalStart = "2030-05-02T14:00:00Z"
+				mockStore.EXPECT().LoadUserEvent("creator_mm_id", "remote_event_uid_2_20300502T140000Z").Return(&store.Event{Remote: prior}, nil).Times(1)
+				mockClient.EXPECT().GetMailboxSettings("remote_user_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil).Times(1)
+				mockPoster.EXPECT().DMWithAttachments("creator_mm_id", gomock.Any()).Return("", nil).Times(1)
+				mockStore.EXPECT().LoadEventMetadata(gomock.Any()).Return(nil, store.ErrNotFound).Times(3)
+				mockStore.EXPECT().DeleteEventMetadata("remote_event_uid_2_20300502T140000Z").Return(nil).Times(1)
+				mockStore.EXPECT().DeleteUserEvent("creator_mm_id", "remote_event_uid_2_20300502T140000Z").Return(nil).Times(1)
+			},
+		},
+		{
+			name: "unknown deleted event is ignored",
+			notification: &remote.Notification{
+				ChangeType: remote.ChangeTypeDeleted,
+				Event:      &remote.Event{ID: "remote_event_id_3"},
+			},
+			setup: func(mockStore *mock_store.MockStore, _ *mock_remote.MockClient, _ *mock_bot.MockPoster) {
+				mockStore.EXPECT().LoadUserEventByRemoteID("creator_mm_id", "remote_event_id_3").Return(nil, store.ErrNotFound).Times(1)
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mock_store.NewMockStore(ctrl)
+			mockPoster := mock_bot.NewMockPoster(ctrl)
+			mockRemote := mock_remote.NewMockRemote(ctrl)
+			mockClient := mock_remote.NewMockClient(ctrl)
+
+			env := Env{
+				Config: &config.Config{PluginVersion: "x.x.x"},
+				Dependencies: &Dependencies{
+					Store:  mockStore,
+					Logger: &bot.NilLogger{},
+					Poster: mockPoster,
+					Remote: mockRemote,
+				},
+			}
+
+			subscription := newTestSubscription()
+			user := &store.User{
+				Settings:         store.Settings{EventSubscriptionID: "remote_subscription_id"},
+				Remote:           &remote.User{ID: "remote_user_id"},
+				OAuth2Token:      &oauth2.Token{AccessToken: "creator_oauth_token"},
+				MattermostUserID: "creator_mm_id",
+			}
+
+			mockStore.EXPECT().LoadSubscription("remote_subscription_id").Return(subscription, nil).Times(1)
+			mockStore.EXPECT().LoadUser("creator_mm_id").Return(user, nil).Times(1)
+			mockRemote.EXPECT().MakeUserClient(context.Background(), user.OAuth2Token, "creator_mm_id", mockPoster, gomock.Any()).Return(mockClient).Times(1)
+			tc.setup(mockStore, mockClient, mockPoster)
+
+			tc.notification.SubscriptionID = "remote_subscription_id"
+			tc.notification.ClientState = "stored_client_state"
+
+			processor := newTestNotificationProcessor(env).(*notificationProcessor)
+			err := processor.processNotification(tc.notification)
+			require.NoError(t, err)
+		})
+	}
+}
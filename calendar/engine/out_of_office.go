@@ -0,0 +1,247 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type OutOfOffice interface {
+	GetAutomaticReplies(user *User) (*remote.AutomaticRepliesSetting, error)
+	SetAutomaticReplies(user *User, setting *remote.AutomaticRepliesSetting) error
+}
+
+const (
+	outOfOfficeCustomStatusEmoji = "palm_tree"
+	outOfOfficeCustomStatusText  = "부재 중"
+)
+
+// setStatusFromOutOfOffice mirrors the automatic replies of the user into
+// Mattermost: while they are out of office, their custom status says so until
+// the scheduled end, and they are optionally set Away. Their previous statuses
+// are restored once they are back. It returns whether the user is out of
+// office, in which case their status is not to be changed from their calendar.
+func (m *mscalendar) setStatusFromOutOfOffice(user *store.User, status *model.Status) (bool, bool, error) {
+	if !user.IsConfiguredForOutOfOfficeSync() {
+		if user.OutOfOffice == nil {
+			return false, false, nil
+		}
+		// The sync was turned off while the user was out of office.
+		isStatusChanged, err := m.restoreStatusAfterOutOfOffice(user, status)
+		return false, isStatusChanged, err
+	}
+
+	mailboxSettings, err := m.getMailboxSettingsForSync(user)
+	if err != nil {
+		return false, false, errors.Wrap(err, "자동 회신 설정을 가져오는 중 오류 발생")
+	}
+
+	replies := mailboxSettings.AutomaticRepliesSetting
+	if !replies.IsActive(time.Now()) {
+		if user.OutOfOffice == nil {
+			return false, false, nil
+		}
+		isStatusChanged, err := m.restoreStatusAfterOutOfOffice(user, status)
+		return false, isStatusChanged, err
+	}
+
+	until := replies.End()
+	outOfOffice := user.OutOfOffice
+	if outOfOffice != nil && outOfOffice.Until.Equal(until) {
+		return true, false, nil
+	}
+
+	if outOfOffice == nil {
+		outOfOffice = &store.OutOfOffice{}
+
+		currentUser, err := m.PluginAPI.GetMattermostUser(user.MattermostUserID)
+		if err != nil {
+			return true, false, err
+		}
+		// A custom status set from the calendar is not worth restoring.
+		if !user.IsCustomStatusSet {
+			outOfOffice.LastCustomStatus = currentUser.GetCustomStatus()
+		}
+
+		if user.Settings.OutOfOfficeSyncOption == store.OutOfOfficeAwayOption && status.Status != model.StatusAway {
+			switch {
+			case len(user.ActiveEvents) > 0:
+				// The status was set from the calendar, the one from before
+				// the meeting is the one to restore.
+				outOfOffice.LastStatus = user.LastStatus
+			case status.Manual:
+				outOfOffice.LastStatus = status.Status
+			}
+
+			_, err = m.PluginAPI.UpdateMattermostUserStatus(user.MattermostUserID, model.StatusAway)
+			if err != nil {
+				return true, false, err
+			}
+			outOfOffice.SetAway = true
+		}
+	}
+
+	customStatus := &model.CustomStatus{
+		Emoji: outOfOfficeCustomStatusEmoji,
+		Text:  outOfOfficeCustomStatusText,
+	}
+	if !until.IsZero() {
+		customStatus.ExpiresAt = until
+		customStatus.Duration = "date_and_time"
+	}
+	if appErr := m.PluginAPI.UpdateMattermostUserCustomStatus(user.MattermostUserID, customStatus); appErr != nil {
+		return true, false, appErr
+	}
+
+	outOfOffice.Until = until
+	if err = m.Store.StoreUserOutOfOffice(user.MattermostUserID, outOfOffice); err != nil {
+		return true, true, err
+	}
+	if user.IsCustomStatusSet {
+		if err = m.Store.StoreUserCustomStatusUpdates(user.MattermostUserID, false); err != nil {
+			return true, true, err
+		}
+	}
+
+	return true, true, nil
+}
+
+// restoreStatusAfterOutOfOffice restores the statuses the user had before
+// they were out of office, unless they changed them in the meantime.
+func (m *mscalendar) restoreStatusAfterOutOfOffice(user *store.User, status *model.Status) (bool, error) {
+	outOfOffice := user.OutOfOffice
+	isStatusChanged := false
+
+	currentUser, err := m.PluginAPI.GetMattermostUser(user.MattermostUserID)
+	if err != nil {
+		return isStatusChanged, err
+	}
+
+	currentCustomStatus := currentUser.GetCustomStatus()
+	if currentCustomStatus == nil || currentCustomStatus.Text == outOfOfficeCustomStatusText {
+		lastCustomStatus := outOfOffice.LastCustomStatus
+		if lastCustomStatus != nil && (lastCustomStatus.ExpiresAt.IsZero() || lastCustomStatus.ExpiresAt.After(time.Now())) {
+			if appErr := m.PluginAPI.UpdateMattermostUserCustomStatus(user.MattermostUserID, lastCustomStatus); appErr != nil {
+				return isStatusChanged, appErr
+			}
+			isStatusChanged = true
+		} else if currentCustomStatus != nil {
+			if appErr := m.PluginAPI.RemoveMattermostUserCustomStatus(user.MattermostUserID); appErr != nil {
+				return isStatusChanged, appErr
+			}
+			isStatusChanged = true
+		}
+	}
+
+	if outOfOffice.SetAway && status.Status == model.StatusAway {
+		toSet := model.StatusOnline
+		if outOfOffice.LastStatus != "" {
+			toSet = outOfOffice.LastStatus
+		}
+		if _, err = m.PluginAPI.UpdateMattermostUserStatus(user.MattermostUserID, toSet); err != nil {
+			return isStatusChanged, err
+		}
+		isStatusChanged = true
+	}
+
+	return isStatusChanged, m.Store.StoreUserOutOfOffice(user.MattermostUserID, nil)
+}
+
+// getMailboxSettingsForSync uses the client of the sync job if it has one,
+// otherwise the client of the user. Mailbox settings are fetched once per
+// user and sync, and kept in the store for store.MailboxSettingsCacheTTL so
+// that every sync doesn't fetch them again.
+func (m *mscalendar) getMailboxSettingsForSync(user *store.User) (*remote.MailboxSettings, error) {
+	if mailboxSettings, ok := m.syncedMailboxSettings[user.MattermostUserID]; ok {
+		return mailboxSettings, nil
+	}
+
+	mailboxSettings, err := m.Store.LoadMailboxSettings(user.MattermostUserID)
+	if err == nil {
+		if m.syncedMailboxSettings != nil {
+			m.syncedMailboxSettings[user.MattermostUserID] = mailboxSettings
+		}
+		return mailboxSettings, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		m.Logger.With(bot.LogContext{
+			"user": user.MattermostUserID,
+			"err":  err,
+		}).Warnf("저장된 사서함 설정을 불러올 수 없습니다")
+	}
+
+	client := m.client
+	if client == nil {
+		engine, err := m.FilterCopy(withActingUser(user.MattermostUserID), withClient)
+		if err != nil {
+			return nil, err
+		}
+		client = engine.client
+	}
+
+	mailboxSettings, err = client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.Store.StoreMailboxSettings(user.MattermostUserID, mailboxSettings); err != nil {
+		m.Logger.With(bot.LogContext{
+			"user": user.MattermostUserID,
+			"err":  err,
+		}).Warnf("사서함 설정을 저장할 수 없습니다")
+	}
+	if m.syncedMailboxSettings != nil {
+		m.syncedMailboxSettings[user.MattermostUserID] = mailboxSettings
+	}
+	return mailboxSettings, nil
+}
+
+// GetAutomaticReplies returns the automatic replies of the user, disabled if
+// they have never set any.
+func (m *mscalendar) GetAutomaticReplies(user *User) (*remote.AutomaticRepliesSetting, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mailboxSettings, err := m.client.GetMailboxSettings(user.Remote.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mailboxSettings.AutomaticRepliesSetting == nil {
+		return &remote.AutomaticRepliesSetting{Status: remote.AutomaticRepliesStatusDisabled}, nil
+	}
+	return mailboxSettings.AutomaticRepliesSetting, nil
+}
+
+func (m *mscalendar) SetAutomaticReplies(user *User, setting *remote.AutomaticRepliesSetting) error {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return err
+	}
+
+	setter, ok := m.client.(remote.AutomaticRepliesSetter)
+	if !ok {
+		return remote.ErrAutomaticRepliesNotSupported
+	}
+	err = setter.SetAutomaticReplies(user.Remote.ID, setting)
+	if err != nil {
+		return err
+	}
+
+	// The status sync picks up the new automatic replies on its next run.
+	return m.Store.DeleteMailboxSettings(user.MattermostUserID)
+}
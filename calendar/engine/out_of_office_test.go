@@ -0,0 +1,160 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+)
+
+func TestSetStatusFromOutOfOffice(t *testing.T) {
+	replies := &remote.AutomaticRepliesSetting{
+		Status:                 remote.AutomaticRepliesStatusScheduled,
+		ScheduledStartDateTime: remote.NewDateTime(time.Now().Add(-time.Hour).UTC(), "UTC"),
+		ScheduledEndDateTime:   remote.NewDateTime(time.Now().Add(24*time.Hour).UTC(), "UTC"),
+	}
+	until := replies.End()
+	lastCustomStatus := &model.CustomStatus{Emoji: "coffee", Text: "Focusing"}
+
+	for name, tc := range map[string]struct {
+		user                    *store.User
+		status                  *model.Status
+		setup                   func(*mock_store.MockStore, *mock_plugin_api.MockPluginAPI, *mock_remote.MockClient)
+		expectedIsOutOfOffice   bool
+		expectedIsStatusChanged bool
+	}{
+		"not configured": {
+			user:   &store.User{},
+			status: &model.Status{Status: model.StatusOnline},
+			setup:  func(*mock_store.MockStore, *mock_plugin_api.MockPluginAPI, *mock_remote.MockClient) {},
+		},
+		"not out of office": {
+			user:   &store.User{Settings: store.Settings{OutOfOfficeSyncOption: store.OutOfOfficeCustomStatusOption}},
+			status: &model.Status{Status: model.StatusOnline},
+			setup: func(_ *mock_store.MockStore, _ *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{
+					AutomaticRepliesSetting: &remote.AutomaticRepliesSetting{Status: remote.AutomaticRepliesStatusDisabled},
+				}, nil)
+			},
+		},
+		"out of office sets the custom status and Away": {
+			user:   &store.User{Settings: store.Settings{OutOfOfficeSyncOption: store.OutOfOfficeAwayOption}},
+			status: &model.Status{Status: model.StatusDnd, Manual: true},
+			setup: func(s *mock_store.MockStore, papi *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{AutomaticRepliesSetting: replies}, nil)
+				mmUser := &model.User{Id: "user_mm_id"}
+				mmUser.SetCustomStatus(lastCustomStatus)
+				papi.EXPECT().GetMattermostUser("user_mm_id").Return(mmUser, nil)
+				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", model.StatusAway).Return(&model.Status{}, nil)
+				papi.EXPECT().UpdateMattermostUserCustomStatus("user_mm_id", &model.CustomStatus{
+					Emoji:     "palm_tree",
+					Text:      "부재 중",
+					ExpiresAt: until,
+					Duration:  "date_and_time",
+				}).Return(nil)
+				s.EXPECT().StoreUserOutOfOffice("user_mm_id", &store.OutOfOffice{
+					Until:            until,
+					SetAway:          true,
+					LastStatus:       model.StatusDnd,
+					LastCustomStatus: lastCustomStatus,
+				}).Return(nil)
+			},
+			expectedIsOutOfOffice:   true,
+			expectedIsStatusChanged: true,
+		},
+		"still out of office": {
+			user: &store.User{
+				Settings:    store.Settings{OutOfOfficeSyncOption: store.OutOfOfficeCustomStatusOption},
+				OutOfOffice: &store.OutOfOffice{Until: until},
+			},
+			status: &model.Status{Status: model.StatusOnline},
+			setup: func(_ *mock_store.MockStore, _ *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{AutomaticRepliesSetting: replies}, nil)
+			},
+			expectedIsOutOfOffice: true,
+		},
+		"back from out of office restores the previous statuses": {
+			user: &store.User{
+				Settings: store.Settings{OutOfOfficeSyncOption: store.OutOfOfficeAwayOption},
+				OutOfOffice: &store.OutOfOffice{
+					Until:            until,
+					SetAway:          true,
+					LastStatus:       model.StatusDnd,
+					LastCustomStatus: lastCustomStatus,
+				},
+			},
+			status: &model.Status{Status: model.StatusAway},
+			setup: func(s *mock_store.MockStore, papi *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{}, nil)
+				papi.EXPECT().GetMattermostUser("user_mm_id").Return(&model.User{Id: "user_mm_id"}, nil)
+				papi.EXPECT().UpdateMattermostUserCustomStatus("user_mm_id", lastCustomStatus).Return(nil)
+				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", model.StatusDnd).Return(&model.Status{}, nil)
+				s.EXPECT().StoreUserOutOfOffice("user_mm_id", nil).Return(nil)
+			},
+			expectedIsStatusChanged: true,
+		},
+		"sync turned off keeps a custom status set by the user": {
+			user: &store.User{
+				OutOfOffice: &store.OutOfOffice{Until: until},
+			},
+			status: &model.Status{Status: model.StatusOnline},
+			setup: func(s *mock_store.MockStore, papi *mock_plugin_api.MockPluginAPI, _ *mock_remote.MockClient) {
+				mmUser := &model.User{Id: "user_mm_id"}
+				mmUser.SetCustomStatus(lastCustomStatus)
+				papi.EXPECT().GetMattermostUser("user_mm_id").Return(mmUser, nil)
+				s.EXPECT().StoreUserOutOfOffice("user_mm_id", nil).Return(nil)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mscalendar, mockStore, _, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+			tc.user.MattermostUserID = "user_mm_id"
+			tc.user.Remote = &remote.User{ID: "user_remote_id"}
+			mockStore.EXPECT().LoadMailboxSettings("user_mm_id").Return(nil, store.ErrNotFound).AnyTimes()
+			mockStore.EXPECT().StoreMailboxSettings("user_mm_id", gomock.Any()).Return(nil).AnyTimes()
+			tc.setup(mockStore, mockPluginAPI, mockClient)
+
+			isOutOfOffice, isStatusChanged, err := mscalendar.setStatusFromOutOfOffice(tc.user, tc.status)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedIsOutOfOffice, isOutOfOffice)
+			require.Equal(t, tc.expectedIsStatusChanged, isStatusChanged)
+		})
+	}
+}
+
+func TestGetMailboxSettingsForSync(t *testing.T) {
+	user := &store.User{MattermostUserID: "user_mm_id", Remote: &remote.User{ID: "user_remote_id"}}
+	mailboxSettings := &remote.MailboxSettings{TimeZone: "UTC"}
+
+	t.Run("cached settings are not fetched again", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+		mockStore.EXPECT().LoadMailboxSettings("user_mm_id").Return(mailboxSettings, nil)
+		mockClient.EXPECT().GetMailboxSettings(gomock.Any()).Times(0)
+
+		got, err := mscalendar.getMailboxSettingsForSync(user)
+		require.NoError(t, err)
+		require.Equal(t, mailboxSettings, got)
+	})
+
+	t.Run("fetched settings are cached", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+		mockStore.EXPECT().LoadMailboxSettings("user_mm_id").Return(nil, store.ErrNotFound)
+		mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(mailboxSettings, nil)
+		mockStore.EXPECT().StoreMailboxSettings("user_mm_id", mailboxSettings).Return(nil)
+
+		got, err := mscalendar.getMailboxSettingsForSync(user)
+		require.NoError(t, err)
+		require.Equal(t, mailboxSettings, got)
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// ReminderLeadTimeOptions are the lead times, in minutes, users can choose to
+// be reminded of their events at.
+var ReminderLeadTimeOptions = []int{1, 5, 10, 15, 30, 60}
+
+// ReminderSnoozeTime is how long a snoozed reminder is put off.
+const ReminderSnoozeTime = 5 * time.Minute
+
+// maxReminderLeadTime bounds the lead times, including the reminder times of
+// the events, so that the status sync job looks ahead a bounded time.
+const maxReminderLeadTime = time.Hour
+
+// reminderSnoozeRetention is how long after the start of its event a snooze
+// is kept.
+const reminderSnoozeRetention = time.Hour
+
+type Reminders interface {
+	SnoozeReminder(user *User, eventKey string, eventStart time.Time, atStart bool) (time.Time, error)
+	DismissReminders(user *User, eventKey, seriesKey string, eventStart time.Time) error
+	RestoreReminders(user *User, seriesKey string) error
+}
+
+// SnoozeReminder puts the reminder of the event off for ReminderSnoozeTime,
+// or until the event starts, and returns when the user is reminded again.
+func (m *mscalendar) SnoozeReminder(user *User, eventKey string, eventStart time.Time, atStart bool) (time.Time, error) {
+	remindAt := time.Now().Add(ReminderSnoozeTime)
+	if atStart || remindAt.After(eventStart) {
+		remindAt = eventStart
+	}
+
+	err := m.Store.StoreReminderSnooze(user.MattermostUserID, eventKey, &store.ReminderSnooze{
+		RemindAt:  remindAt,
+		ExpiresAt: eventStart.Add(reminderSnoozeRetention),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return remindAt, nil
+}
+
+// DismissReminders turns the reminders of the event off, or of all the events
+// of its series when it is an occurrence of one.
+func (m *mscalendar) DismissReminders(user *User, eventKey, seriesKey string, eventStart time.Time) error {
+	snooze := &store.ReminderSnooze{Dismissed: true}
+	if seriesKey == eventKey {
+		snooze.ExpiresAt = eventStart.Add(reminderSnoozeRetention)
+	}
+	return m.Store.StoreReminderSnooze(user.MattermostUserID, seriesKey, snooze)
+}
+
+// RestoreReminders turns the dismissed reminders of the series back on.
+func (m *mscalendar) RestoreReminders(user *User, seriesKey string) error {
+	return m.Store.StoreReminderSnooze(user.MattermostUserID, seriesKey, nil)
+}
+
+// reminderLeadTimes returns the lead times the user is reminded of the event
+// at.
+func reminderLeadTimes(settings store.ReminderSettings, event *remote.Event) []time.Duration {
+	leadTimes := []time.Duration{}
+	for _, minutes := range settings.LeadTimes {
+		leadTimes = append(leadTimes, time.Duration(minutes)*time.Minute)
+	}
+	if len(leadTimes) == 0 && !settings.UseEventReminder {
+		leadTimes = append(leadTimes, upcomingEventNotificationTime)
+	}
+
+	if settings.UseEventReminder && event.IsReminderOn {
+		leadTime := time.Duration(event.ReminderMinutesBeforeStart) * time.Minute
+		if leadTime > maxReminderLeadTime {
+			leadTime = maxReminderLeadTime
+		}
+		leadTimes = append(leadTimes, leadTime)
+	}
+	return leadTimes
+}
+
+// isReminderDue tells whether the reminder at the lead time of an event
+// starting in until is due in the current run of the status sync job. The
+// window is wider than the interval of the job, to bear its jitter, so each
+// reminder is due in more than one run and is tracked in the store to be sent
+// once.
+func isReminderDue(leadTime, until time.Duration) bool {
+	diff := until - leadTime
+	return until > 0 && diff < upcomingEventNotificationWindow && diff > -upcomingEventNotificationWindow
+}
+
+// dueReminderLeadTime returns the lead time, among leadTimes, of the reminder
+// due for an event starting in until. When several are, the closest one to
+// until is.
+func dueReminderLeadTime(leadTimes []time.Duration, until time.Duration) (time.Duration, bool) {
+	var due time.Duration
+	found := false
+	for _, leadTime := range leadTimes {
+		if !isReminderDue(leadTime, until) {
+			continue
+		}
+		if !found || (until-leadTime).Abs() < (until-due).Abs() {
+			due, found = leadTime, true
+		}
+	}
+	return due, found
+}
+
+// dueUserReminder returns the lead time of the reminder of the event due to
+// the user at now. A snoozed reminder is due from its snooze time on, never
+// before it, up to shortly after the event started, and dismissed reminders
+// never are.
+func dueUserReminder(settings store.ReminderSettings, snoozes store.ReminderSnoozes, event *remote.Event, now time.Time) (time.Duration, bool) {
+	if snooze := snoozes[reminderSeriesKey(event)]; snooze != nil && snooze.Dismissed {
+		return 0, false
+	}
+
+	start := event.Start.Time()
+	if snooze := snoozes[reminderEventKey(event)]; snooze != nil && !snooze.RemindAt.IsZero() {
+		if now.Before(snooze.RemindAt) || !now.Before(start.Add(StatusSyncJobInterval)) {
+			return 0, false
+		}
+		return start.Sub(snooze.RemindAt), true
+	}
+
+	return dueReminderLeadTime(reminderLeadTimes(settings, event), start.Sub(now))
+}
+
+// calendarViewTimeWindow returns how far ahead the status sync job looks at
+// the calendar of the user: far enough for their statuses and reminders.
+func calendarViewTimeWindow(u *store.User) time.Duration {
+	window := calendarViewTimeWindowSize
+	if !u.Settings.ReceiveReminders {
+		return window
+	}
+
+	leadTimes := reminderLeadTimes(u.Settings.Reminders, &remote.Event{})
+	if u.Settings.Reminders.UseEventReminder {
+		leadTimes = append(leadTimes, maxReminderLeadTime)
+	}
+	for _, leadTime := range leadTimes {
+		if leadTime+upcomingEventNotificationWindow > window {
+			window = leadTime + upcomingEventNotificationWindow
+		}
+	}
+	return window
+}
+
+// channelReminderOwner is the owner of the reminders sent to the channel.
+func channelReminderOwner(channelID string) string { return "channel_" + channelID }
+
+// reminderEventKey identifies the occurrence of the event the user is
+// reminded of.
+func reminderEventKey(event *remote.Event) string {
+	key := event.OccurrenceKey()
+	if key == "" {
+		key = event.ID
+	}
+	return key
+}
+
+// reminderSeriesKey identifies the series of the event, or the event when it
+// isn't an occurrence of one.
+func reminderSeriesKey(event *remote.Event) string {
+	if event.SeriesMasterID != "" {
+		return event.SeriesMasterID
+	}
+	return reminderEventKey(event)
+}
+
+// reminderID identifies the reminder of the occurrence of the event at the
+// lead time. A rescheduled event gets new reminders.
+func reminderID(event *remote.Event, leadTime time.Duration) string {
+	return fmt.Sprintf("%s_%d_%d", reminderEventKey(event), event.Start.Time().Unix(), int(leadTime.Minutes()))
+}
+
+// markReminderSent tells whether the reminder of the event at the lead time
+// is to be sent to its owner, a user or a channel, and records it as sent.
+func (m *mscalendar) markReminderSent(ownerID string, event *remote.Event, leadTime time.Duration) bool {
+	created, err := m.Store.MarkReminderSent(ownerID, reminderID(event, leadTime), event.Start.Time())
+	if err != nil {
+		m.Logger.With(bot.LogContext{
+			"ownerID": ownerID,
+			"eventID": event.ID,
+			"err":     err,
+		}).Warnf("보낸 알림을 저장하는 중 오류 발생")
+		return false
+	}
+	return created
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestReminderLeadTimes(t *testing.T) {
+	eventWithReminder := &remote.Event{IsReminderOn: true, ReminderMinutesBeforeStart: 15}
+
+	for _, tt := range []struct {
+		name     string
+		settings store.ReminderSettings
+		event    *remote.Event
+		expected []time.Duration
+	}{
+		{
+			name:     "default lead time",
+			event:    eventWithReminder,
+			expected: []time.Duration{10 * time.Minute},
+		},
+		{
+			name:     "chosen lead times",
+			settings: store.ReminderSettings{LeadTimes: []int{1, 30}},
+			event:    eventWithReminder,
+			expected: []time.Duration{time.Minute, 30 * time.Minute},
+		},
+		{
+			name:     "event reminder only",
+			settings: store.ReminderSettings{UseEventReminder: true},
+			event:    eventWithReminder,
+			expected: []time.Duration{15 * time.Minute},
+		},
+		{
+			name:     "event reminder turned off in the event",
+			settings: store.ReminderSettings{UseEventReminder: true},
+			event:    &remote.Event{ReminderMinutesBeforeStart: 15},
+			expected: []time.Duration{},
+		},
+		{
+			name:     "event reminder beyond the maximum lead time",
+			settings: store.ReminderSettings{LeadTimes: []int{5}, UseEventReminder: true},
+			event:    &remote.Event{IsReminderOn: true, ReminderMinutesBeforeStart: 18 * 60},
+			expected: []time.Duration{5 * time.Minute, maxReminderLeadTime},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, reminderLeadTimes(tt.settings, tt.event))
+		})
+	}
+}
+
+func TestDueReminderLeadTime(t *testing.T) {
+	leadTimes := []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute, 60 * time.Minute}
+
+	for _, tt := range []struct {
+		name        string
+		until       time.Duration
+		expected    time.Duration
+		expectedDue bool
+	}{
+		{name: "no reminder due", until: 35 * time.Minute},
+		{name: "reminder due ahead of its time", until: 64 * time.Minute, expected: 60 * time.Minute, expectedDue: true},
+		{name: "reminder due after its time", until: 11 * time.Minute, expected: 15 * time.Minute, expectedDue: true},
+		{name: "closest reminder due", until: 4 * time.Minute, expected: 5 * time.Minute, expectedDue: true},
+		{name: "event started", until: -time.Minute},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			leadTime, due := dueReminderLeadTime(leadTimes, tt.until)
+			require.Equal(t, tt.expectedDue, due)
+			require.Equal(t, tt.expected, leadTime)
+		})
+	}
+}
+
+func TestCalendarViewTimeWindow(t *testing.T) {
+	require.Equal(t, calendarViewTimeWindowSize, calendarViewTimeWindow(&store.User{}))
+	require.Equal(t, upcomingEventNotificationTime+upcomingEventNotificationWindow, calendarViewTimeWindow(&store.User{
+		Settings: store.Settings{ReceiveReminders: true},
+	}))
+	require.Equal(t, 30*time.Minute+upcomingEventNotificationWindow, calendarViewTimeWindow(&store.User{
+		Settings: store.Settings{ReceiveReminders: true, Reminders: store.ReminderSettings{LeadTimes: []int{1, 30}}},
+	}))
+	require.Equal(t, maxReminderLeadTime+upcomingEventNotificationWindow, calendarViewTimeWindow(&store.User{
+		Settings: store.Settings{ReceiveReminders: true, Reminders: store.ReminderSettings{UseEventReminder: true}},
+	}))
+}
+
+func TestNotifyUpcomingEventsOnce(t *testing.T) {
+	start := time.Now().Add(30 * time.Minute).UTC()
+	event := &remote.Event{
+		ID:      "event_id",
+		ICalUID: "ical_uid",
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+	}
+	user := &store.User{
+		MattermostUserID: "user_mm_id",
+		Remote:           &remote.User{ID: "user_remote_id"},
+		Settings: store.Settings{
+			ReceiveReminders: true,
+			Reminders:        store.ReminderSettings{LeadTimes: []int{5, 30}},
+		},
+	}
+
+	t.Run("reminder is sent", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, _, mockClient, _ := GetMockSetup(t)
+		mockStore.EXPECT().LoadUser("user_mm_id").Return(user, nil)
+		mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+		mockStore.EXPECT().LoadReminderSnoozes("user_mm_id").Return(store.ReminderSnoozes{}, nil)
+		mockStore.EXPECT().MarkReminderSent("user_mm_id", reminderID(event, 30*time.Minute), event.Start.Time()).Return(true, nil)
+		mockPoster.EXPECT().DMWithAttachments("user_mm_id", gomock.Any()).Return("post_id", nil)
+
+		mscalendar.notifyUpcomingEvents(user, []*remote.Event{event})
+	})
+
+	t.Run("reminder already sent by another run", func(t *testing.T) {
+		mscalendar, mockStore, mockPoster, _, _, mockClient, _ := GetMockSetup(t)
+		mockStore.EXPECT().LoadUser("user_mm_id").Return(user, nil)
+		mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+		mockStore.EXPECT().LoadReminderSnoozes("user_mm_id").Return(store.ReminderSnoozes{}, nil)
+		mockStore.EXPECT().MarkReminderSent("user_mm_id", reminderID(event, 30*time.Minute), event.Start.Time()).Return(false, nil)
+		mockPoster.EXPECT().DMWithAttachments(gomock.Any(), gomock.Any()).Times(0)
+
+		mscalendar.notifyUpcomingEvents(user, []*remote.Event{event})
+	})
+}
+
+func TestDueUserReminder(t *testing.T) {
+	now := time.Now()
+	event := &remote.Event{
+		ID:             "event_id",
+		SeriesMasterID: "series_id",
+		Start:          remote.NewDateTime(now.Add(20*time.Minute).UTC(), "UTC"),
+	}
+	eventKey := reminderEventKey(event)
+
+	for _, tt := range []struct {
+		name        string
+		snoozes     store.ReminderSnoozes
+		now         time.Time
+		expected    time.Duration
+		expectedDue bool
+	}{
+		{
+			name:    "no reminder due",
+			snoozes: store.ReminderSnoozes{},
+			now:     now,
+		},
+		{
+			name:        "reminder due at its lead time",
+			snoozes:     store.ReminderSnoozes{},
+			now:         now.Add(10 * time.Minute),
+			expected:    10 * time.Minute,
+			expectedDue: true,
+		},
+		{
+			name:    "series dismissed",
+			snoozes: store.ReminderSnoozes{"series_id": {Dismissed: true}},
+			now:     now.Add(10 * time.Minute),
+		},
+		{
+			name:    "snoozed reminder not due yet",
+			snoozes: store.ReminderSnoozes{eventKey: {RemindAt: now.Add(15 * time.Minute)}},
+			now:     now.Add(10 * time.Minute),
+		},
+		{
+			name:    "snoozed reminder not due shortly before its snooze time",
+			snoozes: store.ReminderSnoozes{eventKey: {RemindAt: now.Add(15 * time.Minute)}},
+			now:     now.Add(13 * time.Minute),
+		},
+		{
+			name:        "snoozed reminder due",
+			snoozes:     store.ReminderSnoozes{eventKey: {RemindAt: now.Add(15 * time.Minute)}},
+			now:         now.Add(15 * time.Minute),
+			expected:    5 * time.Minute,
+			expectedDue: true,
+		},
+		{
+			name:        "reminder snoozed until the start due",
+			snoozes:     store.ReminderSnoozes{eventKey: {RemindAt: event.Start.Time()}},
+			now:         now.Add(20 * time.Minute),
+			expectedDue: true,
+		},
+		{
+			name:    "reminder snoozed until the start not due after the event started",
+			snoozes: store.ReminderSnoozes{eventKey: {RemindAt: event.Start.Time()}},
+			now:     now.Add(25 * time.Minute),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			leadTime, due := dueUserReminder(store.ReminderSettings{}, tt.snoozes, event, tt.now)
+			require.Equal(t, tt.expectedDue, due)
+			require.Equal(t, tt.expected, leadTime)
+		})
+	}
+}
+
+func TestSnoozeReminders(t *testing.T) {
+	start := time.Now().Add(30 * time.Minute)
+	user := NewUser("user_mm_id")
+
+	t.Run("snooze for a few minutes", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		mockStore.EXPECT().StoreReminderSnooze("user_mm_id", "event_key", gomock.Any()).Return(nil)
+
+		remindAt, err := mscalendar.SnoozeReminder(user, "event_key", start, false)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(ReminderSnoozeTime), remindAt, time.Second)
+	})
+
+	t.Run("snooze until the event starts", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		mockStore.EXPECT().StoreReminderSnooze("user_mm_id", "event_key", &store.ReminderSnooze{
+			RemindAt:  start,
+			ExpiresAt: start.Add(reminderSnoozeRetention),
+		}).Return(nil)
+
+		remindAt, err := mscalendar.SnoozeReminder(user, "event_key", start, true)
+		require.NoError(t, err)
+		require.Equal(t, start, remindAt)
+	})
+
+	t.Run("snooze past the start of the event", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		soon := time.Now().Add(time.Minute)
+		mockStore.EXPECT().StoreReminderSnooze("user_mm_id", "event_key", gomock.Any()).Return(nil)
+
+		remindAt, err := mscalendar.SnoozeReminder(user, "event_key", soon, false)
+		require.NoError(t, err)
+		require.Equal(t, soon, remindAt)
+	})
+
+	t.Run("dismiss the reminders of a series", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		mockStore.EXPECT().StoreReminderSnooze("user_mm_id", "series_key", &store.ReminderSnooze{Dismissed: true}).Return(nil)
+
+		require.NoError(t, mscalendar.DismissReminders(user, "event_key", "series_key", start))
+	})
+
+	t.Run("dismiss the reminder of a single event", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		mockStore.EXPECT().StoreReminderSnooze("user_mm_id", "event_key", &store.ReminderSnooze{
+			Dismissed: true,
+			ExpiresAt: start.Add(reminderSnoozeRetention),
+		}).Return(nil)
+
+		require.NoError(t, mscalendar.DismissReminders(user, "event_key", "event_key", start))
+	})
+
+	t.Run("restore the reminders of a series", func(t *testing.T) {
+		mscalendar, mockStore, _, _, _, _, _ := GetMockSetup(t)
+		mockStore.EXPECT().StoreReminderSnooze("user_mm_id", "series_key", nil).Return(nil)
+
+		require.NoError(t, mscalendar.RestoreReminders(user, "series_key"))
+	})
+}
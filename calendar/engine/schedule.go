@@ -0,0 +1,114 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+type Schedules interface {
+	GetSchedules(user *User, mattermostUserIDs []string, start, end time.Time, interval time.Duration) ([]*Schedule, error)
+}
+
+// Schedule is the free/busy availability of a Mattermost user between the
+// requested times, by blocks of the requested interval. Error is set instead
+// when it couldn't be looked up.
+type Schedule struct {
+	MattermostUserID string                  `json:"mm_id"`
+	AvailabilityView remote.AvailabilityView `json:"availability_view,omitempty"`
+	Error            string                  `json:"error,omitempty"`
+}
+
+// GetSchedules looks up the availability of the Mattermost users as seen by
+// the user. Users who are not connected are looked up by their Mattermost
+// email, which is usually their work email.
+func (m *mscalendar) GetSchedules(user *User, mattermostUserIDs []string, start, end time.Time, interval time.Duration) ([]*Schedule, error) {
+	err := m.Filter(
+		withClient,
+		withUserExpanded(user),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := []*Schedule{}
+	mails := map[*Schedule]string{}
+	requests := []*remote.ScheduleUserInfo{}
+	for _, mattermostUserID := range mattermostUserIDs {
+		schedule := &Schedule{MattermostUserID: mattermostUserID}
+		schedules = append(schedules, schedule)
+
+		mail, err := m.getScheduleMail(mattermostUserID)
+		if err != nil {
+			m.Logger.Warnf("사용자 %s의 메일 주소를 찾을 수 없습니다. err=%v", mattermostUserID, err)
+			schedule.Error = "메일 주소를 찾을 수 없습니다"
+			continue
+		}
+		mails[schedule] = mail
+		requests = append(requests, &remote.ScheduleUserInfo{
+			RemoteUserID: user.Remote.ID,
+			Mail:         mail,
+		})
+	}
+	if len(requests) == 0 {
+		return schedules, nil
+	}
+
+	infos, err := m.client.GetSchedule(requests, remote.NewDateTime(start.UTC(), "UTC"), remote.NewDateTime(end.UTC(), "UTC"), int(interval.Minutes()))
+	if err != nil {
+		return nil, err
+	}
+
+	byMail := map[string]*remote.ScheduleInformation{}
+	for _, info := range infos {
+		byMail[strings.ToLower(info.ScheduleID)] = info
+	}
+	for _, schedule := range schedules {
+		mail, ok := mails[schedule]
+		if !ok {
+			continue
+		}
+		info := byMail[strings.ToLower(mail)]
+		switch {
+		case info == nil:
+			schedule.Error = "일정을 가져올 수 없습니다"
+		case info.Error != nil:
+			schedule.Error = info.Error.Message
+		default:
+			schedule.AvailabilityView = info.AvailabilityView
+		}
+	}
+
+	return schedules, nil
+}
+
+func (m *mscalendar) getScheduleMail(mattermostUserID string) (string, error) {
+	storedUser, err := m.Store.LoadUser(mattermostUserID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return "", err
+	}
+	if storedUser != nil && storedUser.Remote != nil {
+		if storedUser.Remote.Mail != "" {
+			return storedUser.Remote.Mail, nil
+		}
+		if storedUser.Remote.UserPrincipalName != "" {
+			return storedUser.Remote.UserPrincipalName, nil
+		}
+	}
+
+	mattermostUser, err := m.PluginAPI.GetMattermostUser(mattermostUserID)
+	if err != nil {
+		return "", err
+	}
+	if mattermostUser.Email == "" {
+		return "", errors.New("no email")
+	}
+	return mattermostUser.Email, nil
+}
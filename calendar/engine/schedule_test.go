@@ -0,0 +1,52 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+)
+
+func TestGetSchedules(t *testing.T) {
+	mscalendar, mockStore, _, _, mockPluginAPI, mockClient, mockLogger := GetMockSetup(t)
+	user := &User{
+		MattermostUserID: "user_mm_id",
+		User:             &store.User{Remote: &remote.User{ID: "user_remote_id"}},
+		MattermostUser:   &model.User{Id: "user_mm_id"},
+	}
+	start := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	// A connected user is looked up by their remote mail, others by their
+	// Mattermost email.
+	mockStore.EXPECT().LoadUser("alice_mm_id").Return(&store.User{Remote: &remote.User{Mail: "Alice@example.com"}}, nil)
+	mockStore.EXPECT().LoadUser("bob_mm_id").Return(nil, store.ErrNotFound)
+	mockPluginAPI.EXPECT().GetMattermostUser("bob_mm_id").Return(&model.User{Email: "bob@example.com"}, nil)
+	mockStore.EXPECT().LoadUser("bot_mm_id").Return(nil, store.ErrNotFound)
+	mockPluginAPI.EXPECT().GetMattermostUser("bot_mm_id").Return(&model.User{}, nil)
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockClient.EXPECT().GetSchedule([]*remote.ScheduleUserInfo{
+		{RemoteUserID: "user_remote_id", Mail: "Alice@example.com"},
+		{RemoteUserID: "user_remote_id", Mail: "bob@example.com"},
+	}, remote.NewDateTime(start, "UTC"), remote.NewDateTime(end, "UTC"), 15).Return([]*remote.ScheduleInformation{
+		{ScheduleID: "alice@example.com", AvailabilityView: "0220"},
+		{ScheduleID: "bob@example.com", Error: &remote.ScheduleInformationError{Message: "user not found"}},
+	}, nil)
+
+	schedules, err := mscalendar.GetSchedules(user, []string{"alice_mm_id", "bob_mm_id", "bot_mm_id"}, start, end, 15*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []*Schedule{
+		{MattermostUserID: "alice_mm_id", AvailabilityView: "0220"},
+		{MattermostUserID: "bob_mm_id", Error: "user not found"},
+		{MattermostUserID: "bot_mm_id", Error: "메일 주소를 찾을 수 없습니다"},
+	}, schedules)
+}
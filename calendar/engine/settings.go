@@ -51,6 +51,27 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		"",
 		settingStore,
 	))
+	if providerFeatures.AutomaticReplies {
+		settings = append(settings, settingspanel.NewOptionSetting(
+			store.OutOfOfficeSyncSettingID,
+			"부재 중 상태",
+			"자동 회신(부재 중)이 켜져 있는 동안 Mattermost에서 \"부재 중\" 사용자 지정 상태를 설정하시겠습니까? 부재 중이 끝나면 이전 상태로 돌아갑니다.",
+			"",
+			store.OutOfOfficeNotSetOption,
+			[]string{store.OutOfOfficeCustomStatusOption, store.OutOfOfficeAwayOption, store.OutOfOfficeNotSetOption},
+			settingStore,
+		))
+		settings = append(settings, NewAutomaticRepliesSetting(getCal))
+	}
+	settings = append(settings, settingspanel.NewOptionSetting(
+		store.WorkingHoursSettingID,
+		"근무 시간",
+		"Outlook에 설정된 근무 시간과 요일 외에는 상태를 바꾸거나 알림을 보내지 않도록 하시겠습니까? 그동안의 DM은 근무 시간이 시작되면 전송됩니다. 근무 시간이 끝날 때 자리 비움으로 설정할 수도 있습니다.",
+		"",
+		store.WorkingHoursNotSetOption,
+		[]string{store.WorkingHoursRespectOption, store.WorkingHoursAwayOption, store.WorkingHoursNotSetOption},
+		settingStore,
+	))
 	settings = append(settings, settingspanel.NewBoolSetting(
 		store.ReceiveRemindersSettingID,
 		"알림 받기",
@@ -58,6 +79,8 @@ func NewSettingsPanel(bot bot.Bot, panelStore settingspanel.PanelStore, settingS
 		"",
 		settingStore,
 	))
+	settings = append(settings, NewReminderLeadTimesSetting(settingStore))
+	settings = append(settings, NewCalendarsSetting(settingStore, getCal))
 	if providerFeatures.EventNotifications {
 		settings = append(settings, NewNotificationsSetting(getCal))
 	}
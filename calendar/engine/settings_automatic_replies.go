@@ -0,0 +1,119 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/views"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/settingspanel"
+)
+
+const automaticRepliesSettingOff = "off"
+
+type automaticRepliesSetting struct {
+	getCal      func(string) Engine
+	title       string
+	description string
+	id          string
+	dependsOn   string
+}
+
+// NewAutomaticRepliesSetting shows the current automatic replies of the user.
+// They are set with the ooo command, and can only be turned off from here.
+func NewAutomaticRepliesSetting(getCal func(string) Engine) settingspanel.Setting {
+	return &automaticRepliesSetting{
+		title:       "자동 회신",
+		description: fmt.Sprintf("%s의 자동 회신(부재 중) 상태입니다. `/%s ooo set`으로 자동 회신을 예약할 수 있습니다.", config.Provider.DisplayName, config.Provider.CommandTrigger),
+		id:          "automatic_replies_setting",
+		dependsOn:   "",
+		getCal:      getCal,
+	}
+}
+
+func (s *automaticRepliesSetting) Set(userID string, value interface{}) error {
+	if value != automaticRepliesSettingOff {
+		return errors.New("자동 회신은 끄기만 할 수 있습니다")
+	}
+
+	return s.getCal(userID).SetAutomaticReplies(NewUser(userID), &remote.AutomaticRepliesSetting{
+		Status: remote.AutomaticRepliesStatusDisabled,
+	})
+}
+
+func (s *automaticRepliesSetting) Get(userID string) (interface{}, error) {
+	return s.getCal(userID).GetAutomaticReplies(NewUser(userID))
+}
+
+func (s *automaticRepliesSetting) GetID() string {
+	return s.id
+}
+
+func (s *automaticRepliesSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *automaticRepliesSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *automaticRepliesSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *automaticRepliesSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("설정: %s", s.title)
+	currentValueMessage := "비활성화됨"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		cal := s.getCal(userID)
+		setting, err := cal.GetAutomaticReplies(NewUser(userID))
+		if err != nil {
+			return nil, err
+		}
+		timezone, err := cal.GetTimezone(NewUser(userID))
+		if err != nil {
+			return nil, err
+		}
+
+		currentValueMessage = fmt.Sprintf("**현재 값:** %s", views.RenderAutomaticReplies(setting, timezone))
+		if setting.InternalReplyMessage != "" && setting.Status != remote.AutomaticRepliesStatusDisabled {
+			currentValueMessage += "\n**메시지:** " + views.MarkdownToHTMLEntities(setting.InternalReplyMessage)
+		}
+
+		if setting.Status != remote.AutomaticRepliesStatusDisabled {
+			actionOff := model.PostAction{
+				Name: "자동 회신 끄기",
+				Integration: &model.PostActionIntegration{
+					URL: settingHandler,
+					Context: map[string]interface{}{
+						settingspanel.ContextIDKey:          s.id,
+						settingspanel.ContextButtonValueKey: automaticRepliesSettingOff,
+					},
+				},
+			}
+			actions = append(actions, &actionOff)
+		}
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *automaticRepliesSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
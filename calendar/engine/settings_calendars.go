@@ -0,0 +1,194 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/settingspanel"
+)
+
+type calendarsSetting struct {
+	store       settingspanel.SettingStore
+	getCal      func(string) Engine
+	title       string
+	description string
+	id          string
+	dependsOn   string
+}
+
+// NewCalendarsSetting lets the user choose the calendars that feed the views,
+// the status sync and the daily summary. Selecting a calendar toggles it, and
+// an empty selection stands for the default calendar.
+func NewCalendarsSetting(inStore settingspanel.SettingStore, getCal func(string) Engine) settingspanel.Setting {
+	return &calendarsSetting{
+		title:       "캘린더",
+		description: "일정 보기, 상태 업데이트 및 일일 요약에 사용할 캘린더를 선택하세요. 캘린더를 다시 선택하면 선택이 해제됩니다.",
+		id:          store.CalendarsSettingID,
+		dependsOn:   "",
+		store:       inStore,
+		getCal:      getCal,
+	}
+}
+
+func (s *calendarsSetting) Set(userID string, value interface{}) error {
+	calendarID, ok := value.(string)
+	if !ok {
+		return errors.New("문자열 값 없이 캘린더 설정을 설정하려고 합니다")
+	}
+	if calendarID == "" {
+		return s.store.SetSetting(userID, s.id, []store.SelectedCalendar{})
+	}
+
+	current, err := s.getSelected(userID)
+	if err != nil {
+		return err
+	}
+
+	selected := []store.SelectedCalendar{}
+	for _, cal := range current {
+		if cal.ID != calendarID {
+			selected = append(selected, cal)
+		}
+	}
+	if len(selected) < len(current) {
+		return s.store.SetSetting(userID, s.id, selected)
+	}
+
+	calendars, err := s.getCal(userID).GetCalendars(NewUser(userID))
+	if err != nil {
+		return err
+	}
+	for _, cal := range calendars {
+		if cal.ID == calendarID {
+			selected = append(selected, store.SelectedCalendar{ID: cal.ID, Name: cal.Name})
+			return s.store.SetSetting(userID, s.id, selected)
+		}
+	}
+
+	return fmt.Errorf("캘린더 %s를 찾을 수 없습니다", calendarID)
+}
+
+func (s *calendarsSetting) Get(userID string) (interface{}, error) {
+	return s.getSelected(userID)
+}
+
+func (s *calendarsSetting) getSelected(userID string) ([]store.SelectedCalendar, error) {
+	value, err := s.store.GetSetting(userID, s.id)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, ok := value.([]store.SelectedCalendar)
+	if !ok {
+		return nil, errors.New("현재 값이 캘린더 목록이 아닙니다")
+	}
+
+	return selected, nil
+}
+
+func (s *calendarsSetting) GetID() string {
+	return s.id
+}
+
+func (s *calendarsSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *calendarsSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *calendarsSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+func (s *calendarsSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("설정: %s", s.title)
+	currentValueMessage := "비활성화됨"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		selected, err := s.getSelected(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		calendars, err := s.getCal(userID).GetCalendars(NewUser(userID))
+		if err != nil {
+			return nil, err
+		}
+
+		isSelected := map[string]bool{}
+		names := []string{}
+		for _, cal := range selected {
+			isSelected[cal.ID] = true
+			names = append(names, cal.Name)
+		}
+
+		currentTextValue := "기본 캘린더"
+		if len(names) > 0 {
+			currentTextValue = strings.Join(names, ", ")
+		}
+		currentValueMessage = fmt.Sprintf("**현재 값:** %s", currentTextValue)
+
+		options := []*model.PostActionOptions{}
+		for _, cal := range calendars {
+			text := cal.Name
+			if isSelected[cal.ID] {
+				text = "✓ " + text
+			}
+			options = append(options, &model.PostActionOptions{
+				Text:  text,
+				Value: cal.ID,
+			})
+		}
+
+		actionOptions := model.PostAction{
+			Name: "캘린더 선택:",
+			Integration: &model.PostActionIntegration{
+				URL: settingHandler,
+				Context: map[string]interface{}{
+					settingspanel.ContextIDKey: s.id,
+				},
+			},
+			Type:    "select",
+			Options: options,
+		}
+		actions = []*model.PostAction{&actionOptions}
+
+		if len(selected) > 0 {
+			actionReset := model.PostAction{
+				Name: "기본 캘린더만 사용",
+				Integration: &model.PostActionIntegration{
+					URL: settingHandler,
+					Context: map[string]interface{}{
+						settingspanel.ContextIDKey:          s.id,
+						settingspanel.ContextButtonValueKey: "",
+					},
+				},
+			}
+			actions = append(actions, &actionReset)
+		}
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *calendarsSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/settingspanel"
+)
+
+// reminderEventValue is the value of the button that toggles the reminder
+// times of the events.
+const reminderEventValue = "event"
+
+type reminderLeadTimesSetting struct {
+	store       settingspanel.SettingStore
+	title       string
+	description string
+	id          string
+	dependsOn   string
+}
+
+// NewReminderLeadTimesSetting lets the user choose when they are reminded of
+// their events. Selecting a lead time toggles it, and no lead times stand for
+// the default one.
+func NewReminderLeadTimesSetting(inStore settingspanel.SettingStore) settingspanel.Setting {
+	return &reminderLeadTimesSetting{
+		title:       "알림 시간",
+		description: fmt.Sprintf("이벤트가 시작되기 몇 분 전에 알림을 받을지 선택하세요. 여러 개를 선택할 수 있으며, 다시 선택하면 선택이 해제됩니다. Outlook에 설정된 각 이벤트의 알림 시간을 사용할 수도 있습니다 (최대 %d분 전).", int(maxReminderLeadTime.Minutes())),
+		id:          store.ReminderLeadTimesSettingID,
+		dependsOn:   store.ReceiveRemindersSettingID,
+		store:       inStore,
+	}
+}
+
+func (s *reminderLeadTimesSetting) Set(userID string, value interface{}) error {
+	stringValue, ok := value.(string)
+	if !ok {
+		return errors.New("문자열 값 없이 알림 시간 설정을 설정하려고 합니다")
+	}
+
+	settings, err := s.getSettings(userID)
+	if err != nil {
+		return err
+	}
+
+	switch stringValue {
+	case "":
+		settings = store.ReminderSettings{}
+	case reminderEventValue:
+		settings.UseEventReminder = !settings.UseEventReminder
+	default:
+		minutes, err := strconv.Atoi(stringValue)
+		if err != nil || !isReminderLeadTimeOption(minutes) {
+			return fmt.Errorf("잘못된 알림 시간입니다: %s", stringValue)
+		}
+		settings.LeadTimes = toggleLeadTime(settings.LeadTimes, minutes)
+	}
+
+	return s.store.SetSetting(userID, s.id, settings)
+}
+
+func isReminderLeadTimeOption(minutes int) bool {
+	for _, option := range ReminderLeadTimeOptions {
+		if option == minutes {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleLeadTime removes the lead time from the sorted lead times, or adds it
+// when it isn't in them.
+func toggleLeadTime(leadTimes []int, minutes int) []int {
+	toggled := []int{}
+	for _, leadTime := range leadTimes {
+		if leadTime != minutes {
+			toggled = append(toggled, leadTime)
+		}
+	}
+	if len(toggled) == len(leadTimes) {
+		toggled = append(toggled, minutes)
+		sort.Ints(toggled)
+	}
+	return toggled
+}
+
+func (s *reminderLeadTimesSetting) Get(userID string) (interface{}, error) {
+	return s.getSettings(userID)
+}
+
+func (s *reminderLeadTimesSetting) getSettings(userID string) (store.ReminderSettings, error) {
+	value, err := s.store.GetSetting(userID, s.id)
+	if err != nil {
+		return store.ReminderSettings{}, err
+	}
+
+	settings, ok := value.(store.ReminderSettings)
+	if !ok {
+		return store.ReminderSettings{}, errors.New("현재 값이 알림 설정이 아닙니다")
+	}
+
+	return settings, nil
+}
+
+func (s *reminderLeadTimesSetting) GetID() string {
+	return s.id
+}
+
+func (s *reminderLeadTimesSetting) GetTitle() string {
+	return s.title
+}
+
+func (s *reminderLeadTimesSetting) GetDescription() string {
+	return s.description
+}
+
+func (s *reminderLeadTimesSetting) GetDependency() string {
+	return s.dependsOn
+}
+
+// reminderSettingsText describes the reminder settings to the user.
+func reminderSettingsText(settings store.ReminderSettings) string {
+	texts := []string{}
+	for _, minutes := range settings.LeadTimes {
+		texts = append(texts, fmt.Sprintf("%d분 전", minutes))
+	}
+	if len(texts) == 0 && !settings.UseEventReminder {
+		texts = append(texts, fmt.Sprintf("%d분 전 (기본값)", int(upcomingEventNotificationTime.Minutes())))
+	}
+	if settings.UseEventReminder {
+		texts = append(texts, "Outlook 알림 시간")
+	}
+	return strings.Join(texts, ", ")
+}
+
+func (s *reminderLeadTimesSetting) GetSlackAttachments(userID, settingHandler string, disabled bool) (*model.SlackAttachment, error) {
+	title := fmt.Sprintf("설정: %s", s.title)
+	currentValueMessage := "비활성화됨"
+
+	actions := []*model.PostAction{}
+	if !disabled {
+		settings, err := s.getSettings(userID)
+		if err != nil {
+			return nil, err
+		}
+		currentValueMessage = fmt.Sprintf("**현재 값:** %s", reminderSettingsText(settings))
+
+		isSelected := map[int]bool{}
+		for _, minutes := range settings.LeadTimes {
+			isSelected[minutes] = true
+		}
+		options := []*model.PostActionOptions{}
+		for _, minutes := range ReminderLeadTimeOptions {
+			text := fmt.Sprintf("%d분 전", minutes)
+			if isSelected[minutes] {
+				text = "✓ " + text
+			}
+			options = append(options, &model.PostActionOptions{
+				Text:  text,
+				Value: strconv.Itoa(minutes),
+			})
+		}
+
+		actionOptions := model.PostAction{
+			Name: "알림 시간 선택:",
+			Integration: &model.PostActionIntegration{
+				URL: settingHandler,
+				Context: map[string]interface{}{
+					settingspanel.ContextIDKey: s.id,
+				},
+			},
+			Type:    "select",
+			Options: options,
+		}
+
+		eventReminderName := "Outlook 알림 시간 사용"
+		if settings.UseEventReminder {
+			eventReminderName = "Outlook 알림 시간 사용 안 함"
+		}
+		actionEventReminder := model.PostAction{
+			Name: eventReminderName,
+			Integration: &model.PostActionIntegration{
+				URL: settingHandler,
+				Context: map[string]interface{}{
+					settingspanel.ContextIDKey:          s.id,
+					settingspanel.ContextButtonValueKey: reminderEventValue,
+				},
+			},
+		}
+		actions = []*model.PostAction{&actionOptions, &actionEventReminder}
+
+		if len(settings.LeadTimes) > 0 || settings.UseEventReminder {
+			actionReset := model.PostAction{
+				Name: "기본값으로 되돌리기",
+				Integration: &model.PostActionIntegration{
+					URL: settingHandler,
+					Context: map[string]interface{}{
+						settingspanel.ContextIDKey:          s.id,
+						settingspanel.ContextButtonValueKey: "",
+					},
+				},
+			}
+			actions = append(actions, &actionReset)
+		}
+	}
+
+	text := fmt.Sprintf("%s\n%s", s.description, currentValueMessage)
+	sa := model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+
+	return &sa, nil
+}
+
+func (s *reminderLeadTimesSetting) IsDisabled(foreignValue interface{}) bool {
+	return foreignValue == "false"
+}
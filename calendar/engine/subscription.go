@@ -16,6 +16,7 @@ import (
 type Subscriptions interface {
 	CreateMyEventSubscription() (*store.Subscription, error)
 	RenewMyEventSubscription() (*store.Subscription, error)
+	PollMyEventSubscription() ([]*remote.Notification, error)
 	DeleteOrphanedSubscription(*store.Subscription) error
 	DeleteMyEventSubscription() error
 	ListRemoteSubscriptions() ([]*remote.Subscription, error)
@@ -103,17 +104,59 @@ func (m *mscalendar) RenewMyEventSubscription() (*store.Subscription, error) {
 		return nil, err
 	}
 
-	storedSub, err := m.Store.LoadSubscription(m.actingUser.Settings.EventSubscriptionID)
+	return storeRenewedSubscription(m.Store, m.actingUser.User, sub, renewed)
+}
+
+// storeRenewedSubscription stores a renewed subscription. When the remote
+// kept the subscription, only its expiry is stored, atomically, so that the
+// sync state stored by a concurrent poll isn't overwritten with the one it
+// had when the renewal started.
+func storeRenewedSubscription(subscriptions store.SubscriptionStore, user *store.User, sub *store.Subscription, renewed *remote.Subscription) (*store.Subscription, error) {
+	if renewed.ID == sub.Remote.ID {
+		return subscriptions.StoreSubscriptionExpiration(renewed.ID, renewed.ExpirationDateTime)
+	}
+
+	sub.Remote = renewed
+	if sub.ChannelID != "" {
+		return sub, subscriptions.StoreChannelSubscription(sub)
+	}
+	return sub, subscriptions.StoreUserSubscription(user, sub)
+}
+
+// PollMyEventSubscription fetches the changes of the acting user's calendar
+// from remotes that don't push notifications, and stores the new sync state.
+func (m *mscalendar) PollMyEventSubscription() ([]*remote.Notification, error) {
+	err := m.Filter(withClient)
+	if err != nil {
+		return nil, fmt.Errorf("PollMyEventSubscription에서 withClient 오류: %w", err)
+	}
+
+	subscriptionID := m.actingUser.Settings.EventSubscriptionID
+	if subscriptionID == "" {
+		return nil, nil
+	}
+
+	poller, ok := m.client.(remote.Poller)
+	if !ok {
+		return nil, remote.ErrNotImplemented
+	}
+
+	storedSub, err := m.Store.LoadSubscription(subscriptionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "구독 로드 오류")
+	}
+
+	notifications, polled, err := poller.PollSubscription(storedSub.Remote)
 	if err != nil {
 		return nil, err
 	}
-	storedSub.Remote = renewed
 
+	storedSub.Remote = polled
 	err = m.Store.StoreUserSubscription(m.actingUser.User, storedSub)
 	if err != nil {
 		return nil, err
 	}
-	return storedSub, err
+	return notifications, nil
 }
 
 func (m *mscalendar) DeleteMyEventSubscription() error {
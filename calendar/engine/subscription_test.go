@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -254,13 +255,29 @@ func TestRenewMyEventSubscription(t *testing.T) {
 				mscalendar.client = mockClient
 				mscalendar.actingUser = GetMockUser(model.NewPointer(MockActingUserRemoteID), nil, MockActingUserID, nil)
 				mscalendar.actingUser.Settings.EventSubscriptionID = MockEventSubscriptionID
-				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(&store.Subscription{Remote: &remote.Subscription{}}, nil).Times(2)
-				mockClient.EXPECT().RenewSubscription(gomock.Any(), MockActingUserRemoteID, &remote.Subscription{}).Return(&remote.Subscription{}, nil).Times(1)
-				mockStore.EXPECT().StoreUserSubscription(gomock.Any(), gomock.Any()).Return(nil)
+				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(&store.Subscription{Remote: &remote.Subscription{ID: "remote_subscription_id", SyncToken: "token1"}}, nil).Times(1)
+				mockClient.EXPECT().RenewSubscription(gomock.Any(), MockActingUserRemoteID, gomock.Any()).Return(&remote.Subscription{ID: "remote_subscription_id", ExpirationDateTime: "2030-01-02T00:00:00Z", SyncToken: "token1"}, nil).Times(1)
+				// A poll stored a newer sync token meanwhile, only the expiry is stored.
+				mockStore.EXPECT().StoreSubscriptionExpiration("remote_subscription_id", "2030-01-02T00:00:00Z").Return(&store.Subscription{Remote: &remote.Subscription{ID: "remote_subscription_id", ExpirationDateTime: "2030-01-02T00:00:00Z", SyncToken: "token2"}}, nil).Times(1)
 			},
 			assertion: func(subs *store.Subscription, err error) {
 				require.NoError(t, err)
-				require.Equal(t, &store.Subscription{Remote: &remote.Subscription{}}, subs)
+				require.Equal(t, "token2", subs.Remote.SyncToken)
+			},
+		},
+		{
+			name: "successfully renew a subscription replaced by the remote",
+			setupMock: func() {
+				mscalendar.client = mockClient
+				mscalendar.actingUser = GetMockUser(model.NewPointer(MockActingUserRemoteID), nil, MockActingUserID, nil)
+				mscalendar.actingUser.Settings.EventSubscriptionID = MockEventSubscriptionID
+				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(&store.Subscription{Remote: &remote.Subscription{ID: "remote_subscription_id"}}, nil).Times(1)
+				mockClient.EXPECT().RenewSubscription(gomock.Any(), MockActingUserRemoteID, gomock.Any()).Return(&remote.Subscription{ID: "new_subscription_id"}, nil).Times(1)
+				mockStore.EXPECT().StoreUserSubscription(gomock.Any(), &store.Subscription{Remote: &remote.Subscription{ID: "new_subscription_id"}}).Return(nil).Times(1)
+			},
+			assertion: func(subs *store.Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "new_subscription_id", subs.Remote.ID)
 			},
 		},
 	}
@@ -422,3 +439,93 @@ func TestDeleteOrphanedSubscription(t *testing.T) {
 		})
 	}
 }
+
+type mockPollerClient struct {
+	*mock_remote.MockClient
+	poll func(sub *remote.Subscription) ([]*remote.Notification, *remote.Subscription, error)
+}
+
+func (c *mockPollerClient) PollSubscription(sub *remote.Subscription) ([]*remote.Notification, *remote.Subscription, error) {
+	return c.poll(sub)
+}
+
+func TestPollMyEventSubscription(t *testing.T) {
+	mscalendar, mockStore, _, _, _, mockClient, _ := GetMockSetup(t)
+	polledSub := &remote.Subscription{ID: MockEventSubscriptionID, SyncToken: "token2"}
+	notifications := []*remote.Notification{{SubscriptionID: MockEventSubscriptionID}}
+
+	tests := []struct {
+		name      string
+		setupMock func()
+		assertion func(notifications []*remote.Notification, err error)
+	}{
+		{
+			name: "no subscription",
+			setupMock: func() {
+				mscalendar.client = mockClient
+				mscalendar.actingUser = GetMockUser(model.NewPointer(MockActingUserRemoteID), nil, MockActingUserID, nil)
+			},
+			assertion: func(notifications []*remote.Notification, err error) {
+				require.NoError(t, err)
+				require.Nil(t, notifications)
+			},
+		},
+		{
+			name: "remote does not support polling",
+			setupMock: func() {
+				mscalendar.client = mockClient
+				mscalendar.actingUser = GetMockUser(model.NewPointer(MockActingUserRemoteID), nil, MockActingUserID, &store.Settings{EventSubscriptionID: MockEventSubscriptionID})
+			},
+			assertion: func(notifications []*remote.Notification, err error) {
+				require.Equal(t, remote.ErrNotImplemented, err)
+			},
+		},
+		{
+			name: "error polling the subscription",
+			setupMock: func() {
+				mscalendar.client = &mockPollerClient{
+					MockClient: mockClient,
+					poll: func(*remote.Subscription) ([]*remote.Notification, *remote.Subscription, error) {
+						return nil, nil, errors.New("error polling the subscription")
+					},
+				}
+				mscalendar.actingUser = GetMockUser(model.NewPointer(MockActingUserRemoteID), nil, MockActingUserID, &store.Settings{EventSubscriptionID: MockEventSubscriptionID})
+				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(GetMockSubscription(), nil).Times(1)
+			},
+			assertion: func(notifications []*remote.Notification, err error) {
+				require.EqualError(t, err, "error polling the subscription")
+			},
+		},
+		{
+			name: "subscription polled successfully",
+			setupMock: func() {
+				mscalendar.client = &mockPollerClient{
+					MockClient: mockClient,
+					poll: func(*remote.Subscription) ([]*remote.Notification, *remote.Subscription, error) {
+						return notifications, polledSub, nil
+					},
+				}
+				mscalendar.actingUser = GetMockUser(model.NewPointer(MockActingUserRemoteID), nil, MockActingUserID, &store.Settings{EventSubscriptionID: MockEventSubscriptionID})
+				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(GetMockSubscription(), nil).Times(1)
+				mockStore.EXPECT().StoreUserSubscription(mscalendar.actingUser.User, &store.Subscription{
+					Remote:              polledSub,
+					MattermostCreatorID: MockActingUserID,
+					PluginVersion:       "1.0.0",
+				}).Return(nil).Times(1)
+			},
+			assertion: func(polled []*remote.Notification, err error) {
+				require.NoError(t, err)
+				require.Equal(t, notifications, polled)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			polled, err := mscalendar.PollMyEventSubscription()
+
+			tt.assertion(polled, err)
+		})
+	}
+}
@@ -22,6 +22,7 @@ type Users interface {
 	GetRemoteUser(mattermostUserID string) (*remote.User, error)
 	IsAuthorizedAdmin(mattermostUserID string) (bool, error)
 	GetUserSettings(user *User) (*store.Settings, error)
+	GetMattermostUserByUsername(mattermostUsername string) (*model.User, error)
 }
 
 type User struct {
@@ -103,6 +104,10 @@ func (m *mscalendar) GetTimezone(user *User) (string, error) {
 	return settings.TimeZone, nil
 }
 
+func (m *mscalendar) GetMattermostUserByUsername(mattermostUsername string) (*model.User, error) {
+	return m.PluginAPI.GetMattermostUserByUsername(mattermostUsername)
+}
+
 func (m *mscalendar) GetTimezoneByID(mattermostUserID string) (string, error) {
 	return m.GetTimezone(NewUser(mattermostUserID))
 }
@@ -174,6 +179,8 @@ func (m *mscalendar) DisconnectUser(mattermostUserID string) error {
 		}
 	}
 
+	m.disconnectUserChannelCalendars(mattermostUserID, m.client)
+
 	err = m.Store.DeleteUser(mattermostUserID)
 	if err != nil {
 		return err
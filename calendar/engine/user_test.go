@@ -388,6 +388,7 @@ func TestDisconnectUser(t *testing.T) {
 				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(&store.Subscription{Remote: &remote.Subscription{}}, nil).Times(1)
 				mockStore.EXPECT().DeleteUserSubscription(gomock.Any(), MockEventSubscriptionID).Return(nil).Times(1)
 				mockClient.EXPECT().DeleteSubscription(gomock.Any()).Return(nil).Times(1)
+				mockStore.EXPECT().LoadChannelCalendarIndex().Return(nil, store.ErrNotFound).Times(1)
 				mockStore.EXPECT().DeleteUser(MockMMUserID).Return(errors.New("error deleting user")).Times(1)
 			},
 			assertions: func(err error) {
@@ -405,6 +406,7 @@ func TestDisconnectUser(t *testing.T) {
 				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(&store.Subscription{Remote: &remote.Subscription{}}, nil).Times(1)
 				mockStore.EXPECT().DeleteUserSubscription(gomock.Any(), MockEventSubscriptionID).Return(nil).Times(1)
 				mockClient.EXPECT().DeleteSubscription(gomock.Any()).Return(nil).Times(1)
+				mockStore.EXPECT().LoadChannelCalendarIndex().Return(nil, store.ErrNotFound).Times(1)
 				mockStore.EXPECT().DeleteUser(MockMMUserID).Return(nil).Times(1)
 				mockStore.EXPECT().DeleteUserFromIndex(MockMMUserID).Return(errors.New("error deleting user from index")).Times(1)
 			},
@@ -423,6 +425,7 @@ func TestDisconnectUser(t *testing.T) {
 				mockStore.EXPECT().LoadSubscription(MockEventSubscriptionID).Return(&store.Subscription{Remote: &remote.Subscription{}}, nil).Times(1)
 				mockStore.EXPECT().DeleteUserSubscription(gomock.Any(), MockEventSubscriptionID).Return(nil).Times(1)
 				mockClient.EXPECT().DeleteSubscription(gomock.Any()).Return(nil).Times(1)
+				mockStore.EXPECT().LoadChannelCalendarIndex().Return(nil, store.ErrNotFound).Times(1)
 				mockStore.EXPECT().DeleteUser(MockMMUserID).Return(nil).Times(1)
 				mockStore.EXPECT().DeleteUserFromIndex(MockMMUserID).Return(nil).Times(1)
 			},
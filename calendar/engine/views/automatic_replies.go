@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const automaticRepliesTimeFormat = "2006-01-02 15:04"
+
+// RenderAutomaticReplies describes the state of the automatic replies, e.g.
+// "예약됨 (2024-07-01 09:00 - 2024-07-10 00:00)", with times in timezone.
+func RenderAutomaticReplies(s *remote.AutomaticRepliesSetting, timezone string) string {
+	if s == nil {
+		return "꺼짐"
+	}
+
+	switch s.Status {
+	case remote.AutomaticRepliesStatusAlwaysEnabled:
+		return "켜짐"
+	case remote.AutomaticRepliesStatusScheduled:
+		if s.ScheduledStartDateTime == nil || s.ScheduledEndDateTime == nil {
+			return "예약됨"
+		}
+		return fmt.Sprintf("예약됨 (%s - %s)",
+			s.ScheduledStartDateTime.In(timezone).Time().Format(automaticRepliesTimeFormat),
+			s.ScheduledEndDateTime.In(timezone).Time().Format(automaticRepliesTimeFormat),
+		)
+	}
+	return "꺼짐"
+}
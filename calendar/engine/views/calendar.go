@@ -58,15 +58,26 @@ func RenderCalendarView(events []*remote.Event, timeZone string) (string, error)
 		return events[i].Start.Time().Before(events[j].Start.Time())
 	})
 
+	// Events aggregated from several calendars are labelled with their calendar.
+	showCalendar := false
+	for _, e := range events {
+		if e.CalendarName != "" {
+			showCalendar = true
+		}
+	}
+
 	resp := "시간은 " + events[0].Start.TimeZone + "로 표시됩니다"
 	for _, group := range groupEventsByDate(events) {
 		resp += "\n" + group[0].Start.Time().Format("Monday June 02, 2025") + "\n\n"
-		resp += renderTableHeader()
+		resp += renderTableHeader(showCalendar)
 		for _, e := range group {
 			eventString, err := renderEvent(e, true, timeZone)
 			if err != nil {
 				return "", err
 			}
+			if showCalendar {
+				eventString += fmt.Sprintf(" %s |", MarkdownToHTMLEntities(e.CalendarName))
+			}
 			resp += fmt.Sprintf("\n%s", eventString)
 		}
 	}
@@ -100,6 +111,24 @@ func RenderDaySummary(events []*remote.Event, timezone string) (string, []*model
 				Short: true,
 			})
 		}
+		if event.CalendarName != "" {
+			fields = append(fields, &model.SlackAttachmentField{
+				Title: "캘린더",
+				Value: event.CalendarName,
+				Short: true,
+			})
+		}
+		if event.Conference != nil && event.Conference.URL != "" {
+			title := "회의 URL"
+			if event.Conference.Application != "" {
+				title = event.Conference.Application
+			}
+			fields = append(fields, &model.SlackAttachmentField{
+				Title: title,
+				Value: event.Conference.URL,
+				Short: true,
+			})
+		}
 
 		attachments = append(attachments, &model.SlackAttachment{
 			Title: event.Subject,
@@ -113,7 +142,11 @@ func RenderDaySummary(events []*remote.Event, timezone string) (string, []*model
 	return message, attachments, nil
 }
 
-func renderTableHeader() string {
+func renderTableHeader(showCalendar bool) string {
+	if showCalendar {
+		return `| 시간 | 제목 | 캘린더 |
+| :-- | :-- | :-- |`
+	}
 	return `| 시간 | 제목 |
 | :-- | :-- |`
 }
@@ -160,25 +193,29 @@ func renderEvent(event *remote.Event, asRow bool, timeZone string) (string, erro
 	}
 
 	subject := EnsureSubject(event.Subject)
+	title := fmt.Sprintf("[%s](%s)", MarkdownToHTMLEntities(subject), link)
+	if event.Conference != nil && event.Conference.URL != "" {
+		title += fmt.Sprintf(" · [회의 참가](%s)", event.Conference.URL)
+	}
 
 	if event.IsAllDay {
-		format := "(종일 이벤트) [%s](%s)"
+		format := "(종일 이벤트) %s"
 		if asRow {
-			format = "| 종일 이벤트 | [%s](%s) |"
+			format = "| 종일 이벤트 | %s |"
 		}
 
-		return fmt.Sprintf(format, MarkdownToHTMLEntities(subject), link), nil
+		return fmt.Sprintf(format, title), nil
 	}
 
 	start := event.Start.In(timeZone).Time().Format(time.Kitchen)
 	end := event.End.In(timeZone).Time().Format(time.Kitchen)
 
-	format := "(%s - %s) [%s](%s)"
+	format := "(%s - %s) %s"
 	if asRow {
-		format = "| %s - %s | [%s](%s) |"
+		format = "| %s - %s | %s |"
 	}
 
-	return fmt.Sprintf(format, start, end, MarkdownToHTMLEntities(subject), link), nil
+	return fmt.Sprintf(format, start, end, title), nil
 }
 
 func RenderEventAsAttachment(event *remote.Event, timezone string, options ...Option) (*model.SlackAttachment, error) {
@@ -194,6 +231,22 @@ func RenderEventAsAttachment(event *remote.Event, timezone string, options ...Op
 		})
 	}
 
+	if event.Recurrence != nil {
+		fields = append(fields, &model.SlackAttachmentField{
+			Title: "반복",
+			Value: RenderRecurrence(event.Recurrence),
+			Short: true,
+		})
+	}
+
+	if event.CalendarName != "" {
+		fields = append(fields, &model.SlackAttachmentField{
+			Title: "캘린더",
+			Value: event.CalendarName,
+			Short: true,
+		})
+	}
+
 	if event.Conference != nil {
 		// Use conference URL as title link if there's conference data present
 		titleLink = event.Conference.URL
@@ -5,8 +5,11 @@ package views
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 )
 
 func TestMarkdownToHTMLEntities(t *testing.T) {
@@ -72,3 +75,89 @@ func TestMarkdownToHTMLEntities(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderRecurrence(t *testing.T) {
+	for _, testCase := range []struct {
+		description    string
+		recurrence     *remote.PatternedRecurrence
+		expectedOutput string
+	}{
+		{
+			description: "daily",
+			recurrence: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternDaily, Interval: 1},
+			},
+			expectedOutput: "매일",
+		},
+		{
+			description: "every other week with count",
+			recurrence: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternWeekly, Interval: 2, DaysOfWeek: []string{"monday", "thursday"}},
+				Range:   &remote.RecurrenceRange{Type: remote.RecurrenceRangeNumbered, NumberOfOccurrences: 10},
+			},
+			expectedOutput: "2주마다 월, 목 (10회)",
+		},
+		{
+			description: "last friday of the month until a date",
+			recurrence: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternRelativeMonthly, Interval: 1, DaysOfWeek: []string{"friday"}, Index: "last"},
+				Range:   &remote.RecurrenceRange{Type: remote.RecurrenceRangeEndDate, EndDate: "2024-12-31"},
+			},
+			expectedOutput: "매월 마지막 금요일 (2024-12-31까지)",
+		},
+		{
+			description: "yearly",
+			recurrence: &remote.PatternedRecurrence{
+				Pattern: &remote.RecurrencePattern{Type: remote.RecurrencePatternAbsoluteYearly, Interval: 1, Month: 5, DayOfMonth: 2},
+				Range:   &remote.RecurrenceRange{Type: remote.RecurrenceRangeNoEnd},
+			},
+			expectedOutput: "매년 5월 2일",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			require.Equal(t, testCase.expectedOutput, RenderRecurrence(testCase.recurrence))
+		})
+	}
+}
+
+func TestRenderCalendarViewLabelsCalendars(t *testing.T) {
+	start := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	events := []*remote.Event{
+		{
+			Subject:      "Standup",
+			Start:        remote.NewDateTime(start, "UTC"),
+			End:          remote.NewDateTime(start.Add(15*time.Minute), "UTC"),
+			CalendarName: "Team",
+		},
+		{
+			Subject: "Dentist",
+			Start:   remote.NewDateTime(start.Add(2*time.Hour), "UTC"),
+			End:     remote.NewDateTime(start.Add(3*time.Hour), "UTC"),
+		},
+	}
+
+	out, err := RenderCalendarView(events, "UTC")
+	require.NoError(t, err)
+	require.Contains(t, out, "| 시간 | 제목 | 캘린더 |")
+	require.Contains(t, out, "| 9:00AM - 9:15AM | [Standup]() | Team |")
+	require.Contains(t, out, "| 11:00AM - 12:00PM | [Dentist]() |  |")
+
+	events[0].CalendarName = ""
+	out, err = RenderCalendarView(events, "UTC")
+	require.NoError(t, err)
+	require.NotContains(t, out, "캘린더")
+}
+
+func TestRenderCalendarViewJoinLink(t *testing.T) {
+	start := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	events := []*remote.Event{{
+		Subject:    "Design review",
+		Start:      remote.NewDateTime(start, "UTC"),
+		End:        remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		Conference: &remote.Conference{Application: "Microsoft Teams", URL: "https://teams.microsoft.com/l/meetup-join/1"},
+	}}
+
+	out, err := RenderCalendarView(events, "UTC")
+	require.NoError(t, err)
+	require.Contains(t, out, "| 9:00AM - 10:00AM | [Design review]() · [회의 참가](https://teams.microsoft.com/l/meetup-join/1) |")
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// CreateEventDialogCallbackID identifies submissions of the dialog rendered
+// by RenderCreateEventDialog.
+const CreateEventDialogCallbackID = "create_event"
+
+// CreateEventDefaults are the values the create event dialog is filled with.
+// Attendees are usernames or mail addresses separated by spaces.
+type CreateEventDefaults struct {
+	Subject     string
+	Description string
+	Attendees   string
+	ChannelID   string
+}
+
+// RenderCreateEventDialog renders the dialog to create an event. The names of
+// the elements are the fields of the event creation payload; the event starts
+// at the next full hour by default. The online meeting option is only shown
+// when the provider supports online meetings.
+func RenderCreateEventDialog(now time.Time, timezone string, defaults CreateEventDefaults, onlineMeetings bool) model.Dialog {
+	start := now.Truncate(time.Hour).Add(time.Hour)
+
+	elements := []model.DialogElement{
+		{
+			DisplayName: "제목",
+			Name:        "subject",
+			Type:        "text",
+			Default:     defaults.Subject,
+			MaxLength:   255,
+		},
+		{
+			DisplayName: "날짜",
+			Name:        "date",
+			Type:        "text",
+			Default:     start.Format("2006-01-02"),
+			Placeholder: "YYYY-MM-DD",
+		},
+		{
+			DisplayName: "시작 시간",
+			Name:        "start_time",
+			Type:        "text",
+			Default:     start.Format("15:04"),
+			Placeholder: "HH:MM",
+		},
+		{
+			DisplayName: "종료 시간",
+			Name:        "end_time",
+			Type:        "text",
+			Default:     start.Add(30 * time.Minute).Format("15:04"),
+			Placeholder: "HH:MM",
+		},
+		{
+			DisplayName: "참석자",
+			Name:        "attendees",
+			Type:        "text",
+			Default:     defaults.Attendees,
+			Placeholder: "@alice @bob carol@example.com",
+			HelpText:    "Mattermost 사용자 이름이나 메일 주소를 공백으로 구분해 입력하세요.",
+			Optional:    true,
+		},
+		{
+			DisplayName: "장소",
+			Name:        "location",
+			Type:        "text",
+			Optional:    true,
+		},
+	}
+	if onlineMeetings {
+		elements = append(elements, model.DialogElement{
+			DisplayName: "온라인 회의",
+			Name:        "online_meeting",
+			Type:        "bool",
+			Placeholder: "Teams 회의 추가",
+			Optional:    true,
+		})
+	}
+	elements = append(elements, []model.DialogElement{
+		{
+			DisplayName: "설명",
+			Name:        "description",
+			Type:        "textarea",
+			Default:     defaults.Description,
+			Optional:    true,
+		},
+		{
+			DisplayName: "채널",
+			Name:        "channel_id",
+			Type:        "select",
+			DataSource:  "channels",
+			Default:     defaults.ChannelID,
+			HelpText:    "일정을 채널에 연결하고 채널에 알립니다.",
+			Optional:    true,
+		},
+	}...)
+
+	return model.Dialog{
+		CallbackId:       CreateEventDialogCallbackID,
+		Title:            "새 일정",
+		IntroductionText: fmt.Sprintf("시간은 %s 기준입니다.", timezone),
+		SubmitLabel:      "만들기",
+		Elements:         elements,
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCreateEventDialog(t *testing.T) {
+	now := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	elementNames := func(onlineMeetings bool) []string {
+		names := []string{}
+		for _, element := range RenderCreateEventDialog(now, "UTC", CreateEventDefaults{}, onlineMeetings).Elements {
+			names = append(names, element.Name)
+		}
+		return names
+	}
+
+	require.NotContains(t, elementNames(false), "online_meeting")
+	require.Equal(t, []string{"subject", "date", "start_time", "end_time", "attendees", "location", "online_meeting", "description", "channel_id"}, elementNames(true))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const meetingTimeSuggestionFormat = "Mon 2006-01-02 15:04"
+
+// RenderMeetingTimeSuggestions renders the suggestions as buttons that create
+// the meeting at the suggested time. The context is shared by the buttons,
+// which add the start and the end of their suggestion to it.
+func RenderMeetingTimeSuggestions(subject string, suggestions []*remote.MeetingTimeSuggestion, timezone, url string, context map[string]interface{}) *model.SlackAttachment {
+	loc, err := time.LoadLocation(tz.Go(timezone))
+	if err != nil || tz.Go(timezone) == "" {
+		loc = time.UTC
+	}
+
+	actions := []*model.PostAction{}
+	for _, s := range suggestions {
+		if s.MeetingTimeSlot == nil || s.MeetingTimeSlot.Start == nil || s.MeetingTimeSlot.End == nil {
+			continue
+		}
+		start := s.MeetingTimeSlot.Start.Time().In(loc)
+		end := s.MeetingTimeSlot.End.Time().In(loc)
+
+		actionContext := map[string]interface{}{
+			"start": start.Format(time.RFC3339),
+			"end":   end.Format(time.RFC3339),
+		}
+		for k, v := range context {
+			actionContext[k] = v
+		}
+
+		actions = append(actions, &model.PostAction{
+			Name: fmt.Sprintf("%s - %s", start.Format(meetingTimeSuggestionFormat), end.Format("15:04")),
+			Integration: &model.PostActionIntegration{
+				URL:     url,
+				Context: actionContext,
+			},
+		})
+	}
+
+	title := "회의 시간 제안"
+	text := fmt.Sprintf("**%s**에 모든 참석자가 참석할 수 있는 시간입니다. 시간을 선택하면 이벤트가 만들어집니다.\n시간은 %s로 표시됩니다.", subject, timezone)
+	return &model.SlackAttachment{
+		Title:    title,
+		Text:     text,
+		Actions:  actions,
+		Fallback: fmt.Sprintf("%s: %s", title, text),
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+var weekdayNames = map[string]string{
+	"sunday":    "일",
+	"monday":    "월",
+	"tuesday":   "화",
+	"wednesday": "수",
+	"thursday":  "목",
+	"friday":    "금",
+	"saturday":  "토",
+}
+
+var weekIndexNames = map[string]string{
+	"first":  "첫째",
+	"second": "둘째",
+	"third":  "셋째",
+	"fourth": "넷째",
+	"last":   "마지막",
+}
+
+// RenderRecurrence describes the recurrence of an event, e.g. "2주마다 월, 목 (10회)".
+func RenderRecurrence(r *remote.PatternedRecurrence) string {
+	if r == nil || r.Pattern == nil {
+		return ""
+	}
+	p := r.Pattern
+
+	every := func(unit, single string) string {
+		if p.Interval > 1 {
+			return fmt.Sprintf("%d%s마다", p.Interval, unit)
+		}
+		return single
+	}
+
+	days := []string{}
+	for _, d := range p.DaysOfWeek {
+		if name, ok := weekdayNames[strings.ToLower(d)]; ok {
+			days = append(days, name)
+		}
+	}
+	index := weekIndexNames[p.Index]
+	if index == "" {
+		index = weekIndexNames["first"]
+	}
+
+	var out string
+	switch p.Type {
+	case remote.RecurrencePatternDaily:
+		out = every("일", "매일")
+	case remote.RecurrencePatternWeekly:
+		out = every("주", "매주") + " " + strings.Join(days, ", ")
+	case remote.RecurrencePatternAbsoluteMonthly:
+		out = fmt.Sprintf("%s %d일", every("개월", "매월"), p.DayOfMonth)
+	case remote.RecurrencePatternRelativeMonthly:
+		out = fmt.Sprintf("%s %s %s요일", every("개월", "매월"), index, strings.Join(days, ", "))
+	case remote.RecurrencePatternAbsoluteYearly:
+		out = fmt.Sprintf("%s %d월 %d일", every("년", "매년"), p.Month, p.DayOfMonth)
+	case remote.RecurrencePatternRelativeYearly:
+		out = fmt.Sprintf("%s %d월 %s %s요일", every("년", "매년"), p.Month, index, strings.Join(days, ", "))
+	default:
+		out = "반복"
+	}
+
+	if r.Range != nil {
+		switch r.Range.Type {
+		case remote.RecurrenceRangeEndDate:
+			out += fmt.Sprintf(" (%s까지)", r.Range.EndDate)
+		case remote.RecurrenceRangeNumbered:
+			out += fmt.Sprintf(" (%d회)", r.Range.NumberOfOccurrences)
+		}
+	}
+	return out
+}
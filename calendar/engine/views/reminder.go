@@ -0,0 +1,87 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// The actions of a reminder, as the "action" of the context of its buttons.
+const (
+	ReminderActionSnooze  = "snooze"
+	ReminderActionAtStart = "start"
+	ReminderActionDismiss = "dismiss"
+	ReminderActionRestore = "restore"
+)
+
+// The keys of the context of the buttons of a reminder.
+const (
+	ReminderContextAction    = "action"
+	ReminderContextEventKey  = "event_key"
+	ReminderContextSeriesKey = "series_key"
+	ReminderContextStart     = "start"
+)
+
+type reminderActionsOption struct {
+	url       string
+	eventKey  string
+	seriesKey string
+}
+
+func (opt reminderActionsOption) Apply(event remote.Event, attachment *model.SlackAttachment) {
+	dismissName := "알림 끄기"
+	if event.SeriesMasterID != "" {
+		dismissName = "시리즈 알림 끄기"
+	}
+
+	attachment.Actions = append(attachment.Actions,
+		opt.action("5분 후 다시 알림", ReminderActionSnooze, event),
+		opt.action("시작할 때 알림", ReminderActionAtStart, event),
+		opt.action(dismissName, ReminderActionDismiss, event),
+	)
+}
+
+func (opt reminderActionsOption) action(name, action string, event remote.Event) *model.PostAction {
+	return &model.PostAction{
+		Name: name,
+		Integration: &model.PostActionIntegration{
+			URL: opt.url,
+			Context: map[string]interface{}{
+				ReminderContextAction:    action,
+				ReminderContextEventKey:  opt.eventKey,
+				ReminderContextSeriesKey: opt.seriesKey,
+				ReminderContextStart:     event.Start.Time().Format(time.RFC3339),
+			},
+		},
+	}
+}
+
+// ReminderActionsOption adds the buttons to snooze or dismiss the reminder of
+// the event, identified by the keys of the event and of its series.
+func ReminderActionsOption(url, eventKey, seriesKey string) Option {
+	return reminderActionsOption{
+		url:       url,
+		eventKey:  eventKey,
+		seriesKey: seriesKey,
+	}
+}
+
+// RenderReminderRestoreAction returns the button to turn the dismissed
+// reminders of the series back on.
+func RenderReminderRestoreAction(url, seriesKey string) *model.PostAction {
+	return &model.PostAction{
+		Name: "알림 다시 받기",
+		Integration: &model.PostActionIntegration{
+			URL: url,
+			Context: map[string]interface{}{
+				ReminderContextAction:    ReminderActionRestore,
+				ReminderContextSeriesKey: seriesKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// ScheduleRow is a line of the availability timeline. Error is shown instead
+// of the availability when set.
+type ScheduleRow struct {
+	Name             string
+	AvailabilityView remote.AvailabilityView
+	Error            string
+}
+
+// Availability blocks are ASCII so that the timeline stays aligned whatever
+// the width of ambiguous characters in the font.
+var availabilityBlocks = map[rune]byte{
+	remote.AvailabilityViewFree:             '.',
+	remote.AvailabilityViewTentative:        '?',
+	remote.AvailabilityViewBusy:             '#',
+	remote.AvailabilityViewOutOfOffice:      'x',
+	remote.AvailabilityViewWorkingElsewhere: '~',
+}
+
+const availabilityLegend = "`.` 한가함  `?` 미정  `#` 바쁨  `x` 부재 중  `~` 다른 곳에서 근무"
+
+// RenderSchedules renders the availabilities as a timeline with a block per
+// interval from start, labelled with the hours of start's location.
+func RenderSchedules(rows []ScheduleRow, start time.Time, interval time.Duration) string {
+	nameWidth := 0
+	blocks := 0
+	for _, row := range rows {
+		if len(row.Name) > nameWidth {
+			nameWidth = len(row.Name)
+		}
+		if len(row.AvailabilityView) > blocks {
+			blocks = len(row.AvailabilityView)
+		}
+	}
+	nameWidth += 2
+
+	header := []byte(strings.Repeat(" ", blocks+1))
+	next := 0
+	for i := 0; i < blocks; i++ {
+		t := start.Add(time.Duration(i) * interval)
+		if t.Minute() != 0 || i < next {
+			continue
+		}
+		copy(header[i:], t.Format("15"))
+		next = i + 3
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("```\n")
+	b.WriteString(strings.TrimRight(strings.Repeat(" ", nameWidth)+string(header), " ") + "\n")
+	for _, row := range rows {
+		b.WriteString(fmt.Sprintf("%-*s", nameWidth, row.Name))
+		if row.Error != "" {
+			b.WriteString("(" + row.Error + ")\n")
+			continue
+		}
+		for _, a := range row.AvailabilityView {
+			block, ok := availabilityBlocks[a]
+			if !ok {
+				block = ' '
+			}
+			b.WriteByte(block)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("```\n")
+	b.WriteString(availabilityLegend)
+
+	return b.String()
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderSchedules(t *testing.T) {
+	start := time.Date(2024, 5, 2, 8, 30, 0, 0, time.UTC)
+	out := RenderSchedules([]ScheduleRow{
+		{Name: "@alice", AvailabilityView: "00221300004"},
+		{Name: "@bob", Error: "not found"},
+	}, start, 15*time.Minute)
+
+	require.Equal(t, "```\n"+
+		"          09  10  11\n"+
+		"@alice  ..##?x....~\n"+
+		"@bob    (not found)\n"+
+		"```\n"+
+		availabilityLegend, out)
+}
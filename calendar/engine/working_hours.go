@@ -0,0 +1,130 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// syncWorkingHours records which users are outside of their working hours,
+// so that their statuses are left alone and no reminders are sent to them.
+// Users are optionally set Away when their working hours end, and get the
+// DMs queued in the meantime when they start again.
+func (m *mscalendar) syncWorkingHours(users []*store.User) {
+	now := time.Now()
+	for _, user := range users {
+		isWorkingTime := true
+		if user.IsConfiguredForWorkingHours() {
+			mailboxSettings, err := m.getMailboxSettingsForSync(user)
+			if err != nil {
+				m.Logger.With(bot.LogContext{
+					"user": user.MattermostUserID,
+					"err":  err,
+				}).Warnf("근무 시간을 가져올 수 없습니다")
+				continue
+			}
+			isWorkingTime = mailboxSettings.WorkingHours.IsWorkingTime(now, mailboxSettings.TimeZone)
+		}
+
+		var err error
+		switch {
+		case !isWorkingTime && user.AfterHours == nil:
+			err = m.endWorkingHours(user)
+		case isWorkingTime && (user.AfterHours != nil || user.IsConfiguredForWorkingHours()):
+			// DMs may have been queued before the end of the working hours
+			// was synced, the queue is checked even without AfterHours.
+			err = m.startWorkingHours(user)
+		}
+		if err != nil {
+			m.Logger.With(bot.LogContext{
+				"user": user.MattermostUserID,
+				"err":  err,
+			}).Warnf("근무 시간 동기화 중 오류 발생")
+		}
+	}
+}
+
+func (m *mscalendar) endWorkingHours(user *store.User) error {
+	afterHours := &store.AfterHours{}
+	// Out of office takes precedence over working hours.
+	if user.Settings.WorkingHoursOption == store.WorkingHoursAwayOption && user.OutOfOffice == nil {
+		status, err := m.PluginAPI.GetMattermostUserStatus(user.MattermostUserID)
+		if err != nil {
+			return err
+		}
+		if status.Status == model.StatusOnline {
+			if _, err = m.PluginAPI.UpdateMattermostUserStatus(user.MattermostUserID, model.StatusAway); err != nil {
+				return err
+			}
+			afterHours.SetAway = true
+		}
+	}
+
+	user.AfterHours = afterHours
+	return m.Store.StoreUserAfterHours(user.MattermostUserID, afterHours)
+}
+
+func (m *mscalendar) startWorkingHours(user *store.User) error {
+	if user.AfterHours != nil && user.AfterHours.SetAway && user.OutOfOffice == nil {
+		status, err := m.PluginAPI.GetMattermostUserStatus(user.MattermostUserID)
+		if err != nil {
+			return err
+		}
+		// The user may have changed their status in the meantime.
+		if status.Status == model.StatusAway {
+			if _, err = m.PluginAPI.UpdateMattermostUserStatus(user.MattermostUserID, model.StatusOnline); err != nil {
+				return err
+			}
+		}
+	}
+
+	queuedDMs, err := m.Store.LoadUserQueuedDMs(user.MattermostUserID)
+	if err != nil {
+		return err
+	}
+	if len(queuedDMs) > 0 {
+		// The DMs that couldn't be sent stay queued until the next sync.
+		sent := []*model.SlackAttachment{}
+		for _, attachment := range queuedDMs {
+			if _, err = m.Poster.DMWithAttachments(user.MattermostUserID, attachment); err != nil {
+				m.Logger.With(bot.LogContext{
+					"user": user.MattermostUserID,
+					"err":  err,
+				}).Warnf("대기 중인 DM을 보내는 중 오류 발생")
+				continue
+			}
+			sent = append(sent, attachment)
+		}
+		if err = m.Store.DeleteUserQueuedDMs(user.MattermostUserID, sent); err != nil {
+			return err
+		}
+	}
+
+	if user.AfterHours == nil {
+		return nil
+	}
+	user.AfterHours = nil
+	return m.Store.StoreUserAfterHours(user.MattermostUserID, nil)
+}
+
+// isAfterHours returns whether the user asked to be left alone and is
+// outside of their working hours.
+func isAfterHours(user *store.User) bool {
+	return user.IsConfiguredForWorkingHours() && user.AfterHours != nil
+}
+
+// isWorkingTime returns whether the user is within their working hours, or
+// doesn't mind being notified outside of them.
+func isWorkingTime(user *store.User, mailboxSettings *remote.MailboxSettings, t time.Time) bool {
+	if !user.IsConfiguredForWorkingHours() {
+		return true
+	}
+	return mailboxSettings.WorkingHours.IsWorkingTime(t, mailboxSettings.TimeZone)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine/mock_plugin_api"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/mock_remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store/mock_store"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot/mock_bot"
+)
+
+func TestSyncWorkingHours(t *testing.T) {
+	everyDay := []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+	workingHours := func(from, to time.Duration) *remote.MailboxSettings {
+		now := time.Now().UTC()
+		return &remote.MailboxSettings{
+			TimeZone: "UTC",
+			WorkingHours: remote.WorkingHours{
+				StartTime:  now.Add(from).Format("15:04:05") + ".0000000",
+				EndTime:    now.Add(to).Format("15:04:05") + ".0000000",
+				DaysOfWeek: everyDay,
+			},
+		}
+	}
+	withinWorkingHours := workingHours(-time.Hour, time.Hour)
+	afterHours := workingHours(time.Hour, 2*time.Hour)
+	queuedDM := &model.SlackAttachment{Title: "Queued"}
+
+	for name, tc := range map[string]struct {
+		user               *store.User
+		setup              func(*mock_store.MockStore, *mock_plugin_api.MockPluginAPI, *mock_remote.MockClient, *mock_bot.MockPoster)
+		expectedAfterHours *store.AfterHours
+	}{
+		"not configured": {
+			user: &store.User{},
+			setup: func(*mock_store.MockStore, *mock_plugin_api.MockPluginAPI, *mock_remote.MockClient, *mock_bot.MockPoster) {
+			},
+		},
+		"within working hours": {
+			user: &store.User{Settings: store.Settings{WorkingHoursOption: store.WorkingHoursRespectOption}},
+			setup: func(s *mock_store.MockStore, _ *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient, _ *mock_bot.MockPoster) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(withinWorkingHours, nil)
+				s.EXPECT().LoadUserQueuedDMs("user_mm_id").Return([]*model.SlackAttachment{}, nil)
+			},
+		},
+		"within working hours with DMs queued before the end of the working hours was synced": {
+			user: &store.User{Settings: store.Settings{WorkingHoursOption: store.WorkingHoursRespectOption}},
+			setup: func(s *mock_store.MockStore, _ *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient, poster *mock_bot.MockPoster) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(withinWorkingHours, nil)
+				s.EXPECT().LoadUserQueuedDMs("user_mm_id").Return([]*model.SlackAttachment{queuedDM}, nil)
+				poster.EXPECT().DMWithAttachments("user_mm_id", queuedDM).Return("post_id", nil)
+				s.EXPECT().DeleteUserQueuedDMs("user_mm_id", []*model.SlackAttachment{queuedDM}).Return(nil)
+			},
+		},
+		"working hours end": {
+			user: &store.User{Settings: store.Settings{WorkingHoursOption: store.WorkingHoursRespectOption}},
+			setup: func(s *mock_store.MockStore, _ *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient, _ *mock_bot.MockPoster) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(afterHours, nil)
+				s.EXPECT().StoreUserAfterHours("user_mm_id", &store.AfterHours{}).Return(nil)
+			},
+			expectedAfterHours: &store.AfterHours{},
+		},
+		"working hours end and the user is set Away": {
+			user: &store.User{Settings: store.Settings{WorkingHoursOption: store.WorkingHoursAwayOption}},
+			setup: func(s *mock_store.MockStore, papi *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient, _ *mock_bot.MockPoster) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(afterHours, nil)
+				papi.EXPECT().GetMattermostUserStatus("user_mm_id").Return(&model.Status{Status: model.StatusOnline}, nil)
+				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", model.StatusAway).Return(&model.Status{}, nil)
+				s.EXPECT().StoreUserAfterHours("user_mm_id", &store.AfterHours{SetAway: true}).Return(nil)
+			},
+			expectedAfterHours: &store.AfterHours{SetAway: true},
+		},
+		"still after hours": {
+			user: &store.User{
+				Settings:   store.Settings{WorkingHoursOption: store.WorkingHoursAwayOption},
+				AfterHours: &store.AfterHours{SetAway: true},
+			},
+			setup: func(_ *mock_store.MockStore, _ *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient, _ *mock_bot.MockPoster) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(afterHours, nil)
+			},
+			expectedAfterHours: &store.AfterHours{SetAway: true},
+		},
+		"working hours start, the user is set back online and gets the queued DMs": {
+			user: &store.User{
+				Settings:   store.Settings{WorkingHoursOption: store.WorkingHoursAwayOption},
+				AfterHours: &store.AfterHours{SetAway: true},
+			},
+			setup: func(s *mock_store.MockStore, papi *mock_plugin_api.MockPluginAPI, c *mock_remote.MockClient, poster *mock_bot.MockPoster) {
+				c.EXPECT().GetMailboxSettings("user_remote_id").Return(withinWorkingHours, nil)
+				papi.EXPECT().GetMattermostUserStatus("user_mm_id").Return(&model.Status{Status: model.StatusAway}, nil)
+				papi.EXPECT().UpdateMattermostUserStatus("user_mm_id", model.StatusOnline).Return(&model.Status{}, nil)
+				s.EXPECT().LoadUserQueuedDMs("user_mm_id").Return([]*model.SlackAttachment{queuedDM}, nil)
+				poster.EXPECT().DMWithAttachments("user_mm_id", queuedDM).Return("post_id", nil)
+				s.EXPECT().DeleteUserQueuedDMs("user_mm_id", []*model.SlackAttachment{queuedDM}).Return(nil)
+				s.EXPECT().StoreUserAfterHours("user_mm_id", nil).Return(nil)
+			},
+		},
+		"option turned off after hours keeps a status changed by the user": {
+			user: &store.User{
+				AfterHours: &store.AfterHours{SetAway: true},
+			},
+			setup: func(s *mock_store.MockStore, papi *mock_plugin_api.MockPluginAPI, _ *mock_remote.MockClient, _ *mock_bot.MockPoster) {
+				papi.EXPECT().GetMattermostUserStatus("user_mm_id").Return(&model.Status{Status: model.StatusDnd}, nil)
+				s.EXPECT().LoadUserQueuedDMs("user_mm_id").Return([]*model.SlackAttachment{}, nil)
+				s.EXPECT().StoreUserAfterHours("user_mm_id", nil).Return(nil)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mscalendar, mockStore, mockPoster, _, mockPluginAPI, mockClient, _ := GetMockSetup(t)
+			tc.user.MattermostUserID = "user_mm_id"
+			tc.user.Remote = &remote.User{ID: "user_remote_id"}
+			mockStore.EXPECT().LoadMailboxSettings("user_mm_id").Return(nil, store.ErrNotFound).AnyTimes()
+			mockStore.EXPECT().StoreMailboxSettings("user_mm_id", gomock.Any()).Return(nil).AnyTimes()
+			tc.setup(mockStore, mockPluginAPI, mockClient, mockPoster)
+
+			mscalendar.syncWorkingHours([]*store.User{tc.user})
+			require.Equal(t, tc.expectedAfterHours, tc.user.AfterHours)
+		})
+	}
+}
+
+func TestStartWorkingHoursKeepsUnsentDMs(t *testing.T) {
+	mscalendar, mockStore, mockPoster, _, _, _, mockLogger := GetMockSetup(t)
+	ctrl := gomock.NewController(t)
+	mockLoggerWith := mock_bot.NewMockLogger(ctrl)
+	sentDM := &model.SlackAttachment{Title: "Sent"}
+	unsentDM := &model.SlackAttachment{Title: "Unsent"}
+	user := &store.User{MattermostUserID: "user_mm_id"}
+
+	mockStore.EXPECT().LoadUserQueuedDMs("user_mm_id").Return([]*model.SlackAttachment{sentDM, unsentDM}, nil)
+	mockPoster.EXPECT().DMWithAttachments("user_mm_id", sentDM).Return("post_id", nil)
+	mockPoster.EXPECT().DMWithAttachments("user_mm_id", unsentDM).Return("", errors.New("some error"))
+	mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith)
+	mockLoggerWith.EXPECT().Warnf("대기 중인 DM을 보내는 중 오류 발생")
+	mockStore.EXPECT().DeleteUserQueuedDMs("user_mm_id", []*model.SlackAttachment{sentDM}).Return(nil)
+
+	require.NoError(t, mscalendar.startWorkingHours(user))
+}
+
+func TestDeliverRemindersAfterHours(t *testing.T) {
+	mscalendar, mockStore, mockPoster, _, _, mockClient, _ := GetMockSetup(t)
+	user := &store.User{
+		MattermostUserID: "user_mm_id",
+		Remote:           &remote.User{ID: "user_remote_id"},
+		Settings:         store.Settings{ReceiveReminders: true, WorkingHoursOption: store.WorkingHoursRespectOption},
+		AfterHours:       &store.AfterHours{},
+	}
+	event := &remote.Event{
+		ID:      "event_id",
+		Subject: "Late meeting",
+		Start:   remote.NewDateTime(time.Now().Add(upcomingEventNotificationTime).UTC(), "UTC"),
+		End:     remote.NewDateTime(time.Now().Add(upcomingEventNotificationTime+time.Hour).UTC(), "UTC"),
+	}
+
+	// The linked channel is reminded, the user isn't: the poster mock fails
+	// on a DM.
+	mockStore.EXPECT().LoadReminderSnoozes("user_mm_id").Return(store.ReminderSnoozes{}, nil)
+	mockStore.EXPECT().LoadUser("user_mm_id").Return(user, nil)
+	mockClient.EXPECT().GetMailboxSettings("user_remote_id").Return(&remote.MailboxSettings{TimeZone: "UTC"}, nil)
+	mockStore.EXPECT().LoadEventMetadata(event.OccurrenceKey()).Return(&store.EventMetadata{
+		LinkedChannelIDs: map[string]struct{}{"channel_id": {}},
+	}, nil)
+	mockStore.EXPECT().MarkReminderSent("channel_channel_id", gomock.Any(), gomock.Any()).Return(true, nil)
+	mockPoster.EXPECT().CreatePost(gomock.Any()).DoAndReturn(func(post *model.Post) error {
+		require.Equal(t, "channel_id", post.ChannelId)
+		return nil
+	})
+
+	mscalendar.deliverReminders([]*store.User{user}, []*remote.ViewCalendarResponse{
+		{RemoteUserID: "user_remote_id", Events: []*remote.Event{event}},
+	}, false)
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+// Unique id for the channel calendar job
+const channelCalendarJobID = "channel_calendar"
+
+// NewChannelCalendarJob creates a RegisteredJob with the parameters specific to the ChannelCalendarJob
+func NewChannelCalendarJob() RegisteredJob {
+	return RegisteredJob{
+		id:       channelCalendarJobID,
+		interval: engine.ChannelCalendarJobInterval,
+		work:     runChannelCalendarJob,
+	}
+}
+
+// runChannelCalendarJob posts the agendas and reminders of the channel calendars in their channels
+func runChannelCalendarJob(env engine.Env) {
+	env.Logger.Debugf("Channel calendar job beginning")
+
+	err := engine.New(env, "").ProcessAllChannelCalendars(time.Now())
+	if err != nil {
+		env.Logger.Errorf("Error during channel calendar job. err=%v", err)
+	}
+
+	env.Logger.Debugf("Channel calendar job finished")
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+)
+
+const (
+	PollJobInterval = 2 * time.Minute
+	ditherPoll      = 50 * time.Millisecond
+)
+
+// NewPollJob creates the job fetching event changes for providers that can't
+// push notifications. The changes are handed to the notification processor.
+func NewPollJob(processor engine.NotificationProcessor) RegisteredJob {
+	return RegisteredJob{
+		id:       "poll",
+		interval: PollJobInterval,
+		work: func(env engine.Env) {
+			runPollJob(env, processor)
+		},
+	}
+}
+
+// runPollJob polls the event subscription of each connected user
+func runPollJob(env engine.Env, processor engine.NotificationProcessor) {
+	uindex, err := env.Store.LoadUserIndex()
+	if err != nil {
+		env.Logger.Errorf("Poll job failed to load user index. err=%v", err)
+		return
+	}
+	env.Logger.Debugf("Poll job: %v users", len(uindex))
+
+	for _, u := range uindex {
+		asUser := engine.New(env, u.MattermostUserID)
+
+		notifications, err := asUser.PollMyEventSubscription()
+		if err != nil {
+			env.Logger.Errorf("Error polling subscription for user %s. err=%v", u.MattermostUserID, err)
+			continue
+		}
+
+		err = processor.Enqueue(notifications...)
+		if err != nil {
+			env.Logger.Errorf("Error enqueuing polled notifications. err=%v", err)
+		}
+
+		time.Sleep(ditherPoll)
+	}
+
+	env.Logger.Debugf("Poll job finished")
+}
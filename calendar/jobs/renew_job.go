@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 )
 
 const ditherRenew = 50 * time.Millisecond
@@ -19,7 +20,8 @@ func NewRenewJob() RegisteredJob {
 	}
 }
 
-// runRenewJob calls renews the event subscription for each connected user
+// runRenewJob calls renews the event subscription for each connected user, and
+// the subscription of each channel calendar
 func runRenewJob(env engine.Env) {
 	uindex, err := env.Store.LoadUserIndex()
 	if err != nil {
@@ -40,5 +42,25 @@ func runRenewJob(env engine.Env) {
 		time.Sleep(ditherRenew)
 	}
 
+	channelIndex, err := env.Store.LoadChannelCalendarIndex()
+	if err != nil && err != store.ErrNotFound {
+		env.Logger.Errorf("Renew job failed to load channel calendar index. err=%v", err)
+	}
+	for _, channelID := range channelIndex {
+		channelCalendar, err := env.Store.LoadChannelCalendar(channelID)
+		if err != nil {
+			env.Logger.Errorf("Error loading channel calendar. err=%v", err)
+			continue
+		}
+
+		env.Logger.Debugf("Renewing for channel: %s", channelID)
+		err = engine.New(env, channelCalendar.MattermostCreatorID).RenewChannelCalendarSubscription(channelID)
+		if err != nil {
+			env.Logger.Errorf("Error renewing channel calendar subscription. err=%v", err)
+		}
+
+		time.Sleep(ditherRenew)
+	}
+
 	env.Logger.Debugf("Renew job finished")
 }
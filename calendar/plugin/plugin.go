@@ -25,6 +25,7 @@ import (
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/jobs"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote/local"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/telemetry"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/tracker"
@@ -175,7 +176,11 @@ func (p *Plugin) OnConfigurationChange() (err error) {
 		e.Config.PluginURLPath = pluginURLPath
 
 		e.bot = e.bot.WithConfig(stored.Config)
-		e.Dependencies.Remote = remote.Makers[config.Provider.Name](e.Config, e.bot)
+		remoteName := config.Provider.Name
+		if stored.LocalFixturesPath != "" {
+			remoteName = local.Kind
+		}
+		e.Dependencies.Remote = remote.Makers[remoteName](e.Config, e.bot)
 
 		mscalendarBot := engine.NewMSCalendarBot(e.bot, e.Env, pluginURL)
 
@@ -230,12 +235,17 @@ func (p *Plugin) OnConfigurationChange() (err error) {
 		flow.Init(e.httpHandler, welcomeFlow, mscalendarBot)
 		settingspanel.Init(e.httpHandler, e.Dependencies.SettingsPanel)
 		api.Init(e.httpHandler, e.Env, e.notificationProcessor)
+		local.Init(e.httpHandler, e.Dependencies.Remote)
 
 		if e.jobManager == nil {
 			e.jobManager = jobs.NewJobManager(p.API, e.Env)
 			e.jobManager.AddJob(jobs.NewStatusSyncJob())
 			e.jobManager.AddJob(jobs.NewDailySummaryJob())
 			e.jobManager.AddJob(jobs.NewRenewJob())
+			e.jobManager.AddJob(jobs.NewChannelCalendarJob())
+			if e.Provider.Features.EventNotifications && e.Provider.Features.EventPolling {
+				e.jobManager.AddJob(jobs.NewPollJob(e.notificationProcessor))
+			}
 		}
 	})
 
@@ -15,14 +15,50 @@ type Calendar struct {
 	CalendarView []Event `json:"calendarView,omitempty"`
 }
 
+// Group is a group of users with a calendar of its own, such as a Microsoft
+// 365 group.
+type Group struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// ViewCalendarParams requests the events of a calendar of a user. An empty
+// CalendarID stands for the default calendar.
 type ViewCalendarParams struct {
 	StartTime    time.Time
 	EndTime      time.Time
 	RemoteUserID string
+	CalendarID   string
 }
 
 type ViewCalendarResponse struct {
 	Error        *APIError
 	RemoteUserID string
+	CalendarID   string
 	Events       []*Event
 }
+
+// CalendarViewDelta holds the changes of a calendar view. RemovedIDs lists the
+// events that left the view, and DeltaLink resumes the tracking of the view.
+type CalendarViewDelta struct {
+	Events     []*Event
+	RemovedIDs []string
+	DeltaLink  string
+}
+
+// CalendarViewDeltaParams requests the changes of a calendar view, as the
+// arguments of GetCalendarViewDelta do.
+type CalendarViewDeltaParams struct {
+	StartTime    time.Time
+	EndTime      time.Time
+	RemoteUserID string
+	CalendarID   string
+	DeltaLink    string
+}
+
+// CalendarViewDeltaResponse holds the changes of a calendar view requested in
+// a batch, or the error GetCalendarViewDelta would have returned.
+type CalendarViewDeltaResponse struct {
+	Delta *CalendarViewDelta
+	Error error
+}
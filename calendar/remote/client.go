@@ -25,12 +25,17 @@ type Calendars interface {
 	GetEvent(remoteUserID, eventID string) (*Event, error)
 	GetCalendars(remoteUserID string) ([]*Calendar, error)
 	GetDefaultCalendarView(remoteUserID string, startTime, endTime time.Time) ([]*Event, error)
+	GetCalendarView(remoteUserID, calendarID string, startTime, endTime time.Time) ([]*Event, error)
 	DoBatchViewCalendarRequests([]*ViewCalendarParams) ([]*ViewCalendarResponse, error)
 	GetMailboxSettings(remoteUserID string) (*MailboxSettings, error)
+	GetSchedule(requests []*ScheduleUserInfo, startTime, endTime *DateTime, availabilityViewInterval int) ([]*ScheduleInformation, error)
 }
 
 type Events interface {
 	CreateEvent(remoteUserID string, calendarEvent *Event) (*Event, error)
+	UpdateEvent(remoteUserID, eventID string, calendarEvent *Event) (*Event, error)
+	DeleteEvent(remoteUserID, eventID string) error
+	CancelEvent(remoteUserID, eventID, comment string) error
 	AcceptEvent(remoteUserID, eventID string) error
 	DeclineEvent(remoteUserID, eventID string) error
 	TentativelyAcceptEvent(remoteUserID, eventID string) error
@@ -56,3 +61,44 @@ type Unsupported interface {
 	DeleteCalendar(remoteUserID, calendarID string) error
 	FindMeetingTimes(remoteUserID string, meetingParams *FindMeetingTimesParameters) (*MeetingTimeSuggestionResults, error)
 }
+
+// Poller is implemented by clients of remotes that can't push notifications.
+// PollSubscription returns the notifications for the changes since the last
+// poll, and the subscription updated with the new change tracking state.
+type Poller interface {
+	PollSubscription(sub *Subscription) ([]*Notification, *Subscription, error)
+}
+
+// CalendarSubscriber is implemented by clients of remotes that can notify the
+// changes of a single calendar in the calendar list of the user, such as a
+// calendar shared with them, rather than of all their events.
+type CalendarSubscriber interface {
+	CreateCalendarSubscription(notificationURL, remoteUserID, calendarID string) (*Subscription, error)
+}
+
+// GroupCalendarClient is implemented by clients of remotes whose groups have a
+// calendar of their own, such as Microsoft 365 groups. GetGroups returns the
+// groups of the user that have a calendar; their calendars are read and
+// subscribed to with the user's credentials.
+type GroupCalendarClient interface {
+	GetGroups(remoteUserID string) ([]*Group, error)
+	GetGroupCalendarView(groupID string, startTime, endTime time.Time) ([]*Event, error)
+	CreateGroupCalendarSubscription(notificationURL, groupID string) (*Subscription, error)
+}
+
+// DeltaSyncer is implemented by clients of remotes that can track the changes
+// of a calendar view. GetCalendarViewDelta returns the events of the window
+// when deltaLink is empty, and the changes since deltaLink otherwise, with the
+// link to use for the next round. DoBatchCalendarViewDeltaRequests does the
+// same for several views at once, and returns a response per request, in the
+// order of the requests.
+type DeltaSyncer interface {
+	GetCalendarViewDelta(remoteUserID, calendarID string, start, end time.Time, deltaLink string) (*CalendarViewDelta, error)
+	DoBatchCalendarViewDeltaRequests(params []*CalendarViewDeltaParams) ([]*CalendarViewDeltaResponse, error)
+}
+
+// AutomaticRepliesSetter is implemented by clients of remotes that let users
+// change their automatic replies, or out of office message.
+type AutomaticRepliesSetter interface {
+	SetAutomaticReplies(remoteUserID string, setting *AutomaticRepliesSetting) error
+}
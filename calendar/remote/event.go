@@ -16,6 +16,7 @@ type Event struct {
 	Conference                 *Conference          `json:"conference,omitempty"`
 	End                        *DateTime            `json:"end,omitempty"`
 	Organizer                  *Attendee            `json:"organizer,omitempty"`
+	Recurrence                 *PatternedRecurrence `json:"recurrence,omitempty"`
 	Body                       *ItemBody            `json:"Body,omitempty"`
 	ResponseStatus             *EventResponseStatus `json:"responseStatus,omitempty"`
 	Importance                 string               `json:"importance,omitempty"`
@@ -25,12 +26,24 @@ type Event struct {
 	ShowAs                     string               `json:"showAs,omitempty"`
 	Weblink                    string               `json:"weblink,omitempty"`
 	ID                         string               `json:"id,omitempty"`
+	Type                       string               `json:"type,omitempty"`
+	SeriesMasterID             string               `json:"seriesMasterId,omitempty"`
+	OriginalStart              string               `json:"originalStart,omitempty"`
+	LastModifiedDateTime       string               `json:"lastModifiedDateTime,omitempty"`
+	CalendarName               string               `json:"-"` // Set by the plugin when aggregating several calendars
 	Attendees                  []*Attendee          `json:"attendees,omitempty"`
 	ReminderMinutesBeforeStart int                  `json:"reminderMinutesBeforeStart,omitempty"`
+	IsReminderOn               bool                 `json:"isReminderOn,omitempty"`
 	IsOrganizer                bool                 `json:"isOrganizer,omitempty"`
 	IsCancelled                bool                 `json:"isCancelled,omitempty"`
 	IsAllDay                   bool                 `json:"isAllDay,omitempty"`
 	ResponseRequested          bool                 `json:"responseRequested,omitempty"`
+
+	// Online meeting request and details, as in Microsoft Graph. Providers
+	// expose the join URL of the meeting as the Conference of the event.
+	IsOnlineMeeting       bool               `json:"isOnlineMeeting,omitempty"`
+	OnlineMeetingProvider string             `json:"onlineMeetingProvider,omitempty"`
+	OnlineMeeting         *OnlineMeetingInfo `json:"onlineMeeting,omitempty"`
 }
 
 type ItemBody struct {
@@ -68,6 +81,12 @@ type Conference struct {
 	URL         string `json:"url"`
 }
 
+const OnlineMeetingProviderTeams = "teamsForBusiness"
+
+type OnlineMeetingInfo struct {
+	JoinURL string `json:"joinUrl,omitempty"`
+}
+
 type Attendee struct {
 	RemoteID     string               `json:"remoteId,omitempty"`
 	Status       *EventResponseStatus `json:"status,omitempty"`
@@ -0,0 +1,114 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func (c *client) GetCalendars(remoteUserID string) ([]*remote.Calendar, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	calendars, err := c.impl.store.getCalendars(remoteUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "local GetCalendars")
+	}
+
+	return calendars, nil
+}
+
+func (c *client) CreateCalendar(remoteUserID string, calIn *remote.Calendar) (*remote.Calendar, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return nil, errors.Wrap(err, "local CreateCalendar")
+	}
+
+	cal, err := c.impl.store.createCalendar(remoteUserID, calIn)
+	if err != nil {
+		return nil, errors.Wrap(err, "local CreateCalendar")
+	}
+
+	return cal, nil
+}
+
+func (c *client) DeleteCalendar(remoteUserID string, calID string) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return errors.Wrap(err, "local DeleteCalendar")
+	}
+
+	err := c.impl.store.deleteCalendar(remoteUserID, calID)
+	if err != nil {
+		return errors.Wrap(err, "local DeleteCalendar")
+	}
+
+	return nil
+}
+
+func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	return c.GetEventsBetweenDates(remoteUserID, start, end)
+}
+
+func (c *client) GetCalendarView(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	events, err := c.impl.store.getEventsBetween(remoteUserID, calendarID, start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "local GetCalendarView")
+	}
+
+	return events, nil
+}
+
+func (c *client) GetEventsBetweenDates(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	events, err := c.impl.store.getEventsBetween(remoteUserID, "", start, end)
+	if err != nil {
+		return nil, errors.Wrap(err, "local GetEventsBetweenDates")
+	}
+
+	return events, nil
+}
+
+// DoBatchViewCalendarRequests reports unknown users in the response of their
+// request, as a Graph batch does.
+func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	result := []*remote.ViewCalendarResponse{}
+	for _, params := range allParams {
+		res := &remote.ViewCalendarResponse{
+			RemoteUserID: params.RemoteUserID,
+			CalendarID:   params.CalendarID,
+		}
+		events, err := c.impl.store.getEventsBetween(params.RemoteUserID, params.CalendarID, params.StartTime, params.EndTime)
+		if err != nil {
+			res.Error = &remote.APIError{
+				Code:    "ErrorItemNotFound",
+				Message: err.Error(),
+			}
+		}
+		res.Events = events
+		result = append(result, res)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// superuserToken is the access token of superuser clients, which may access
+// the calendars of all fixture users.
+const superuserToken = "superuser"
+
+type client struct {
+	// caching the context here since it's a "single-use" client, usually used
+	// within a single API request
+	ctx context.Context
+
+	impl             *impl
+	remoteUserID     string
+	mattermostUserID string
+	tokenHelpers     remote.UserTokenHelpers
+
+	bot.Logger
+	bot.Poster
+}
+
+// checkConnected mirrors the inactive user check of the Graph client.
+// Superuser clients have no token helpers and are always connected.
+func (c *client) checkConnected() error {
+	if c.tokenHelpers != nil && !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+	if c.remoteUserID == "" {
+		return errors.New("not authenticated")
+	}
+	return nil
+}
+
+func (c *client) GetSuperuserToken() (string, error) {
+	return superuserToken, nil
+}
+
+// CallJSON is not implemented: there is no Graph API to call.
+func (c *client) CallJSON(_, _ string, _, _ interface{}) (responseData []byte, err error) {
+	return nil, remote.ErrNotImplemented
+}
+
+// CallFormPost is not implemented: there is no Graph API to call.
+func (c *client) CallFormPost(_, _ string, _ url.Values, _ interface{}) (responseData []byte, err error) {
+	return nil, remote.ErrNotImplemented
+}
+
+// checkWriteAccess only lets users change their own calendars. Reading other
+// users' calendars is allowed, as with the shared calendar permissions
+// requested from Graph.
+func (c *client) checkWriteAccess(remoteUserID string) error {
+	if c.remoteUserID != superuserToken && c.remoteUserID != remoteUserID {
+		return errors.Errorf("access denied to the calendar of user %s", remoteUserID)
+	}
+	return nil
+}
@@ -0,0 +1,406 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type testTokenHelpers struct{}
+
+func (testTokenHelpers) CheckUserConnected(string) bool                   { return true }
+func (testTokenHelpers) DisconnectUserFromStoreIfNecessary(error, string) {}
+func (testTokenHelpers) RefreshAndStoreToken(token *oauth2.Token, _ *oauth2.Config, _ string) (*oauth2.Token, error) {
+	return token, nil
+}
+
+type delivery struct {
+	url  string
+	body string
+}
+
+func newTestRemote(t *testing.T) (*impl, chan delivery) {
+	r := NewRemote(&config.Config{
+		PluginURL: "https://mm.example.com/plugins/calendar",
+		StoredConfig: config.StoredConfig{
+			LocalFixturesPath: "testdata",
+		},
+	}, &bot.NilLogger{}).(*impl)
+	require.NoError(t, r.CheckConfiguration(r.conf.StoredConfig))
+
+	deliveries := make(chan delivery, 10)
+	r.deliver = func(notificationURL string, body []byte) {
+		deliveries <- delivery{notificationURL, string(body)}
+	}
+	return r, deliveries
+}
+
+func newTestClient(r *impl, remoteUserID string) remote.Client {
+	return r.MakeUserClient(context.Background(), &oauth2.Token{AccessToken: remoteUserID}, "mm-"+remoteUserID, nil, testTokenHelpers{})
+}
+
+func receive(t *testing.T, deliveries chan delivery) delivery {
+	select {
+	case d := <-deliveries:
+		return d
+	case <-time.After(time.Second):
+		require.Fail(t, "no webhook delivered")
+		return delivery{}
+	}
+}
+
+func TestLoadFixtures(t *testing.T) {
+	r, _ := newTestRemote(t)
+	c := newTestClient(r, "alice")
+
+	me, err := c.GetMe()
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", me.Mail)
+
+	settings, err := c.GetMailboxSettings("alice")
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Seoul", settings.TimeZone)
+
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+	now := time.Now().In(seoul)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, seoul)
+
+	events, err := c.GetDefaultCalendarView("alice", today, today.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, "Daily standup", events[0].Subject)
+	require.True(t, events[0].Start.Time().Equal(today.Add(10*time.Hour)))
+	require.Equal(t, "Holiday", events[2].Subject)
+	require.True(t, events[2].End.Time().Equal(today.AddDate(0, 0, 3)))
+
+	calendars, err := c.GetCalendars("alice")
+	require.NoError(t, err)
+	require.Len(t, calendars, 1)
+
+	responses, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "bob", StartTime: today, EndTime: today.AddDate(0, 0, 3)},
+		{RemoteUserID: "carol", StartTime: today, EndTime: today.AddDate(0, 0, 3)},
+	})
+	require.NoError(t, err)
+	require.Len(t, responses, 2)
+	require.Nil(t, responses[0].Error)
+	require.Len(t, responses[0].Events, 1)
+	require.NotNil(t, responses[1].Error)
+}
+
+func TestGetCalendarView(t *testing.T) {
+	r, _ := newTestRemote(t)
+	bob := newTestClient(r, "bob")
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	calendars, err := bob.GetCalendars("bob")
+	require.NoError(t, err)
+	require.Len(t, calendars, 2)
+
+	events, err := bob.GetCalendarView("bob", "team", today, today.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "Team offsite", events[0].Subject)
+
+	events, err = bob.GetCalendarView("bob", "", today, today.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "Sprint planning", events[0].Subject)
+
+	_, err = bob.GetCalendarView("bob", "unknown", today, today.AddDate(0, 0, 3))
+	require.Error(t, err)
+
+	responses, err := bob.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "bob", CalendarID: "team", StartTime: today, EndTime: today.AddDate(0, 0, 3)},
+	})
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	require.Equal(t, "team", responses[0].CalendarID)
+	require.Len(t, responses[0].Events, 1)
+}
+
+func TestCreateEventNotifiesAttendees(t *testing.T) {
+	r, deliveries := newTestRemote(t)
+	alice := newTestClient(r, "alice")
+	bob := newTestClient(r, "bob")
+
+	sub, err := bob.CreateMySubscription("https://mm.example.com/notify", "bob")
+	require.NoError(t, err)
+
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Minute)
+	created, err := alice.CreateEvent("alice", &remote.Event{
+		Subject: "Design review",
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		Attendees: []*remote.Attendee{{
+			EmailAddress: &remote.EmailAddress{Address: "BOB@example.com"},
+		}},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+	require.True(t, created.IsOrganizer)
+	require.Equal(t, "alice@example.com", created.Organizer.EmailAddress.Address)
+
+	_, err = bob.CreateEvent("alice", &remote.Event{})
+	require.Error(t, err)
+
+	d := receive(t, deliveries)
+	require.Equal(t, "https://mm.example.com/notify", d.url)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/notification/v1/event", strings.NewReader(d.body))
+	notifications := r.HandleWebhook(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Len(t, notifications, 1)
+	require.Equal(t, sub.ID, notifications[0].SubscriptionID)
+	require.Equal(t, sub.ClientState, notifications[0].ClientState)
+	require.Equal(t, "created", notifications[0].ChangeType)
+	require.True(t, notifications[0].IsBare)
+	require.False(t, notifications[0].RecommendRenew)
+
+	n, err := bob.GetNotificationData(notifications[0])
+	require.NoError(t, err)
+	require.False(t, n.IsBare)
+	require.Equal(t, created.ICalUID, n.Event.ICalUID)
+	require.NotEqual(t, created.ID, n.Event.ID)
+	require.False(t, n.Event.IsOrganizer)
+	require.Equal(t, remote.EventResponseStatusNotAnswered, n.Event.ResponseStatus.Response)
+}
+
+func TestRespondUpdatesOrganizer(t *testing.T) {
+	r, deliveries := newTestRemote(t)
+	alice := newTestClient(r, "alice")
+	bob := newTestClient(r, "bob")
+
+	_, err := bob.CreateMySubscription("https://mm.example.com/notify", "bob")
+	require.NoError(t, err)
+
+	require.NoError(t, alice.AcceptEvent("alice", "alice-planning"))
+	event, err := alice.GetEvent("alice", "alice-planning")
+	require.NoError(t, err)
+	require.Equal(t, remote.EventResponseStatusAccepted, event.ResponseStatus.Response)
+
+	d := receive(t, deliveries)
+	require.Contains(t, d.body, `"changeType":"updated"`)
+	require.Contains(t, d.body, `"resource":"Users/bob/Events/bob-planning"`)
+
+	event, err = bob.GetEvent("bob", "bob-planning")
+	require.NoError(t, err)
+	require.Equal(t, remote.EventResponseStatusAccepted, event.Attendees[0].Status.Response)
+
+	require.NoError(t, alice.DeclineEvent("alice", "alice-planning"))
+	event, err = bob.GetEvent("bob", "bob-planning")
+	require.NoError(t, err)
+	require.Equal(t, remote.EventResponseStatusDeclined, event.Attendees[0].Status.Response)
+
+	require.Error(t, bob.TentativelyAcceptEvent("bob", "bob-planning"))
+	require.Error(t, alice.AcceptEvent("alice", "missing"))
+}
+
+func TestUpdateAndCancelEvent(t *testing.T) {
+	r, deliveries := newTestRemote(t)
+	alice := newTestClient(r, "alice")
+	bob := newTestClient(r, "bob")
+
+	_, err := alice.CreateMySubscription("https://mm.example.com/notify", "alice")
+	require.NoError(t, err)
+
+	_, err = bob.UpdateEvent("alice", "alice-planning", &remote.Event{Subject: "Hijacked"})
+	require.Error(t, err)
+
+	updated, err := bob.UpdateEvent("bob", "bob-planning", &remote.Event{Subject: "Sprint review"})
+	require.NoError(t, err)
+	require.Equal(t, "Sprint review", updated.Subject)
+
+	d := receive(t, deliveries)
+	require.Contains(t, d.body, `"changeType":"updated"`)
+	require.Contains(t, d.body, `"resource":"Users/alice/Events/alice-planning"`)
+	event, err := alice.GetEvent("alice", "alice-planning")
+	require.NoError(t, err)
+	require.Equal(t, "Sprint review", event.Subject)
+
+	require.Error(t, alice.CancelEvent("alice", "alice-planning", ""))
+	require.NoError(t, bob.CancelEvent("bob", "bob-planning", "Moved to Friday"))
+
+	d = receive(t, deliveries)
+	require.Contains(t, d.body, `"resource":"Users/alice/Events/alice-planning"`)
+	event, err = alice.GetEvent("alice", "alice-planning")
+	require.NoError(t, err)
+	require.True(t, event.IsCancelled)
+	require.Equal(t, "Moved to Friday", event.Body.Content)
+
+	_, err = bob.GetEvent("bob", "bob-planning")
+	require.Error(t, err)
+
+	require.NoError(t, alice.DeleteEvent("alice", "alice-planning"))
+	_, err = alice.GetEvent("alice", "alice-planning")
+	require.Error(t, err)
+
+	d = receive(t, deliveries)
+	require.Contains(t, d.body, `"changeType":"deleted"`)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/notification/v1/event", strings.NewReader(d.body))
+	notifications := r.HandleWebhook(w, req)
+	require.Len(t, notifications, 1)
+
+	n, err := alice.GetNotificationData(notifications[0])
+	require.NoError(t, err)
+	require.False(t, n.IsBare)
+	require.Equal(t, remote.ChangeTypeDeleted, n.ChangeType)
+	require.Equal(t, "alice-planning", n.Event.ID)
+}
+
+func TestRecurringEvent(t *testing.T) {
+	r, _ := newTestRemote(t)
+	alice := newTestClient(r, "alice")
+	bob := newTestClient(r, "bob")
+
+	start := time.Now().Add(time.Hour).UTC().Truncate(time.Minute)
+	recurrence, err := remote.NewPatternedRecurrence(remote.FrequencyDaily, 1, start)
+	require.NoError(t, err)
+	recurrence.EndAfter(3)
+
+	created, err := alice.CreateEvent("alice", &remote.Event{
+		Subject:    "Daily check-in",
+		Start:      remote.NewDateTime(start, "UTC"),
+		End:        remote.NewDateTime(start.Add(15*time.Minute), "UTC"),
+		Recurrence: recurrence,
+		Attendees: []*remote.Attendee{{
+			EmailAddress: &remote.EmailAddress{Address: "bob@example.com"},
+		}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.Recurrence)
+
+	events, err := bob.GetDefaultCalendarView("bob", start, start.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	occurrences := []*remote.Event{}
+	for _, e := range events {
+		if e.ICalUID == created.ICalUID {
+			occurrences = append(occurrences, e)
+		}
+	}
+	require.Len(t, occurrences, 3)
+	for i, e := range occurrences {
+		require.Equal(t, remote.EventTypeOccurrence, e.Type)
+		require.NotEmpty(t, e.SeriesMasterID)
+		require.Nil(t, e.Recurrence)
+		require.True(t, e.Start.Time().Equal(start.AddDate(0, 0, i)))
+		require.Equal(t, created.ICalUID+"_"+start.AddDate(0, 0, i).Format("20060102T150405Z"), e.OccurrenceKey())
+	}
+
+	event, err := bob.GetEvent("bob", occurrences[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, occurrences[1], event)
+
+	_, err = bob.GetEvent("bob", occurrences[1].SeriesMasterID+"_20000101T000000Z")
+	require.Error(t, err)
+}
+
+func TestSubscriptions(t *testing.T) {
+	r, _ := newTestRemote(t)
+	alice := newTestClient(r, "alice")
+	bob := newTestClient(r, "bob")
+
+	sub, err := alice.CreateMySubscription("https://mm.example.com/notify", "alice")
+	require.NoError(t, err)
+	_, err = bob.CreateMySubscription("https://mm.example.com/notify", "bob")
+	require.NoError(t, err)
+
+	subs, err := alice.ListSubscriptions()
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+
+	su, err := r.MakeSuperuserClient(context.Background())
+	require.NoError(t, err)
+	subs, err = su.ListSubscriptions()
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+
+	sub.ExpirationDateTime = time.Now().Format(time.RFC3339)
+	renewed, err := alice.RenewSubscription("", "", sub)
+	require.NoError(t, err)
+	expires, err := time.Parse(time.RFC3339, renewed.ExpirationDateTime)
+	require.NoError(t, err)
+	require.True(t, expires.After(time.Now().Add(subscribeTTL-time.Minute)))
+	require.Equal(t, sub.ClientState, renewed.ClientState)
+
+	require.NoError(t, alice.DeleteSubscription(sub))
+	require.Error(t, alice.DeleteSubscription(sub))
+	_, err = alice.RenewSubscription("", "", sub)
+	require.Error(t, err)
+}
+
+func TestFindMeetingTimes(t *testing.T) {
+	r, _ := newTestRemote(t)
+	alice := newTestClient(r, "alice")
+
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+	now := time.Now().In(seoul)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, seoul)
+
+	duration := time.Hour
+	results, err := alice.FindMeetingTimes("alice", &remote.FindMeetingTimesParameters{
+		MeetingDuration: &duration,
+		TimeConstraint: &remote.TimeConstraint{
+			TimeSlots: []remote.TimeSlot{{
+				Start: remote.NewDateTime(today.Add(9*time.Hour), "Asia/Seoul"),
+				End:   remote.NewDateTime(today.Add(12*time.Hour), "Asia/Seoul"),
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	starts := []time.Time{}
+	for _, s := range results.MeetingTimeSuggestions {
+		starts = append(starts, s.MeetingTimeSlot.Start.Time())
+	}
+	require.Equal(t, []time.Time{
+		today.Add(9 * time.Hour).UTC(),
+		today.Add(10*time.Hour + 30*time.Minute).UTC(),
+		today.Add(11 * time.Hour).UTC(),
+	}, starts)
+}
+
+func TestGetSchedule(t *testing.T) {
+	r, _ := newTestRemote(t)
+	bob := newTestClient(r, "bob")
+
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+	now := time.Now().In(seoul)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, seoul)
+
+	schedules, err := bob.GetSchedule([]*remote.ScheduleUserInfo{
+		{RemoteUserID: "bob", Mail: "alice@example.com"},
+		{RemoteUserID: "bob", Mail: "nobody@example.com"},
+	}, remote.NewDateTime(today.Add(9*time.Hour), "Asia/Seoul"), remote.NewDateTime(today.Add(11*time.Hour), "Asia/Seoul"), 15)
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+
+	require.Equal(t, "alice@example.com", schedules[0].ScheduleID)
+	require.Nil(t, schedules[0].Error)
+	require.Equal(t, remote.AvailabilityView("00002000"), schedules[0].AvailabilityView)
+	require.Len(t, schedules[0].ScheduleItems, 1)
+	require.Equal(t, remote.ScheduleStatusBusy, schedules[0].ScheduleItems[0].Status)
+
+	require.Equal(t, "nobody@example.com", schedules[1].ScheduleID)
+	require.NotNil(t, schedules[1].Error)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	event, err := c.impl.store.getEvent(remoteUserID, eventID)
+	if err != nil {
+		return nil, errors.Wrap(err, "local GetEvent")
+	}
+
+	return event, nil
+}
+
+// CreateEvent adds the event to the calendar of the user and to the calendars
+// of the attendees that are fixture users, and notifies their subscriptions.
+func (c *client) CreateEvent(remoteUserID string, in *remote.Event) (*remote.Event, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return nil, errors.Wrap(err, "local CreateEvent")
+	}
+
+	e := *in
+	e.ID = ""
+	e.ICalUID = ""
+	event, err := c.impl.putEvent(remoteUserID, &e)
+	if err != nil {
+		return nil, errors.Wrap(err, "local CreateEvent")
+	}
+
+	return event, nil
+}
+
+func (c *client) AcceptEvent(remoteUserID, eventID string) error {
+	return c.respond("AcceptEvent", remoteUserID, eventID, remote.EventResponseStatusAccepted)
+}
+
+func (c *client) DeclineEvent(remoteUserID, eventID string) error {
+	return c.respond("DeclineEvent", remoteUserID, eventID, remote.EventResponseStatusDeclined)
+}
+
+func (c *client) TentativelyAcceptEvent(remoteUserID, eventID string) error {
+	return c.respond("TentativelyAcceptEvent", remoteUserID, eventID, remote.EventResponseStatusTentative)
+}
+
+func (c *client) respond(method, remoteUserID, eventID, response string) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return errors.Wrap(err, "local "+method)
+	}
+
+	changes, err := c.impl.store.respond(remoteUserID, eventID, response)
+	if err != nil {
+		return errors.Wrap(err, "local "+method)
+	}
+	c.impl.notify(changes)
+
+	return nil
+}
+
+// UpdateEvent sets the fields of the event that are set in the update. Updates
+// by the organizer are sent to the attendees.
+func (c *client) UpdateEvent(remoteUserID, eventID string, in *remote.Event) (*remote.Event, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return nil, errors.Wrap(err, "local UpdateEvent")
+	}
+
+	event, changes, err := c.impl.store.updateEvent(remoteUserID, eventID, in)
+	if err != nil {
+		return nil, errors.Wrap(err, "local UpdateEvent")
+	}
+	c.impl.notify(changes)
+
+	return event, nil
+}
+
+func (c *client) DeleteEvent(remoteUserID, eventID string) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return errors.Wrap(err, "local DeleteEvent")
+	}
+
+	changes, err := c.impl.store.deleteEvent(remoteUserID, eventID)
+	if err != nil {
+		return errors.Wrap(err, "local DeleteEvent")
+	}
+	c.impl.notify(changes)
+
+	return nil
+}
+
+// CancelEvent removes the event from the calendar of the organizer and marks
+// the copies of the attendees as cancelled.
+func (c *client) CancelEvent(remoteUserID, eventID, comment string) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return errors.Wrap(err, "local CancelEvent")
+	}
+
+	changes, err := c.impl.store.cancelEvent(remoteUserID, eventID, comment)
+	if err != nil {
+		return errors.Wrap(err, "local CancelEvent")
+	}
+	c.impl.notify(changes)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const (
+	defaultMeetingDuration = 30 * time.Minute
+	defaultMaxCandidates   = 5
+	defaultSearchWindow    = 48 * time.Hour
+	meetingTimeStep        = 30 * time.Minute
+)
+
+// FindMeetingTimes suggests the earliest slots of the time constraint where
+// the user and the attendees known to the store are all free. Attendees that
+// aren't fixture users are assumed to be free.
+func (c *client) FindMeetingTimes(remoteUserID string, params *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	duration := defaultMeetingDuration
+	if params.MeetingDuration != nil && *params.MeetingDuration > 0 {
+		duration = *params.MeetingDuration
+	}
+	maxCandidates := defaultMaxCandidates
+	if params.MaxCandidates != nil && *params.MaxCandidates > 0 {
+		maxCandidates = *params.MaxCandidates
+	}
+
+	slots := []remote.TimeSlot{}
+	if params.TimeConstraint != nil {
+		slots = params.TimeConstraint.TimeSlots
+	}
+	if len(slots) == 0 {
+		start := time.Now().UTC().Truncate(meetingTimeStep).Add(meetingTimeStep)
+		slots = []remote.TimeSlot{{
+			Start: remote.NewDateTime(start, "UTC"),
+			End:   remote.NewDateTime(start.Add(defaultSearchWindow), "UTC"),
+		}}
+	}
+
+	userIDs := []string{remoteUserID}
+	for _, a := range params.Attendees {
+		if a.EmailAddress == nil {
+			continue
+		}
+		attendeeID := c.impl.store.findUserID(a.EmailAddress.Address)
+		if attendeeID != "" && attendeeID != remoteUserID {
+			userIDs = append(userIDs, attendeeID)
+		}
+	}
+
+	results := &remote.MeetingTimeSuggestionResults{
+		MeetingTimeSuggestions: []*remote.MeetingTimeSuggestion{},
+	}
+	for _, slot := range slots {
+		slotStart, slotEnd := slot.Start.Time(), slot.End.Time()
+		busy := []*remote.Event{}
+		for _, userID := range userIDs {
+			events, err := c.impl.store.getEventsBetween(userID, "", slotStart, slotEnd)
+			if err != nil {
+				return nil, errors.Wrap(err, "local FindMeetingTimes")
+			}
+			for _, e := range events {
+				if e.ShowAs != "free" {
+					busy = append(busy, e)
+				}
+			}
+		}
+
+		for start := slotStart; !start.Add(duration).After(slotEnd); start = start.Add(meetingTimeStep) {
+			if len(results.MeetingTimeSuggestions) >= maxCandidates {
+				return results, nil
+			}
+			end := start.Add(duration)
+			if overlapsAny(busy, start, end) {
+				continue
+			}
+			results.MeetingTimeSuggestions = append(results.MeetingTimeSuggestions, &remote.MeetingTimeSuggestion{
+				MeetingTimeSlot: &remote.TimeSlot{
+					Start: remote.NewDateTime(start.UTC(), "UTC"),
+					End:   remote.NewDateTime(end.UTC(), "UTC"),
+				},
+				OrganizerAvailability: "free",
+				Confidence:            100,
+				Order:                 int32(len(results.MeetingTimeSuggestions) + 1),
+			})
+		}
+	}
+
+	if len(results.MeetingTimeSuggestions) == 0 {
+		results.EmptySuggestionReason = "attendeesUnavailable"
+	}
+	return results, nil
+}
+
+func overlapsAny(events []*remote.Event, start, end time.Time) bool {
+	for _, e := range events {
+		if e.Start.Time().Before(end) && e.End.Time().After(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// fixture is the content of a JSON file in the fixtures directory, one per
+// calendar user.
+type fixture struct {
+	User            *remote.User            `json:"user"`
+	MailboxSettings *remote.MailboxSettings `json:"mailboxSettings,omitempty"`
+	Calendars       []*remote.Calendar      `json:"calendars,omitempty"`
+	Events          []*fixtureEvent         `json:"events,omitempty"`
+}
+
+// fixtureEvent is an event, optionally scheduled relative to the day the
+// fixtures are loaded so that the fixtures stay useful over time. When Day is
+// set, StartTime and EndTime ("15:04") replace Start and End. Events without
+// CalendarID belong to the first calendar of the user.
+type fixtureEvent struct {
+	remote.Event
+	CalendarID string `json:"calendarId,omitempty"`
+	Day        *int   `json:"day,omitempty"`
+	StartTime  string `json:"startTime,omitempty"`
+	EndTime    string `json:"endTime,omitempty"`
+}
+
+// loadFixtures reads all JSON fixtures of the directory. Relative events are
+// placed in the time zone of their user.
+func loadFixtures(dir string, now time.Time) ([]*fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := []*fixture{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		f := &fixture{}
+		err = json.Unmarshal(data, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid fixture %s", filepath.Base(path))
+		}
+		if f.User == nil || f.User.ID == "" {
+			return nil, errors.Errorf("fixture %s has no user", filepath.Base(path))
+		}
+		if f.MailboxSettings == nil {
+			f.MailboxSettings = &remote.MailboxSettings{TimeZone: "UTC"}
+		}
+
+		for _, e := range f.Events {
+			err = e.resolve(now, f.MailboxSettings.TimeZone)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid event %s in fixture %s", e.ID, filepath.Base(path))
+			}
+		}
+
+		fixtures = append(fixtures, f)
+	}
+
+	return fixtures, nil
+}
+
+func (e *fixtureEvent) resolve(now time.Time, timeZone string) error {
+	if e.Day == nil {
+		if e.Start == nil || e.End == nil {
+			return errors.New("event has no start or end")
+		}
+		return nil
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	day := now.In(loc)
+	day = time.Date(day.Year(), day.Month(), day.Day()+*e.Day, 0, 0, 0, 0, loc)
+
+	if e.IsAllDay {
+		e.Start = remote.NewDateTime(day, loc.String())
+		e.End = remote.NewDateTime(day.AddDate(0, 0, 1), loc.String())
+		return nil
+	}
+
+	start, err := time.ParseInLocation("15:04", e.StartTime, loc)
+	if err != nil {
+		return err
+	}
+	end, err := time.ParseInLocation("15:04", e.EndTime, loc)
+	if err != nil {
+		return err
+	}
+	e.Start = remote.NewDateTime(day.Add(time.Duration(start.Hour())*time.Hour+time.Duration(start.Minute())*time.Minute), loc.String())
+	e.End = remote.NewDateTime(day.Add(time.Duration(end.Hour())*time.Hour+time.Duration(end.Minute())*time.Minute), loc.String())
+	return nil
+}
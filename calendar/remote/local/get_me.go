@@ -0,0 +1,49 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const (
+	ErrorUserInactive = "You have been marked inactive because your refresh token is expired. Please disconnect and reconnect your account again."
+	LogUserInactive   = "User %s is inactive. Please disconnect and reconnect your account."
+)
+
+func (c *client) GetMe() (*remote.User, error) {
+	user, err := c.impl.store.getUser(c.remoteUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "local GetMe")
+	}
+
+	return user, nil
+}
+
+func (c *client) GetMailboxSettings(remoteUserID string) (*remote.MailboxSettings, error) {
+	settings, err := c.impl.store.getMailboxSettings(remoteUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "local GetMailboxSettings")
+	}
+
+	return settings, nil
+}
+
+func (c *client) SetAutomaticReplies(remoteUserID string, setting *remote.AutomaticRepliesSetting) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+	if err := c.checkWriteAccess(remoteUserID); err != nil {
+		return errors.Wrap(err, "local SetAutomaticReplies")
+	}
+
+	err := c.impl.store.setAutomaticReplies(remoteUserID, setting)
+	if err != nil {
+		return errors.Wrap(err, "local SetAutomaticReplies")
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// availabilityRank orders the availabilities from the least to the most
+// busy, a block being as busy as its busiest event.
+var availabilityRank = map[byte]int{
+	remote.AvailabilityViewFree:             0,
+	remote.AvailabilityViewWorkingElsewhere: 1,
+	remote.AvailabilityViewTentative:        2,
+	remote.AvailabilityViewBusy:             3,
+	remote.AvailabilityViewOutOfOffice:      4,
+}
+
+// GetSchedule returns the free/busy availability of the fixture users with
+// the requested mails, as getSchedule does. Unknown mails get an error.
+func (c *client) GetSchedule(requests []*remote.ScheduleUserInfo, startTime, endTime *remote.DateTime, availabilityViewInterval int) ([]*remote.ScheduleInformation, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+	if availabilityViewInterval <= 0 {
+		return nil, errors.New("local GetSchedule: invalid availability view interval")
+	}
+
+	start, end := startTime.Time(), endTime.Time()
+	interval := time.Duration(availabilityViewInterval) * time.Minute
+
+	result := []*remote.ScheduleInformation{}
+	for _, req := range requests {
+		userID := c.impl.store.findUserID(req.Mail)
+		if userID == "" {
+			result = append(result, &remote.ScheduleInformation{
+				ScheduleID: req.Mail,
+				Error: &remote.ScheduleInformationError{
+					Message:      "user not found",
+					ResponseCode: "ErrorMailRecipientNotFound",
+				},
+			})
+			continue
+		}
+
+		events, err := c.impl.store.getEventsBetween(userID, "", start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, "local GetSchedule")
+		}
+
+		view := []byte{}
+		for blockStart := start; blockStart.Before(end); blockStart = blockStart.Add(interval) {
+			blockEnd := blockStart.Add(interval)
+			availability := byte(remote.AvailabilityViewFree)
+			for _, e := range events {
+				if !e.Start.Time().Before(blockEnd) || !e.End.Time().After(blockStart) {
+					continue
+				}
+				if a := showAsAvailability(e.ShowAs); availabilityRank[a] > availabilityRank[availability] {
+					availability = a
+				}
+			}
+			view = append(view, availability)
+		}
+
+		items := []*remote.ScheduleItem{}
+		for _, e := range events {
+			items = append(items, &remote.ScheduleItem{
+				Start:   e.Start,
+				End:     e.End,
+				Status:  scheduleStatus(e.ShowAs),
+				Subject: e.Subject,
+			})
+		}
+
+		result = append(result, &remote.ScheduleInformation{
+			ScheduleID:       req.Mail,
+			AvailabilityView: remote.AvailabilityView(view),
+			ScheduleItems:    items,
+		})
+	}
+
+	return result, nil
+}
+
+func showAsAvailability(showAs string) byte {
+	switch showAs {
+	case remote.ScheduleStatusFree:
+		return remote.AvailabilityViewFree
+	case remote.ScheduleStatusTentative:
+		return remote.AvailabilityViewTentative
+	case remote.ScheduleStatusOof:
+		return remote.AvailabilityViewOutOfOffice
+	case remote.ScheduleStatusWorkingElsewhere:
+		return remote.AvailabilityViewWorkingElsewhere
+	}
+	return remote.AvailabilityViewBusy
+}
+
+func scheduleStatus(showAs string) string {
+	if showAs == "" {
+		return remote.ScheduleStatusBusy
+	}
+	return showAs
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const renewSubscriptionBeforeExpiration = 12 * time.Hour
+
+// webhook is a Graph change notification.
+type webhook struct {
+	ChangeType                     string `json:"changeType"`
+	ClientState                    string `json:"clientState,omitempty"`
+	Resource                       string `json:"resource,omitempty"`
+	SubscriptionExpirationDateTime string `json:"subscriptionExpirationDateTime,omitempty"`
+	SubscriptionID                 string `json:"subscriptionId"`
+}
+
+func (r *impl) HandleWebhook(w http.ResponseWriter, req *http.Request) []*remote.Notification {
+	if vtok := req.FormValue("validationToken"); vtok != "" {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(vtok))
+		return nil
+	}
+
+	rawData, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		r.logger.Infof("local: failed to process webhook: `%v`.", err)
+		return nil
+	}
+
+	var v struct {
+		Value []*webhook `json:"value"`
+	}
+	err = json.Unmarshal(rawData, &v)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		r.logger.Infof("local: failed to process webhook: `%v`.", err)
+		return nil
+	}
+
+	notifications := []*remote.Notification{}
+	for _, wh := range v.Value {
+		n := &remote.Notification{
+			SubscriptionID: wh.SubscriptionID,
+			ChangeType:     wh.ChangeType,
+			ClientState:    wh.ClientState,
+			IsBare:         true,
+			WebhookRawData: rawData,
+			Webhook:        wh,
+		}
+
+		expires, err := time.Parse(time.RFC3339, wh.SubscriptionExpirationDateTime)
+		if err != nil {
+			r.logger.With(bot.LogContext{
+				"SubscriptionID": wh.SubscriptionID,
+			}).Infof("local: invalid subscription expiration in webhook: `%v`.", err)
+			return nil
+		}
+		if time.Now().After(expires.Add(-renewSubscriptionBeforeExpiration)) {
+			n.RecommendRenew = true
+		}
+
+		notifications = append(notifications, n)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	return notifications
+}
+
+// notify delivers a webhook for each change to the subscriptions of the user
+// whose calendar changed. Expired subscriptions are not notified.
+func (r *impl) notify(changes []change) {
+	for _, ch := range changes {
+		for _, sub := range r.store.listSubscriptions(ch.userID) {
+			expires, err := time.Parse(time.RFC3339, sub.ExpirationDateTime)
+			if err != nil || time.Now().After(expires) {
+				continue
+			}
+
+			body, err := json.Marshal(map[string][]*webhook{
+				"value": {{
+					ChangeType:                     ch.changeType,
+					ClientState:                    sub.ClientState,
+					Resource:                       makeResource(ch.userID, ch.eventID),
+					SubscriptionExpirationDateTime: sub.ExpirationDateTime,
+					SubscriptionID:                 sub.ID,
+				}},
+			})
+			if err != nil {
+				r.logger.Warnf("local: failed to encode webhook: %v", err)
+				continue
+			}
+			go r.deliver(sub.NotificationURL, body)
+		}
+	}
+}
+
+func makeResource(userID, eventID string) string {
+	return "Users/" + userID + "/Events/" + eventID
+}
+
+func parseResource(resource string) (userID, eventID string, err error) {
+	parts := strings.Split(resource, "/")
+	if len(parts) != 4 || parts[0] != "Users" || parts[2] != "Events" {
+		return "", "", errors.Errorf("unknown resource %s", resource)
+	}
+	return parts[1], parts[3], nil
+}
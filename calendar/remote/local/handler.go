@@ -0,0 +1,137 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+)
+
+const (
+	pathAuthorize = "/oauth2/authorize"
+	pathToken     = "/oauth2/token"
+
+	// tokenTTL is long enough for the tokens to never be refreshed in a
+	// development session.
+	tokenTTL = 365 * 24 * time.Hour
+)
+
+type handler struct {
+	*impl
+}
+
+// Init registers the fake OAuth2 endpoints and the event injection endpoint
+// of the local remote.
+func Init(h *httputils.Handler, r remote.Remote) {
+	localImpl, ok := r.(*impl)
+	if !ok {
+		return
+	}
+	handler := &handler{
+		impl: localImpl,
+	}
+
+	localRouter := h.Router.PathPrefix(config.PathLocal).Subrouter()
+	localRouter.HandleFunc(pathAuthorize, handler.authorize).Methods(http.MethodGet)
+	localRouter.HandleFunc(pathToken, handler.token).Methods(http.MethodPost)
+	localRouter.HandleFunc(config.PathEvents, handler.injectEvent).Methods(http.MethodPost)
+}
+
+// authorize lets the user pick the fixture user to connect as.
+func (h *handler) authorize(w http.ResponseWriter, r *http.Request) {
+	redirectURI := r.URL.Query().Get("redirect_uri")
+	state := r.URL.Query().Get("state")
+	if redirectURI == "" {
+		http.Error(w, "missing redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	items := ""
+	for _, u := range h.store.listUsers() {
+		q := url.Values{}
+		q.Set("code", u.ID)
+		q.Set("state", state)
+		items += fmt.Sprintf(`<li><a href="%s">%s (%s)</a></li>`,
+			html.EscapeString(redirectURI+"?"+q.Encode()),
+			html.EscapeString(u.DisplayName),
+			html.EscapeString(u.Mail))
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintf(w, `<!DOCTYPE html><html><body><p>Connect as:</p><ul>%s</ul></body></html>`, items)
+}
+
+// token issues tokens whose value is the ID of the fixture user.
+func (h *handler) token(w http.ResponseWriter, r *http.Request) {
+	userID := ""
+	switch r.FormValue("grant_type") {
+	case "authorization_code":
+		userID = r.FormValue("code")
+	case "refresh_token":
+		userID = r.FormValue("refresh_token")
+	}
+
+	if _, err := h.store.getUser(userID); err != nil {
+		httputils.WriteJSONResponse(w, map[string]string{"error": "invalid_grant"}, http.StatusBadRequest)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, map[string]interface{}{
+		"access_token":  userID,
+		"refresh_token": userID,
+		"token_type":    "Bearer",
+		"expires_in":    int(tokenTTL.Seconds()),
+	}, http.StatusOK)
+}
+
+// injectEvent creates or updates an event organized by the user of the "user"
+// query parameter, and notifies the subscriptions of the calendars it lands
+// in.
+func (h *handler) injectEvent(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Mattermost-User-Id") == "" {
+		httputils.WriteUnauthorizedError(w, errors.New("unauthorized"))
+		return
+	}
+
+	in := &remote.Event{}
+	err := json.NewDecoder(r.Body).Decode(in)
+	if err != nil {
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	event, err := h.putEvent(r.URL.Query().Get("user"), in)
+	if err != nil {
+		httputils.WriteBadRequestError(w, err)
+		return
+	}
+
+	httputils.WriteJSONResponse(w, event, http.StatusOK)
+}
+
+// putEvent stores the event and notifies the subscriptions of the changed
+// calendars, as if the event had been changed in the remote calendar.
+func (r *impl) putEvent(remoteUserID string, in *remote.Event) (*remote.Event, error) {
+	if in.Start == nil || in.End == nil {
+		return nil, errors.New("event has no start or end")
+	}
+
+	event, changes, err := r.store.putEvent(remoteUserID, in)
+	if err != nil {
+		return nil, err
+	}
+	r.notify(changes)
+
+	return event, nil
+}
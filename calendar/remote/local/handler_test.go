@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/httputils"
+)
+
+func TestHandler(t *testing.T) {
+	r, deliveries := newTestRemote(t)
+	h := httputils.NewHandler()
+	Init(h, r)
+
+	t.Run("authorize lists fixture users", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/local/oauth2/authorize?redirect_uri=https://mm/complete&state=s1", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `href="https://mm/complete?code=alice&amp;state=s1"`)
+		require.Contains(t, w.Body.String(), "Bob Lee")
+	})
+
+	t.Run("token", func(t *testing.T) {
+		for grant, field := range map[string]string{"authorization_code": "code", "refresh_token": "refresh_token"} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/local/oauth2/token", strings.NewReader(url.Values{
+				"grant_type": {grant},
+				field:        {"bob"},
+			}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			h.ServeHTTP(w, req)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var token struct {
+				AccessToken string `json:"access_token"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &token))
+			require.Equal(t, "bob", token.AccessToken)
+		}
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/local/oauth2/token", strings.NewReader("grant_type=authorization_code&code=carol"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		h.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("inject event", func(t *testing.T) {
+		alice := newTestClient(r, "alice")
+		_, err := alice.CreateMySubscription("https://mm.example.com/notify", "alice")
+		require.NoError(t, err)
+
+		body := `{"id":"alice-standup","subject":"Daily standup (moved)",` +
+			`"start":{"dateTime":"2030-01-01T11:00:00","timeZone":"UTC"},` +
+			`"end":{"dateTime":"2030-01-01T11:15:00","timeZone":"UTC"}}`
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/local/events?user=alice", strings.NewReader(body)))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/local/events?user=alice", strings.NewReader(body))
+		req.Header.Set("Mattermost-User-Id", "mm-user")
+		h.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		d := receive(t, deliveries)
+		require.Contains(t, d.body, `"changeType":"updated"`)
+
+		event, err := alice.GetEvent("alice", "alice-standup")
+		require.NoError(t, err)
+		require.Equal(t, "Daily standup (moved)", event.Subject)
+		require.Equal(t, remote.EventResponseStatusAccepted, event.ResponseStatus.Response)
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// Kind is the name of the local remote. It is not a build provider: it
+// replaces the build provider's remote when LocalFixturesPath is configured.
+const Kind = "local"
+
+// impl is an in-memory remote loaded from a directory of JSON fixtures, one
+// per user. It serves its own OAuth2 endpoints under config.PathLocal, and
+// delivers Graph-like webhooks to the subscriptions when events change.
+// Changes are lost when the configuration changes and the fixtures are
+// reloaded.
+type impl struct {
+	conf    *config.Config
+	logger  bot.Logger
+	store   *store
+	loadErr error
+
+	// deliver posts a webhook body to a notification URL.
+	deliver func(notificationURL string, body []byte)
+}
+
+func init() {
+	remote.Makers[Kind] = NewRemote
+}
+
+func NewRemote(conf *config.Config, logger bot.Logger) remote.Remote {
+	r := &impl{
+		conf:   conf,
+		logger: logger,
+	}
+	r.deliver = r.post
+
+	fixtures, err := loadFixtures(conf.LocalFixturesPath, time.Now())
+	if err != nil {
+		r.loadErr = err
+		logger.Warnf("local: failed to load fixtures from %s: %v", conf.LocalFixturesPath, err)
+	}
+	r.store = newStore(fixtures)
+
+	return r
+}
+
+// makeClient creates a new client acting as the fixture user identified by
+// the token.
+func (r *impl) makeClient(ctx context.Context, token *oauth2.Token, mattermostUserID string, poster bot.Poster, userTokenHelpers remote.UserTokenHelpers) remote.Client {
+	return &client{
+		ctx:              ctx,
+		impl:             r,
+		remoteUserID:     token.AccessToken,
+		mattermostUserID: mattermostUserID,
+		tokenHelpers:     userTokenHelpers,
+		Logger:           r.logger,
+		Poster:           poster,
+	}
+}
+
+// MakeUserClient creates a new client having user-delegated permissions with refreshed token.
+func (r *impl) MakeUserClient(ctx context.Context, oauthToken *oauth2.Token, mattermostUserID string, poster bot.Poster, userTokenHelpers remote.UserTokenHelpers) remote.Client {
+	config := r.NewOAuth2Config()
+
+	token, err := userTokenHelpers.RefreshAndStoreToken(oauthToken, config, mattermostUserID)
+	if err != nil {
+		r.logger.Warnf("Not able to refresh or store the token", "error", err.Error())
+		return &client{impl: r}
+	}
+
+	return r.makeClient(ctx, token, mattermostUserID, poster, userTokenHelpers)
+}
+
+// MakeSuperuserClient creates a new client used for app-only permissions.
+func (r *impl) MakeSuperuserClient(ctx context.Context) (remote.Client, error) {
+	c := &client{
+		ctx:    ctx,
+		impl:   r,
+		Logger: r.logger,
+	}
+	token, err := c.GetSuperuserToken()
+	if err != nil {
+		return nil, err
+	}
+
+	o := &oauth2.Token{
+		AccessToken: token,
+		TokenType:   "Bearer",
+	}
+	return r.makeClient(ctx, o, "", nil, nil), nil
+}
+
+func (r *impl) NewOAuth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     Kind,
+		ClientSecret: Kind,
+		RedirectURL:  r.conf.PluginURL + config.FullPathOAuth2Redirect,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   r.conf.PluginURL + config.PathLocal + pathAuthorize,
+			TokenURL:  r.conf.PluginURL + config.PathLocal + pathToken,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+func (r *impl) CheckConfiguration(cfg config.StoredConfig) error {
+	if cfg.LocalFixturesPath == "" {
+		return errors.New("LocalFixturesPath to be set in the config")
+	}
+	if r.loadErr != nil {
+		return errors.Wrap(r.loadErr, "failed to load local fixtures")
+	}
+
+	return nil
+}
+
+func (r *impl) post(notificationURL string, body []byte) {
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	resp, err := httpClient.Post(notificationURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		r.logger.Warnf("local: failed to deliver webhook: %v", err)
+		return
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,565 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const defaultCalendarID = "calendar"
+
+const (
+	occurrenceIDSeparator = "_"
+	occurrenceIDFormat    = "20060102T150405Z"
+)
+
+const (
+	changeTypeCreated = "created"
+	changeTypeUpdated = "updated"
+	changeTypeDeleted = "deleted"
+)
+
+var errNotFound = errors.New("not found")
+
+// store holds the calendars of all fixture users in memory. Values are copied
+// in and out so that callers never share state with the store.
+type store struct {
+	lock sync.Mutex
+
+	users           map[string]*remote.User
+	mailboxSettings map[string]*remote.MailboxSettings
+	calendars       map[string][]*remote.Calendar
+	events          map[string]map[string]*remote.Event
+	eventCalendars  map[string]map[string]string
+	subscriptions   map[string]*remote.Subscription
+}
+
+func newStore(fixtures []*fixture) *store {
+	s := &store{
+		users:           map[string]*remote.User{},
+		mailboxSettings: map[string]*remote.MailboxSettings{},
+		calendars:       map[string][]*remote.Calendar{},
+		events:          map[string]map[string]*remote.Event{},
+		eventCalendars:  map[string]map[string]string{},
+		subscriptions:   map[string]*remote.Subscription{},
+	}
+
+	for _, f := range fixtures {
+		userID := f.User.ID
+		s.users[userID] = f.User
+		s.mailboxSettings[userID] = f.MailboxSettings
+		s.calendars[userID] = f.Calendars
+		if len(f.Calendars) == 0 {
+			s.calendars[userID] = []*remote.Calendar{{ID: defaultCalendarID, Name: "Calendar", Owner: f.User}}
+		}
+		s.events[userID] = map[string]*remote.Event{}
+		s.eventCalendars[userID] = map[string]string{}
+		for _, e := range f.Events {
+			event := e.Event
+			if event.ID == "" {
+				event.ID = model.NewId()
+			}
+			if event.ICalUID == "" {
+				event.ICalUID = event.ID
+			}
+			if event.ResponseStatus == nil {
+				event.ResponseStatus = &remote.EventResponseStatus{Response: remote.EventResponseStatusNotAnswered}
+			}
+			s.events[userID][event.ID] = &event
+			if e.CalendarID != "" {
+				s.eventCalendars[userID][event.ID] = e.CalendarID
+			}
+		}
+	}
+
+	return s
+}
+
+func (s *store) listUsers() []*remote.User {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	users := []*remote.User{}
+	for _, u := range s.users {
+		users = append(users, clone(u))
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users
+}
+
+func (s *store) getUser(userID string) (*remote.User, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	u := s.users[userID]
+	if u == nil {
+		return nil, errors.Wrapf(errNotFound, "user %s", userID)
+	}
+	return clone(u), nil
+}
+
+func (s *store) findUserID(email string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.userIDByEmail(email)
+}
+
+// userIDByEmail must be called with the lock held.
+func (s *store) userIDByEmail(email string) string {
+	for id, u := range s.users {
+		if strings.EqualFold(u.Mail, email) || strings.EqualFold(u.UserPrincipalName, email) {
+			return id
+		}
+	}
+	return ""
+}
+
+func (s *store) getMailboxSettings(userID string) (*remote.MailboxSettings, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	settings := s.mailboxSettings[userID]
+	if settings == nil {
+		return nil, errors.Wrapf(errNotFound, "user %s", userID)
+	}
+	return clone(settings), nil
+}
+
+func (s *store) setAutomaticReplies(userID string, setting *remote.AutomaticRepliesSetting) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	settings := s.mailboxSettings[userID]
+	if settings == nil {
+		return errors.Wrapf(errNotFound, "user %s", userID)
+	}
+	settings.AutomaticRepliesSetting = clone(setting)
+	return nil
+}
+
+func (s *store) getCalendars(userID string) ([]*remote.Calendar, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.users[userID] == nil {
+		return nil, errors.Wrapf(errNotFound, "user %s", userID)
+	}
+	return clone(s.calendars[userID]), nil
+}
+
+func (s *store) createCalendar(userID string, cal *remote.Calendar) (*remote.Calendar, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.users[userID] == nil {
+		return nil, errors.Wrapf(errNotFound, "user %s", userID)
+	}
+	cal = clone(cal)
+	cal.ID = model.NewId()
+	cal.Owner = clone(s.users[userID])
+	s.calendars[userID] = append(s.calendars[userID], cal)
+	return clone(cal), nil
+}
+
+func (s *store) hasCalendarLocked(userID, calendarID string) bool {
+	for _, cal := range s.calendars[userID] {
+		if cal.ID == calendarID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *store) deleteCalendar(userID, calendarID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	calendars := s.calendars[userID]
+	for i, cal := range calendars {
+		if cal.ID == calendarID {
+			s.calendars[userID] = append(calendars[:i:i], calendars[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Wrapf(errNotFound, "calendar %s", calendarID)
+}
+
+func (s *store) getEvent(userID, eventID string) (*remote.Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e := s.events[userID][eventID]
+	if e == nil {
+		return s.getOccurrenceLocked(userID, eventID)
+	}
+	return clone(e), nil
+}
+
+// getOccurrenceLocked returns the occurrence of a recurring event from its
+// ID, made of the ID of the series master and of the original start.
+func (s *store) getOccurrenceLocked(userID, eventID string) (*remote.Event, error) {
+	i := strings.LastIndex(eventID, occurrenceIDSeparator)
+	if i < 0 {
+		return nil, errors.Wrapf(errNotFound, "event %s", eventID)
+	}
+	master := s.events[userID][eventID[:i]]
+	if master == nil || master.Recurrence == nil {
+		return nil, errors.Wrapf(errNotFound, "event %s", eventID)
+	}
+	originalStart, err := time.Parse(occurrenceIDFormat, eventID[i+1:])
+	if err != nil {
+		return nil, errors.Wrapf(errNotFound, "event %s", eventID)
+	}
+
+	start := master.Start.Time()
+	duration := master.End.Time().Sub(start)
+	for _, t := range master.Recurrence.Occurrences(start, duration, originalStart, originalStart.Add(time.Second)) {
+		if t.Equal(originalStart) {
+			return occurrence(master, t), nil
+		}
+	}
+	return nil, errors.Wrapf(errNotFound, "event %s", eventID)
+}
+
+// occurrence returns the occurrence of the series starting at the given time,
+// as listed in a calendar view.
+func occurrence(master *remote.Event, start time.Time) *remote.Event {
+	duration := master.End.Time().Sub(master.Start.Time())
+
+	e := clone(master)
+	e.ID = master.ID + occurrenceIDSeparator + start.UTC().Format(occurrenceIDFormat)
+	e.Type = remote.EventTypeOccurrence
+	e.SeriesMasterID = master.ID
+	e.OriginalStart = start.UTC().Format(time.RFC3339)
+	e.Recurrence = nil
+	e.Start = remote.NewDateTime(start, master.Start.TimeZone)
+	e.End = remote.NewDateTime(start.Add(duration), master.End.TimeZone)
+	return e
+}
+
+// calendarOfLocked returns the ID of the calendar holding the event. Events
+// created through the client go to the default calendar.
+func (s *store) calendarOfLocked(userID, eventID string) string {
+	if calendarID, ok := s.eventCalendars[userID][eventID]; ok {
+		return calendarID
+	}
+	return s.defaultCalendarIDLocked(userID)
+}
+
+// defaultCalendarIDLocked returns the ID of the first calendar of the user.
+func (s *store) defaultCalendarIDLocked(userID string) string {
+	if len(s.calendars[userID]) == 0 {
+		return ""
+	}
+	return s.calendars[userID][0].ID
+}
+
+// getEventsBetween returns the non-cancelled events of a calendar of the user
+// overlapping with [start, end), sorted by start time. An empty calendarID
+// stands for the default calendar.
+func (s *store) getEventsBetween(userID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	events, ok := s.events[userID]
+	if !ok {
+		return nil, errors.Wrapf(errNotFound, "user %s", userID)
+	}
+	if calendarID == "" {
+		calendarID = s.defaultCalendarIDLocked(userID)
+	} else if !s.hasCalendarLocked(userID, calendarID) {
+		return nil, errors.Wrapf(errNotFound, "calendar %s", calendarID)
+	}
+
+	result := []*remote.Event{}
+	for _, e := range events {
+		if e.IsCancelled || s.calendarOfLocked(userID, e.ID) != calendarID {
+			continue
+		}
+		// Series are listed as their occurrences, as in a calendar view.
+		if e.Recurrence != nil {
+			seriesStart := e.Start.Time()
+			for _, t := range e.Recurrence.Occurrences(seriesStart, e.End.Time().Sub(seriesStart), start, end) {
+				result = append(result, occurrence(e, t))
+			}
+			continue
+		}
+		if e.Start.Time().Before(end) && e.End.Time().After(start) {
+			result = append(result, clone(e))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Time().Before(result[j].Start.Time())
+	})
+	return result, nil
+}
+
+// change is an event change in the calendar of a user.
+type change struct {
+	userID     string
+	eventID    string
+	changeType string
+}
+
+// putEvent stores the event of the organizer, and copies it to the calendars
+// of the attendees known to the store. It returns the changes made to each
+// calendar.
+func (s *store) putEvent(organizerID string, event *remote.Event) (*remote.Event, []change, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.putEventLocked(organizerID, event)
+}
+
+func (s *store) putEventLocked(organizerID string, event *remote.Event) (*remote.Event, []change, error) {
+	if s.users[organizerID] == nil {
+		return nil, nil, errors.Wrapf(errNotFound, "user %s", organizerID)
+	}
+
+	event = clone(event)
+	if event.ID == "" {
+		event.ID = model.NewId()
+	}
+	if event.ICalUID == "" {
+		event.ICalUID = model.NewId()
+	}
+	if event.Organizer == nil {
+		organizer := s.users[organizerID]
+		event.Organizer = &remote.Attendee{
+			RemoteID:     organizer.ID,
+			EmailAddress: &remote.EmailAddress{Address: organizer.Mail, Name: organizer.DisplayName},
+		}
+	}
+	event.IsOrganizer = true
+	event.ResponseStatus = &remote.EventResponseStatus{Response: remote.EventResponseStatusAccepted}
+	changes := []change{{organizerID, event.ID, changeType(s.events[organizerID][event.ID])}}
+	s.events[organizerID][event.ID] = event
+
+	for _, a := range event.Attendees {
+		if a.EmailAddress == nil {
+			continue
+		}
+		attendeeID := s.userIDByEmail(a.EmailAddress.Address)
+		if attendeeID == "" || attendeeID == organizerID {
+			continue
+		}
+		a.RemoteID = attendeeID
+
+		copied := clone(event)
+		copied.IsOrganizer = false
+		copied.ResponseRequested = true
+		copied.ResponseStatus = &remote.EventResponseStatus{Response: remote.EventResponseStatusNotAnswered}
+		copied.ID = model.NewId()
+		var existing *remote.Event
+		for _, e := range s.events[attendeeID] {
+			if e.ICalUID == event.ICalUID {
+				existing = e
+				copied.ID = e.ID
+				copied.ResponseStatus = e.ResponseStatus
+			}
+		}
+		s.events[attendeeID][copied.ID] = copied
+		changes = append(changes, change{attendeeID, copied.ID, changeType(existing)})
+	}
+
+	return clone(event), changes, nil
+}
+
+// updateEvent sets the fields of the event that are set in the update.
+// Updates by the organizer are sent to the attendees.
+func (s *store) updateEvent(userID, eventID string, update *remote.Event) (*remote.Event, []change, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e := s.events[userID][eventID]
+	if e == nil {
+		return nil, nil, errors.Wrapf(errNotFound, "event %s", eventID)
+	}
+
+	updated := clone(e)
+	if update.Start != nil {
+		updated.Start = update.Start
+	}
+	if update.End != nil {
+		updated.End = update.End
+	}
+	if update.Subject != "" {
+		updated.Subject = update.Subject
+	}
+	if update.Body != nil {
+		updated.Body = update.Body
+	}
+	if update.Location != nil {
+		updated.Location = update.Location
+	}
+	if update.ShowAs != "" {
+		updated.ShowAs = update.ShowAs
+	}
+	if update.Recurrence != nil {
+		updated.Recurrence = update.Recurrence
+		updated.Type = remote.EventTypeSeriesMaster
+	}
+	if len(update.Attendees) > 0 {
+		updated.Attendees = update.Attendees
+	}
+
+	if !e.IsOrganizer {
+		s.events[userID][eventID] = updated
+		return clone(updated), []change{{userID, eventID, changeTypeUpdated}}, nil
+	}
+	return s.putEventLocked(userID, updated)
+}
+
+// deleteEvent removes the event from the calendar of the user only.
+func (s *store) deleteEvent(userID, eventID string) ([]change, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.events[userID][eventID] == nil {
+		return nil, errors.Wrapf(errNotFound, "event %s", eventID)
+	}
+	delete(s.events[userID], eventID)
+	return []change{{userID, eventID, changeTypeDeleted}}, nil
+}
+
+// cancelEvent removes the event from the calendar of its organizer, and marks
+// the copies of the attendees as cancelled, with the comment as body.
+func (s *store) cancelEvent(userID, eventID, comment string) ([]change, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e := s.events[userID][eventID]
+	if e == nil {
+		return nil, errors.Wrapf(errNotFound, "event %s", eventID)
+	}
+	if !e.IsOrganizer {
+		return nil, errors.New("only the organizer can cancel the event")
+	}
+
+	delete(s.events[userID], eventID)
+	changes := []change{{userID, eventID, changeTypeDeleted}}
+	for attendeeID, events := range s.events {
+		for _, copied := range events {
+			if attendeeID == userID || copied.ICalUID != e.ICalUID {
+				continue
+			}
+			copied.IsCancelled = true
+			copied.Subject = "Canceled: " + e.Subject
+			if comment != "" {
+				copied.Body = &remote.ItemBody{Content: comment, ContentType: "text"}
+			}
+			changes = append(changes, change{attendeeID, copied.ID, changeTypeUpdated})
+		}
+	}
+	return changes, nil
+}
+
+func changeType(existing *remote.Event) string {
+	if existing == nil {
+		return changeTypeCreated
+	}
+	return changeTypeUpdated
+}
+
+// respond records the response of the user to the event, on the user's copy
+// and on the organizer's attendee list. It returns the change made to the
+// organizer's calendar, if the organizer is known to the store.
+func (s *store) respond(userID, eventID, response string) ([]change, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e := s.events[userID][eventID]
+	if e == nil {
+		return nil, errors.Wrapf(errNotFound, "event %s", eventID)
+	}
+	if e.IsOrganizer {
+		return nil, errors.New("the organizer can't respond to their own event")
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	e.ResponseStatus = &remote.EventResponseStatus{Response: response, Time: now}
+
+	organizerID := ""
+	if e.Organizer != nil && e.Organizer.EmailAddress != nil {
+		organizerID = s.userIDByEmail(e.Organizer.EmailAddress.Address)
+	}
+	changes := []change{}
+	for _, organizerEvent := range s.events[organizerID] {
+		if organizerEvent.ICalUID != e.ICalUID {
+			continue
+		}
+		for _, a := range organizerEvent.Attendees {
+			if a.EmailAddress != nil && s.userIDByEmail(a.EmailAddress.Address) == userID {
+				a.Status = &remote.EventResponseStatus{Response: response, Time: now}
+			}
+		}
+		changes = append(changes, change{organizerID, organizerEvent.ID, changeTypeUpdated})
+	}
+
+	return changes, nil
+}
+
+func (s *store) getSubscription(subscriptionID string) (*remote.Subscription, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sub := s.subscriptions[subscriptionID]
+	if sub == nil {
+		return nil, errors.Wrapf(errNotFound, "subscription %s", subscriptionID)
+	}
+	return clone(sub), nil
+}
+
+func (s *store) putSubscription(sub *remote.Subscription) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.subscriptions[sub.ID] = clone(sub)
+}
+
+func (s *store) deleteSubscription(subscriptionID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.subscriptions[subscriptionID] == nil {
+		return errors.Wrapf(errNotFound, "subscription %s", subscriptionID)
+	}
+	delete(s.subscriptions, subscriptionID)
+	return nil
+}
+
+// listSubscriptions returns the subscriptions of the user, or all of them if
+// userID is empty.
+func (s *store) listSubscriptions(userID string) []*remote.Subscription {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	subs := []*remote.Subscription{}
+	for _, sub := range s.subscriptions {
+		if userID == "" || sub.CreatorID == userID {
+			subs = append(subs, clone(sub))
+		}
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+	return subs
+}
+
+// clone deep-copies v through its JSON representation, which is all the
+// remote types carry.
+func clone[T any](v T) T {
+	var out T
+	data, _ := json.Marshal(v)
+	_ = json.Unmarshal(data, &out)
+	return out
+}
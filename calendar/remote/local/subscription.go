@@ -0,0 +1,142 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package local
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const subscribeTTL = 48 * time.Hour
+
+func newRandomString() string {
+	b := make([]byte, 96)
+	rand.Read(b)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+func (c *client) CreateMySubscription(notificationURL, remoteUserID string) (*remote.Subscription, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+	if remoteUserID == "" {
+		remoteUserID = c.remoteUserID
+	}
+
+	sub := &remote.Subscription{
+		ID:                 model.NewId(),
+		Resource:           "me/events",
+		ChangeType:         "created,updated,deleted",
+		NotificationURL:    notificationURL,
+		ExpirationDateTime: time.Now().Add(subscribeTTL).Format(time.RFC3339),
+		ClientState:        newRandomString(),
+		CreatorID:          remoteUserID,
+	}
+	c.impl.store.putSubscription(sub)
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"resource":           sub.Resource,
+		"changeType":         sub.ChangeType,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("local: created subscription.")
+
+	return sub, nil
+}
+
+func (c *client) DeleteSubscription(sub *remote.Subscription) error {
+	if err := c.checkConnected(); err != nil {
+		return err
+	}
+
+	err := c.impl.store.deleteSubscription(sub.ID)
+	if err != nil {
+		return errors.Wrap(err, "local DeleteSubscription")
+	}
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID": sub.ID,
+	}).Debugf("local: deleted subscription.")
+
+	return nil
+}
+
+func (c *client) RenewSubscription(_, _ string, oldSub *remote.Subscription) (*remote.Subscription, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	sub, err := c.impl.store.getSubscription(oldSub.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "local RenewSubscription")
+	}
+	sub.ExpirationDateTime = time.Now().Add(subscribeTTL).Format(time.RFC3339)
+	c.impl.store.putSubscription(sub)
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("local: renewed subscription.")
+
+	return sub, nil
+}
+
+// ListSubscriptions returns the subscriptions of the user, or all of them for
+// superuser clients.
+func (c *client) ListSubscriptions() ([]*remote.Subscription, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	userID := c.remoteUserID
+	if userID == superuserToken {
+		userID = ""
+	}
+
+	return c.impl.store.listSubscriptions(userID), nil
+}
+
+func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notification, error) {
+	if err := c.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	n := *orig
+	wh, ok := n.Webhook.(*webhook)
+	if !ok {
+		return nil, errors.New("local GetNotificationData: unknown webhook")
+	}
+
+	userID, eventID, err := parseResource(wh.Resource)
+	if err != nil {
+		return nil, errors.Wrap(err, "local GetNotificationData")
+	}
+	if wh.ChangeType == remote.ChangeTypeDeleted {
+		n.Event = &remote.Event{ID: eventID}
+		n.ChangeType = wh.ChangeType
+		n.IsBare = false
+		return &n, nil
+	}
+
+	event, err := c.impl.store.getEvent(userID, eventID)
+	if err != nil {
+		c.Logger.With(bot.LogContext{
+			"Resource":       wh.Resource,
+			"subscriptionID": wh.SubscriptionID,
+		}).Infof("local: failed to fetch notification data resource: `%v`.", err)
+		return nil, errors.Wrap(err, "local GetNotificationData")
+	}
+	n.Event = event
+	n.ChangeType = wh.ChangeType
+	n.IsBare = false
+
+	return &n, nil
+}
@@ -80,6 +80,20 @@ func (mr *MockClientMockRecorder) CallJSON(arg0, arg1, arg2, arg3 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallJSON", reflect.TypeOf((*MockClient)(nil).CallJSON), arg0, arg1, arg2, arg3)
 }
 
+// CancelEvent mocks base method.
+func (m *MockClient) CancelEvent(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelEvent indicates an expected call of CancelEvent.
+func (mr *MockClientMockRecorder) CancelEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelEvent", reflect.TypeOf((*MockClient)(nil).CancelEvent), arg0, arg1, arg2)
+}
+
 // CreateCalendar mocks base method.
 func (m *MockClient) CreateCalendar(arg0 string, arg1 *remote.Calendar) (*remote.Calendar, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +167,20 @@ func (mr *MockClientMockRecorder) DeleteCalendar(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendar", reflect.TypeOf((*MockClient)(nil).DeleteCalendar), arg0, arg1)
 }
 
+// DeleteEvent mocks base method.
+func (m *MockClient) DeleteEvent(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvent indicates an expected call of DeleteEvent.
+func (mr *MockClientMockRecorder) DeleteEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvent", reflect.TypeOf((*MockClient)(nil).DeleteEvent), arg0, arg1)
+}
+
 // DeleteSubscription mocks base method.
 func (m *MockClient) DeleteSubscription(arg0 *remote.Subscription) error {
 	m.ctrl.T.Helper()
@@ -197,6 +225,21 @@ func (mr *MockClientMockRecorder) FindMeetingTimes(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindMeetingTimes", reflect.TypeOf((*MockClient)(nil).FindMeetingTimes), arg0, arg1)
 }
 
+// GetCalendarView mocks base method.
+func (m *MockClient) GetCalendarView(arg0, arg1 string, arg2, arg3 time.Time) ([]*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarView", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarView indicates an expected call of GetCalendarView.
+func (mr *MockClientMockRecorder) GetCalendarView(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarView", reflect.TypeOf((*MockClient)(nil).GetCalendarView), arg0, arg1, arg2, arg3)
+}
+
 // GetCalendars mocks base method.
 func (m *MockClient) GetCalendars(arg0 string) ([]*remote.Calendar, error) {
 	m.ctrl.T.Helper()
@@ -302,6 +345,21 @@ func (mr *MockClientMockRecorder) GetNotificationData(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationData", reflect.TypeOf((*MockClient)(nil).GetNotificationData), arg0)
 }
 
+// GetSchedule mocks base method.
+func (m *MockClient) GetSchedule(arg0 []*remote.ScheduleUserInfo, arg1, arg2 *remote.DateTime, arg3 int) ([]*remote.ScheduleInformation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*remote.ScheduleInformation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockClientMockRecorder) GetSchedule(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockClient)(nil).GetSchedule), arg0, arg1, arg2, arg3)
+}
+
 // GetSuperuserToken mocks base method.
 func (m *MockClient) GetSuperuserToken() (string, error) {
 	m.ctrl.T.Helper()
@@ -360,3 +418,18 @@ func (mr *MockClientMockRecorder) TentativelyAcceptEvent(arg0, arg1 interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TentativelyAcceptEvent", reflect.TypeOf((*MockClient)(nil).TentativelyAcceptEvent), arg0, arg1)
 }
+
+// UpdateEvent mocks base method.
+func (m *MockClient) UpdateEvent(arg0, arg1 string, arg2 *remote.Event) (*remote.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*remote.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEvent indicates an expected call of UpdateEvent.
+func (mr *MockClientMockRecorder) UpdateEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEvent", reflect.TypeOf((*MockClient)(nil).UpdateEvent), arg0, arg1, arg2)
+}
@@ -3,6 +3,20 @@
 
 package remote
 
+// Types of changes to the resource of a subscription.
+const (
+	ChangeTypeCreated = "created"
+	ChangeTypeUpdated = "updated"
+	ChangeTypeDeleted = "deleted"
+)
+
+// Lifecycle events sent by remotes about the subscriptions themselves.
+const (
+	LifecycleEventReauthorizationRequired = "reauthorizationRequired"
+	LifecycleEventSubscriptionRemoved     = "subscriptionRemoved"
+	LifecycleEventMissed                  = "missed"
+)
+
 type Notification struct {
 	Webhook interface{}
 
@@ -15,9 +29,15 @@ type Notification struct {
 	// persistent secret.
 	ClientState string
 
-	// Notification type
+	// Notification type, one of the ChangeType constants. The event of a
+	// deleted change may only have its ID set.
 	ChangeType string
 
+	// Set for notifications about the subscription rather than its resource,
+	// to one of the LifecycleEvent constants. The handler is to act on the
+	// subscription, and there is no event data.
+	LifecycleEvent string
+
 	// The (remote) subscription ID the notification is for
 	SubscriptionID string
 
@@ -0,0 +1,572 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remote
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Event types, as in Microsoft Graph.
+const (
+	EventTypeSingleInstance = "singleInstance"
+	EventTypeOccurrence     = "occurrence"
+	EventTypeException      = "exception"
+	EventTypeSeriesMaster   = "seriesMaster"
+)
+
+// Recurrence pattern types, as in Microsoft Graph.
+const (
+	RecurrencePatternDaily           = "daily"
+	RecurrencePatternWeekly          = "weekly"
+	RecurrencePatternAbsoluteMonthly = "absoluteMonthly"
+	RecurrencePatternRelativeMonthly = "relativeMonthly"
+	RecurrencePatternAbsoluteYearly  = "absoluteYearly"
+	RecurrencePatternRelativeYearly  = "relativeYearly"
+)
+
+// Recurrence range types, as in Microsoft Graph.
+const (
+	RecurrenceRangeEndDate  = "endDate"
+	RecurrenceRangeNoEnd    = "noEnd"
+	RecurrenceRangeNumbered = "numbered"
+)
+
+const (
+	recurrenceDateFormat = "2006-01-02"
+	occurrenceKeyFormat  = "20060102T150405Z"
+	rruleUntilFormat     = "20060102T150405Z"
+	rruleDateFormat      = "20060102"
+
+	// maxOccurrences bounds the expansion of series without an end.
+	maxOccurrences = 1000
+)
+
+var weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var weekIndexes = map[string]int{
+	"first":  1,
+	"second": 2,
+	"third":  3,
+	"fourth": 4,
+	"last":   -1,
+}
+
+type PatternedRecurrence struct {
+	Pattern *RecurrencePattern `json:"pattern,omitempty"`
+	Range   *RecurrenceRange   `json:"range,omitempty"`
+}
+
+type RecurrencePattern struct {
+	Type           string   `json:"type,omitempty"`
+	Interval       int      `json:"interval,omitempty"`
+	Month          int      `json:"month,omitempty"`
+	DayOfMonth     int      `json:"dayOfMonth,omitempty"`
+	DaysOfWeek     []string `json:"daysOfWeek,omitempty"`
+	FirstDayOfWeek string   `json:"firstDayOfWeek,omitempty"`
+	Index          string   `json:"index,omitempty"`
+}
+
+type RecurrenceRange struct {
+	Type                string `json:"type,omitempty"`
+	StartDate           string `json:"startDate,omitempty"`
+	EndDate             string `json:"endDate,omitempty"`
+	RecurrenceTimeZone  string `json:"recurrenceTimeZone,omitempty"`
+	NumberOfOccurrences int    `json:"numberOfOccurrences,omitempty"`
+}
+
+// Frequencies accepted by NewPatternedRecurrence.
+const (
+	FrequencyDaily    = "daily"
+	FrequencyWeekdays = "weekdays"
+	FrequencyWeekly   = "weekly"
+	FrequencyMonthly  = "monthly"
+	FrequencyYearly   = "yearly"
+)
+
+// NewPatternedRecurrence returns a recurrence without end, repeating the
+// first occurrence at start with the given frequency.
+func NewPatternedRecurrence(frequency string, interval int, start time.Time) (*PatternedRecurrence, error) {
+	if interval < 1 {
+		interval = 1
+	}
+	p := &RecurrencePattern{
+		Interval: interval,
+	}
+
+	switch frequency {
+	case FrequencyDaily:
+		p.Type = RecurrencePatternDaily
+	case FrequencyWeekdays:
+		p.Type = RecurrencePatternWeekly
+		p.DaysOfWeek = append([]string{}, weekdays[1:6]...)
+	case FrequencyWeekly:
+		p.Type = RecurrencePatternWeekly
+		p.DaysOfWeek = []string{weekdays[start.Weekday()]}
+	case FrequencyMonthly:
+		p.Type = RecurrencePatternAbsoluteMonthly
+		p.DayOfMonth = start.Day()
+	case FrequencyYearly:
+		p.Type = RecurrencePatternAbsoluteYearly
+		p.Month = int(start.Month())
+		p.DayOfMonth = start.Day()
+	default:
+		return nil, errors.Errorf("unsupported frequency %q", frequency)
+	}
+
+	return &PatternedRecurrence{
+		Pattern: p,
+		Range: &RecurrenceRange{
+			Type:      RecurrenceRangeNoEnd,
+			StartDate: start.Format(recurrenceDateFormat),
+		},
+	}, nil
+}
+
+// EndBy limits the series to the occurrences up to the given date, formatted
+// as 2006-01-02.
+func (r *PatternedRecurrence) EndBy(date string) {
+	r.Range.Type = RecurrenceRangeEndDate
+	r.Range.EndDate = date
+	r.Range.NumberOfOccurrences = 0
+}
+
+// EndAfter limits the series to the given number of occurrences.
+func (r *PatternedRecurrence) EndAfter(count int) {
+	r.Range.Type = RecurrenceRangeNumbered
+	r.Range.NumberOfOccurrences = count
+	r.Range.EndDate = ""
+}
+
+// IsRecurring returns true for series masters and for their occurrences.
+func (e *Event) IsRecurring() bool {
+	return e.Recurrence != nil || e.SeriesMasterID != "" ||
+		e.Type == EventTypeOccurrence || e.Type == EventTypeException || e.Type == EventTypeSeriesMaster
+}
+
+// OccurrenceKey identifies an event across calendars. Occurrences of a
+// recurring event share their ICalUID with some providers, so the original
+// start of the occurrence is added to it.
+func (e *Event) OccurrenceKey() string {
+	if e.SeriesMasterID == "" && e.Type != EventTypeOccurrence && e.Type != EventTypeException {
+		return e.ICalUID
+	}
+
+	var start time.Time
+	if e.OriginalStart != "" {
+		start, _ = time.Parse(time.RFC3339, e.OriginalStart)
+	}
+	if start.IsZero() && e.Start != nil {
+		start = e.Start.Time()
+	}
+	if start.IsZero() {
+		return e.ICalUID
+	}
+	return e.ICalUID + "_" + start.UTC().Format(occurrenceKeyFormat)
+}
+
+// EndTime returns the end of the last day of the series, or the zero time if
+// the series has no end date.
+func (r *PatternedRecurrence) EndTime(loc *time.Location) time.Time {
+	if r.Range == nil || r.Range.Type != RecurrenceRangeEndDate {
+		return time.Time{}
+	}
+	end, err := time.ParseInLocation(recurrenceDateFormat, r.Range.EndDate, loc)
+	if err != nil {
+		return time.Time{}
+	}
+	return end.AddDate(0, 0, 1)
+}
+
+func (p *RecurrencePattern) interval() int {
+	if p.Interval < 1 {
+		return 1
+	}
+	return p.Interval
+}
+
+// Validate checks that the recurrence can be expanded and converted.
+func (r *PatternedRecurrence) Validate() error {
+	if r.Pattern == nil {
+		return errors.New("recurrence pattern must be set")
+	}
+
+	switch r.Pattern.Type {
+	case RecurrencePatternDaily:
+	case RecurrencePatternWeekly:
+		if len(r.Pattern.DaysOfWeek) == 0 {
+			return errors.New("days of week must be set for a weekly recurrence")
+		}
+	case RecurrencePatternAbsoluteMonthly:
+		if r.Pattern.DayOfMonth < 1 || r.Pattern.DayOfMonth > 31 {
+			return errors.New("invalid day of month")
+		}
+	case RecurrencePatternAbsoluteYearly:
+		if r.Pattern.DayOfMonth < 1 || r.Pattern.DayOfMonth > 31 || r.Pattern.Month < 1 || r.Pattern.Month > 12 {
+			return errors.New("invalid day of year")
+		}
+	case RecurrencePatternRelativeMonthly, RecurrencePatternRelativeYearly:
+		if len(r.Pattern.DaysOfWeek) == 0 {
+			return errors.New("days of week must be set for a relative recurrence")
+		}
+		if _, ok := weekIndexes[r.Pattern.Index]; !ok && r.Pattern.Index != "" {
+			return errors.Errorf("invalid week index %s", r.Pattern.Index)
+		}
+		if r.Pattern.Type == RecurrencePatternRelativeYearly && (r.Pattern.Month < 1 || r.Pattern.Month > 12) {
+			return errors.New("invalid month")
+		}
+	default:
+		return errors.Errorf("unsupported recurrence pattern %q", r.Pattern.Type)
+	}
+
+	for _, day := range r.Pattern.DaysOfWeek {
+		if weekdayIndex(day) < 0 {
+			return errors.Errorf("invalid day of week %s", day)
+		}
+	}
+
+	if r.Range == nil {
+		return nil
+	}
+	switch r.Range.Type {
+	case "", RecurrenceRangeNoEnd:
+	case RecurrenceRangeEndDate:
+		if _, err := time.Parse(recurrenceDateFormat, r.Range.EndDate); err != nil {
+			return errors.New("invalid recurrence end date")
+		}
+	case RecurrenceRangeNumbered:
+		if r.Range.NumberOfOccurrences < 1 {
+			return errors.New("number of occurrences must be positive")
+		}
+	default:
+		return errors.Errorf("unsupported recurrence range %q", r.Range.Type)
+	}
+	return nil
+}
+
+func weekdayIndex(day string) int {
+	for i, d := range weekdays {
+		if strings.EqualFold(d, day) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matches returns true if the pattern, anchored on the first occurrence,
+// has an occurrence on the day.
+func (p *RecurrencePattern) matches(first, day time.Time) bool {
+	switch p.Type {
+	case RecurrencePatternDaily:
+		days := int(day.Sub(first).Hours()+12) / 24
+		return days%p.interval() == 0
+
+	case RecurrencePatternWeekly:
+		weekStart := time.Sunday
+		if i := weekdayIndex(p.FirstDayOfWeek); i >= 0 {
+			weekStart = time.Weekday(i)
+		}
+		startOfWeek := func(t time.Time) time.Time {
+			return t.AddDate(0, 0, -((int(t.Weekday()) - int(weekStart) + 7) % 7))
+		}
+		weeks := int(startOfWeek(day).Sub(startOfWeek(first)).Hours()+12) / (24 * 7)
+		return weeks%p.interval() == 0 && p.onDayOfWeek(day)
+
+	case RecurrencePatternAbsoluteMonthly:
+		return monthsBetween(first, day)%p.interval() == 0 && day.Day() == p.DayOfMonth
+
+	case RecurrencePatternRelativeMonthly:
+		return monthsBetween(first, day)%p.interval() == 0 && p.onDayOfWeek(day) && p.onWeekIndex(day)
+
+	case RecurrencePatternAbsoluteYearly:
+		return (day.Year()-first.Year())%p.interval() == 0 && int(day.Month()) == p.Month && day.Day() == p.DayOfMonth
+
+	case RecurrencePatternRelativeYearly:
+		return (day.Year()-first.Year())%p.interval() == 0 && int(day.Month()) == p.Month && p.onDayOfWeek(day) && p.onWeekIndex(day)
+	}
+	return false
+}
+
+func (p *RecurrencePattern) onDayOfWeek(day time.Time) bool {
+	for _, d := range p.DaysOfWeek {
+		if weekdayIndex(d) == int(day.Weekday()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RecurrencePattern) onWeekIndex(day time.Time) bool {
+	index, ok := weekIndexes[p.Index]
+	if !ok {
+		index = 1
+	}
+	if index < 0 {
+		return day.AddDate(0, 0, 7).Month() != day.Month()
+	}
+	return (day.Day()-1)/7+1 == index
+}
+
+func monthsBetween(first, day time.Time) int {
+	return (day.Year()-first.Year())*12 + int(day.Month()) - int(first.Month())
+}
+
+// Occurrences returns the start times of the occurrences of a series whose
+// first occurrence starts at start, that start before to and end after from.
+func (r *PatternedRecurrence) Occurrences(start time.Time, duration time.Duration, from, to time.Time) []time.Time {
+	if r.Validate() != nil {
+		return nil
+	}
+
+	loc := start.Location()
+	end := r.EndTime(loc)
+	count := maxOccurrences
+	if r.Range != nil && r.Range.Type == RecurrenceRangeNumbered {
+		count = r.Range.NumberOfOccurrences
+	}
+
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	if r.Range != nil && r.Range.StartDate != "" {
+		if d, err := time.ParseInLocation(recurrenceDateFormat, r.Range.StartDate, loc); err == nil {
+			first = d
+		}
+	}
+
+	var out []time.Time
+	for day, n := first, 0; n < count && day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !end.IsZero() && !day.Before(end) {
+			break
+		}
+		if day.Sub(first) > 100*365*24*time.Hour {
+			break
+		}
+		if !r.Pattern.matches(first, day) {
+			continue
+		}
+		n++
+		occurrence := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+		if occurrence.Before(to) && occurrence.Add(duration).After(from) {
+			out = append(out, occurrence)
+		}
+	}
+	return out
+}
+
+// RRule formats the recurrence as an iCalendar RRULE value, as defined in
+// RFC 5545, without the "RRULE:" prefix.
+func (r *PatternedRecurrence) RRule() (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+
+	p := r.Pattern
+	parts := []string{}
+	byDay := func() string {
+		days := []string{}
+		for _, d := range p.DaysOfWeek {
+			days = append(days, rruleWeekdays[weekdayIndex(d)])
+		}
+		return "BYDAY=" + strings.Join(days, ",")
+	}
+
+	switch p.Type {
+	case RecurrencePatternDaily:
+		parts = append(parts, "FREQ=DAILY")
+	case RecurrencePatternWeekly:
+		parts = append(parts, "FREQ=WEEKLY", byDay())
+		if i := weekdayIndex(p.FirstDayOfWeek); i >= 0 {
+			parts = append(parts, "WKST="+rruleWeekdays[i])
+		}
+	case RecurrencePatternAbsoluteMonthly:
+		parts = append(parts, "FREQ=MONTHLY", fmt.Sprintf("BYMONTHDAY=%d", p.DayOfMonth))
+	case RecurrencePatternRelativeMonthly:
+		parts = append(parts, "FREQ=MONTHLY", byDay(), fmt.Sprintf("BYSETPOS=%d", setPos(p.Index)))
+	case RecurrencePatternAbsoluteYearly:
+		parts = append(parts, "FREQ=YEARLY", fmt.Sprintf("BYMONTH=%d", p.Month), fmt.Sprintf("BYMONTHDAY=%d", p.DayOfMonth))
+	case RecurrencePatternRelativeYearly:
+		parts = append(parts, "FREQ=YEARLY", fmt.Sprintf("BYMONTH=%d", p.Month), byDay(), fmt.Sprintf("BYSETPOS=%d", setPos(p.Index)))
+	}
+
+	if p.interval() > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", p.interval()))
+	}
+
+	if r.Range != nil {
+		switch r.Range.Type {
+		case RecurrenceRangeEndDate:
+			end, _ := time.Parse(recurrenceDateFormat, r.Range.EndDate)
+			parts = append(parts, "UNTIL="+end.Add(24*time.Hour-time.Second).Format(rruleUntilFormat))
+		case RecurrenceRangeNumbered:
+			parts = append(parts, fmt.Sprintf("COUNT=%d", r.Range.NumberOfOccurrences))
+		}
+	}
+
+	return strings.Join(parts, ";"), nil
+}
+
+func setPos(index string) int {
+	if pos, ok := weekIndexes[index]; ok {
+		return pos
+	}
+	return 1
+}
+
+// ParseRRule parses an iCalendar RRULE value, with or without the "RRULE:"
+// prefix, into the recurrence of a series whose first occurrence starts at
+// start.
+func ParseRRule(rule string, start time.Time) (*PatternedRecurrence, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+
+	values := map[string]string{}
+	for _, part := range strings.Split(rule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid RRULE part %q", part)
+		}
+		values[strings.ToUpper(kv[0])] = kv[1]
+	}
+
+	p := &RecurrencePattern{
+		Interval: 1,
+	}
+	if interval := values["INTERVAL"]; interval != "" {
+		n, err := strconv.Atoi(interval)
+		if err != nil || n < 1 {
+			return nil, errors.Errorf("invalid RRULE interval %q", interval)
+		}
+		p.Interval = n
+	}
+	if wkst := values["WKST"]; wkst != "" {
+		if i := rruleWeekdayIndex(wkst); i >= 0 {
+			p.FirstDayOfWeek = weekdays[i]
+		}
+	}
+
+	// BYDAY entries may carry their own position, e.g. 1MO or -1FR.
+	index := ""
+	for _, day := range strings.Split(values["BYDAY"], ",") {
+		if day == "" {
+			continue
+		}
+		name := strings.TrimLeft(day, "+-0123456789")
+		i := rruleWeekdayIndex(name)
+		if i < 0 {
+			return nil, errors.Errorf("invalid RRULE day %q", day)
+		}
+		p.DaysOfWeek = append(p.DaysOfWeek, weekdays[i])
+		if pos := strings.TrimSuffix(day, name); pos != "" {
+			index = pos
+		}
+	}
+	if pos := values["BYSETPOS"]; pos != "" {
+		index = pos
+	}
+	if index != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(index, "+"))
+		if err != nil {
+			return nil, errors.Errorf("invalid RRULE position %q", index)
+		}
+		for name, pos := range weekIndexes {
+			if pos == n {
+				p.Index = name
+			}
+		}
+		if p.Index == "" {
+			return nil, errors.Errorf("unsupported RRULE position %q", index)
+		}
+	}
+
+	dayOfMonth := start.Day()
+	if v := values["BYMONTHDAY"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.Errorf("unsupported RRULE month day %q", v)
+		}
+		dayOfMonth = n
+	}
+	month := int(start.Month())
+	if v := values["BYMONTH"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.Errorf("unsupported RRULE month %q", v)
+		}
+		month = n
+	}
+
+	switch strings.ToUpper(values["FREQ"]) {
+	case "DAILY":
+		p.Type = RecurrencePatternDaily
+	case "WEEKLY":
+		p.Type = RecurrencePatternWeekly
+		if len(p.DaysOfWeek) == 0 {
+			p.DaysOfWeek = []string{weekdays[start.Weekday()]}
+		}
+	case "MONTHLY":
+		p.Type = RecurrencePatternAbsoluteMonthly
+		p.DayOfMonth = dayOfMonth
+		if len(p.DaysOfWeek) > 0 {
+			p.Type = RecurrencePatternRelativeMonthly
+			p.DayOfMonth = 0
+		}
+	case "YEARLY":
+		p.Type = RecurrencePatternAbsoluteYearly
+		p.Month = month
+		p.DayOfMonth = dayOfMonth
+		if len(p.DaysOfWeek) > 0 {
+			p.Type = RecurrencePatternRelativeYearly
+			p.DayOfMonth = 0
+		}
+	default:
+		return nil, errors.Errorf("unsupported RRULE frequency %q", values["FREQ"])
+	}
+	if p.Type == RecurrencePatternRelativeMonthly || p.Type == RecurrencePatternRelativeYearly {
+		if p.Index == "" {
+			p.Index = "first"
+		}
+	}
+
+	r := &PatternedRecurrence{
+		Pattern: p,
+		Range: &RecurrenceRange{
+			Type:      RecurrenceRangeNoEnd,
+			StartDate: start.Format(recurrenceDateFormat),
+		},
+	}
+	if count := values["COUNT"]; count != "" {
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return nil, errors.Errorf("invalid RRULE count %q", count)
+		}
+		r.Range.Type = RecurrenceRangeNumbered
+		r.Range.NumberOfOccurrences = n
+	}
+	if until := values["UNTIL"]; until != "" {
+		end, err := time.Parse(rruleUntilFormat, until)
+		if err != nil {
+			end, err = time.ParseInLocation(rruleDateFormat, until, start.Location())
+		}
+		if err != nil {
+			return nil, errors.Errorf("invalid RRULE until %q", until)
+		}
+		r.Range.Type = RecurrenceRangeEndDate
+		r.Range.EndDate = end.In(start.Location()).Format(recurrenceDateFormat)
+	}
+
+	return r, nil
+}
+
+func rruleWeekdayIndex(day string) int {
+	for i, d := range rruleWeekdays {
+		if strings.EqualFold(d, day) {
+			return i
+		}
+	}
+	return -1
+}
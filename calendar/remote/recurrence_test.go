@@ -0,0 +1,129 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRule(t *testing.T) {
+	// Thursday, May 2nd 2024
+	start := time.Date(2024, 5, 2, 14, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name       string
+		recurrence *PatternedRecurrence
+		rrule      string
+	}{
+		{
+			name: "daily with count",
+			recurrence: &PatternedRecurrence{
+				Pattern: &RecurrencePattern{Type: RecurrencePatternDaily, Interval: 2},
+				Range:   &RecurrenceRange{Type: RecurrenceRangeNumbered, StartDate: "2024-05-02", NumberOfOccurrences: 5},
+			},
+			rrule: "FREQ=DAILY;INTERVAL=2;COUNT=5",
+		},
+		{
+			name: "weekly until",
+			recurrence: &PatternedRecurrence{
+				Pattern: &RecurrencePattern{Type: RecurrencePatternWeekly, Interval: 1, DaysOfWeek: []string{"monday", "thursday"}},
+				Range:   &RecurrenceRange{Type: RecurrenceRangeEndDate, StartDate: "2024-05-02", EndDate: "2024-06-30"},
+			},
+			rrule: "FREQ=WEEKLY;BYDAY=MO,TH;UNTIL=20240630T235959Z",
+		},
+		{
+			name: "monthly on the last thursday",
+			recurrence: &PatternedRecurrence{
+				Pattern: &RecurrencePattern{Type: RecurrencePatternRelativeMonthly, Interval: 1, DaysOfWeek: []string{"thursday"}, Index: "last"},
+				Range:   &RecurrenceRange{Type: RecurrenceRangeNoEnd, StartDate: "2024-05-02"},
+			},
+			rrule: "FREQ=MONTHLY;BYDAY=TH;BYSETPOS=-1",
+		},
+		{
+			name: "yearly",
+			recurrence: &PatternedRecurrence{
+				Pattern: &RecurrencePattern{Type: RecurrencePatternAbsoluteYearly, Interval: 1, Month: 5, DayOfMonth: 2},
+				Range:   &RecurrenceRange{Type: RecurrenceRangeNoEnd, StartDate: "2024-05-02"},
+			},
+			rrule: "FREQ=YEARLY;BYMONTH=5;BYMONTHDAY=2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rrule, err := tc.recurrence.RRule()
+			require.NoError(t, err)
+			require.Equal(t, tc.rrule, rrule)
+
+			parsed, err := ParseRRule("RRULE:"+rrule, start)
+			require.NoError(t, err)
+			require.Equal(t, tc.recurrence, parsed)
+		})
+	}
+
+	parsed, err := ParseRRule("FREQ=MONTHLY;BYDAY=1MO", start)
+	require.NoError(t, err)
+	require.Equal(t, RecurrencePatternRelativeMonthly, parsed.Pattern.Type)
+	require.Equal(t, "first", parsed.Pattern.Index)
+	require.Equal(t, []string{"monday"}, parsed.Pattern.DaysOfWeek)
+
+	_, err = ParseRRule("FREQ=SECONDLY", start)
+	require.Error(t, err)
+	_, err = (&PatternedRecurrence{Pattern: &RecurrencePattern{Type: RecurrencePatternWeekly}}).RRule()
+	require.Error(t, err)
+}
+
+func TestOccurrences(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	require.NoError(t, err)
+	// Thursday, May 2nd 2024
+	start := time.Date(2024, 5, 2, 14, 0, 0, 0, seoul)
+
+	weekly := &PatternedRecurrence{
+		Pattern: &RecurrencePattern{Type: RecurrencePatternWeekly, Interval: 2, DaysOfWeek: []string{"monday", "thursday"}},
+		Range:   &RecurrenceRange{Type: RecurrenceRangeNumbered, StartDate: "2024-05-02", NumberOfOccurrences: 4},
+	}
+	occurrences := weekly.Occurrences(start, time.Hour, start, start.AddDate(0, 2, 0))
+	require.Equal(t, []time.Time{
+		start,
+		start.AddDate(0, 0, 11),
+		start.AddDate(0, 0, 14),
+		start.AddDate(0, 0, 25),
+	}, occurrences)
+
+	// Occurrences that ended before the window are counted but not returned.
+	occurrences = weekly.Occurrences(start, time.Hour, start.AddDate(0, 0, 12), start.AddDate(0, 2, 0))
+	require.Equal(t, []time.Time{start.AddDate(0, 0, 14), start.AddDate(0, 0, 25)}, occurrences)
+
+	monthly := &PatternedRecurrence{
+		Pattern: &RecurrencePattern{Type: RecurrencePatternRelativeMonthly, Interval: 1, DaysOfWeek: []string{"friday"}, Index: "last"},
+		Range:   &RecurrenceRange{Type: RecurrenceRangeEndDate, StartDate: "2024-05-02", EndDate: "2024-07-26"},
+	}
+	occurrences = monthly.Occurrences(start, time.Hour, start, start.AddDate(1, 0, 0))
+	require.Equal(t, []time.Time{
+		time.Date(2024, 5, 31, 14, 0, 0, 0, seoul),
+		time.Date(2024, 6, 28, 14, 0, 0, 0, seoul),
+		time.Date(2024, 7, 26, 14, 0, 0, 0, seoul),
+	}, occurrences)
+}
+
+func TestOccurrenceKey(t *testing.T) {
+	single := &Event{ICalUID: "uid"}
+	require.Equal(t, "uid", single.OccurrenceKey())
+	require.False(t, single.IsRecurring())
+
+	occurrence := &Event{
+		ICalUID:        "uid",
+		Type:           EventTypeOccurrence,
+		SeriesMasterID: "master",
+		OriginalStart:  "2024-05-02T14:00:00+09:00",
+		Start:          NewDateTime(time.Date(2024, 5, 2, 15, 0, 0, 0, time.UTC), "UTC"),
+	}
+	require.Equal(t, "uid_20240502T050000Z", occurrence.OccurrenceKey())
+	require.True(t, occurrence.IsRecurring())
+
+	occurrence.OriginalStart = ""
+	require.Equal(t, "uid_20240502T150000Z", occurrence.OccurrenceKey())
+}
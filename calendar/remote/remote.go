@@ -15,8 +15,11 @@ import (
 )
 
 var (
-	ErrSuperUserClientNotSupported = errors.New("superuser client is not supported")
-	ErrNotImplemented              = errors.New("not implemented")
+	ErrSuperUserClientNotSupported  = errors.New("superuser client is not supported")
+	ErrNotImplemented               = errors.New("not implemented")
+	ErrDeltaLinkExpired             = errors.New("delta link expired")
+	ErrAutomaticRepliesNotSupported = errors.New("automatic replies are not supported")
+	ErrAutomaticRepliesForbidden    = errors.New("the account must be connected again to set automatic replies")
 )
 
 type Remote interface {
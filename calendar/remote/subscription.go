@@ -4,13 +4,20 @@
 package remote
 
 type Subscription struct {
-	ID                 string `json:"id"`
-	ResourceID         string `json:"resourceId,omitempty"`
-	Resource           string `json:"resource,omitempty"`
-	ApplicationID      string `json:"applicationId,omitempty"`
-	ChangeType         string `json:"changeType,omitempty"`
-	ClientState        string `json:"clientState,omitempty"`
-	NotificationURL    string `json:"notificationUrl,omitempty"`
-	ExpirationDateTime string `json:"expirationDateTime,omitempty"`
-	CreatorID          string `json:"creatorId,omitempty"`
+	ID              string `json:"id"`
+	ResourceID      string `json:"resourceId,omitempty"`
+	Resource        string `json:"resource,omitempty"`
+	ApplicationID   string `json:"applicationId,omitempty"`
+	ChangeType      string `json:"changeType,omitempty"`
+	ClientState     string `json:"clientState,omitempty"`
+	NotificationURL string `json:"notificationUrl,omitempty"`
+	// LifecycleNotificationURL receives the notifications about the
+	// subscription itself, such as its removal by the remote.
+	LifecycleNotificationURL string `json:"lifecycleNotificationUrl,omitempty"`
+	ExpirationDateTime       string `json:"expirationDateTime,omitempty"`
+	CreatorID                string `json:"creatorId,omitempty"`
+
+	// SyncToken is the change tracking state of subscriptions whose changes
+	// are fetched with PollSubscription.
+	SyncToken string `json:"syncToken,omitempty"`
 }
@@ -3,7 +3,14 @@
 
 package remote
 
-import "golang.org/x/oauth2"
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
 
 type User struct {
 	ID                string `json:"id"`
@@ -21,9 +28,109 @@ type WorkingHours struct {
 	DaysOfWeek []string `json:"daysOfWeek"`
 }
 
+// IsWorkingTime returns whether t falls within the working hours. The time
+// zone of the mailbox is used when the working hours have none that Go
+// knows of. Working hours that are not set, or can't be read, span the
+// whole week.
+func (wh WorkingHours) IsWorkingTime(t time.Time, mailboxTimeZone string) bool {
+	start, okStart := parseWorkingHoursTime(wh.StartTime)
+	end, okEnd := parseWorkingHoursTime(wh.EndTime)
+	if len(wh.DaysOfWeek) == 0 || !okStart || !okEnd {
+		return true
+	}
+
+	timeZone := tz.Go(wh.TimeZone.Name)
+	if timeZone == "" {
+		timeZone = tz.Go(mailboxTimeZone)
+	}
+	if loc, err := time.LoadLocation(timeZone); err == nil {
+		t = t.In(loc)
+	}
+
+	isWorkingDay := func(day time.Time) bool {
+		for _, d := range wh.DaysOfWeek {
+			if strings.EqualFold(d, day.Weekday().String()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	switch {
+	case start == end:
+		return isWorkingDay(t)
+	case start < end:
+		return isWorkingDay(t) && sinceMidnight >= start && sinceMidnight < end
+	}
+
+	// Working hours spanning midnight belong to the day they start on.
+	return (isWorkingDay(t) && sinceMidnight >= start) || (isWorkingDay(t.AddDate(0, 0, -1)) && sinceMidnight < end)
+}
+
+// parseWorkingHoursTime parses times such as "08:30:00.0000000" into the
+// duration since midnight.
+func parseWorkingHoursTime(value string) (time.Duration, bool) {
+	value, _, _ = strings.Cut(value, ".")
+	t, err := time.Parse("15:04:05", value)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, true
+}
+
+// Statuses of the automatic replies of a mailbox.
+const (
+	AutomaticRepliesStatusDisabled      = "disabled"
+	AutomaticRepliesStatusAlwaysEnabled = "alwaysEnabled"
+	AutomaticRepliesStatusScheduled     = "scheduled"
+)
+
+// AutomaticRepliesSetting describes the automatic replies, or out of office
+// message, of a mailbox. The scheduled window only applies to the scheduled
+// status.
+type AutomaticRepliesSetting struct {
+	Status                 string    `json:"status"`
+	ScheduledStartDateTime *DateTime `json:"scheduledStartDateTime,omitempty"`
+	ScheduledEndDateTime   *DateTime `json:"scheduledEndDateTime,omitempty"`
+	InternalReplyMessage   string    `json:"internalReplyMessage,omitempty"`
+	ExternalReplyMessage   string    `json:"externalReplyMessage,omitempty"`
+}
+
+// IsActive returns whether the automatic replies are sent at the given time.
+func (s *AutomaticRepliesSetting) IsActive(t time.Time) bool {
+	if s == nil {
+		return false
+	}
+
+	switch s.Status {
+	case AutomaticRepliesStatusAlwaysEnabled:
+		return true
+	case AutomaticRepliesStatusScheduled:
+		if s.ScheduledStartDateTime != nil && t.Before(s.ScheduledStartDateTime.Time()) {
+			return false
+		}
+		if s.ScheduledEndDateTime != nil && !t.Before(s.ScheduledEndDateTime.Time()) {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// End returns the end of the automatic replies, or the zero time if they are
+// not scheduled to end.
+func (s *AutomaticRepliesSetting) End() time.Time {
+	if s == nil || s.Status != AutomaticRepliesStatusScheduled || s.ScheduledEndDateTime == nil {
+		return time.Time{}
+	}
+	return s.ScheduledEndDateTime.Time()
+}
+
 type MailboxSettings struct {
-	TimeZone     string       `json:"timeZone"`
-	WorkingHours WorkingHours `json:"workingHours"`
+	TimeZone                string                   `json:"timeZone"`
+	WorkingHours            WorkingHours             `json:"workingHours"`
+	AutomaticRepliesSetting *AutomaticRepliesSetting `json:"automaticRepliesSetting,omitempty"`
 }
 
 type UserTokenHelpers interface {
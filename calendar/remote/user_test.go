@@ -0,0 +1,120 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomaticRepliesSetting(t *testing.T) {
+	now := time.Date(2030, 5, 2, 14, 0, 0, 0, time.UTC)
+	start := NewDateTime(now.Add(-time.Hour), "UTC")
+	end := NewDateTime(now.Add(time.Hour), "UTC")
+
+	for name, tc := range map[string]struct {
+		setting     *AutomaticRepliesSetting
+		expectedEnd time.Time
+		active      bool
+	}{
+		"no setting": {
+			setting: nil,
+		},
+		"disabled": {
+			setting: &AutomaticRepliesSetting{Status: AutomaticRepliesStatusDisabled, ScheduledStartDateTime: start, ScheduledEndDateTime: end},
+		},
+		"always enabled ignores the window": {
+			setting: &AutomaticRepliesSetting{Status: AutomaticRepliesStatusAlwaysEnabled, ScheduledEndDateTime: NewDateTime(now.Add(-time.Minute), "UTC")},
+			active:  true,
+		},
+		"scheduled within the window": {
+			setting:     &AutomaticRepliesSetting{Status: AutomaticRepliesStatusScheduled, ScheduledStartDateTime: start, ScheduledEndDateTime: end},
+			active:      true,
+			expectedEnd: now.Add(time.Hour),
+		},
+		"scheduled before the window": {
+			setting:     &AutomaticRepliesSetting{Status: AutomaticRepliesStatusScheduled, ScheduledStartDateTime: NewDateTime(now.Add(time.Minute), "UTC"), ScheduledEndDateTime: end},
+			expectedEnd: now.Add(time.Hour),
+		},
+		"scheduled after the window": {
+			setting:     &AutomaticRepliesSetting{Status: AutomaticRepliesStatusScheduled, ScheduledStartDateTime: start, ScheduledEndDateTime: NewDateTime(now, "UTC")},
+			expectedEnd: now,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.active, tc.setting.IsActive(now))
+			require.True(t, tc.expectedEnd.Equal(tc.setting.End()))
+		})
+	}
+}
+
+func TestWorkingHoursIsWorkingTime(t *testing.T) {
+	weekdays := []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+	// Thursday
+	day := time.Date(2030, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		workingHours    WorkingHours
+		mailboxTimeZone string
+		t               time.Time
+		expected        bool
+	}{
+		"not set": {
+			workingHours: WorkingHours{},
+			t:            day.Add(23 * time.Hour),
+			expected:     true,
+		},
+		"within working hours": {
+			workingHours: WorkingHours{StartTime: "08:00:00.0000000", EndTime: "17:00:00.0000000", DaysOfWeek: weekdays},
+			t:            day.Add(9 * time.Hour),
+			expected:     true,
+		},
+		"at the end of working hours": {
+			workingHours: WorkingHours{StartTime: "08:00:00.0000000", EndTime: "17:00:00.0000000", DaysOfWeek: weekdays},
+			t:            day.Add(17 * time.Hour),
+			expected:     false,
+		},
+		"on a day off": {
+			workingHours: WorkingHours{StartTime: "08:00:00.0000000", EndTime: "17:00:00.0000000", DaysOfWeek: weekdays},
+			t:            day.AddDate(0, 0, 2).Add(9 * time.Hour),
+			expected:     false,
+		},
+		"in the time zone of the working hours": {
+			workingHours: func() WorkingHours {
+				wh := WorkingHours{StartTime: "08:00:00.0000000", EndTime: "17:00:00.0000000", DaysOfWeek: weekdays}
+				wh.TimeZone.Name = "Korea Standard Time"
+				return wh
+			}(),
+			t:        day.Add(9 * time.Hour),
+			expected: false,
+		},
+		"in the time zone of the mailbox": {
+			workingHours:    WorkingHours{StartTime: "08:00:00.0000000", EndTime: "17:00:00.0000000", DaysOfWeek: weekdays},
+			mailboxTimeZone: "Asia/Seoul",
+			t:               day.Add(time.Hour),
+			expected:        true,
+		},
+		"overnight, after the start": {
+			workingHours: WorkingHours{StartTime: "22:00:00.0000000", EndTime: "06:00:00.0000000", DaysOfWeek: []string{"thursday"}},
+			t:            day.Add(23 * time.Hour),
+			expected:     true,
+		},
+		"overnight, before the end of the day after": {
+			workingHours: WorkingHours{StartTime: "22:00:00.0000000", EndTime: "06:00:00.0000000", DaysOfWeek: []string{"thursday"}},
+			t:            day.AddDate(0, 0, 1).Add(5 * time.Hour),
+			expected:     true,
+		},
+		"overnight, before the start": {
+			workingHours: WorkingHours{StartTime: "22:00:00.0000000", EndTime: "06:00:00.0000000", DaysOfWeek: []string{"thursday"}},
+			t:            day.Add(5 * time.Hour),
+			expected:     false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.workingHours.IsWorkingTime(tc.t, tc.mailboxTimeZone))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// CalendarCache holds the events of a calendar of a user within a window of
+// time, along with the delta link used to fetch the changes made to them since
+// the last synchronization. Caches expire at the end of their window.
+type CalendarCache struct {
+	WindowStart time.Time                `json:"window_start"`
+	WindowEnd   time.Time                `json:"window_end"`
+	DeltaLink   string                   `json:"delta_link"`
+	Events      map[string]*remote.Event `json:"events"`
+}
+
+type CalendarCacheStore interface {
+	LoadCalendarCache(remoteUserID, calendarID string) (*CalendarCache, error)
+	StoreCalendarCache(remoteUserID, calendarID string, cache *CalendarCache) error
+	DeleteCalendarCache(remoteUserID, calendarID string) error
+}
+
+// An empty calendarID stands for the default calendar.
+func calendarCacheKey(remoteUserID, calendarID string) string {
+	return remoteUserID + "_" + calendarID
+}
+
+// Covers tells whether the window of the cache contains [start, end).
+func (c *CalendarCache) Covers(start, end time.Time) bool {
+	return !start.Before(c.WindowStart) && !end.After(c.WindowEnd)
+}
+
+func (s *pluginStore) LoadCalendarCache(remoteUserID, calendarID string) (*CalendarCache, error) {
+	cache := CalendarCache{}
+	err := kvstore.LoadJSON(s.calendarCacheKV, calendarCacheKey(remoteUserID, calendarID), &cache)
+	if err != nil {
+		return nil, err
+	}
+	if cache.Events == nil {
+		cache.Events = map[string]*remote.Event{}
+	}
+	return &cache, nil
+}
+
+func (s *pluginStore) StoreCalendarCache(remoteUserID, calendarID string, cache *CalendarCache) error {
+	ttl := int64(time.Until(cache.WindowEnd).Seconds())
+	if ttl <= 0 {
+		// no point storing expired keys
+		return nil
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return s.calendarCacheKV.StoreTTL(calendarCacheKey(remoteUserID, calendarID), data, ttl)
+}
+
+func (s *pluginStore) DeleteCalendarCache(remoteUserID, calendarID string) error {
+	return s.calendarCacheKV.Delete(calendarCacheKey(remoteUserID, calendarID))
+}
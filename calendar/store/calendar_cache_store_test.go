@@ -0,0 +1,127 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/testutil"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const mockCalendarCacheKey = "calcache_868f70af13be873e9cffdc822ab88163"
+
+func TestLoadCalendarCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, *CalendarCache, error)
+	}{
+		{
+			name: "Error loading cache",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockCalendarCacheKey).Return(nil, &model.AppError{Message: "Cache not found"}).Times(1)
+			},
+			assertions: func(t *testing.T, cache *CalendarCache, err error) {
+				require.Nil(t, cache)
+				require.EqualError(t, err, "failed plugin KVGet: Cache not found")
+			},
+		},
+		{
+			name: "Successful load",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockCalendarCacheKey).Return([]byte(`{"delta_link":"mockDeltaLink","events":{"mockEventID":{"id":"mockEventID"}}}`), nil).Times(1)
+			},
+			assertions: func(t *testing.T, cache *CalendarCache, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "mockDeltaLink", cache.DeltaLink)
+				require.Equal(t, MockEventID, cache.Events[MockEventID].ID)
+			},
+		},
+		{
+			name: "Load cache without events",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockCalendarCacheKey).Return([]byte(`{"delta_link":"mockDeltaLink"}`), nil).Times(1)
+			},
+			assertions: func(t *testing.T, cache *CalendarCache, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, cache.Events)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			cache, err := store.LoadCalendarCache(MockRemoteUserID, "")
+
+			tt.assertions(t, cache, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStoreCalendarCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		windowEnd  time.Time
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, error)
+	}{
+		{
+			name:      "Store expired cache",
+			windowEnd: time.Now().Add(-time.Hour),
+			setup:     func(_ *testutil.MockPluginAPI) {},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "Error storing cache",
+			windowEnd: time.Now().Add(time.Hour),
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSetWithExpiry", mockCalendarCacheKey, mock.Anything, mock.AnythingOfType("int64")).Return(&model.AppError{Message: "Failed to store cache"}).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "Failed to store cache")
+			},
+		},
+		{
+			name:      "Successful store",
+			windowEnd: time.Now().Add(time.Hour),
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSetWithExpiry", mockCalendarCacheKey, mock.Anything, mock.MatchedBy(func(ttl int64) bool {
+					return ttl > 0 && ttl <= 3600
+				})).Return(nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			err := store.StoreCalendarCache(MockRemoteUserID, "", &CalendarCache{
+				WindowEnd: tt.windowEnd,
+				DeltaLink: "mockDeltaLink",
+				Events: map[string]*remote.Event{
+					MockEventID: {ID: MockEventID},
+				},
+			})
+
+			tt.assertions(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
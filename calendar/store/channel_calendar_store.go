@@ -0,0 +1,171 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// ChannelCalendar binds a calendar in the calendar list of its creator, such
+// as a calendar shared with them, or the calendar of a group they are a
+// member of, to a channel. The events of the calendar are read with the
+// creator's credentials, and its changes are notified through a subscription
+// owned by the creator.
+//
+// GroupID is set for the calendar of a group, such as a Microsoft 365 group,
+// CalendarID otherwise.
+//
+// AgendaTime is the time of day, as HH:MM in the creator's timezone, the
+// agenda of the day is posted in the channel, or empty for no agenda.
+// LastAgendaDate is the day, as YYYY-MM-DD, it was last posted.
+type ChannelCalendar struct {
+	PluginVersion       string
+	ChannelID           string
+	CalendarID          string
+	GroupID             string
+	CalendarName        string
+	MattermostCreatorID string
+	SubscriptionID      string
+	AgendaTime          string
+	LastAgendaDate      string
+}
+
+// ChannelCalendarIndex lists the channels bound to a calendar.
+type ChannelCalendarIndex []string
+
+type ChannelCalendarStore interface {
+	LoadChannelCalendar(channelID string) (*ChannelCalendar, error)
+	StoreChannelCalendar(channelCalendar *ChannelCalendar) error
+	ModifyChannelCalendar(channelID string, modify func(channelCalendar *ChannelCalendar) error) (*ChannelCalendar, error)
+	DeleteChannelCalendar(channelID string) error
+	LoadChannelCalendarIndex() (ChannelCalendarIndex, error)
+
+	LoadChannelCalendarEvent(channelID, eventID string) (*Event, error)
+	LoadChannelCalendarEventByRemoteID(channelID, remoteEventID string) (*Event, error)
+	StoreChannelCalendarEvent(channelID string, event *Event) error
+	DeleteChannelCalendarEvent(channelID, eventID string) error
+}
+
+// The events of channel calendars are stored beside the events of users, to
+// share their expiration, under an owner that can't be taken for a user.
+func channelEventOwner(channelID string) string { return "channel_" + channelID }
+
+func (s *pluginStore) LoadChannelCalendar(channelID string) (*ChannelCalendar, error) {
+	channelCalendar := ChannelCalendar{}
+	err := kvstore.LoadJSON(s.channelCalendarKV, channelID, &channelCalendar)
+	if err != nil {
+		return nil, err
+	}
+	return &channelCalendar, nil
+}
+
+func (s *pluginStore) StoreChannelCalendar(channelCalendar *ChannelCalendar) error {
+	err := kvstore.StoreJSON(s.channelCalendarKV, channelCalendar.ChannelID, channelCalendar)
+	if err != nil {
+		return errors.Wrap(err, "error storing channel calendar")
+	}
+
+	return s.modifyChannelCalendarIndex(func(index ChannelCalendarIndex) ChannelCalendarIndex {
+		for _, channelID := range index {
+			if channelID == channelCalendar.ChannelID {
+				return index
+			}
+		}
+		return append(index, channelCalendar.ChannelID)
+	})
+}
+
+// ModifyChannelCalendar atomically applies modify to the stored channel
+// calendar, so that the jobs updating it don't overwrite each other, and
+// returns the modified channel calendar.
+func (s *pluginStore) ModifyChannelCalendar(channelID string, modify func(channelCalendar *ChannelCalendar) error) (*ChannelCalendar, error) {
+	var modified *ChannelCalendar
+	err := kvstore.AtomicModify(s.channelCalendarKV, channelID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil {
+			return nil, storeErr
+		}
+		if len(initial) == 0 {
+			return nil, ErrNotFound
+		}
+
+		channelCalendar := &ChannelCalendar{}
+		if err := json.Unmarshal(initial, channelCalendar); err != nil {
+			return nil, err
+		}
+		if err := modify(channelCalendar); err != nil {
+			return nil, err
+		}
+		modified = channelCalendar
+
+		return json.Marshal(channelCalendar)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error modifying channel calendar")
+	}
+	return modified, nil
+}
+
+func (s *pluginStore) DeleteChannelCalendar(channelID string) error {
+	err := s.channelCalendarKV.Delete(channelID)
+	if err != nil {
+		return err
+	}
+
+	return s.modifyChannelCalendarIndex(func(index ChannelCalendarIndex) ChannelCalendarIndex {
+		result := ChannelCalendarIndex{}
+		for _, id := range index {
+			if id != channelID {
+				result = append(result, id)
+			}
+		}
+		return result
+	})
+}
+
+func (s *pluginStore) LoadChannelCalendarIndex() (ChannelCalendarIndex, error) {
+	index := ChannelCalendarIndex{}
+	err := kvstore.LoadJSON(s.channelCalendarKV, "", &index)
+	if err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+func (s *pluginStore) modifyChannelCalendarIndex(modify func(index ChannelCalendarIndex) ChannelCalendarIndex) error {
+	return kvstore.AtomicModify(s.channelCalendarKV, "", func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		var index ChannelCalendarIndex
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &index)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return json.Marshal(modify(index))
+	})
+}
+
+func (s *pluginStore) LoadChannelCalendarEvent(channelID, eventID string) (*Event, error) {
+	return s.LoadUserEvent(channelEventOwner(channelID), eventID)
+}
+
+func (s *pluginStore) LoadChannelCalendarEventByRemoteID(channelID, remoteEventID string) (*Event, error) {
+	return s.LoadUserEventByRemoteID(channelEventOwner(channelID), remoteEventID)
+}
+
+func (s *pluginStore) StoreChannelCalendarEvent(channelID string, event *Event) error {
+	return s.StoreUserEvent(channelEventOwner(channelID), event)
+}
+
+func (s *pluginStore) DeleteChannelCalendarEvent(channelID, eventID string) error {
+	return s.DeleteUserEvent(channelEventOwner(channelID), eventID)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/testutil"
+)
+
+func TestStoreChannelCalendar(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "Channel is added to the index",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSet", mock.Anything, mock.Anything).Return(nil).Times(1)
+				mockAPI.On("KVGet", mock.Anything).Return([]byte(`["otherChannelID"]`), nil).Times(1)
+				mockAPI.On("KVSetWithOptions", mock.Anything, []byte(`["otherChannelID","mockChannelID"]`), mock.MatchedBy(func(opts model.PluginKVSetOptions) bool {
+					return opts.Atomic
+				})).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Index is left as is when the channel is in it",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSet", mock.Anything, mock.Anything).Return(nil).Times(1)
+				mockAPI.On("KVGet", mock.Anything).Return([]byte(`["mockChannelID"]`), nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Error storing channel calendar",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSet", mock.Anything, mock.Anything).Return(&model.AppError{Message: "KVSet failed"}).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "error storing channel calendar")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			err := store.StoreChannelCalendar(&ChannelCalendar{ChannelID: "mockChannelID", CalendarID: "mockCalendarID"})
+
+			tt.assertions(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestModifyChannelCalendar(t *testing.T) {
+	t.Run("Channel calendar not found", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mock.Anything).Return(nil, nil).Times(1)
+
+		channelCalendar, err := store.ModifyChannelCalendar("mockChannelID", func(*ChannelCalendar) error { return nil })
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Nil(t, channelCalendar)
+	})
+
+	t.Run("Only the modified field is changed", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mock.Anything).Return([]byte(`{"ChannelID":"mockChannelID","SubscriptionID":"renewedSubscriptionID"}`), nil).Times(1)
+		mockAPI.On("KVSetWithOptions", mock.Anything, mock.MatchedBy(func(value []byte) bool {
+			channelCalendar := ChannelCalendar{}
+			return json.Unmarshal(value, &channelCalendar) == nil &&
+				channelCalendar.SubscriptionID == "renewedSubscriptionID" &&
+				channelCalendar.LastAgendaDate == "2024-03-04"
+		}), mock.MatchedBy(func(opts model.PluginKVSetOptions) bool {
+			return opts.Atomic
+		})).Return(true, nil).Times(1)
+
+		channelCalendar, err := store.ModifyChannelCalendar("mockChannelID", func(channelCalendar *ChannelCalendar) error {
+			channelCalendar.LastAgendaDate = "2024-03-04"
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "renewedSubscriptionID", channelCalendar.SubscriptionID)
+		mockAPI.AssertExpectations(t)
+	})
+}
+
+func TestDeleteChannelCalendar(t *testing.T) {
+	mockAPI, store, _, _, _ := GetMockSetup(t)
+	mockAPI.On("KVDelete", mock.Anything).Return(nil).Times(1)
+	mockAPI.On("KVGet", mock.Anything).Return([]byte(`["otherChannelID","mockChannelID"]`), nil).Times(1)
+	mockAPI.On("KVSetWithOptions", mock.Anything, []byte(`["otherChannelID"]`), mock.Anything).Return(true, nil).Times(1)
+
+	err := store.DeleteChannelCalendar("mockChannelID")
+
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestLoadChannelCalendarIndex(t *testing.T) {
+	mockAPI, store, _, _, _ := GetMockSetup(t)
+	mockAPI.On("KVGet", mock.Anything).Return(nil, nil).Times(1)
+
+	_, err := store.LoadChannelCalendarIndex()
+
+	require.ErrorIs(t, err, ErrNotFound)
+	mockAPI.AssertExpectations(t)
+}
@@ -17,6 +17,12 @@ import (
 // If event has an end date/time, its record will be set to expire ttlAfterEventEnd
 // after its end. Events that have no end-date are created for defaultEventsTTL.
 // Expirations are updated when events themselves are updated.
+//
+// User events are keyed by remote.Event.OccurrenceKey, so that each occurrence
+// of a recurring event has its own record. Series masters expire after the
+// end of their last occurrence. They are also indexed by their remote ID, as
+// notifications of deleted events carry nothing else; the index records
+// expire along with the events.
 const ttlAfterEventEnd = 30 * 24 * time.Hour // 30 days
 const defaultEventTTL = 30 * 24 * time.Hour  // 30 days
 
@@ -38,12 +44,16 @@ type EventStore interface {
 	DeleteLinkedChannelFromEvent(eventID, channelID string) error
 
 	LoadUserEvent(mattermostUserID, eventID string) (*Event, error)
+	LoadUserEventByRemoteID(mattermostUserID, remoteEventID string) (*Event, error)
 	StoreUserEvent(mattermostUserID string, event *Event) error
 	DeleteUserEvent(mattermostUserID, eventID string) error
 }
 
 func eventKey(mattermostUserID, eventID string) string { return mattermostUserID + "_" + eventID }
 func eventMetaKey(eventID string) string               { return "metadata_" + eventID }
+func eventRemoteIDKey(mattermostUserID, remoteEventID string) string {
+	return "remoteid_" + mattermostUserID + "_" + remoteEventID
+}
 
 func (s *pluginStore) LoadUserEvent(mattermostUserID, eventID string) (*Event, error) {
 	event := Event{}
@@ -54,6 +64,14 @@ func (s *pluginStore) LoadUserEvent(mattermostUserID, eventID string) (*Event, e
 	return &event, nil
 }
 
+func (s *pluginStore) LoadUserEventByRemoteID(mattermostUserID, remoteEventID string) (*Event, error) {
+	data, err := s.eventKV.Load(eventRemoteIDKey(mattermostUserID, remoteEventID))
+	if err != nil {
+		return nil, err
+	}
+	return s.LoadUserEvent(mattermostUserID, string(data))
+}
+
 func (s *pluginStore) AddLinkedChannelToEvent(eventID, channelID string) error {
 	eventMeta, err := s.LoadEventMetadata(eventID)
 	if err != nil && !errors.Is(err, ErrNotFound) {
@@ -77,6 +95,10 @@ func (s *pluginStore) DeleteLinkedChannelFromEvent(eventID, channelID string) er
 		return err
 	}
 
+	if eventMeta == nil {
+		return nil
+	}
+
 	delete(eventMeta.LinkedChannelIDs, channelID)
 
 	return s.StoreEventMetadata(eventID, eventMeta)
@@ -106,12 +128,17 @@ func (s *pluginStore) DeleteEventMetadata(eventID string) error {
 func (s *pluginStore) StoreUserEvent(mattermostUserID string, event *Event) error {
 	now := time.Now()
 	end := now.Add(defaultEventTTL)
-	if event.Remote.End != nil {
-		end = event.Remote.End.Time().Add(ttlAfterEventEnd)
-		if end.Before(now) {
-			// no point storing expired keys
-			return nil
+	switch {
+	case event.Remote.Recurrence != nil:
+		if seriesEnd := event.Remote.Recurrence.EndTime(time.UTC); !seriesEnd.IsZero() {
+			end = seriesEnd.Add(ttlAfterEventEnd)
 		}
+	case event.Remote.End != nil:
+		end = event.Remote.End.Time().Add(ttlAfterEventEnd)
+	}
+	if end.Before(now) {
+		// no point storing expired keys
+		return nil
 	}
 
 	ttl := int64(end.Sub(now).Seconds())
@@ -119,10 +146,16 @@ func (s *pluginStore) StoreUserEvent(mattermostUserID string, event *Event) erro
 	if err != nil {
 		return err
 	}
-	err = s.eventKV.StoreTTL(eventKey(mattermostUserID, event.Remote.ICalUID), data, ttl)
+	err = s.eventKV.StoreTTL(eventKey(mattermostUserID, event.Remote.OccurrenceKey()), data, ttl)
 	if err != nil {
 		return err
 	}
+	if event.Remote.ID != "" {
+		err = s.eventKV.StoreTTL(eventRemoteIDKey(mattermostUserID, event.Remote.ID), []byte(event.Remote.OccurrenceKey()), ttl)
+		if err != nil {
+			return err
+		}
+	}
 
 	s.Logger.With(bot.LogContext{
 		"mattermostUserID": mattermostUserID,
@@ -133,12 +166,26 @@ func (s *pluginStore) StoreUserEvent(mattermostUserID string, event *Event) erro
 	return nil
 }
 
+// DeleteUserEvent deletes the event, and its remote ID index record unless
+// the index was since pointed at another occurrence.
 func (s *pluginStore) DeleteUserEvent(mattermostUserID, eventID string) error {
-	err := s.eventKV.Delete(eventKey(mattermostUserID, eventID))
+	event, err := s.LoadUserEvent(mattermostUserID, eventID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	err = s.eventKV.Delete(eventKey(mattermostUserID, eventID))
 	if err != nil {
 		return err
 	}
 
+	if event != nil && event.Remote != nil && event.Remote.ID != "" {
+		err = s.deleteUserEventRemoteID(mattermostUserID, event.Remote.ID, eventID)
+		if err != nil {
+			return err
+		}
+	}
+
 	s.Logger.With(bot.LogContext{
 		"mattermostUserID": mattermostUserID,
 		"eventID":          eventID,
@@ -146,3 +193,17 @@ func (s *pluginStore) DeleteUserEvent(mattermostUserID, eventID string) error {
 
 	return nil
 }
+
+func (s *pluginStore) deleteUserEventRemoteID(mattermostUserID, remoteEventID, eventID string) error {
+	data, err := s.eventKV.Load(eventRemoteIDKey(mattermostUserID, remoteEventID))
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if string(data) != eventID {
+		return nil
+	}
+	return s.eventKV.Delete(eventRemoteIDKey(mattermostUserID, remoteEventID))
+}
@@ -113,6 +113,15 @@ func TestDeleteLinkedChannelFromEvent(t *testing.T) {
 				require.ErrorContains(t, err, "Metadata not found")
 			},
 		},
+		{
+			name: "Event metadata not found",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", "ev_cf7c446273a2f147fa59573564da6b75").Return(nil, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
 		{
 			name: "Channel ID not present",
 			setup: func(mockAPI *testutil.MockPluginAPI) {
@@ -322,6 +331,23 @@ func TestStoreUserEvent(t *testing.T) {
 			setup: func(mockAPI *testutil.MockPluginAPI, mockLogger *mock_bot.MockLogger, mockLoggerWith *mock_bot.MockLogger) {
 				mockEvent.Remote.End = remote.NewDateTime(time.Now(), "UTC")
 				mockAPI.On("KVSetWithExpiry", "ev_ad2104c3b0ad765e6e9e03857a3348a5", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Times(1)
+				mockAPI.On("KVSetWithExpiry", "ev_e7aebc08fcee5e3721641959e41089e9", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Times(1)
+				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
+				mockLoggerWith.EXPECT().Debugf("store: stored user event.").Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Store occurrence of a recurring event",
+			setup: func(mockAPI *testutil.MockPluginAPI, mockLogger *mock_bot.MockLogger, mockLoggerWith *mock_bot.MockLogger) {
+				mockEvent.Remote.End = remote.NewDateTime(time.Now(), "UTC")
+				mockEvent.Remote.Type = remote.EventTypeOccurrence
+				mockEvent.Remote.SeriesMasterID = "mockSeriesMasterID"
+				mockEvent.Remote.OriginalStart = "2030-05-02T14:00:00Z"
+				mockAPI.On("KVSetWithExpiry", "ev_437cf36c84d2da9c8a5cd00b3abec4f0", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Times(1)
+				mockAPI.On("KVSetWithExpiry", "ev_e7aebc08fcee5e3721641959e41089e9", mock.Anything, mock.AnythingOfType("int64")).Return(nil).Times(1)
 				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
 				mockLoggerWith.EXPECT().Debugf("store: stored user event.").Times(1)
 			},
@@ -344,15 +370,29 @@ func TestStoreUserEvent(t *testing.T) {
 }
 
 func TestDeleteUserEvent(t *testing.T) {
+	eventKey := "ev_ff63e69da944334bfa44f98fe45e3c0c"
+	remoteIDKey := "ev_7eff23f8066182ae854a63b271067c57"
+
 	tests := []struct {
 		name       string
 		setup      func(*testutil.MockPluginAPI, *mock_bot.MockLogger, *mock_bot.MockLogger)
 		assertions func(*testing.T, error)
 	}{
+		{
+			name: "Error loading user event",
+			setup: func(mockAPI *testutil.MockPluginAPI, _ *mock_bot.MockLogger, _ *mock_bot.MockLogger) {
+				mockAPI.On("KVGet", eventKey).Return(nil, &model.AppError{Message: "Failed to load event"}).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.ErrorContains(t, err, "Failed to load event")
+			},
+		},
 		{
 			name: "Error deleting user event",
 			setup: func(mockAPI *testutil.MockPluginAPI, _ *mock_bot.MockLogger, _ *mock_bot.MockLogger) {
-				mockAPI.On("KVDelete", mock.Anything).Return(&model.AppError{Message: "Failed to delete event"}).Times(1)
+				mockAPI.On("KVGet", eventKey).Return(nil, nil).Times(1)
+				mockAPI.On("KVDelete", eventKey).Return(&model.AppError{Message: "Failed to delete event"}).Times(1)
 			},
 			assertions: func(t *testing.T, err error) {
 				require.Error(t, err)
@@ -360,9 +400,37 @@ func TestDeleteUserEvent(t *testing.T) {
 			},
 		},
 		{
-			name: "Successful delete",
+			name: "Successful delete of an event not found",
+			setup: func(mockAPI *testutil.MockPluginAPI, mockLogger *mock_bot.MockLogger, mockLoggerWith *mock_bot.MockLogger) {
+				mockAPI.On("KVGet", eventKey).Return(nil, nil).Times(1)
+				mockAPI.On("KVDelete", eventKey).Return(nil).Times(1)
+				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
+				mockLoggerWith.EXPECT().Debugf("store: deleted event.").Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Successful delete with its remote ID index",
+			setup: func(mockAPI *testutil.MockPluginAPI, mockLogger *mock_bot.MockLogger, mockLoggerWith *mock_bot.MockLogger) {
+				mockAPI.On("KVGet", eventKey).Return([]byte(`{"Remote":{"ID":"mockRemoteID"}}`), nil).Times(1)
+				mockAPI.On("KVDelete", eventKey).Return(nil).Times(1)
+				mockAPI.On("KVGet", remoteIDKey).Return([]byte(MockEventID), nil).Times(1)
+				mockAPI.On("KVDelete", remoteIDKey).Return(nil).Times(1)
+				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
+				mockLoggerWith.EXPECT().Debugf("store: deleted event.").Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Remote ID index of another occurrence is kept",
 			setup: func(mockAPI *testutil.MockPluginAPI, mockLogger *mock_bot.MockLogger, mockLoggerWith *mock_bot.MockLogger) {
-				mockAPI.On("KVDelete", mock.Anything).Return(nil).Times(1)
+				mockAPI.On("KVGet", eventKey).Return([]byte(`{"Remote":{"ID":"mockRemoteID"}}`), nil).Times(1)
+				mockAPI.On("KVDelete", eventKey).Return(nil).Times(1)
+				mockAPI.On("KVGet", remoteIDKey).Return([]byte("otherEventID"), nil).Times(1)
 				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
 				mockLoggerWith.EXPECT().Debugf("store: deleted event.").Times(1)
 			},
@@ -376,7 +444,7 @@ func TestDeleteUserEvent(t *testing.T) {
 			mockAPI, store, mockLogger, mockLoggerWith, _ := GetMockSetup(t)
 			tt.setup(mockAPI, mockLogger, mockLoggerWith)
 
-			err := store.DeleteUserEvent("mockUserID", "mockEventID")
+			err := store.DeleteUserEvent(MockUserID, MockEventID)
 
 			tt.assertions(t, err)
 			mockAPI.AssertExpectations(t)
@@ -0,0 +1,45 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// MailboxSettingsCacheTTL bounds how long the mailbox settings of a user are
+// reused by the status sync before they are fetched again.
+const MailboxSettingsCacheTTL = 30 * time.Minute
+
+// MailboxSettingsStore caches the mailbox settings of users, so that the
+// status sync doesn't fetch them for every user on every run.
+type MailboxSettingsStore interface {
+	LoadMailboxSettings(mattermostUserID string) (*remote.MailboxSettings, error)
+	StoreMailboxSettings(mattermostUserID string, mailboxSettings *remote.MailboxSettings) error
+	DeleteMailboxSettings(mattermostUserID string) error
+}
+
+func (s *pluginStore) LoadMailboxSettings(mattermostUserID string) (*remote.MailboxSettings, error) {
+	mailboxSettings := remote.MailboxSettings{}
+	err := kvstore.LoadJSON(s.mailboxSettingsKV, mattermostUserID, &mailboxSettings)
+	if err != nil {
+		return nil, err
+	}
+	return &mailboxSettings, nil
+}
+
+func (s *pluginStore) StoreMailboxSettings(mattermostUserID string, mailboxSettings *remote.MailboxSettings) error {
+	data, err := json.Marshal(mailboxSettings)
+	if err != nil {
+		return err
+	}
+	return s.mailboxSettingsKV.StoreTTL(mattermostUserID, data, int64(MailboxSettingsCacheTTL.Seconds()))
+}
+
+func (s *pluginStore) DeleteMailboxSettings(mattermostUserID string) error {
+	return s.mailboxSettingsKV.Delete(mattermostUserID)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/testutil"
+)
+
+const mockMailboxSettingsKey = "mailbox_c3b5020d58a049787bc969768465b890"
+
+func TestLoadMailboxSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, *remote.MailboxSettings, error)
+	}{
+		{
+			name: "Mailbox settings not cached",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockMailboxSettingsKey).Return(nil, nil).Times(1)
+			},
+			assertions: func(t *testing.T, mailboxSettings *remote.MailboxSettings, err error) {
+				require.Nil(t, mailboxSettings)
+				require.ErrorIs(t, err, ErrNotFound)
+			},
+		},
+		{
+			name: "Successful load",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockMailboxSettingsKey).Return([]byte(`{"timeZone":"Pacific Standard Time"}`), nil).Times(1)
+			},
+			assertions: func(t *testing.T, mailboxSettings *remote.MailboxSettings, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "Pacific Standard Time", mailboxSettings.TimeZone)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			mailboxSettings, err := store.LoadMailboxSettings(MockMMUserID)
+
+			tt.assertions(t, mailboxSettings, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStoreMailboxSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "Error storing mailbox settings",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSetWithExpiry", mockMailboxSettingsKey, mock.Anything, mock.AnythingOfType("int64")).Return(&model.AppError{Message: "Failed to store mailbox settings"}).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "Failed to store mailbox settings")
+			},
+		},
+		{
+			name: "Successful store",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSetWithExpiry", mockMailboxSettingsKey, mock.Anything, int64(MailboxSettingsCacheTTL.Seconds())).Return(nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			err := store.StoreMailboxSettings(MockMMUserID, &remote.MailboxSettings{TimeZone: "Pacific Standard Time"})
+
+			tt.assertions(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
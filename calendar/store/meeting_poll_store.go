@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// Meeting polls expire meetingPollTTL after they are stored, whether they
+// were finalized or not.
+const meetingPollTTL = 30 * 24 * time.Hour // 30 days
+
+// MeetingPoll lets the members of a channel vote for the time of a meeting
+// among the times suggested to its organizer. FinalizedSlot is the index of
+// the time the organizer chose, if any.
+type MeetingPoll struct {
+	ID            string
+	OrganizerID   string
+	ChannelID     string
+	Subject       string
+	TimeZone      string
+	Slots         []*MeetingPollSlot
+	FinalizedSlot *int `json:",omitempty"`
+}
+
+type MeetingPollSlot struct {
+	Start  time.Time
+	End    time.Time
+	Voters []*MeetingPollVoter
+}
+
+type MeetingPollVoter struct {
+	MattermostUserID string
+	Username         string
+}
+
+type MeetingPollStore interface {
+	LoadMeetingPoll(pollID string) (*MeetingPoll, error)
+	StoreMeetingPoll(poll *MeetingPoll) error
+	ModifyMeetingPoll(pollID string, modify func(poll *MeetingPoll) error) (*MeetingPoll, error)
+}
+
+func (s *pluginStore) LoadMeetingPoll(pollID string) (*MeetingPoll, error) {
+	poll := MeetingPoll{}
+	err := kvstore.LoadJSON(s.meetingPollKV, pollID, &poll)
+	if err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+func (s *pluginStore) StoreMeetingPoll(poll *MeetingPoll) error {
+	data, err := json.Marshal(poll)
+	if err != nil {
+		return err
+	}
+	err = s.meetingPollKV.StoreTTL(poll.ID, data, int64(meetingPollTTL.Seconds()))
+	if err != nil {
+		return errors.Wrap(err, "error storing meeting poll")
+	}
+	return nil
+}
+
+// ModifyMeetingPoll atomically applies modify to the stored poll, so that
+// concurrent votes are not lost, and returns the modified poll.
+func (s *pluginStore) ModifyMeetingPoll(pollID string, modify func(poll *MeetingPoll) error) (*MeetingPoll, error) {
+	var modified *MeetingPoll
+	err := kvstore.AtomicModifyWithOptions(s.meetingPollKV, pollID, func(initial []byte, storeErr error) ([]byte, *model.PluginKVSetOptions, error) {
+		if storeErr != nil {
+			return nil, nil, storeErr
+		}
+		if len(initial) == 0 {
+			return nil, nil, ErrNotFound
+		}
+
+		poll := &MeetingPoll{}
+		if err := json.Unmarshal(initial, poll); err != nil {
+			return nil, nil, err
+		}
+		if err := modify(poll); err != nil {
+			return nil, nil, err
+		}
+		modified = poll
+
+		data, err := json.Marshal(poll)
+		if err != nil {
+			return nil, nil, err
+		}
+		return data, &model.PluginKVSetOptions{ExpireInSeconds: int64(meetingPollTTL.Seconds())}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return modified, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/testutil"
+)
+
+const (
+	MockPollID  = "mockPollID"
+	MockPollKey = "poll_26e341edb4d5ad5b29bf87e449ff1caf"
+)
+
+func TestLoadMeetingPoll(t *testing.T) {
+	mockAPI, store, _, _, _ := GetMockSetup(t)
+	mockAPI.On("KVGet", MockPollKey).Return([]byte(`{"ID":"mockPollID","Subject":"Planning","Slots":[{"Voters":[{"MattermostUserID":"mockUserID"}]}]}`), nil).Times(1)
+
+	poll, err := store.LoadMeetingPoll(MockPollID)
+	require.NoError(t, err)
+	require.Equal(t, "Planning", poll.Subject)
+	require.Equal(t, MockUserID, poll.Slots[0].Voters[0].MattermostUserID)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestStoreMeetingPoll(t *testing.T) {
+	mockAPI, store, _, _, _ := GetMockSetup(t)
+	mockAPI.On("KVSetWithExpiry", MockPollKey, MockByteValue, int64(30*24*60*60)).Return(nil).Times(1)
+
+	err := store.StoreMeetingPoll(&MeetingPoll{ID: MockPollID})
+	require.NoError(t, err)
+	mockAPI.AssertExpectations(t)
+}
+
+func TestModifyMeetingPoll(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, *MeetingPoll, error)
+	}{
+		{
+			name: "Poll not found",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", MockPollKey).Return(nil, nil).Times(1)
+			},
+			assertions: func(t *testing.T, poll *MeetingPoll, err error) {
+				require.ErrorIs(t, err, ErrNotFound)
+				require.Nil(t, poll)
+			},
+		},
+		{
+			name: "Vote is stored atomically",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", MockPollKey).Return([]byte(`{"ID":"mockPollID","Slots":[{}]}`), nil).Times(1)
+				mockAPI.On("KVSetWithOptions", MockPollKey, MockByteValue, mock.MatchedBy(func(opts model.PluginKVSetOptions) bool {
+					return opts.Atomic && opts.ExpireInSeconds == int64(30*24*60*60)
+				})).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, poll *MeetingPoll, err error) {
+				require.NoError(t, err)
+				require.Len(t, poll.Slots[0].Voters, 1)
+				data, _ := json.Marshal(poll)
+				require.Contains(t, string(data), MockUserID)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			poll, err := store.ModifyMeetingPoll(MockPollID, func(poll *MeetingPoll) error {
+				poll.Slots[0].Voters = append(poll.Slots[0].Voters, &MeetingPollVoter{MattermostUserID: MockUserID})
+				return nil
+			})
+
+			tt.assertions(t, poll, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -6,9 +6,12 @@ package mock_store
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	remote "github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 	store "github.com/mattermost/mattermost-plugin-mscalendar/calendar/store"
+	model "github.com/mattermost/mattermost/server/public/model"
 	oauth2 "golang.org/x/oauth2"
 )
 
@@ -63,6 +66,48 @@ func (mr *MockStoreMockRecorder) CheckUserConnected(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserConnected", reflect.TypeOf((*MockStore)(nil).CheckUserConnected), arg0)
 }
 
+// DeleteCalendarCache mocks base method.
+func (m *MockStore) DeleteCalendarCache(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarCache", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendarCache indicates an expected call of DeleteCalendarCache.
+func (mr *MockStoreMockRecorder) DeleteCalendarCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarCache", reflect.TypeOf((*MockStore)(nil).DeleteCalendarCache), arg0, arg1)
+}
+
+// DeleteChannelCalendar mocks base method.
+func (m *MockStore) DeleteChannelCalendar(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelCalendar", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannelCalendar indicates an expected call of DeleteChannelCalendar.
+func (mr *MockStoreMockRecorder) DeleteChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelCalendar", reflect.TypeOf((*MockStore)(nil).DeleteChannelCalendar), arg0)
+}
+
+// DeleteChannelCalendarEvent mocks base method.
+func (m *MockStore) DeleteChannelCalendarEvent(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelCalendarEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannelCalendarEvent indicates an expected call of DeleteChannelCalendarEvent.
+func (mr *MockStoreMockRecorder) DeleteChannelCalendarEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelCalendarEvent", reflect.TypeOf((*MockStore)(nil).DeleteChannelCalendarEvent), arg0, arg1)
+}
+
 // DeleteCurrentStep mocks base method.
 func (m *MockStore) DeleteCurrentStep(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -105,6 +150,20 @@ func (mr *MockStoreMockRecorder) DeleteLinkedChannelFromEvent(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLinkedChannelFromEvent", reflect.TypeOf((*MockStore)(nil).DeleteLinkedChannelFromEvent), arg0, arg1)
 }
 
+// DeleteMailboxSettings mocks base method.
+func (m *MockStore) DeleteMailboxSettings(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMailboxSettings", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMailboxSettings indicates an expected call of DeleteMailboxSettings.
+func (mr *MockStoreMockRecorder) DeleteMailboxSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMailboxSettings", reflect.TypeOf((*MockStore)(nil).DeleteMailboxSettings), arg0)
+}
+
 // DeletePanelPostID mocks base method.
 func (m *MockStore) DeletePanelPostID(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -161,6 +220,34 @@ func (mr *MockStoreMockRecorder) DeleteUserFromIndex(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserFromIndex", reflect.TypeOf((*MockStore)(nil).DeleteUserFromIndex), arg0)
 }
 
+// DeleteUserLinkedEvent mocks base method.
+func (m *MockStore) DeleteUserLinkedEvent(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserLinkedEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserLinkedEvent indicates an expected call of DeleteUserLinkedEvent.
+func (mr *MockStoreMockRecorder) DeleteUserLinkedEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserLinkedEvent", reflect.TypeOf((*MockStore)(nil).DeleteUserLinkedEvent), arg0, arg1)
+}
+
+// DeleteUserQueuedDMs mocks base method.
+func (m *MockStore) DeleteUserQueuedDMs(arg0 string, arg1 []*model.SlackAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserQueuedDMs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserQueuedDMs indicates an expected call of DeleteUserQueuedDMs.
+func (mr *MockStoreMockRecorder) DeleteUserQueuedDMs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserQueuedDMs", reflect.TypeOf((*MockStore)(nil).DeleteUserQueuedDMs), arg0, arg1)
+}
+
 // DeleteUserSubscription mocks base method.
 func (m *MockStore) DeleteUserSubscription(arg0 *store.User, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -292,6 +379,81 @@ func (mr *MockStoreMockRecorder) GetSubscriptionCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionCount", reflect.TypeOf((*MockStore)(nil).GetSubscriptionCount))
 }
 
+// LoadCalendarCache mocks base method.
+func (m *MockStore) LoadCalendarCache(arg0, arg1 string) (*store.CalendarCache, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadCalendarCache", arg0, arg1)
+	ret0, _ := ret[0].(*store.CalendarCache)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadCalendarCache indicates an expected call of LoadCalendarCache.
+func (mr *MockStoreMockRecorder) LoadCalendarCache(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadCalendarCache", reflect.TypeOf((*MockStore)(nil).LoadCalendarCache), arg0, arg1)
+}
+
+// LoadChannelCalendar mocks base method.
+func (m *MockStore) LoadChannelCalendar(arg0 string) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChannelCalendar", arg0)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadChannelCalendar indicates an expected call of LoadChannelCalendar.
+func (mr *MockStoreMockRecorder) LoadChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChannelCalendar", reflect.TypeOf((*MockStore)(nil).LoadChannelCalendar), arg0)
+}
+
+// LoadChannelCalendarEvent mocks base method.
+func (m *MockStore) LoadChannelCalendarEvent(arg0, arg1 string) (*store.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChannelCalendarEvent", arg0, arg1)
+	ret0, _ := ret[0].(*store.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadChannelCalendarEvent indicates an expected call of LoadChannelCalendarEvent.
+func (mr *MockStoreMockRecorder) LoadChannelCalendarEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChannelCalendarEvent", reflect.TypeOf((*MockStore)(nil).LoadChannelCalendarEvent), arg0, arg1)
+}
+
+// LoadChannelCalendarEventByRemoteID mocks base method.
+func (m *MockStore) LoadChannelCalendarEventByRemoteID(arg0, arg1 string) (*store.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChannelCalendarEventByRemoteID", arg0, arg1)
+	ret0, _ := ret[0].(*store.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadChannelCalendarEventByRemoteID indicates an expected call of LoadChannelCalendarEventByRemoteID.
+func (mr *MockStoreMockRecorder) LoadChannelCalendarEventByRemoteID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChannelCalendarEventByRemoteID", reflect.TypeOf((*MockStore)(nil).LoadChannelCalendarEventByRemoteID), arg0, arg1)
+}
+
+// LoadChannelCalendarIndex mocks base method.
+func (m *MockStore) LoadChannelCalendarIndex() (store.ChannelCalendarIndex, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChannelCalendarIndex")
+	ret0, _ := ret[0].(store.ChannelCalendarIndex)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadChannelCalendarIndex indicates an expected call of LoadChannelCalendarIndex.
+func (mr *MockStoreMockRecorder) LoadChannelCalendarIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChannelCalendarIndex", reflect.TypeOf((*MockStore)(nil).LoadChannelCalendarIndex))
+}
+
 // LoadEventMetadata mocks base method.
 func (m *MockStore) LoadEventMetadata(arg0 string) (*store.EventMetadata, error) {
 	m.ctrl.T.Helper()
@@ -307,6 +469,21 @@ func (mr *MockStoreMockRecorder) LoadEventMetadata(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventMetadata", reflect.TypeOf((*MockStore)(nil).LoadEventMetadata), arg0)
 }
 
+// LoadMailboxSettings mocks base method.
+func (m *MockStore) LoadMailboxSettings(arg0 string) (*remote.MailboxSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadMailboxSettings", arg0)
+	ret0, _ := ret[0].(*remote.MailboxSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadMailboxSettings indicates an expected call of LoadMailboxSettings.
+func (mr *MockStoreMockRecorder) LoadMailboxSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMailboxSettings", reflect.TypeOf((*MockStore)(nil).LoadMailboxSettings), arg0)
+}
+
 // LoadMattermostUserID mocks base method.
 func (m *MockStore) LoadMattermostUserID(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -322,6 +499,36 @@ func (mr *MockStoreMockRecorder) LoadMattermostUserID(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMattermostUserID", reflect.TypeOf((*MockStore)(nil).LoadMattermostUserID), arg0)
 }
 
+// LoadMeetingPoll mocks base method.
+func (m *MockStore) LoadMeetingPoll(arg0 string) (*store.MeetingPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadMeetingPoll", arg0)
+	ret0, _ := ret[0].(*store.MeetingPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadMeetingPoll indicates an expected call of LoadMeetingPoll.
+func (mr *MockStoreMockRecorder) LoadMeetingPoll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadMeetingPoll", reflect.TypeOf((*MockStore)(nil).LoadMeetingPoll), arg0)
+}
+
+// LoadReminderSnoozes mocks base method.
+func (m *MockStore) LoadReminderSnoozes(arg0 string) (store.ReminderSnoozes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadReminderSnoozes", arg0)
+	ret0, _ := ret[0].(store.ReminderSnoozes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadReminderSnoozes indicates an expected call of LoadReminderSnoozes.
+func (mr *MockStoreMockRecorder) LoadReminderSnoozes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadReminderSnoozes", reflect.TypeOf((*MockStore)(nil).LoadReminderSnoozes), arg0)
+}
+
 // LoadSubscription mocks base method.
 func (m *MockStore) LoadSubscription(arg0 string) (*store.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -367,6 +574,21 @@ func (mr *MockStoreMockRecorder) LoadUserEvent(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserEvent", reflect.TypeOf((*MockStore)(nil).LoadUserEvent), arg0, arg1)
 }
 
+// LoadUserEventByRemoteID mocks base method.
+func (m *MockStore) LoadUserEventByRemoteID(arg0, arg1 string) (*store.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserEventByRemoteID", arg0, arg1)
+	ret0, _ := ret[0].(*store.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserEventByRemoteID indicates an expected call of LoadUserEventByRemoteID.
+func (mr *MockStoreMockRecorder) LoadUserEventByRemoteID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserEventByRemoteID", reflect.TypeOf((*MockStore)(nil).LoadUserEventByRemoteID), arg0, arg1)
+}
+
 // LoadUserFromIndex mocks base method.
 func (m *MockStore) LoadUserFromIndex(arg0 string) (*store.UserShort, error) {
 	m.ctrl.T.Helper()
@@ -397,6 +619,21 @@ func (mr *MockStoreMockRecorder) LoadUserIndex() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserIndex", reflect.TypeOf((*MockStore)(nil).LoadUserIndex))
 }
 
+// LoadUserQueuedDMs mocks base method.
+func (m *MockStore) LoadUserQueuedDMs(arg0 string) ([]*model.SlackAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserQueuedDMs", arg0)
+	ret0, _ := ret[0].([]*model.SlackAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserQueuedDMs indicates an expected call of LoadUserQueuedDMs.
+func (mr *MockStoreMockRecorder) LoadUserQueuedDMs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserQueuedDMs", reflect.TypeOf((*MockStore)(nil).LoadUserQueuedDMs), arg0)
+}
+
 // LoadUserWelcomePost mocks base method.
 func (m *MockStore) LoadUserWelcomePost(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -412,6 +649,51 @@ func (mr *MockStoreMockRecorder) LoadUserWelcomePost(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserWelcomePost", reflect.TypeOf((*MockStore)(nil).LoadUserWelcomePost), arg0)
 }
 
+// MarkReminderSent mocks base method.
+func (m *MockStore) MarkReminderSent(arg0, arg1 string, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockStoreMockRecorder) MarkReminderSent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockStore)(nil).MarkReminderSent), arg0, arg1, arg2)
+}
+
+// ModifyChannelCalendar mocks base method.
+func (m *MockStore) ModifyChannelCalendar(arg0 string, arg1 func(*store.ChannelCalendar) error) (*store.ChannelCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyChannelCalendar", arg0, arg1)
+	ret0, _ := ret[0].(*store.ChannelCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyChannelCalendar indicates an expected call of ModifyChannelCalendar.
+func (mr *MockStoreMockRecorder) ModifyChannelCalendar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyChannelCalendar", reflect.TypeOf((*MockStore)(nil).ModifyChannelCalendar), arg0, arg1)
+}
+
+// ModifyMeetingPoll mocks base method.
+func (m *MockStore) ModifyMeetingPoll(arg0 string, arg1 func(*store.MeetingPoll) error) (*store.MeetingPoll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyMeetingPoll", arg0, arg1)
+	ret0, _ := ret[0].(*store.MeetingPoll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyMeetingPoll indicates an expected call of ModifyMeetingPoll.
+func (mr *MockStoreMockRecorder) ModifyMeetingPoll(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyMeetingPoll", reflect.TypeOf((*MockStore)(nil).ModifyMeetingPoll), arg0, arg1)
+}
+
 // ModifyUserIndex mocks base method.
 func (m *MockStore) ModifyUserIndex(arg0 func(store.UserIndex) (store.UserIndex, error)) error {
 	m.ctrl.T.Helper()
@@ -426,6 +708,20 @@ func (mr *MockStoreMockRecorder) ModifyUserIndex(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyUserIndex", reflect.TypeOf((*MockStore)(nil).ModifyUserIndex), arg0)
 }
 
+// QueueUserDM mocks base method.
+func (m *MockStore) QueueUserDM(arg0 string, arg1 *model.SlackAttachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueUserDM", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// QueueUserDM indicates an expected call of QueueUserDM.
+func (mr *MockStoreMockRecorder) QueueUserDM(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueUserDM", reflect.TypeOf((*MockStore)(nil).QueueUserDM), arg0, arg1)
+}
+
 // RefreshAndStoreToken mocks base method.
 func (m *MockStore) RefreshAndStoreToken(arg0 *oauth2.Token, arg1 *oauth2.Config, arg2 string) (*oauth2.Token, error) {
 	m.ctrl.T.Helper()
@@ -540,6 +836,62 @@ func (mr *MockStoreMockRecorder) SetSetting(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSetting", reflect.TypeOf((*MockStore)(nil).SetSetting), arg0, arg1, arg2)
 }
 
+// StoreCalendarCache mocks base method.
+func (m *MockStore) StoreCalendarCache(arg0, arg1 string, arg2 *store.CalendarCache) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreCalendarCache", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreCalendarCache indicates an expected call of StoreCalendarCache.
+func (mr *MockStoreMockRecorder) StoreCalendarCache(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCalendarCache", reflect.TypeOf((*MockStore)(nil).StoreCalendarCache), arg0, arg1, arg2)
+}
+
+// StoreChannelCalendar mocks base method.
+func (m *MockStore) StoreChannelCalendar(arg0 *store.ChannelCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreChannelCalendar", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreChannelCalendar indicates an expected call of StoreChannelCalendar.
+func (mr *MockStoreMockRecorder) StoreChannelCalendar(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreChannelCalendar", reflect.TypeOf((*MockStore)(nil).StoreChannelCalendar), arg0)
+}
+
+// StoreChannelCalendarEvent mocks base method.
+func (m *MockStore) StoreChannelCalendarEvent(arg0 string, arg1 *store.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreChannelCalendarEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreChannelCalendarEvent indicates an expected call of StoreChannelCalendarEvent.
+func (mr *MockStoreMockRecorder) StoreChannelCalendarEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreChannelCalendarEvent", reflect.TypeOf((*MockStore)(nil).StoreChannelCalendarEvent), arg0, arg1)
+}
+
+// StoreChannelSubscription mocks base method.
+func (m *MockStore) StoreChannelSubscription(arg0 *store.Subscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreChannelSubscription", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreChannelSubscription indicates an expected call of StoreChannelSubscription.
+func (mr *MockStoreMockRecorder) StoreChannelSubscription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreChannelSubscription", reflect.TypeOf((*MockStore)(nil).StoreChannelSubscription), arg0)
+}
+
 // StoreEventMetadata mocks base method.
 func (m *MockStore) StoreEventMetadata(arg0 string, arg1 *store.EventMetadata) error {
 	m.ctrl.T.Helper()
@@ -554,6 +906,34 @@ func (mr *MockStoreMockRecorder) StoreEventMetadata(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEventMetadata", reflect.TypeOf((*MockStore)(nil).StoreEventMetadata), arg0, arg1)
 }
 
+// StoreMailboxSettings mocks base method.
+func (m *MockStore) StoreMailboxSettings(arg0 string, arg1 *remote.MailboxSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreMailboxSettings", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMailboxSettings indicates an expected call of StoreMailboxSettings.
+func (mr *MockStoreMockRecorder) StoreMailboxSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMailboxSettings", reflect.TypeOf((*MockStore)(nil).StoreMailboxSettings), arg0, arg1)
+}
+
+// StoreMeetingPoll mocks base method.
+func (m *MockStore) StoreMeetingPoll(arg0 *store.MeetingPoll) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreMeetingPoll", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMeetingPoll indicates an expected call of StoreMeetingPoll.
+func (mr *MockStoreMockRecorder) StoreMeetingPoll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMeetingPoll", reflect.TypeOf((*MockStore)(nil).StoreMeetingPoll), arg0)
+}
+
 // StoreOAuth2State mocks base method.
 func (m *MockStore) StoreOAuth2State(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -568,6 +948,35 @@ func (mr *MockStoreMockRecorder) StoreOAuth2State(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOAuth2State", reflect.TypeOf((*MockStore)(nil).StoreOAuth2State), arg0)
 }
 
+// StoreReminderSnooze mocks base method.
+func (m *MockStore) StoreReminderSnooze(arg0, arg1 string, arg2 *store.ReminderSnooze) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreReminderSnooze", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreReminderSnooze indicates an expected call of StoreReminderSnooze.
+func (mr *MockStoreMockRecorder) StoreReminderSnooze(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreReminderSnooze", reflect.TypeOf((*MockStore)(nil).StoreReminderSnooze), arg0, arg1, arg2)
+}
+
+// StoreSubscriptionExpiration mocks base method.
+func (m *MockStore) StoreSubscriptionExpiration(arg0, arg1 string) (*store.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSubscriptionExpiration", arg0, arg1)
+	ret0, _ := ret[0].(*store.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreSubscriptionExpiration indicates an expected call of StoreSubscriptionExpiration.
+func (mr *MockStoreMockRecorder) StoreSubscriptionExpiration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSubscriptionExpiration", reflect.TypeOf((*MockStore)(nil).StoreSubscriptionExpiration), arg0, arg1)
+}
+
 // StoreUser mocks base method.
 func (m *MockStore) StoreUser(arg0 *store.User) error {
 	m.ctrl.T.Helper()
@@ -596,6 +1005,20 @@ func (mr *MockStoreMockRecorder) StoreUserActiveEvents(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserActiveEvents", reflect.TypeOf((*MockStore)(nil).StoreUserActiveEvents), arg0, arg1)
 }
 
+// StoreUserAfterHours mocks base method.
+func (m *MockStore) StoreUserAfterHours(arg0 string, arg1 *store.AfterHours) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserAfterHours", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserAfterHours indicates an expected call of StoreUserAfterHours.
+func (mr *MockStoreMockRecorder) StoreUserAfterHours(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserAfterHours", reflect.TypeOf((*MockStore)(nil).StoreUserAfterHours), arg0, arg1)
+}
+
 // StoreUserCustomStatusUpdates mocks base method.
 func (m *MockStore) StoreUserCustomStatusUpdates(arg0 string, arg1 bool) error {
 	m.ctrl.T.Helper()
@@ -652,6 +1075,20 @@ func (mr *MockStoreMockRecorder) StoreUserLinkedEvent(arg0, arg1, arg2 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserLinkedEvent", reflect.TypeOf((*MockStore)(nil).StoreUserLinkedEvent), arg0, arg1, arg2)
 }
 
+// StoreUserOutOfOffice mocks base method.
+func (m *MockStore) StoreUserOutOfOffice(arg0 string, arg1 *store.OutOfOffice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreUserOutOfOffice", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreUserOutOfOffice indicates an expected call of StoreUserOutOfOffice.
+func (mr *MockStoreMockRecorder) StoreUserOutOfOffice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreUserOutOfOffice", reflect.TypeOf((*MockStore)(nil).StoreUserOutOfOffice), arg0, arg1)
+}
+
 // StoreUserSubscription mocks base method.
 func (m *MockStore) StoreUserSubscription(arg0 *store.User, arg1 *store.Subscription) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// maxQueuedDMs bounds the DMs kept for a user until their working hours start.
+const maxQueuedDMs = 50
+
+// QueuedDMStore keeps the DMs of users outside of their working hours. The
+// queues are stored apart from the users, and modified atomically, so that
+// DMs queued by concurrent notifications aren't lost.
+type QueuedDMStore interface {
+	QueueUserDM(mattermostUserID string, attachment *model.SlackAttachment) error
+	LoadUserQueuedDMs(mattermostUserID string) ([]*model.SlackAttachment, error)
+	DeleteUserQueuedDMs(mattermostUserID string, sent []*model.SlackAttachment) error
+}
+
+// QueueUserDM keeps a DM to send once the working hours of the user start.
+// The oldest DMs are dropped past maxQueuedDMs.
+func (s *pluginStore) QueueUserDM(mattermostUserID string, attachment *model.SlackAttachment) error {
+	err := s.modifyUserQueuedDMs(mattermostUserID, func(queued []*model.SlackAttachment) ([]*model.SlackAttachment, error) {
+		queued = append(queued, attachment)
+		if len(queued) > maxQueuedDMs {
+			queued = queued[len(queued)-maxQueuedDMs:]
+		}
+		return queued, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error queueing user DM")
+	}
+	return nil
+}
+
+func (s *pluginStore) LoadUserQueuedDMs(mattermostUserID string) ([]*model.SlackAttachment, error) {
+	queued := []*model.SlackAttachment{}
+	err := kvstore.LoadJSON(s.queuedDMKV, mattermostUserID, &queued)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return queued, nil
+}
+
+// DeleteUserQueuedDMs removes the sent DMs from the queue of the user. The
+// DMs queued since they were loaded, and the ones that couldn't be sent, are
+// kept.
+func (s *pluginStore) DeleteUserQueuedDMs(mattermostUserID string, sent []*model.SlackAttachment) error {
+	sentData := [][]byte{}
+	for _, attachment := range sent {
+		data, err := json.Marshal(attachment)
+		if err != nil {
+			return err
+		}
+		sentData = append(sentData, data)
+	}
+
+	err := s.modifyUserQueuedDMs(mattermostUserID, func(queued []*model.SlackAttachment) ([]*model.SlackAttachment, error) {
+		// Each sent DM removes one queued DM only, the same DM may be queued
+		// again in the meantime.
+		unmatched := append([][]byte{}, sentData...)
+		kept := []*model.SlackAttachment{}
+		for _, attachment := range queued {
+			data, err := json.Marshal(attachment)
+			if err != nil {
+				return nil, err
+			}
+			if i := indexOfData(unmatched, data); i >= 0 {
+				unmatched = append(unmatched[:i], unmatched[i+1:]...)
+				continue
+			}
+			kept = append(kept, attachment)
+		}
+		return kept, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error deleting user queued DMs")
+	}
+	return nil
+}
+
+func (s *pluginStore) modifyUserQueuedDMs(mattermostUserID string, modify func(queued []*model.SlackAttachment) ([]*model.SlackAttachment, error)) error {
+	return kvstore.AtomicModify(s.queuedDMKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		queued := []*model.SlackAttachment{}
+		if len(initial) > 0 {
+			if err := json.Unmarshal(initial, &queued); err != nil {
+				return nil, err
+			}
+		}
+
+		queued, err := modify(queued)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(queued)
+	})
+}
+
+func indexOfData(list [][]byte, data []byte) int {
+	for i, d := range list {
+		if bytes.Equal(d, data) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/testutil"
+)
+
+const mockQueuedDMKey = "dmqueue_c3b5020d58a049787bc969768465b890"
+
+func queuedDMs(titles ...string) []*model.SlackAttachment {
+	attachments := []*model.SlackAttachment{}
+	for _, title := range titles {
+		attachments = append(attachments, &model.SlackAttachment{Title: title})
+	}
+	return attachments
+}
+
+func storedQueuedDMs(titles ...string) []byte {
+	data, _ := json.Marshal(queuedDMs(titles...))
+	return data
+}
+
+func atomicallyStoredQueuedDMs(titles ...string) (interface{}, interface{}) {
+	expected := storedQueuedDMs(titles...)
+	return mock.MatchedBy(func(data []byte) bool {
+			return string(data) == string(expected)
+		}), mock.MatchedBy(func(opts model.PluginKVSetOptions) bool {
+			return opts.Atomic
+		})
+}
+
+func TestQueueUserDM(t *testing.T) {
+	full := []string{}
+	for i := 0; i < maxQueuedDMs; i++ {
+		full = append(full, fmt.Sprintf("DM %d", i))
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "Error loading queued DMs",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockQueuedDMKey).Return(nil, &model.AppError{Message: "KVGet failed"}).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "KVGet failed")
+			},
+		},
+		{
+			name: "First queued DM",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockQueuedDMKey).Return(nil, nil).Times(1)
+				value, opts := atomicallyStoredQueuedDMs("New DM")
+				mockAPI.On("KVSetWithOptions", mockQueuedDMKey, value, opts).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Queue DM",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockQueuedDMKey).Return(storedQueuedDMs("DM 0"), nil).Times(1)
+				value, opts := atomicallyStoredQueuedDMs("DM 0", "New DM")
+				mockAPI.On("KVSetWithOptions", mockQueuedDMKey, value, opts).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Drop the oldest DM when the queue is full",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mockQueuedDMKey).Return(storedQueuedDMs(full...), nil).Times(1)
+				value, opts := atomicallyStoredQueuedDMs(append(full[1:], "New DM")...)
+				mockAPI.On("KVSetWithOptions", mockQueuedDMKey, value, opts).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			err := store.QueueUserDM(MockMMUserID, &model.SlackAttachment{Title: "New DM"})
+
+			tt.assertions(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoadUserQueuedDMs(t *testing.T) {
+	t.Run("No queued DMs", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mockQueuedDMKey).Return(nil, nil).Times(1)
+
+		dms, err := store.LoadUserQueuedDMs(MockMMUserID)
+		require.NoError(t, err)
+		require.Empty(t, dms)
+	})
+
+	t.Run("Queued DMs", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mockQueuedDMKey).Return(storedQueuedDMs("DM 0", "DM 1"), nil).Times(1)
+
+		dms, err := store.LoadUserQueuedDMs(MockMMUserID)
+		require.NoError(t, err)
+		require.Equal(t, queuedDMs("DM 0", "DM 1"), dms)
+	})
+}
+
+func TestDeleteUserQueuedDMs(t *testing.T) {
+	t.Run("Only the sent DMs are removed", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		// DM 1 couldn't be sent, and DM 2 was queued while sending.
+		mockAPI.On("KVGet", mockQueuedDMKey).Return(storedQueuedDMs("DM 0", "DM 1", "DM 2"), nil).Times(1)
+		value, opts := atomicallyStoredQueuedDMs("DM 1", "DM 2")
+		mockAPI.On("KVSetWithOptions", mockQueuedDMKey, value, opts).Return(true, nil).Times(1)
+
+		err := store.DeleteUserQueuedDMs(MockMMUserID, queuedDMs("DM 0"))
+		require.NoError(t, err)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("A DM queued again is kept", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mockQueuedDMKey).Return(storedQueuedDMs("DM 0", "DM 0"), nil).Times(1)
+		value, opts := atomicallyStoredQueuedDMs("DM 0")
+		mockAPI.On("KVSetWithOptions", mockQueuedDMKey, value, opts).Return(true, nil).Times(1)
+
+		err := store.DeleteUserQueuedDMs(MockMMUserID, queuedDMs("DM 0"))
+		require.NoError(t, err)
+		mockAPI.AssertExpectations(t)
+	})
+
+	t.Run("Retry when the queue changed", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mockQueuedDMKey).Return(storedQueuedDMs("DM 0"), nil).Once()
+		value, opts := atomicallyStoredQueuedDMs()
+		mockAPI.On("KVSetWithOptions", mockQueuedDMKey, value, opts).Return(false, nil).Once()
+		mockAPI.On("KVGet", mockQueuedDMKey).Return(storedQueuedDMs("DM 0", "DM 1"), nil).Once()
+		value, opts = atomicallyStoredQueuedDMs("DM 1")
+		mockAPI.On("KVSetWithOptions", mockQueuedDMKey, value, opts).Return(true, nil).Once()
+
+		err := store.DeleteUserQueuedDMs(MockMMUserID, queuedDMs("DM 0"))
+		require.NoError(t, err)
+		mockAPI.AssertExpectations(t)
+	})
+}
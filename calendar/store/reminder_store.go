@@ -0,0 +1,103 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/kvstore"
+)
+
+// Sent reminders are kept until reminderRetention after the start of their
+// event, when they can't be due anymore.
+const reminderRetention = time.Hour
+
+type ReminderStore interface {
+	MarkReminderSent(ownerID, reminderID string, eventStart time.Time) (bool, error)
+	LoadReminderSnoozes(mattermostUserID string) (ReminderSnoozes, error)
+	StoreReminderSnooze(mattermostUserID, key string, snooze *ReminderSnooze) error
+}
+
+// MarkReminderSent records that the reminder was sent to its owner, a user or
+// a channel, and tells whether it wasn't already. The record is created
+// atomically, so that only one run of the reminders, on any node of the
+// cluster, sends it.
+func (s *pluginStore) MarkReminderSent(ownerID, reminderID string, eventStart time.Time) (bool, error) {
+	ttl := int64(time.Until(eventStart.Add(reminderRetention)).Seconds())
+	if ttl < 1 {
+		ttl = 1
+	}
+
+	created, err := s.reminderKV.StoreWithOptions(ownerID+"_"+reminderID, []byte("1"), model.PluginKVSetOptions{
+		Atomic:          true,
+		OldValue:        nil,
+		ExpireInSeconds: ttl,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "error storing sent reminder")
+	}
+	return created, nil
+}
+
+// ReminderSnooze changes the reminders of an event, or of all the events of a
+// series, for a user: they are reminded of the event at RemindAt only, or not
+// at all when Dismissed. ExpiresAt is when the snooze can be forgotten, zero
+// for never.
+type ReminderSnooze struct {
+	RemindAt  time.Time `json:"remind_at,omitempty"`
+	Dismissed bool      `json:"dismissed,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// ReminderSnoozes are the snoozes of a user, by the key of their event or
+// series.
+type ReminderSnoozes map[string]*ReminderSnooze
+
+func (s *pluginStore) LoadReminderSnoozes(mattermostUserID string) (ReminderSnoozes, error) {
+	snoozes := ReminderSnoozes{}
+	err := kvstore.LoadJSON(s.reminderSnoozeKV, mattermostUserID, &snoozes)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return snoozes, nil
+}
+
+// StoreReminderSnooze atomically sets the snooze of the event or series of
+// the user, or removes it when snooze is nil, and forgets the expired ones.
+func (s *pluginStore) StoreReminderSnooze(mattermostUserID, key string, snooze *ReminderSnooze) error {
+	err := kvstore.AtomicModify(s.reminderSnoozeKV, mattermostUserID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil && storeErr != ErrNotFound {
+			return initial, storeErr
+		}
+
+		snoozes := ReminderSnoozes{}
+		if len(initial) > 0 {
+			err := json.Unmarshal(initial, &snoozes)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		now := time.Now()
+		for k, v := range snoozes {
+			if !v.ExpiresAt.IsZero() && v.ExpiresAt.Before(now) {
+				delete(snoozes, k)
+			}
+		}
+		if snooze == nil {
+			delete(snoozes, key)
+		} else {
+			snoozes[key] = snooze
+		}
+		return json.Marshal(snoozes)
+	})
+	if err != nil {
+		return errors.Wrap(err, "error storing reminder snooze")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package store
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/testutil"
+)
+
+func TestMarkReminderSent(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, bool, error)
+	}{
+		{
+			name: "Reminder is marked sent",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSetWithOptions", mock.Anything, []byte("1"), mock.MatchedBy(func(opts model.PluginKVSetOptions) bool {
+					return opts.Atomic && opts.OldValue == nil && opts.ExpireInSeconds > int64((time.Hour+time.Minute).Seconds())
+				})).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, created bool, err error) {
+				require.NoError(t, err)
+				require.True(t, created)
+			},
+		},
+		{
+			name: "Reminder already sent",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSetWithOptions", mock.Anything, []byte("1"), mock.Anything).Return(false, nil).Times(1)
+			},
+			assertions: func(t *testing.T, created bool, err error) {
+				require.NoError(t, err)
+				require.False(t, created)
+			},
+		},
+		{
+			name: "Error storing sent reminder",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVSetWithOptions", mock.Anything, []byte("1"), mock.Anything).Return(false, &model.AppError{Message: "KVSetWithOptions failed"}).Times(1)
+			},
+			assertions: func(t *testing.T, created bool, err error) {
+				require.ErrorContains(t, err, "error storing sent reminder")
+				require.False(t, created)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			created, err := store.MarkReminderSent(MockUserID, "mockReminderID", time.Now().Add(10*time.Minute))
+
+			tt.assertions(t, created, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoadReminderSnoozes(t *testing.T) {
+	t.Run("No snoozes stored", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mock.Anything).Return(nil, nil).Times(1)
+
+		snoozes, err := store.LoadReminderSnoozes(MockUserID)
+		require.NoError(t, err)
+		require.Empty(t, snoozes)
+	})
+
+	t.Run("Snoozes are loaded", func(t *testing.T) {
+		mockAPI, store, _, _, _ := GetMockSetup(t)
+		mockAPI.On("KVGet", mock.Anything).Return([]byte(`{"series_key":{"dismissed":true}}`), nil).Times(1)
+
+		snoozes, err := store.LoadReminderSnoozes(MockUserID)
+		require.NoError(t, err)
+		require.Equal(t, ReminderSnoozes{"series_key": {Dismissed: true}}, snoozes)
+	})
+}
+
+func TestStoreReminderSnooze(t *testing.T) {
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name       string
+		key        string
+		snooze     *ReminderSnooze
+		setup      func(*testutil.MockPluginAPI)
+		assertions func(*testing.T, error)
+	}{
+		{
+			name:   "Snooze is stored and expired snoozes are forgotten",
+			key:    "event_key",
+			snooze: &ReminderSnooze{Dismissed: true},
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mock.Anything).Return([]byte(`{"old_key":{"dismissed":true,"expires_at":"`+expired+`"}}`), nil).Times(1)
+				mockAPI.On("KVSetWithOptions", mock.Anything, mock.MatchedBy(func(value []byte) bool {
+					snoozes := ReminderSnoozes{}
+					return json.Unmarshal(value, &snoozes) == nil && len(snoozes) == 1 && snoozes["event_key"].Dismissed
+				}), mock.MatchedBy(func(opts model.PluginKVSetOptions) bool {
+					return opts.Atomic
+				})).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Snooze is removed",
+			key:  "series_key",
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mock.Anything).Return([]byte(`{"series_key":{"dismissed":true}}`), nil).Times(1)
+				mockAPI.On("KVSetWithOptions", mock.Anything, []byte(`{}`), mock.Anything).Return(true, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "Error storing snooze",
+			key:    "event_key",
+			snooze: &ReminderSnooze{Dismissed: true},
+			setup: func(mockAPI *testutil.MockPluginAPI) {
+				mockAPI.On("KVGet", mock.Anything).Return(nil, &model.AppError{Message: "KVGet failed"}).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "error storing reminder snooze")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, _, _, _ := GetMockSetup(t)
+			tt.setup(mockAPI)
+
+			err := store.StoreReminderSnooze(MockUserID, tt.key, tt.snooze)
+
+			tt.assertions(t, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
@@ -13,7 +13,11 @@ const (
 	GetConfirmationSettingID         = "get_confirmation"
 	SetCustomStatusSettingID         = "set_custom_status"
 	ReceiveRemindersSettingID        = "get_reminders"
+	ReminderLeadTimesSettingID       = "reminder_lead_times"
 	DailySummarySettingID            = "summary_setting"
+	CalendarsSettingID               = "calendars_setting"
+	OutOfOfficeSyncSettingID         = "out_of_office_sync"
+	WorkingHoursSettingID            = "working_hours"
 )
 
 func (s *pluginStore) SetSetting(userID, settingID string, value interface{}) error {
@@ -45,14 +49,38 @@ func (s *pluginStore) SetSetting(userID, settingID string, value interface{}) er
 			return fmt.Errorf("설정 %s에 대한 값 %v를 읽을 수 없습니다 (불린 필요)", settingID, value)
 		}
 		user.Settings.SetCustomStatus = storableValue
+	case OutOfOfficeSyncSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("설정 %s에 대한 값 %v를 읽을 수 없습니다 (문자열 필요)", settingID, value)
+		}
+		user.Settings.OutOfOfficeSyncOption = storableValue
+	case WorkingHoursSettingID:
+		storableValue, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("설정 %s에 대한 값 %v를 읽을 수 없습니다 (문자열 필요)", settingID, value)
+		}
+		user.Settings.WorkingHoursOption = storableValue
 	case ReceiveRemindersSettingID:
 		storableValue, ok := value.(bool)
 		if !ok {
 			return fmt.Errorf("설정 %s에 대한 값 %v를 읽을 수 없습니다 (불린 필요)", settingID, value)
 		}
 		user.Settings.ReceiveReminders = storableValue
+	case ReminderLeadTimesSettingID:
+		storableValue, ok := value.(ReminderSettings)
+		if !ok {
+			return fmt.Errorf("설정 %s에 대한 값 %v를 읽을 수 없습니다 (알림 설정 필요)", settingID, value)
+		}
+		user.Settings.Reminders = storableValue
 	case DailySummarySettingID:
 		s.updateDailySummarySettingForUser(user, value)
+	case CalendarsSettingID:
+		storableValue, ok := value.([]SelectedCalendar)
+		if !ok {
+			return fmt.Errorf("설정 %s에 대한 값 %v를 읽을 수 없습니다 (캘린더 목록 필요)", settingID, value)
+		}
+		user.Settings.Calendars = storableValue
 	default:
 		return fmt.Errorf("설정 %s를 찾을 수 없습니다", settingID)
 	}
@@ -78,11 +106,19 @@ func (s *pluginStore) GetSetting(userID, settingID string) (interface{}, error)
 		return user.Settings.GetConfirmation, nil
 	case SetCustomStatusSettingID:
 		return user.Settings.SetCustomStatus, nil
+	case OutOfOfficeSyncSettingID:
+		return user.Settings.OutOfOfficeSyncOption, nil
+	case WorkingHoursSettingID:
+		return user.Settings.WorkingHoursOption, nil
 	case ReceiveRemindersSettingID:
 		return user.Settings.ReceiveReminders, nil
+	case ReminderLeadTimesSettingID:
+		return user.Settings.Reminders, nil
 	case DailySummarySettingID:
 		dsum := user.Settings.DailySummary
 		return dsum, nil
+	case CalendarsSettingID:
+		return user.Settings.Calendars, nil
 	default:
 		return nil, fmt.Errorf("설정 %s를 찾을 수 없습니다", settingID)
 	}
@@ -152,6 +152,31 @@ func TestSetSetting(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name:      "error setting CalendarsSettingID",
+			settingID: CalendarsSettingID,
+			value:     "calendar-id",
+			setup: func(mockAPI *testutil.MockPluginAPI, _ *mock_tracker.MockTracker) {
+				mockAPI.On("KVGet", "user_ed8ba8dcdc37081824b09b84f8e061e6").Return(mockUserJSON, nil).Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.EqualError(t, err, "설정 calendars_setting에 대한 값 calendar-id를 읽을 수 없습니다 (캘린더 목록 필요)")
+			},
+		},
+		{
+			name:      "Set CalendarsSettingID",
+			settingID: CalendarsSettingID,
+			value:     []SelectedCalendar{{ID: "calendar-id", Name: "Team"}},
+			setup: func(mockAPI *testutil.MockPluginAPI, mockTracker *mock_tracker.MockTracker) {
+				mockAPI.On("KVGet", "user_ed8ba8dcdc37081824b09b84f8e061e6").Return(mockUserJSON, nil).Times(1)
+				mockAPI.On("KVSet", "user_c3b5020d58a049787bc969768465b890", mock.Anything).Return(nil).Times(1)
+				mockAPI.On("KVSet", "mmuid_e138a0f218087f9324d8c77f87d5f3a0", mock.Anything).Return(nil).Times(1)
+				mockTracker.EXPECT().TrackAutomaticStatusUpdate(MockUserID, "available", "settings").Times(1)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
 		{
 			name:      "invalid setting ID",
 			settingID: "invalidSettingID",
@@ -22,9 +22,15 @@ const (
 	OAuth2KeyPrefix           = "oauth2_"
 	SubscriptionKeyPrefix     = "sub_"
 	EventKeyPrefix            = "ev_"
+	ChannelCalendarKeyPrefix  = "chcal_"
 	WelcomeKeyPrefix          = "welcome_"
 	SettingsPanelPrefix       = "settings_panel_"
-	CacheKeyPrefix            = "cache_"
+	CalendarCacheKeyPrefix    = "calcache_"
+	MeetingPollKeyPrefix      = "poll_"
+	ReminderKeyPrefix         = "reminder_"
+	ReminderSnoozeKeyPrefix   = "snooze_"
+	QueuedDMKeyPrefix         = "dmqueue_"
+	MailboxSettingsKeyPrefix  = "mailbox_"
 )
 
 const OAuth2KeyExpiration = 15 * time.Minute
@@ -36,7 +42,13 @@ type Store interface {
 	OAuth2StateStore
 	SubscriptionStore
 	EventStore
+	ChannelCalendarStore
+	CalendarCacheStore
 	WelcomeStore
+	MeetingPollStore
+	ReminderStore
+	QueuedDMStore
+	MailboxSettingsStore
 	flow.Store
 	settingspanel.SettingStore
 	settingspanel.PanelStore
@@ -50,8 +62,15 @@ type pluginStore struct {
 	userIndexKV        kvstore.KVStore
 	subscriptionKV     kvstore.KVStore
 	eventKV            kvstore.KVStore
+	channelCalendarKV  kvstore.KVStore
+	calendarCacheKV    kvstore.KVStore
 	welcomeIndexKV     kvstore.KVStore
 	settingsPanelKV    kvstore.KVStore
+	meetingPollKV      kvstore.KVStore
+	reminderKV         kvstore.KVStore
+	reminderSnoozeKV   kvstore.KVStore
+	queuedDMKV         kvstore.KVStore
+	mailboxSettingsKV  kvstore.KVStore
 	Logger             bot.Logger
 	Poster             bot.Poster
 	Tracker            tracker.Tracker
@@ -74,9 +93,16 @@ func NewPluginStore(api plugin.API, logger bot.Logger, poster bot.Poster, tracke
 		mattermostUserIDKV: kvstore.NewHashedKeyStore(basicKV, MattermostUserIDKeyPrefix),
 		subscriptionKV:     kvstore.NewHashedKeyStore(basicKV, SubscriptionKeyPrefix),
 		eventKV:            kvstore.NewHashedKeyStore(basicKV, EventKeyPrefix),
+		channelCalendarKV:  kvstore.NewHashedKeyStore(basicKV, ChannelCalendarKeyPrefix),
+		calendarCacheKV:    kvstore.NewHashedKeyStore(basicKV, CalendarCacheKeyPrefix),
 		oauth2KV:           oauth2KV,
 		welcomeIndexKV:     kvstore.NewCacheStore(kvstore.NewHashedKeyStore(basicKV, WelcomeKeyPrefix)),
 		settingsPanelKV:    kvstore.NewCacheStore(kvstore.NewHashedKeyStore(basicKV, SettingsPanelPrefix)),
+		meetingPollKV:      kvstore.NewHashedKeyStore(basicKV, MeetingPollKeyPrefix),
+		reminderKV:         kvstore.NewHashedKeyStore(basicKV, ReminderKeyPrefix),
+		reminderSnoozeKV:   kvstore.NewHashedKeyStore(basicKV, ReminderSnoozeKeyPrefix),
+		queuedDMKV:         kvstore.NewHashedKeyStore(basicKV, QueuedDMKeyPrefix),
+		mailboxSettingsKV:  kvstore.NewHashedKeyStore(basicKV, MailboxSettingsKeyPrefix),
 		Logger:             logger,
 		Poster:             poster,
 		Tracker:            tracker,
@@ -4,6 +4,7 @@
 package store
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
@@ -14,14 +15,20 @@ import (
 type SubscriptionStore interface {
 	LoadSubscription(subscriptionID string) (*Subscription, error)
 	StoreUserSubscription(user *User, subscription *Subscription) error
+	StoreChannelSubscription(subscription *Subscription) error
+	StoreSubscriptionExpiration(subscriptionID, expirationDateTime string) (*Subscription, error)
 	DeleteUserSubscription(user *User, subscriptionID string) error
 	GetSubscriptionCount() (uint64, error)
 }
 
+// Subscription is a remote subscription owned by MattermostCreatorID. The
+// subscriptions of channel calendars have the ChannelID of their channel, and
+// are not referenced by the settings of their creator.
 type Subscription struct {
 	PluginVersion       string
 	Remote              *remote.Subscription
 	MattermostCreatorID string
+	ChannelID           string `json:",omitempty"`
 }
 
 func (s *pluginStore) LoadSubscription(subscriptionID string) (*Subscription, error) {
@@ -56,6 +63,49 @@ func (s *pluginStore) StoreUserSubscription(user *User, subscription *Subscripti
 	return nil
 }
 
+func (s *pluginStore) StoreChannelSubscription(subscription *Subscription) error {
+	err := kvstore.StoreJSON(s.subscriptionKV, subscription.Remote.ID, subscription)
+	if err != nil {
+		return err
+	}
+
+	s.Logger.With(bot.LogContext{
+		"mattermostUserID": subscription.MattermostCreatorID,
+		"channelID":        subscription.ChannelID,
+		"subscriptionID":   subscription.Remote.ID,
+	}).Debugf("store: stored channel calendar subscription.")
+	return nil
+}
+
+// StoreSubscriptionExpiration sets the expiry of a renewed subscription
+// atomically, so that the sync state stored by a concurrent poll is kept.
+func (s *pluginStore) StoreSubscriptionExpiration(subscriptionID, expirationDateTime string) (*Subscription, error) {
+	sub := Subscription{}
+	err := kvstore.AtomicModify(s.subscriptionKV, subscriptionID, func(initial []byte, storeErr error) ([]byte, error) {
+		if storeErr != nil {
+			return nil, storeErr
+		}
+		sub = Subscription{}
+		if err := json.Unmarshal(initial, &sub); err != nil {
+			return nil, err
+		}
+		if sub.Remote == nil {
+			return nil, ErrNotFound
+		}
+		sub.Remote.ExpirationDateTime = expirationDateTime
+		return json.Marshal(&sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.Logger.With(bot.LogContext{
+		"subscriptionID":     subscriptionID,
+		"expirationDateTime": expirationDateTime,
+	}).Debugf("store: stored subscription expiration.")
+	return &sub, nil
+}
+
 func (s *pluginStore) DeleteUserSubscription(user *User, subscriptionID string) error {
 	err := s.subscriptionKV.Delete(subscriptionID)
 	if err != nil {
@@ -254,3 +254,51 @@ func TestDeleteUserSubscription(t *testing.T) {
 		})
 	}
 }
+
+func TestStoreSubscriptionExpiration(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(*testutil.MockPluginAPI, *mock_bot.MockLogger, *mock_bot.MockLogger)
+		assertions func(*testing.T, *Subscription, error)
+	}{
+		{
+			name: "Subscription not found",
+			setup: func(mockAPI *testutil.MockPluginAPI, _ *mock_bot.MockLogger, _ *mock_bot.MockLogger) {
+				mockAPI.On("KVGet", "sub_0c47c5b7e2a88ec9256c8ac0e71b0f6e").Return(nil, nil).Times(1)
+			},
+			assertions: func(t *testing.T, sub *Subscription, err error) {
+				require.ErrorIs(t, err, ErrNotFound)
+				require.Nil(t, sub)
+			},
+		},
+		{
+			name: "Only the expiry of the stored subscription is changed",
+			setup: func(mockAPI *testutil.MockPluginAPI, mockLogger *mock_bot.MockLogger, mockLoggerWith *mock_bot.MockLogger) {
+				mockAPI.On("KVGet", "sub_0c47c5b7e2a88ec9256c8ac0e71b0f6e").Return([]byte(`{"PluginVersion":"1.0","Remote":{"id":"mockSubscriptionID","expirationDateTime":"2030-01-01T00:00:00Z","syncToken":"token2"}}`), nil).Times(1)
+				mockAPI.On("KVSetWithOptions", "sub_0c47c5b7e2a88ec9256c8ac0e71b0f6e", mock.MatchedBy(func(data []byte) bool {
+					return string(data) == `{"PluginVersion":"1.0","Remote":{"id":"mockSubscriptionID","expirationDateTime":"2030-01-02T00:00:00Z","syncToken":"token2"},"MattermostCreatorID":""}`
+				}), mock.MatchedBy(func(opts model.PluginKVSetOptions) bool {
+					return opts.Atomic
+				})).Return(true, nil).Times(1)
+				mockLogger.EXPECT().With(gomock.Any()).Return(mockLoggerWith).Times(1)
+				mockLoggerWith.EXPECT().Debugf("store: stored subscription expiration.").Times(1)
+			},
+			assertions: func(t *testing.T, sub *Subscription, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "2030-01-02T00:00:00Z", sub.Remote.ExpirationDateTime)
+				require.Equal(t, "token2", sub.Remote.SyncToken)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAPI, store, mockLogger, mockLoggerWith, _ := GetMockSetup(t)
+			tt.setup(mockAPI, mockLogger, mockLoggerWith)
+
+			sub, err := store.StoreSubscriptionExpiration(MockSubscriptionID, "2030-01-02T00:00:00Z")
+
+			tt.assertions(t, sub, err)
+			mockAPI.AssertExpectations(t)
+		})
+	}
+}
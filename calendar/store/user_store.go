@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
@@ -39,10 +40,13 @@ type UserStore interface {
 	DeleteUserFromIndex(mattermostUserID string) error
 	StoreUserActiveEvents(mattermostUserID string, events []string) error
 	StoreUserLinkedEvent(mattermostUserID, eventID, channelID string) error
+	DeleteUserLinkedEvent(mattermostUserID, eventID string) error
 	RefreshAndStoreToken(token *oauth2.Token, oconf *oauth2.Config, mattermostUserID string) (*oauth2.Token, error)
 	CheckUserConnected(mattermostUserID string) bool
 	DisconnectUserFromStoreIfNecessary(err error, mattermostUserID string)
 	StoreUserCustomStatusUpdates(mattermostUserID string, values bool) error
+	StoreUserOutOfOffice(mattermostUserID string, outOfOffice *OutOfOffice) error
+	StoreUserAfterHours(mattermostUserID string, afterHours *AfterHours) error
 }
 
 type UserIndex []*UserShort
@@ -86,6 +90,24 @@ type User struct {
 	ActiveEvents          []string          `json:"events"`
 	ChannelEvents         ChannelEventLink  `json:"linkedEvents,omitempty"`
 	IsCustomStatusSet     bool
+	OutOfOffice           *OutOfOffice `json:"outOfOffice,omitempty"`
+	AfterHours            *AfterHours  `json:"afterHours,omitempty"`
+}
+
+// OutOfOffice records the statuses the user had before their out of office
+// was mirrored into Mattermost, to restore them once they are back. Until is
+// zero when the out of office has no scheduled end.
+type OutOfOffice struct {
+	Until            time.Time
+	SetAway          bool
+	LastStatus       string
+	LastCustomStatus *model.CustomStatus
+}
+
+// AfterHours is set while the user is outside of their working hours.
+// SetAway records whether they were set Away when their working hours ended.
+type AfterHours struct {
+	SetAway bool
 }
 
 var DefaultSettings = Settings{
@@ -98,13 +120,34 @@ type Settings struct {
 	UpdateStatusFromOptions string
 	GetConfirmation         bool
 	ReceiveReminders        bool
+	Reminders               ReminderSettings
 	SetCustomStatus         bool
+	OutOfOfficeSyncOption   string
+	WorkingHoursOption      string
+	Calendars               []SelectedCalendar // Empty for the default calendar only
 
 	// Legacy settings
 	UpdateStatus                      bool
 	ReceiveNotificationsDuringMeeting bool
 }
 
+// ReminderSettings are when the user is reminded of their upcoming events:
+// the lead times, in minutes, before the start of each event, and the
+// reminder time of the event itself when UseEventReminder is set. No lead
+// times stand for the default one, unless the reminder times of the events
+// are used.
+type ReminderSettings struct {
+	LeadTimes        []int `json:"lead_times,omitempty"`
+	UseEventReminder bool  `json:"use_event_reminder,omitempty"`
+}
+
+// SelectedCalendar is a calendar the user chose to follow, with the name its
+// events are labelled with.
+type SelectedCalendar struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 type DailySummaryUserSettings struct {
 	PostTime     string `json:"post_time"` // Kitchen format, i.e. 8:30AM
 	Timezone     string `json:"tz"`        // Timezone in MSCal when PostTime is set/updated
@@ -123,6 +166,18 @@ const (
 	NotSetStatusOption = "Don't set status for me"
 )
 
+const (
+	OutOfOfficeCustomStatusOption = "Set custom status"
+	OutOfOfficeAwayOption         = "Set custom status and Away"
+	OutOfOfficeNotSetOption       = "Don't mirror my out of office"
+)
+
+const (
+	WorkingHoursRespectOption = "Only during working hours"
+	WorkingHoursAwayOption    = "Only during working hours, Away after"
+	WorkingHoursNotSetOption  = "Anytime"
+)
+
 func (settings Settings) String() string {
 	sub := "no subscription"
 	if settings.EventSubscriptionID != "" {
@@ -223,7 +278,12 @@ func (s *pluginStore) DeleteUser(mattermostUserID string) error {
 		return err
 	}
 
-	return nil
+	err = s.queuedDMKV.Delete(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	return s.mailboxSettingsKV.Delete(mattermostUserID)
 }
 
 func (s *pluginStore) GetConnectedUserCount() (uint64, error) {
@@ -429,6 +489,20 @@ func (s *pluginStore) StoreUserLinkedEvent(mattermostUserID, eventID, channelID
 	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
 }
 
+func (s *pluginStore) DeleteUserLinkedEvent(mattermostUserID, eventID string) error {
+	u, err := s.LoadUser(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := u.ChannelEvents[eventID]; !ok {
+		return nil
+	}
+	delete(u.ChannelEvents, eventID)
+
+	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
+}
+
 func (index UserIndex) ToDTO() (result []UserShortDTO) {
 	for _, u := range index {
 		result = append(result, u.ToDTO())
@@ -446,6 +520,26 @@ func (s *pluginStore) StoreUserCustomStatusUpdates(mattermostUserID string, valu
 	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
 }
 
+func (s *pluginStore) StoreUserOutOfOffice(mattermostUserID string, outOfOffice *OutOfOffice) error {
+	u, err := s.LoadUser(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	u.OutOfOffice = outOfOffice
+	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
+}
+
+func (s *pluginStore) StoreUserAfterHours(mattermostUserID string, afterHours *AfterHours) error {
+	u, err := s.LoadUser(mattermostUserID)
+	if err != nil {
+		return err
+	}
+
+	u.AfterHours = afterHours
+	return kvstore.StoreJSON(s.userKV, mattermostUserID, u)
+}
+
 func (index UserIndex) ByMattermostID() map[string]*UserShort {
 	result := map[string]*UserShort{}
 
@@ -508,3 +602,11 @@ func (user *User) IsConfiguredForStatusUpdates() bool {
 func (user *User) IsConfiguredForCustomStatusUpdates() bool {
 	return user.Settings.SetCustomStatus
 }
+
+func (user *User) IsConfiguredForOutOfOfficeSync() bool {
+	return user.Settings.OutOfOfficeSyncOption == OutOfOfficeCustomStatusOption || user.Settings.OutOfOfficeSyncOption == OutOfOfficeAwayOption
+}
+
+func (user *User) IsConfiguredForWorkingHours() bool {
+	return user.Settings.WorkingHoursOption == WorkingHoursRespectOption || user.Settings.WorkingHoursOption == WorkingHoursAwayOption
+}
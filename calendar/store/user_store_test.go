@@ -308,6 +308,8 @@ func TestDeleteUser(t *testing.T) {
 				mockAPI.On("KVDelete", "mmuid_e138a0f218087f9324d8c77f87d5f3a0").Return(nil).Times(1)
 				mockAPI.On("KVGet", "userindex_").Return([]byte(`[]`), nil).Times(1)
 				mockAPI.On("KVSet", "userindex_", mock.Anything).Return(nil)
+				mockAPI.On("KVDelete", "dmqueue_c3b5020d58a049787bc969768465b890").Return(nil).Times(1)
+				mockAPI.On("KVDelete", "mailbox_c3b5020d58a049787bc969768465b890").Return(nil).Times(1)
 			},
 			assertions: func(t *testing.T, err error) {
 				require.NoError(t, err)
@@ -115,10 +115,52 @@ func (a *API) GetMattermostUserTeams(mattermostUserID string) ([]*model.Team, er
 	return teams, nil
 }
 
+func (a *API) GetMattermostChannelByName(teamID, channelName string) (*model.Channel, error) {
+	ch, appErr := a.api.GetChannelByName(teamID, channelName, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return ch, nil
+}
+
+// GetMattermostChannelUsers returns the active users of the channel, bots
+// excluded.
+func (a *API) GetMattermostChannelUsers(channelID string) ([]*model.User, error) {
+	const perPage = 100
+
+	users := []*model.User{}
+	for page := 0; ; page++ {
+		pageUsers, appErr := a.api.GetUsersInChannel(channelID, "username", page, perPage)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, u := range pageUsers {
+			if !u.IsBot && u.DeleteAt == 0 {
+				users = append(users, u)
+			}
+		}
+		if len(pageUsers) < perPage {
+			return users, nil
+		}
+	}
+}
+
+func (a *API) OpenInteractiveDialog(request model.OpenDialogRequest) error {
+	appErr := a.api.OpenInteractiveDialog(request)
+	if appErr != nil {
+		return appErr
+	}
+	return nil
+}
+
 func (a *API) CanLinkEventToChannel(channelID, userID string) bool {
 	return a.api.HasPermissionToChannel(userID, channelID, model.PermissionCreatePost)
 }
 
+func (a *API) CanReadChannel(channelID, userID string) bool {
+	return a.api.HasPermissionToChannel(userID, channelID, model.PermissionReadChannel)
+}
+
 func (a *API) CleanKVStore() error {
 	appErr := a.api.KVDeleteAll()
 	if appErr != nil {
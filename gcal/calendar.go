@@ -0,0 +1,146 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type calendarListEntry struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+func (c *client) GetCalendars(remoteUserID string) ([]*remote.Calendar, error) {
+	var v struct {
+		Items []*calendarListEntry `json:"items"`
+	}
+
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	_, err := c.CallJSON(http.MethodGet, "/users/me/calendarList", nil, &v)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal GetCalendars")
+	}
+
+	calendars := []*remote.Calendar{}
+	for _, entry := range v.Items {
+		calendars = append(calendars, &remote.Calendar{
+			ID:   entry.ID,
+			Name: entry.Summary,
+		})
+	}
+
+	c.Logger.With(bot.LogContext{
+		"UserID": remoteUserID,
+	}).Infof("gcal: GetUserCalendars returned `%d` calendars.", len(calendars))
+	return calendars, nil
+}
+
+func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	return c.GetEventsBetweenDates(remoteUserID, start, end)
+}
+
+// GetCalendarView lists the events of one of the calendars of the user. The
+// primary calendar is named after the user.
+func (c *client) GetCalendarView(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	if calendarID == "" {
+		calendarID = remoteUserID
+	}
+	return c.GetEventsBetweenDates(calendarID, start, end)
+}
+
+// DoBatchViewCalendarRequests fetches the calendar views one user at a time,
+// since Google Calendar batching uses a multipart format. Per-user failures
+// are reported in the response instead of failing the whole batch.
+func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+	result := []*remote.ViewCalendarResponse{}
+	for _, params := range allParams {
+		res := &remote.ViewCalendarResponse{
+			RemoteUserID: params.RemoteUserID,
+			CalendarID:   params.CalendarID,
+		}
+
+		calendarID := params.CalendarID
+		if calendarID == "" {
+			calendarID = params.RemoteUserID
+		}
+
+		e := &eventList{}
+		_, err := c.CallJSON(http.MethodGet, eventsPath(calendarID)+getQueryParamStringForCalendarView(params.StartTime, params.EndTime), nil, e)
+		if err != nil {
+			apiErr := &remote.APIError{Message: err.Error()}
+			var errResp *errorResponse
+			if errors.As(err, &errResp) {
+				apiErr.Code = errResp.Err.Status
+				apiErr.Message = errResp.Err.Message
+			}
+			res.Error = apiErr
+		} else {
+			res.Events = toRemoteEvents(e.Items)
+		}
+
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func (c *client) CreateCalendar(_ string, in *remote.Calendar) (*remote.Calendar, error) {
+	out := &calendarListEntry{}
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	_, err := c.CallJSON(http.MethodPost, "/calendars", &calendarListEntry{Summary: in.Name}, out)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal CreateCalendar")
+	}
+
+	c.Logger.With(bot.LogContext{
+		"v": out,
+	}).Infof("gcal: CreateCalendar created the following calendar.")
+	return &remote.Calendar{
+		ID:   out.ID,
+		Name: out.Summary,
+	}, nil
+}
+
+func (c *client) DeleteCalendar(_, calendarID string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	_, err := c.CallJSON(http.MethodDelete, "/calendars/"+url.PathEscape(calendarID), nil, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "gcal DeleteCalendar")
+	}
+
+	c.Logger.With(bot.LogContext{}).Infof("gcal: DeleteCalendar deleted calendar `%v`.", calendarID)
+	return nil
+}
+
+func (c *client) FindMeetingTimes(_ string, _ *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
+	return nil, remote.ErrNotImplemented
+}
+
+func (c *client) GetSchedule(_ []*remote.ScheduleUserInfo, _, _ *remote.DateTime, _ int) ([]*remote.ScheduleInformation, error) {
+	return nil, remote.ErrNotImplemented
+}
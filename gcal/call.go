@@ -0,0 +1,118 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// errorResponse is the error envelope returned by Google APIs.
+type errorResponse struct {
+	Response *http.Response `json:"-"`
+	Err      struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status,omitempty"`
+	} `json:"error"`
+}
+
+func (e *errorResponse) Error() string {
+	status := ""
+	if e.Response != nil {
+		status = e.Response.Status
+	}
+	return fmt.Sprintf("%s: %s", status, e.Err.Message)
+}
+
+func (c *client) CallJSON(method, path string, in, out interface{}) (responseData []byte, err error) {
+	contentType := "application/json"
+	var body io.Reader
+	if in != nil {
+		buf := &bytes.Buffer{}
+		err = json.NewEncoder(buf).Encode(in)
+		if err != nil {
+			return nil, err
+		}
+		body = buf
+	}
+	return c.call(method, path, contentType, body, out)
+}
+
+func (c *client) CallFormPost(method, path string, in url.Values, out interface{}) (responseData []byte, err error) {
+	contentType := "application/x-www-form-urlencoded"
+	buf := strings.NewReader(in.Encode())
+	return c.call(method, path, contentType, buf, out)
+}
+
+func (c *client) call(method, path, contentType string, inBody io.Reader, out interface{}) (responseData []byte, err error) {
+	errContext := fmt.Sprintf("gcal: Call failed: method:%s, path:%s", method, path)
+	pathURL, err := url.Parse(path)
+	if err != nil {
+		return nil, errors.WithMessage(err, errContext)
+	}
+
+	if pathURL.Scheme == "" || pathURL.Host == "" {
+		if path[0] != '/' {
+			path = "/" + path
+		}
+		path = strings.TrimSuffix(c.baseURL, "/") + path
+	}
+
+	req, err := http.NewRequest(method, path, inBody)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" && inBody != nil {
+		req.Header.Add("Content-Type", contentType)
+	}
+
+	if c.ctx != nil {
+		req = req.WithContext(c.ctx)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Body == nil {
+		return nil, nil
+	}
+	defer resp.Body.Close()
+
+	responseData, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		if out != nil && len(responseData) > 0 {
+			err = json.Unmarshal(responseData, out)
+			if err != nil {
+				return responseData, err
+			}
+		}
+		return responseData, nil
+
+	case http.StatusNoContent:
+		return nil, nil
+	}
+
+	errResp := errorResponse{Response: resp}
+	err = json.Unmarshal(responseData, &errResp)
+	if err != nil {
+		return responseData, errors.WithMessagef(err, "status: %s. response: %s", resp.Status, string(responseData))
+	}
+
+	return responseData, &errResp
+}
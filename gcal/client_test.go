@@ -0,0 +1,343 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type testTokenHelpers struct{}
+
+func (testTokenHelpers) CheckUserConnected(string) bool                   { return true }
+func (testTokenHelpers) DisconnectUserFromStoreIfNecessary(error, string) {}
+func (testTokenHelpers) RefreshAndStoreToken(token *oauth2.Token, _ *oauth2.Config, _ string) (*oauth2.Token, error) {
+	return token, nil
+}
+
+// newTestClient returns a client talking to a local stand-in for the Google
+// Calendar API.
+func newTestClient(t *testing.T, handler http.Handler) remote.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	r := NewRemote(&config.Config{}, &bot.NilLogger{}).(*impl)
+	r.baseURL = server.URL
+	token := &oauth2.Token{AccessToken: "token", Expiry: time.Now().Add(time.Hour)}
+	return r.MakeUserClient(context.Background(), token, "mm_user_id", nil, testTokenHelpers{})
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func TestGetMe(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/me/calendarList/primary", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		writeJSON(t, w, calendarListEntry{ID: "user@example.com", Summary: "Some User", Primary: true})
+	})
+	c := newTestClient(t, mux)
+
+	user, err := c.GetMe()
+	require.NoError(t, err)
+	require.Equal(t, "user@example.com", user.ID)
+	require.Equal(t, "Some User", user.DisplayName)
+	require.Equal(t, "user@example.com", user.Mail)
+}
+
+func TestGetMailboxSettings(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/me/settings/timezone", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]string{"id": "timezone", "value": "Europe/Berlin"})
+	})
+	c := newTestClient(t, mux)
+
+	settings, err := c.GetMailboxSettings("user@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Berlin", settings.TimeZone)
+}
+
+func TestGetEventsBetweenDates(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendars/user@example.com/events", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, start.Format(time.RFC3339), r.URL.Query().Get("timeMin"))
+		require.Equal(t, end.Format(time.RFC3339), r.URL.Query().Get("timeMax"))
+		require.Equal(t, "true", r.URL.Query().Get("singleEvents"))
+		writeJSON(t, w, eventList{Items: []*event{{
+			ID:      "event_id",
+			ICalUID: "ical_uid",
+			Summary: "Standup",
+			Start:   &eventDateTime{DateTime: "2024-03-04T10:00:00+01:00", TimeZone: "Europe/Berlin"},
+			End:     &eventDateTime{DateTime: "2024-03-04T10:15:00+01:00", TimeZone: "Europe/Berlin"},
+			Attendees: []*eventAttendee{
+				{Email: "user@example.com", Self: true, ResponseStatus: GoogleResponseStatusYes},
+			},
+		}}})
+	})
+	c := newTestClient(t, mux)
+
+	events, err := c.GetEventsBetweenDates("user@example.com", start, end)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "event_id", events[0].ID)
+	require.Equal(t, remote.EventResponseStatusAccepted, events[0].ResponseStatus.Response)
+	require.True(t, start.Equal(events[0].Start.Time()))
+}
+
+func TestDoBatchViewCalendarRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendars/ok@example.com/events", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, eventList{Items: []*event{{ID: "event_id"}}})
+	})
+	mux.HandleFunc("/calendars/forbidden@example.com/events", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(t, w, map[string]interface{}{"error": map[string]interface{}{"code": 403, "message": "Forbidden", "status": "PERMISSION_DENIED"}})
+	})
+	mux.HandleFunc("/calendars/team@group.calendar.google.com/events", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, eventList{Items: []*event{{ID: "team_event_id"}, {ID: "other_team_event_id"}}})
+	})
+	c := newTestClient(t, mux)
+
+	now := time.Now()
+	res, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "ok@example.com", StartTime: now, EndTime: now.Add(time.Hour)},
+		{RemoteUserID: "forbidden@example.com", StartTime: now, EndTime: now.Add(time.Hour)},
+		{RemoteUserID: "ok@example.com", CalendarID: "team@group.calendar.google.com", StartTime: now, EndTime: now.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	require.Nil(t, res[0].Error)
+	require.Len(t, res[0].Events, 1)
+	require.NotNil(t, res[1].Error)
+	require.Equal(t, "PERMISSION_DENIED", res[1].Error.Code)
+	require.Equal(t, "Forbidden", res[1].Error.Message)
+	require.Nil(t, res[2].Error)
+	require.Equal(t, "ok@example.com", res[2].RemoteUserID)
+	require.Equal(t, "team@group.calendar.google.com", res[2].CalendarID)
+	require.Len(t, res[2].Events, 2)
+}
+
+func TestRespondToEvent(t *testing.T) {
+	for name, tc := range map[string]struct {
+		respond  func(c remote.Client) error
+		expected string
+	}{
+		"accept": {
+			respond:  func(c remote.Client) error { return c.AcceptEvent("user@example.com", "event_id") },
+			expected: GoogleResponseStatusYes,
+		},
+		"decline": {
+			respond:  func(c remote.Client) error { return c.DeclineEvent("user@example.com", "event_id") },
+			expected: GoogleResponseStatusNo,
+		},
+		"tentative": {
+			respond:  func(c remote.Client) error { return c.TentativelyAcceptEvent("user@example.com", "event_id") },
+			expected: GoogleResponseStatusMaybe,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			patched := false
+			mux := http.NewServeMux()
+			mux.HandleFunc("/calendars/user@example.com/events/event_id", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					writeJSON(t, w, event{ID: "event_id", Attendees: []*eventAttendee{
+						{Email: "organizer@example.com", Organizer: true, ResponseStatus: GoogleResponseStatusYes},
+						{Email: "user@example.com", Self: true, ResponseStatus: GoogleResponseStatusNone},
+					}})
+				case http.MethodPatch:
+					in := event{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+					require.Len(t, in.Attendees, 2)
+					require.Equal(t, GoogleResponseStatusYes, in.Attendees[0].ResponseStatus)
+					require.Equal(t, tc.expected, in.Attendees[1].ResponseStatus)
+					patched = true
+					writeJSON(t, w, in)
+				}
+			})
+			c := newTestClient(t, mux)
+
+			require.NoError(t, tc.respond(c))
+			require.True(t, patched)
+		})
+	}
+}
+
+func TestUpdateEvent(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 15, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendars/user@example.com/events/event_id", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPatch, r.Method)
+		require.Equal(t, "all", r.URL.Query().Get("sendUpdates"))
+		in := event{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		require.Empty(t, in.Summary)
+		require.Nil(t, in.Attendees)
+		require.Equal(t, "2024-03-04T09:15:00Z", in.Start.DateTime)
+		writeJSON(t, w, event{ID: "event_id", Summary: "Planning", Start: in.Start, End: in.End})
+	})
+	c := newTestClient(t, mux)
+
+	e, err := c.UpdateEvent("user@example.com", "event_id", &remote.Event{
+		Start: remote.NewDateTime(start, "UTC"),
+		End:   remote.NewDateTime(start.Add(time.Hour), "UTC"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Planning", e.Subject)
+	require.True(t, e.Start.Time().Equal(start))
+}
+
+func TestCancelEvent(t *testing.T) {
+	requests := []string{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendars/user@example.com/events/event_id", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Query().Get("sendUpdates"))
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(t, w, event{ID: "event_id", Description: "Agenda"})
+		case http.MethodPatch:
+			in := event{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+			require.Equal(t, "Moved to next week\n\nAgenda", in.Description)
+			writeJSON(t, w, in)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	c := newTestClient(t, mux)
+
+	require.NoError(t, c.CancelEvent("user@example.com", "event_id", "Moved to next week"))
+	require.Equal(t, []string{"GET ", "PATCH none", "DELETE all"}, requests)
+}
+
+func TestCreateMySubscription(t *testing.T) {
+	expiration := time.Now().Add(subscribeTTL).Truncate(time.Second)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendars/user@example.com/events", func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.URL.Query().Get("syncToken"))
+		if r.URL.Query().Get("pageToken") == "" {
+			writeJSON(t, w, eventList{NextPageToken: "page_2"})
+			return
+		}
+		writeJSON(t, w, eventList{NextSyncToken: "sync_token"})
+	})
+	mux.HandleFunc("/calendars/user@example.com/events/watch", func(w http.ResponseWriter, r *http.Request) {
+		in := channel{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		require.Equal(t, "web_hook", in.Type)
+		require.Equal(t, "https://mattermost.example.com/notification", in.Address)
+		require.NotEmpty(t, in.ID)
+		require.NotEmpty(t, in.Token)
+
+		in.ResourceID = "resource_id"
+		in.Expiration = strconv.FormatInt(expiration.UnixMilli(), 10)
+		writeJSON(t, w, in)
+	})
+	c := newTestClient(t, mux)
+
+	sub, err := c.CreateMySubscription("https://mattermost.example.com/notification", "user@example.com")
+	require.NoError(t, err)
+	require.NotEmpty(t, sub.ID)
+	require.NotEmpty(t, sub.ClientState)
+	require.Equal(t, "resource_id", sub.ResourceID)
+	require.Equal(t, "user@example.com", sub.CreatorID)
+	require.Equal(t, expiration.Format(time.RFC3339), sub.ExpirationDateTime)
+	require.Equal(t, "sync_token", sub.SyncToken)
+}
+
+func TestPollSubscription(t *testing.T) {
+	sub := &remote.Subscription{
+		ID:          "channel_id",
+		Resource:    eventsPath("user@example.com"),
+		ClientState: "token",
+		SyncToken:   "sync_token",
+	}
+
+	t.Run("a notification per changed event", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/calendars/user@example.com/events", func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "sync_token", r.URL.Query().Get("syncToken"))
+			require.Equal(t, "true", r.URL.Query().Get("showDeleted"))
+			if r.URL.Query().Get("pageToken") == "" {
+				writeJSON(t, w, eventList{
+					Items: []*event{
+						{ID: "created_event_id", Created: "2024-03-04T10:00:00.000Z", Updated: "2024-03-04T10:00:00.000Z"},
+						{ID: "updated_event_id", Created: "2024-03-01T10:00:00.000Z", Updated: "2024-03-04T10:00:00.000Z"},
+					},
+					NextPageToken: "page_2",
+				})
+				return
+			}
+			writeJSON(t, w, eventList{
+				Items:         []*event{{ID: "cancelled_event_id", Status: googleEventStatusCancelled}},
+				NextSyncToken: "next_sync_token",
+			})
+		})
+		c := newTestClient(t, mux)
+
+		notifications, polled, err := c.(remote.Poller).PollSubscription(sub)
+		require.NoError(t, err)
+		require.Equal(t, "next_sync_token", polled.SyncToken)
+		require.Equal(t, "sync_token", sub.SyncToken)
+		require.Len(t, notifications, 3)
+		require.Equal(t, "created_event_id", notifications[0].Event.ID)
+		require.Equal(t, remote.ChangeTypeCreated, notifications[0].ChangeType)
+		require.Equal(t, remote.ChangeTypeUpdated, notifications[1].ChangeType)
+		require.Equal(t, remote.ChangeTypeDeleted, notifications[2].ChangeType)
+		for _, n := range notifications {
+			require.False(t, n.IsBare)
+			require.Equal(t, "channel_id", n.SubscriptionID)
+			require.Equal(t, "token", n.ClientState)
+		}
+	})
+
+	t.Run("expired sync token", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/calendars/user@example.com/events", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("syncToken") != "" {
+				w.WriteHeader(http.StatusGone)
+				writeJSON(t, w, map[string]interface{}{"error": map[string]interface{}{"code": 410, "message": "Sync token is no longer valid"}})
+				return
+			}
+			writeJSON(t, w, eventList{NextSyncToken: "new_sync_token"})
+		})
+		c := newTestClient(t, mux)
+
+		notifications, polled, err := c.(remote.Poller).PollSubscription(sub)
+		require.NoError(t, err)
+		require.Empty(t, notifications)
+		require.Equal(t, "new_sync_token", polled.SyncToken)
+	})
+}
+
+func TestGetNotificationData(t *testing.T) {
+	c := newTestClient(t, http.NewServeMux())
+
+	_, err := c.GetNotificationData(&remote.Notification{SubscriptionID: "channel_id", IsBare: true})
+	require.Error(t, err)
+
+	n, err := c.GetNotificationData(&remote.Notification{SubscriptionID: "channel_id", Event: &remote.Event{ID: "event_id"}, IsBare: true})
+	require.NoError(t, err)
+	require.False(t, n.IsBare)
+	require.Equal(t, "event_id", n.Event.ID)
+}
@@ -0,0 +1,522 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/tz"
+)
+
+const (
+	GoogleResponseStatusYes   = "accepted"
+	GoogleResponseStatusMaybe = "tentative"
+	GoogleResponseStatusNo    = "declined"
+	GoogleResponseStatusNone  = "needsAction"
+
+	googleEventStatusCancelled    = "cancelled"
+	googleTransparencyTransparent = "transparent"
+
+	// defaultReminderMinutes is used when an event relies on the calendar's
+	// default reminders, which are not part of the event resource.
+	defaultReminderMinutes = 10
+
+	dateFormat = "2006-01-02"
+)
+
+var responseStatusConversion = map[string]string{
+	GoogleResponseStatusYes:   remote.EventResponseStatusAccepted,
+	GoogleResponseStatusMaybe: remote.EventResponseStatusTentative,
+	GoogleResponseStatusNo:    remote.EventResponseStatusDeclined,
+	GoogleResponseStatusNone:  remote.EventResponseStatusNotAnswered,
+}
+
+type eventDateTime struct {
+	Date     string `json:"date,omitempty"`
+	DateTime string `json:"dateTime,omitempty"`
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+type eventAttendee struct {
+	Email          string `json:"email,omitempty"`
+	DisplayName    string `json:"displayName,omitempty"`
+	ResponseStatus string `json:"responseStatus,omitempty"`
+	Optional       bool   `json:"optional,omitempty"`
+	Organizer      bool   `json:"organizer,omitempty"`
+	Resource       bool   `json:"resource,omitempty"`
+	Self           bool   `json:"self,omitempty"`
+}
+
+type eventPerson struct {
+	Email       string `json:"email,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Self        bool   `json:"self,omitempty"`
+}
+
+type eventReminder struct {
+	Method  string `json:"method"`
+	Minutes int    `json:"minutes"`
+}
+
+type conferenceData struct {
+	EntryPoints []struct {
+		EntryPointType string `json:"entryPointType"`
+		URI            string `json:"uri"`
+	} `json:"entryPoints,omitempty"`
+	ConferenceSolution *struct {
+		Name string `json:"name"`
+	} `json:"conferenceSolution,omitempty"`
+}
+
+// event is the subset of the Google Calendar event resource used by the plugin.
+type event struct {
+	ID             string           `json:"id,omitempty"`
+	ICalUID        string           `json:"iCalUID,omitempty"`
+	Status         string           `json:"status,omitempty"`
+	HTMLLink       string           `json:"htmlLink,omitempty"`
+	Summary        string           `json:"summary,omitempty"`
+	Description    string           `json:"description,omitempty"`
+	Location       string           `json:"location,omitempty"`
+	Transparency   string           `json:"transparency,omitempty"`
+	HangoutLink    string           `json:"hangoutLink,omitempty"`
+	Created        string           `json:"created,omitempty"`
+	Updated        string           `json:"updated,omitempty"`
+	Start          *eventDateTime   `json:"start,omitempty"`
+	End            *eventDateTime   `json:"end,omitempty"`
+	Organizer      *eventPerson     `json:"organizer,omitempty"`
+	Attendees      []*eventAttendee `json:"attendees,omitempty"`
+	ConferenceData *conferenceData  `json:"conferenceData,omitempty"`
+	// Recurrence holds the RRULE, EXRULE, RDATE and EXDATE lines of a
+	// recurring event. Instances of the event refer to it instead.
+	Recurrence        []string       `json:"recurrence,omitempty"`
+	RecurringEventID  string         `json:"recurringEventId,omitempty"`
+	OriginalStartTime *eventDateTime `json:"originalStartTime,omitempty"`
+	Reminders         *struct {
+		UseDefault bool             `json:"useDefault"`
+		Overrides  []*eventReminder `json:"overrides,omitempty"`
+	} `json:"reminders,omitempty"`
+}
+
+type eventList struct {
+	Items         []*event `json:"items"`
+	NextPageToken string   `json:"nextPageToken,omitempty"`
+	NextSyncToken string   `json:"nextSyncToken,omitempty"`
+}
+
+// toRemoteDateTime converts a Google date or date-time into the remote
+// representation, expressed in the event's time zone (UTC if none is given).
+func toRemoteDateTime(in *eventDateTime) *remote.DateTime {
+	if in == nil {
+		return nil
+	}
+
+	timeZone := in.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	loc, err := time.LoadLocation(tz.Go(timeZone))
+	if err != nil {
+		timeZone = "UTC"
+		loc = time.UTC
+	}
+
+	var t time.Time
+	if in.DateTime != "" {
+		t, err = time.Parse(time.RFC3339, in.DateTime)
+	} else {
+		t, err = time.ParseInLocation(dateFormat, in.Date, loc)
+	}
+	if err != nil {
+		return &remote.DateTime{}
+	}
+
+	return &remote.DateTime{
+		DateTime: t.In(loc).Format(remote.RFC3339NanoNoTimezone),
+		TimeZone: timeZone,
+	}
+}
+
+// fromRemoteDateTime converts a remote date-time into a Google date-time.
+// All-day events use plain dates.
+func fromRemoteDateTime(in *remote.DateTime, allDay bool) *eventDateTime {
+	if in == nil {
+		return nil
+	}
+
+	if allDay {
+		return &eventDateTime{
+			Date: in.Time().Format(dateFormat),
+		}
+	}
+
+	return &eventDateTime{
+		DateTime: in.Time().Format(time.RFC3339),
+		TimeZone: tz.Go(in.TimeZone),
+	}
+}
+
+func toRemoteResponseStatus(status string) *remote.EventResponseStatus {
+	response, ok := responseStatusConversion[status]
+	if !ok {
+		response = remote.EventResponseStatusNotAnswered
+	}
+	return &remote.EventResponseStatus{Response: response}
+}
+
+// toRemoteEvent converts a Google event into our representation of fields.
+func toRemoteEvent(in *event) *remote.Event {
+	out := &remote.Event{
+		ID:                in.ID,
+		ICalUID:           in.ICalUID,
+		Subject:           in.Summary,
+		BodyPreview:       in.Description,
+		Weblink:           in.HTMLLink,
+		Start:             toRemoteDateTime(in.Start),
+		End:               toRemoteDateTime(in.End),
+		IsAllDay:          in.Start != nil && in.Start.Date != "",
+		IsCancelled:       in.Status == googleEventStatusCancelled,
+		ShowAs:            "busy",
+		Importance:        "normal",
+		ResponseStatus:    toRemoteResponseStatus(GoogleResponseStatusNone),
+		ResponseRequested: len(in.Attendees) > 0,
+	}
+
+	if in.Transparency == googleTransparencyTransparent {
+		out.ShowAs = "free"
+	}
+
+	if in.RecurringEventID != "" {
+		out.Type = remote.EventTypeOccurrence
+		out.SeriesMasterID = in.RecurringEventID
+		if originalStart := toRemoteDateTime(in.OriginalStartTime); originalStart != nil && !originalStart.Time().IsZero() {
+			out.OriginalStart = originalStart.Time().UTC().Format(time.RFC3339)
+		}
+	}
+	for _, line := range in.Recurrence {
+		if !strings.HasPrefix(line, "RRULE:") || out.Start == nil {
+			continue
+		}
+		recurrence, err := remote.ParseRRule(line, out.Start.Time())
+		if err != nil {
+			continue
+		}
+		out.Type = remote.EventTypeSeriesMaster
+		out.Recurrence = recurrence
+		break
+	}
+
+	if in.Description != "" {
+		out.Body = &remote.ItemBody{
+			Content:     in.Description,
+			ContentType: "text",
+		}
+	}
+
+	if in.Location != "" {
+		out.Location = &remote.Location{
+			DisplayName: in.Location,
+		}
+	}
+
+	if in.Organizer != nil {
+		out.Organizer = &remote.Attendee{
+			EmailAddress: &remote.EmailAddress{
+				Address: in.Organizer.Email,
+				Name:    in.Organizer.DisplayName,
+			},
+		}
+		out.IsOrganizer = in.Organizer.Self
+	}
+
+	for _, a := range in.Attendees {
+		if a.Self {
+			out.ResponseStatus = toRemoteResponseStatus(a.ResponseStatus)
+		}
+		attendeeType := "required"
+		if a.Optional {
+			attendeeType = "optional"
+		}
+		if a.Resource {
+			attendeeType = "resource"
+		}
+		out.Attendees = append(out.Attendees, &remote.Attendee{
+			Type:   attendeeType,
+			Status: toRemoteResponseStatus(a.ResponseStatus),
+			EmailAddress: &remote.EmailAddress{
+				Address: a.Email,
+				Name:    a.DisplayName,
+			},
+		})
+	}
+
+	// Organizers of events without attendees own the event outright.
+	if out.IsOrganizer && len(in.Attendees) == 0 {
+		out.ResponseStatus = toRemoteResponseStatus(GoogleResponseStatusYes)
+	}
+
+	out.ReminderMinutesBeforeStart = defaultReminderMinutes
+	out.IsReminderOn = true
+	if in.Reminders != nil && !in.Reminders.UseDefault {
+		out.ReminderMinutesBeforeStart = 0
+		out.IsReminderOn = len(in.Reminders.Overrides) > 0
+		for _, r := range in.Reminders.Overrides {
+			if r.Minutes > out.ReminderMinutesBeforeStart {
+				out.ReminderMinutesBeforeStart = r.Minutes
+			}
+		}
+	}
+
+	switch {
+	case in.HangoutLink != "":
+		out.Conference = &remote.Conference{
+			Application: "Google Meet",
+			URL:         in.HangoutLink,
+		}
+	case in.ConferenceData != nil:
+		for _, ep := range in.ConferenceData.EntryPoints {
+			if ep.EntryPointType != "video" {
+				continue
+			}
+			out.Conference = &remote.Conference{URL: ep.URI}
+			if in.ConferenceData.ConferenceSolution != nil {
+				out.Conference.Application = in.ConferenceData.ConferenceSolution.Name
+			}
+			break
+		}
+	}
+
+	return out
+}
+
+// fromRemoteEvent converts our representation of an event into a Google event.
+func fromRemoteEvent(in *remote.Event) *event {
+	out := &event{
+		Summary: in.Subject,
+		Start:   fromRemoteDateTime(in.Start, in.IsAllDay),
+		End:     fromRemoteDateTime(in.End, in.IsAllDay),
+	}
+
+	if in.Body != nil {
+		out.Description = in.Body.Content
+	}
+	if in.Location != nil {
+		out.Location = in.Location.DisplayName
+	}
+	if in.ShowAs == "free" {
+		out.Transparency = googleTransparencyTransparent
+	}
+	if in.Recurrence != nil {
+		if rrule, err := in.Recurrence.RRule(); err == nil {
+			out.Recurrence = []string{"RRULE:" + rrule}
+		}
+	}
+
+	for _, a := range in.Attendees {
+		if a.EmailAddress == nil {
+			continue
+		}
+		out.Attendees = append(out.Attendees, &eventAttendee{
+			Email:       a.EmailAddress.Address,
+			DisplayName: a.EmailAddress.Name,
+			Optional:    a.Type == "optional",
+		})
+	}
+
+	return out
+}
+
+func toRemoteEvents(in []*event) []*remote.Event {
+	events := []*remote.Event{}
+	for _, e := range in {
+		events = append(events, toRemoteEvent(e))
+	}
+	return events
+}
+
+func eventsPath(remoteUserID string) string {
+	return "/calendars/" + url.PathEscape(remoteUserID) + "/events"
+}
+
+func eventPath(remoteUserID, eventID string) string {
+	return eventsPath(remoteUserID) + "/" + url.PathEscape(eventID)
+}
+
+func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
+	e := &event{}
+
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	_, err := c.CallJSON(http.MethodGet, eventPath(remoteUserID, eventID), nil, e)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal GetEvent")
+	}
+	return toRemoteEvent(e), nil
+}
+
+func (c *client) AcceptEvent(remoteUserID, eventID string) error {
+	return errors.Wrap(c.respondToEvent(remoteUserID, eventID, GoogleResponseStatusYes), "gcal AcceptEvent")
+}
+
+func (c *client) DeclineEvent(remoteUserID, eventID string) error {
+	return errors.Wrap(c.respondToEvent(remoteUserID, eventID, GoogleResponseStatusNo), "gcal DeclineEvent")
+}
+
+func (c *client) TentativelyAcceptEvent(remoteUserID, eventID string) error {
+	return errors.Wrap(c.respondToEvent(remoteUserID, eventID, GoogleResponseStatusMaybe), "gcal TentativelyAcceptEvent")
+}
+
+// respondToEvent sets the response of the calling user. Google has no
+// dedicated RSVP endpoint, so the attendee list is patched with the user's
+// own entry updated.
+func (c *client) respondToEvent(remoteUserID, eventID, response string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	e := &event{}
+	_, err := c.CallJSON(http.MethodGet, eventPath(remoteUserID, eventID), nil, e)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return err
+	}
+
+	found := false
+	for _, a := range e.Attendees {
+		if a.Self {
+			a.ResponseStatus = response
+			found = true
+		}
+	}
+	if !found {
+		return errors.New("user is not an attendee of the event")
+	}
+
+	patch := struct {
+		Attendees []*eventAttendee `json:"attendees"`
+	}{e.Attendees}
+	_, err = c.CallJSON(http.MethodPatch, eventPath(remoteUserID, eventID)+"?sendUpdates=all", patch, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return err
+	}
+	return nil
+}
+
+func (c *client) GetEventsBetweenDates(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	res := &eventList{}
+	_, err := c.CallJSON(http.MethodGet, eventsPath(remoteUserID)+getQueryParamStringForCalendarView(start, end), nil, res)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal GetEventsBetweenDates")
+	}
+
+	return toRemoteEvents(res.Items), nil
+}
+
+// CreateEvent creates a calendar event
+func (c *client) CreateEvent(remoteUserID string, in *remote.Event) (*remote.Event, error) {
+	out := &event{}
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	_, err := c.CallJSON(http.MethodPost, eventsPath(remoteUserID)+"?sendUpdates=all", fromRemoteEvent(in), out)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal CreateEvent")
+	}
+	return toRemoteEvent(out), nil
+}
+
+func getQueryParamStringForCalendarView(start, end time.Time) string {
+	q := url.Values{}
+	q.Add("timeMin", start.Format(time.RFC3339))
+	q.Add("timeMax", end.Format(time.RFC3339))
+	q.Add("singleEvents", "true")
+	q.Add("orderBy", "startTime")
+	q.Add("maxResults", "20")
+	return "?" + q.Encode()
+}
+
+// UpdateEvent patches the fields set in the event, and notifies the attendees
+func (c *client) UpdateEvent(remoteUserID, eventID string, in *remote.Event) (*remote.Event, error) {
+	out := &event{}
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	_, err := c.CallJSON(http.MethodPatch, eventPath(remoteUserID, eventID)+"?sendUpdates=all", fromRemoteEvent(in), out)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal UpdateEvent")
+	}
+	return toRemoteEvent(out), nil
+}
+
+// DeleteEvent removes an event without notifying the attendees.
+func (c *client) DeleteEvent(remoteUserID, eventID string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	_, err := c.CallJSON(http.MethodDelete, eventPath(remoteUserID, eventID)+"?sendUpdates=none", nil, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "gcal DeleteEvent")
+	}
+	return nil
+}
+
+// CancelEvent deletes an event and sends the cancellation to the attendees.
+// Google has no cancellation message, so the comment is added to the
+// description of the event before it is deleted.
+func (c *client) CancelEvent(remoteUserID, eventID, comment string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	if comment != "" {
+		e := &event{}
+		_, err := c.CallJSON(http.MethodGet, eventPath(remoteUserID, eventID), nil, e)
+		if err != nil {
+			c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+			return errors.Wrap(err, "gcal CancelEvent")
+		}
+		patch := &event{
+			Description: strings.TrimSpace(comment + "\n\n" + e.Description),
+		}
+		_, err = c.CallJSON(http.MethodPatch, eventPath(remoteUserID, eventID)+"?sendUpdates=none", patch, nil)
+		if err != nil {
+			c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+			return errors.Wrap(err, "gcal CancelEvent")
+		}
+	}
+
+	_, err := c.CallJSON(http.MethodDelete, eventPath(remoteUserID, eventID)+"?sendUpdates=all", nil, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "gcal CancelEvent")
+	}
+	return nil
+}
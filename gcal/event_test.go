@@ -0,0 +1,140 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestToRemoteEvent(t *testing.T) {
+	in := &event{
+		ID:           "event_id",
+		ICalUID:      "ical_uid",
+		Status:       "confirmed",
+		HTMLLink:     "https://calendar.google.com/event?eid=1",
+		Summary:      "Planning",
+		Description:  "Agenda",
+		Location:     "Room 1",
+		Transparency: googleTransparencyTransparent,
+		HangoutLink:  "https://meet.google.com/abc-defg-hij",
+		Start:        &eventDateTime{DateTime: "2024-03-04T10:00:00Z", TimeZone: "America/New_York"},
+		End:          &eventDateTime{DateTime: "2024-03-04T11:00:00Z", TimeZone: "America/New_York"},
+		Organizer:    &eventPerson{Email: "organizer@example.com", DisplayName: "Organizer"},
+		Attendees: []*eventAttendee{
+			{Email: "organizer@example.com", Organizer: true, ResponseStatus: GoogleResponseStatusYes},
+			{Email: "user@example.com", Self: true, Optional: true, ResponseStatus: GoogleResponseStatusMaybe},
+		},
+	}
+
+	out := toRemoteEvent(in)
+	require.Equal(t, "event_id", out.ID)
+	require.Equal(t, "ical_uid", out.ICalUID)
+	require.Equal(t, "Planning", out.Subject)
+	require.Equal(t, "Agenda", out.Body.Content)
+	require.Equal(t, "Room 1", out.Location.DisplayName)
+	require.Equal(t, "free", out.ShowAs)
+	require.Equal(t, remote.EventResponseStatusTentative, out.ResponseStatus.Response)
+	require.Equal(t, "https://meet.google.com/abc-defg-hij", out.Conference.URL)
+	require.Equal(t, "organizer@example.com", out.Organizer.EmailAddress.Address)
+	require.False(t, out.IsOrganizer)
+	require.False(t, out.IsAllDay)
+	require.False(t, out.IsCancelled)
+	require.Equal(t, defaultReminderMinutes, out.ReminderMinutesBeforeStart)
+	require.Len(t, out.Attendees, 2)
+	require.Equal(t, "optional", out.Attendees[1].Type)
+
+	require.Equal(t, "America/New_York", out.Start.TimeZone)
+	require.Equal(t, "2024-03-04T05:00:00", out.Start.DateTime)
+	require.True(t, time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC).Equal(out.Start.Time()))
+}
+
+func TestToRemoteEventAllDayAndCancelled(t *testing.T) {
+	in := &event{
+		ID:        "event_id",
+		Status:    googleEventStatusCancelled,
+		Start:     &eventDateTime{Date: "2024-03-04"},
+		End:       &eventDateTime{Date: "2024-03-05"},
+		Organizer: &eventPerson{Email: "user@example.com", Self: true},
+	}
+
+	out := toRemoteEvent(in)
+	require.True(t, out.IsAllDay)
+	require.True(t, out.IsCancelled)
+	require.True(t, out.IsOrganizer)
+	require.Equal(t, "busy", out.ShowAs)
+	require.Equal(t, remote.EventResponseStatusAccepted, out.ResponseStatus.Response)
+	require.Equal(t, "2024-03-04T00:00:00", out.Start.DateTime)
+	require.Equal(t, "UTC", out.Start.TimeZone)
+}
+
+func TestFromRemoteEvent(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	in := &remote.Event{
+		Subject: "Planning",
+		Body:    &remote.ItemBody{Content: "Agenda"},
+		Start:   remote.NewDateTime(start, "UTC"),
+		End:     remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		Attendees: []*remote.Attendee{
+			{EmailAddress: &remote.EmailAddress{Address: "user@example.com"}},
+			{Type: "optional", EmailAddress: &remote.EmailAddress{Address: "other@example.com"}},
+		},
+	}
+
+	out := fromRemoteEvent(in)
+	require.Equal(t, "Planning", out.Summary)
+	require.Equal(t, "Agenda", out.Description)
+	require.Equal(t, "2024-03-04T09:00:00Z", out.Start.DateTime)
+	require.Equal(t, "UTC", out.Start.TimeZone)
+	require.Equal(t, "2024-03-04T10:00:00Z", out.End.DateTime)
+	require.Len(t, out.Attendees, 2)
+	require.False(t, out.Attendees[0].Optional)
+	require.True(t, out.Attendees[1].Optional)
+
+	in.IsAllDay = true
+	out = fromRemoteEvent(in)
+	require.Equal(t, "2024-03-04", out.Start.Date)
+	require.Empty(t, out.Start.DateTime)
+}
+
+func TestRecurringEvent(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	recurrence, err := remote.NewPatternedRecurrence(remote.FrequencyWeekly, 1, start)
+	require.NoError(t, err)
+	recurrence.EndAfter(10)
+
+	out := fromRemoteEvent(&remote.Event{
+		Subject:    "Weekly sync",
+		Start:      remote.NewDateTime(start, "UTC"),
+		End:        remote.NewDateTime(start.Add(time.Hour), "UTC"),
+		Recurrence: recurrence,
+	})
+	require.Equal(t, []string{"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=10"}, out.Recurrence)
+
+	master := toRemoteEvent(&event{
+		ID:         "series_id",
+		Start:      &eventDateTime{DateTime: "2024-03-04T09:00:00Z", TimeZone: "UTC"},
+		End:        &eventDateTime{DateTime: "2024-03-04T10:00:00Z", TimeZone: "UTC"},
+		Recurrence: out.Recurrence,
+	})
+	require.Equal(t, remote.EventTypeSeriesMaster, master.Type)
+	require.Equal(t, recurrence, master.Recurrence)
+
+	instance := toRemoteEvent(&event{
+		ID:                "series_id_20240311T090000Z",
+		ICalUID:           "ical_uid",
+		RecurringEventID:  "series_id",
+		Start:             &eventDateTime{DateTime: "2024-03-11T10:00:00Z", TimeZone: "UTC"},
+		End:               &eventDateTime{DateTime: "2024-03-11T11:00:00Z", TimeZone: "UTC"},
+		OriginalStartTime: &eventDateTime{DateTime: "2024-03-11T09:00:00Z", TimeZone: "UTC"},
+	})
+	require.Equal(t, remote.EventTypeOccurrence, instance.Type)
+	require.Equal(t, "series_id", instance.SeriesMasterID)
+	require.Equal(t, "2024-03-11T09:00:00Z", instance.OriginalStart)
+	require.Equal(t, "ical_uid_20240311T090000Z", instance.OccurrenceKey())
+}
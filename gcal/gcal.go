@@ -0,0 +1,34 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+)
+
+const (
+	ProviderGCal            = Kind
+	ProviderGCalDisplayName = "Google Calendar"
+	ProviderGCalRepository  = "mattermost-plugin-mscalendar"
+)
+
+func GetGoogleCalendarProviderConfig() config.ProviderConfig {
+	return config.ProviderConfig{
+		Name:        ProviderGCal,
+		DisplayName: ProviderGCalDisplayName,
+		Repository:  ProviderGCalRepository,
+
+		CommandTrigger: ProviderGCal,
+
+		TelemetryShortName: ProviderGCal,
+
+		BotUsername:    ProviderGCal,
+		BotDisplayName: ProviderGCalDisplayName,
+
+		Features: config.ProviderFeatures{
+			EncryptedStore:     false,
+			EventNotifications: true,
+		},
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// GetMailboxSettings returns the calendar time zone of the calling user.
+// Google Calendar does not expose working hours.
+func (c *client) GetMailboxSettings(_ string) (*remote.MailboxSettings, error) {
+	var v struct {
+		Value string `json:"value"`
+	}
+
+	_, err := c.CallJSON(http.MethodGet, "/users/me/settings/timezone", nil, &v)
+	if err != nil {
+		return nil, errors.Wrap(err, "gcal GetMailboxSettings")
+	}
+	return &remote.MailboxSettings{
+		TimeZone: v.Value,
+	}, nil
+}
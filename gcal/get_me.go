@@ -0,0 +1,49 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+const (
+	ErrorUserInactive = "You have been marked inactive because your refresh token is expired. Please disconnect and reconnect your account again."
+	LogUserInactive   = "User %s is inactive. Please disconnect and reconnect your account."
+)
+
+// GetMe returns the owner of the primary calendar. The primary calendar ID is
+// the user's email address, and is used as the remote user ID.
+func (c *client) GetMe() (*remote.User, error) {
+	primary := &calendarListEntry{}
+	_, err := c.CallJSON(http.MethodGet, "/users/me/calendarList/primary", nil, primary)
+	if err != nil {
+		return nil, errors.Wrap(err, "gcal GetMe")
+	}
+
+	if primary.ID == "" {
+		return nil, errors.New("user has no primary calendar")
+	}
+
+	displayName := primary.Summary
+	if displayName == "" {
+		displayName = primary.ID
+	}
+
+	user := &remote.User{
+		ID:                primary.ID,
+		DisplayName:       displayName,
+		UserPrincipalName: primary.ID,
+		Mail:              primary.ID,
+	}
+
+	return user, nil
+}
+
+func (c *client) GetSuperuserToken() (string, error) {
+	return "", remote.ErrSuperUserClientNotSupported
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// Page sizes of the event lists: incremental syncs fetch the changed events,
+// the initial one only pages through the events for its sync token.
+const (
+	syncPageSize        = 250
+	initialSyncPageSize = 2500
+)
+
+// GetNotificationData returns the notification as is when it carries its
+// event. Google push notifications don't identify the changed events: they
+// are fetched with PollSubscription.
+func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notification, error) {
+	if orig.Event == nil {
+		return nil, errors.New("gcal GetNotificationData: notification has no event, poll the subscription instead")
+	}
+	n := *orig
+	n.IsBare = false
+	return &n, nil
+}
+
+// PollSubscription fetches the events changed since the sync token of the
+// subscription, and returns a notification per changed event. If Google no
+// longer accepts the sync token, or the subscription has none yet, tracking
+// restarts from the current state without notifications.
+func (c *client) PollSubscription(sub *remote.Subscription) ([]*remote.Notification, *remote.Subscription, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, nil, errors.New(ErrorUserInactive)
+	}
+
+	updated := *sub
+	if sub.SyncToken == "" {
+		syncToken, err := c.getSyncToken(sub.Resource)
+		if err != nil {
+			c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+			return nil, nil, errors.Wrap(err, "gcal PollSubscription")
+		}
+		updated.SyncToken = syncToken
+		return nil, &updated, nil
+	}
+
+	items, syncToken, err := c.listChanges(sub.Resource, sub.SyncToken)
+	if isStatus(err, http.StatusGone) {
+		c.Logger.With(bot.LogContext{
+			"subscriptionID": sub.ID,
+		}).Infof("gcal: sync token expired, restarting sync.")
+		updated.SyncToken, err = c.getSyncToken(sub.Resource)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "gcal PollSubscription")
+		}
+		return nil, &updated, nil
+	}
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		c.Logger.With(bot.LogContext{
+			"Resource":       sub.Resource,
+			"subscriptionID": sub.ID,
+		}).Infof("gcal: failed to fetch changed events: `%v`.", err)
+		return nil, nil, errors.Wrap(err, "gcal PollSubscription")
+	}
+	updated.SyncToken = syncToken
+
+	notifications := []*remote.Notification{}
+	for _, item := range items {
+		notifications = append(notifications, &remote.Notification{
+			SubscriptionID: sub.ID,
+			ChangeType:     changeType(item),
+			ClientState:    sub.ClientState,
+			Event:          toRemoteEvent(item),
+		})
+	}
+
+	return notifications, &updated, nil
+}
+
+// changeType tells how the event changed. Google reports no change type, an
+// event whose creation is its last update is taken as created.
+func changeType(e *event) string {
+	switch {
+	case e.Status == googleEventStatusCancelled:
+		return remote.ChangeTypeDeleted
+	case e.Created != "" && e.Created == e.Updated:
+		return remote.ChangeTypeCreated
+	default:
+		return remote.ChangeTypeUpdated
+	}
+}
+
+// getSyncToken returns the sync token of the current state of the events,
+// paging through them without their data.
+func (c *client) getSyncToken(resource string) (string, error) {
+	_, syncToken, err := c.listChanges(resource, "")
+	if err != nil {
+		return "", err
+	}
+	if syncToken == "" {
+		return "", errors.Errorf("no sync token returned for %s", resource)
+	}
+	return syncToken, nil
+}
+
+// listChanges returns the events changed since the sync token, and the sync
+// token to fetch the next changes with. Without a sync token, only the sync
+// token of the current state is fetched.
+func (c *client) listChanges(resource, syncToken string) ([]*event, string, error) {
+	items := []*event{}
+	pageToken := ""
+	for {
+		q := url.Values{}
+		q.Add("showDeleted", "true")
+		q.Add("singleEvents", "true")
+		if syncToken != "" {
+			q.Add("maxResults", strconv.Itoa(syncPageSize))
+			q.Add("syncToken", syncToken)
+		} else {
+			q.Add("maxResults", strconv.Itoa(initialSyncPageSize))
+			q.Add("fields", "nextPageToken,nextSyncToken")
+		}
+		if pageToken != "" {
+			q.Add("pageToken", pageToken)
+		}
+
+		res := &eventList{}
+		_, err := c.CallJSON(http.MethodGet, resource+"?"+q.Encode(), nil, res)
+		if err != nil {
+			return nil, "", err
+		}
+		items = append(items, res.Items...)
+
+		if res.NextPageToken == "" {
+			return items, res.NextSyncToken, nil
+		}
+		pageToken = res.NextPageToken
+	}
+}
+
+// isStatus tells whether err is an error response of Google with the status
+// code.
+func isStatus(err error, statusCode int) bool {
+	var errResp *errorResponse
+	if !errors.As(err, &errResp) {
+		return false
+	}
+	return errResp.Err.Code == statusCode || (errResp.Response != nil && errResp.Response.StatusCode == statusCode)
+}
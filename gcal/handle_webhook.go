@@ -0,0 +1,86 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const renewSubscriptionBeforeExpiration = 12 * time.Hour
+
+const (
+	resourceStateSync      = "sync"
+	resourceStateExists    = "exists"
+	resourceStateNotExists = "not_exists"
+)
+
+// webhook holds the headers of a Google push notification. Notifications
+// carry no body, the changed events have to be fetched separately.
+type webhook struct {
+	ChannelID     string
+	ResourceID    string
+	ResourceURI   string
+	ResourceState string
+	MessageNumber string
+	Expiration    string
+}
+
+func (r *impl) HandleWebhook(w http.ResponseWriter, req *http.Request) []*remote.Notification {
+	wh := &webhook{
+		ChannelID:     req.Header.Get("X-Goog-Channel-ID"),
+		ResourceID:    req.Header.Get("X-Goog-Resource-ID"),
+		ResourceURI:   req.Header.Get("X-Goog-Resource-URI"),
+		ResourceState: req.Header.Get("X-Goog-Resource-State"),
+		MessageNumber: req.Header.Get("X-Goog-Message-Number"),
+		Expiration:    req.Header.Get("X-Goog-Channel-Expiration"),
+	}
+
+	if wh.ChannelID == "" || wh.ResourceState == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		r.logger.Infof("gcal: failed to process webhook: missing channel headers.")
+		return nil
+	}
+
+	// Google sends a sync message when a channel is created.
+	if wh.ResourceState == resourceStateSync {
+		w.WriteHeader(http.StatusOK)
+		r.logger.Debugf("gcal: validated event webhook channel.")
+		return nil
+	}
+
+	changeType := "updated"
+	if wh.ResourceState == resourceStateNotExists {
+		changeType = "deleted"
+	}
+
+	n := &remote.Notification{
+		SubscriptionID: wh.ChannelID,
+		ChangeType:     changeType,
+		ClientState:    req.Header.Get("X-Goog-Channel-Token"),
+		IsBare:         true,
+		Webhook:        wh,
+	}
+
+	if wh.Expiration != "" {
+		expires, err := time.Parse(time.RFC1123, wh.Expiration)
+		if err != nil {
+			r.logger.With(bot.LogContext{
+				"SubscriptionID": wh.ChannelID,
+			}).Infof("gcal: invalid subscription expiration in webhook: `%v`.", err)
+			w.WriteHeader(http.StatusOK)
+			return nil
+		}
+		expires = expires.Add(-renewSubscriptionBeforeExpiration)
+		if time.Now().After(expires) {
+			n.RecommendRenew = true
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	return []*remote.Notification{n}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func TestHandleWebhook(t *testing.T) {
+	r := NewRemote(&config.Config{}, &bot.NilLogger{})
+
+	for name, tc := range map[string]struct {
+		headers            map[string]string
+		expectedStatus     int
+		expectedCount      int
+		expectedChangeType string
+		expectedRenew      bool
+	}{
+		"missing headers": {
+			headers:        map[string]string{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		"sync message": {
+			headers: map[string]string{
+				"X-Goog-Channel-ID":     "channel_id",
+				"X-Goog-Resource-State": resourceStateSync,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		"event changed": {
+			headers: map[string]string{
+				"X-Goog-Channel-ID":         "channel_id",
+				"X-Goog-Channel-Token":      "client_state",
+				"X-Goog-Resource-State":     resourceStateExists,
+				"X-Goog-Channel-Expiration": time.Now().Add(subscribeTTL).UTC().Format(time.RFC1123),
+			},
+			expectedStatus:     http.StatusOK,
+			expectedCount:      1,
+			expectedChangeType: "updated",
+		},
+		"expiring channel": {
+			headers: map[string]string{
+				"X-Goog-Channel-ID":         "channel_id",
+				"X-Goog-Channel-Token":      "client_state",
+				"X-Goog-Resource-State":     resourceStateNotExists,
+				"X-Goog-Channel-Expiration": time.Now().Add(time.Hour).UTC().Format(time.RFC1123),
+			},
+			expectedStatus:     http.StatusOK,
+			expectedCount:      1,
+			expectedChangeType: "deleted",
+			expectedRenew:      true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/notification", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+
+			notifications := r.HandleWebhook(w, req)
+			require.Equal(t, tc.expectedStatus, w.Code)
+			require.Len(t, notifications, tc.expectedCount)
+			if tc.expectedCount == 0 {
+				return
+			}
+
+			n := notifications[0]
+			require.Equal(t, "channel_id", n.SubscriptionID)
+			require.Equal(t, "client_state", n.ClientState)
+			require.Equal(t, tc.expectedChangeType, n.ChangeType)
+			require.Equal(t, tc.expectedRenew, n.RecommendRenew)
+			require.True(t, n.IsBare)
+		})
+	}
+}
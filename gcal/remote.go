@@ -0,0 +1,102 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const Kind = "gcal"
+
+const (
+	// DefaultBaseURL is the root of the Google Calendar v3 REST API.
+	DefaultBaseURL = "https://www.googleapis.com/calendar/v3"
+
+	authURL  = "https://accounts.google.com/o/oauth2/auth"
+	tokenURL = "https://oauth2.googleapis.com/token"
+)
+
+type impl struct {
+	conf    *config.Config
+	logger  bot.Logger
+	baseURL string
+}
+
+func init() {
+	remote.Makers[Kind] = NewRemote
+}
+
+func NewRemote(conf *config.Config, logger bot.Logger) remote.Remote {
+	return &impl{
+		conf:    conf,
+		logger:  logger,
+		baseURL: DefaultBaseURL,
+	}
+}
+
+// makeClient creates a new client for user-delegated permissions.
+func (r *impl) makeClient(ctx context.Context, token *oauth2.Token, mattermostUserID string, poster bot.Poster, userTokenHelpers remote.UserTokenHelpers) remote.Client {
+	c := &client{
+		conf:             r.conf,
+		ctx:              ctx,
+		httpClient:       r.NewOAuth2Config().Client(ctx, token),
+		baseURL:          r.baseURL,
+		Logger:           r.logger,
+		tokenHelpers:     userTokenHelpers,
+		mattermostUserID: mattermostUserID,
+		Poster:           poster,
+	}
+
+	return c
+}
+
+// MakeUserClient creates a new client having user-delegated permissions with refreshed token.
+func (r *impl) MakeUserClient(ctx context.Context, oauthToken *oauth2.Token, mattermostUserID string, poster bot.Poster, userTokenHelpers remote.UserTokenHelpers) remote.Client {
+	config := r.NewOAuth2Config()
+
+	token, err := userTokenHelpers.RefreshAndStoreToken(oauthToken, config, mattermostUserID)
+	if err != nil {
+		r.logger.Warnf("Not able to refresh or store the token", "error", err.Error())
+		return &client{}
+	}
+
+	return r.makeClient(ctx, token, mattermostUserID, poster, userTokenHelpers)
+}
+
+// MakeSuperuserClient is not supported: Google Calendar has no app-only
+// access to user calendars outside of domain-wide delegation.
+func (r *impl) MakeSuperuserClient(_ context.Context) (remote.Client, error) {
+	return nil, remote.ErrSuperUserClientNotSupported
+}
+
+func (r *impl) NewOAuth2Config() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     r.conf.OAuth2ClientID,
+		ClientSecret: r.conf.OAuth2ClientSecret,
+		RedirectURL:  r.conf.PluginURL + config.FullPathOAuth2Redirect,
+		Scopes: []string{
+			"https://www.googleapis.com/auth/calendar",
+			"https://www.googleapis.com/auth/calendar.settings.readonly",
+		},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  authURL,
+			TokenURL: tokenURL,
+		},
+	}
+}
+
+func (r *impl) CheckConfiguration(cfg config.StoredConfig) error {
+	if cfg.OAuth2ClientID == "" || cfg.OAuth2ClientSecret == "" {
+		return fmt.Errorf("OAuth2 credentials to be set in the config")
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package gcal
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+const subscribeTTL = 48 * time.Hour
+
+// channel is a Google Calendar push notification channel.
+type channel struct {
+	ID          string            `json:"id"`
+	ResourceID  string            `json:"resourceId,omitempty"`
+	ResourceURI string            `json:"resourceUri,omitempty"`
+	Token       string            `json:"token,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Address     string            `json:"address,omitempty"`
+	Expiration  string            `json:"expiration,omitempty"`
+	Params      map[string]string `json:"params,omitempty"`
+}
+
+func newRandomString() string {
+	b := make([]byte, 96)
+	rand.Read(b)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+// CreateMySubscription opens a push notification channel on the user's
+// primary calendar. The channel ID is used as the subscription ID.
+func (c *client) CreateMySubscription(notificationURL, remoteUserID string) (*remote.Subscription, error) {
+	in := &channel{
+		ID:      model.NewId(),
+		Type:    "web_hook",
+		Address: notificationURL,
+		Token:   newRandomString(),
+		Params: map[string]string{
+			"ttl": strconv.Itoa(int(subscribeTTL.Seconds())),
+		},
+	}
+
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	// The sync token is taken before the channel is opened, so that no change
+	// notified by the channel is missed.
+	syncToken, err := c.getSyncToken(eventsPath(remoteUserID))
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal CreateMySubscription")
+	}
+
+	out := &channel{}
+	_, err = c.CallJSON(http.MethodPost, eventsPath(remoteUserID)+"/watch", in, out)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "gcal CreateMySubscription")
+	}
+
+	expires := time.Now().Add(subscribeTTL)
+	if ms, parseErr := strconv.ParseInt(out.Expiration, 10, 64); parseErr == nil {
+		expires = time.UnixMilli(ms)
+	}
+
+	sub := &remote.Subscription{
+		ID:                 out.ID,
+		ResourceID:         out.ResourceID,
+		Resource:           eventsPath(remoteUserID),
+		ChangeType:         "created,updated,deleted",
+		ClientState:        in.Token,
+		NotificationURL:    notificationURL,
+		ExpirationDateTime: expires.Format(time.RFC3339),
+		CreatorID:          remoteUserID,
+		SyncToken:          syncToken,
+	}
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"resource":           sub.Resource,
+		"changeType":         sub.ChangeType,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("gcal: created subscription.")
+
+	return sub, nil
+}
+
+func (c *client) DeleteSubscription(sub *remote.Subscription) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	in := &channel{
+		ID:         sub.ID,
+		ResourceID: sub.ResourceID,
+	}
+	_, err := c.CallJSON(http.MethodPost, "/channels/stop", in, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "gcal DeleteSubscription")
+	}
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID": sub.ID,
+	}).Debugf("gcal: deleted subscription.")
+
+	return nil
+}
+
+// RenewSubscription replaces the channel with a new one, since Google push
+// channels cannot be extended. The new channel carries on from the sync
+// token of the old one, so that the changes in between are still notified.
+func (c *client) RenewSubscription(notificationURL, remoteUserID string, oldSub *remote.Subscription) (*remote.Subscription, error) {
+	sub, err := c.CreateMySubscription(notificationURL, remoteUserID)
+	if err != nil {
+		return nil, errors.Wrap(err, "gcal RenewSubscription")
+	}
+	if oldSub.SyncToken != "" {
+		sub.SyncToken = oldSub.SyncToken
+	}
+
+	err = c.DeleteSubscription(oldSub)
+	if err != nil {
+		c.Logger.With(bot.LogContext{
+			"subscriptionID": oldSub.ID,
+		}).Warnf("gcal: failed to stop renewed subscription: `%v`.", err)
+	}
+
+	c.Logger.With(bot.LogContext{
+		"subscriptionID":     sub.ID,
+		"oldSubscriptionID":  oldSub.ID,
+		"expirationDateTime": sub.ExpirationDateTime,
+	}).Debugf("gcal: renewed subscription.")
+
+	return sub, nil
+}
+
+// ListSubscriptions is not supported: Google Calendar has no API to list push
+// notification channels.
+func (c *client) ListSubscriptions() ([]*remote.Subscription, error) {
+	return nil, remote.ErrNotImplemented
+}
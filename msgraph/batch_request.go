@@ -4,7 +4,11 @@
 package msgraph
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 )
 
 const maxNumRequestsPerBatch = 20
@@ -21,13 +25,97 @@ type fullBatchRequest struct {
 	Requests []*singleRequest `json:"requests"`
 }
 
+type singleResponse struct {
+	Headers map[string]string `json:"headers"`
+	ID      string            `json:"id"`
+	Body    json.RawMessage   `json:"body"`
+	Status  int               `json:"status"`
+}
+
+type batchResponse struct {
+	Responses []*singleResponse `json:"responses"`
+}
+
 func (c *client) batchRequest(req fullBatchRequest, out interface{}) error {
-	u := "https://graph.microsoft.com/v1.0/$batch"
+	u := graphBaseURL(c.conf) + "/$batch"
 
 	_, err := c.CallJSON(http.MethodPost, u, req, out)
 	return err
 }
 
+// doBatchRequests sends the requests in batches, and sends again the requests
+// whose response was throttled or failed transiently, as the retry transport
+// does for single requests. The batches only carry reads, so every request
+// can be sent again. Responses are returned in the order of the requests, and
+// requests that got no response are left out.
+func (c *client) doBatchRequests(requests []*singleRequest) ([]*singleResponse, error) {
+	byID := map[string]*singleResponse{}
+	pending := requests
+	for attempt := 0; len(pending) > 0; attempt++ {
+		requestsByID := map[string]*singleRequest{}
+		for _, req := range pending {
+			requestsByID[req.ID] = req
+		}
+
+		retry := []*singleRequest{}
+		var wait time.Duration
+		for _, batchReq := range prepareBatchRequests(pending) {
+			res := &batchResponse{}
+			err := c.batchRequest(batchReq, res)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, r := range res.Responses {
+				byID[r.ID] = r
+				req, ok := requestsByID[r.ID]
+				if !ok || attempt >= maxRetries {
+					continue
+				}
+				delay, shouldRetry := retryDelay(r.Status, headerValue(r.Headers, "Retry-After"), attempt, true)
+				if !shouldRetry {
+					continue
+				}
+				logRetry(c.Logger, c.throttled, req.URL, r.Status, attempt, delay, nil)
+				retry = append(retry, req)
+				if delay > wait {
+					wait = delay
+				}
+			}
+		}
+
+		if len(retry) > 0 {
+			ctx := c.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+		pending = retry
+	}
+
+	responses := []*singleResponse{}
+	for _, req := range requests {
+		if r, ok := byID[req.ID]; ok {
+			responses = append(responses, r)
+		}
+	}
+	return responses, nil
+}
+
+// headerValue looks up a header of a batch response, whose names are not
+// canonicalized.
+func headerValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
 func prepareBatchRequests(requests []*singleRequest) []fullBatchRequest {
 	numFullRequests := len(requests) / maxNumRequestsPerBatch
 	if len(requests)%maxNumRequestsPerBatch != 0 {
@@ -41,7 +41,7 @@ func (c *client) call(method, path, contentType string, inBody io.Reader, out in
 
 	if pathURL.Scheme == "" || pathURL.Host == "" {
 		var baseURL *url.URL
-		baseURL, err = url.Parse(c.rbuilder.URL())
+		baseURL, err = url.Parse(graphBaseURL(c.conf))
 		if err != nil {
 			return nil, errors.WithMessage(err, errContext)
 		}
@@ -88,7 +88,7 @@ func (c *client) call(method, path, contentType string, inBody io.Reader, out in
 		}
 		return responseData, nil
 
-	case http.StatusNoContent:
+	case http.StatusAccepted, http.StatusNoContent:
 		return nil, nil
 	}
 
@@ -25,6 +25,9 @@ type client struct {
 	conf             *config.Config
 	tokenHelpers     remote.UserTokenHelpers
 
+	// throttled counts the throttled requests of all the clients of the remote
+	throttled *int64
+
 	bot.Logger
 	bot.Poster
 }
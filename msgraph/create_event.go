@@ -23,5 +23,5 @@ func (c *client) CreateEvent(remoteUserID string, in *remote.Event) (*remote.Eve
 		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
 		return nil, errors.Wrap(err, "msgraph CreateEvent")
 	}
-	return &out, nil
+	return setConference(&out), nil
 }
@@ -0,0 +1,45 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// DeleteEvent removes an event from the user's calendar. Attendees are not
+// notified; organizers should use CancelEvent instead.
+func (c *client) DeleteEvent(remoteUserID, eventID string) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+	err := c.rbuilder.Users().ID(remoteUserID).Events().ID(eventID).Request().Delete(c.ctx)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "msgraph DeleteEvent")
+	}
+	return nil
+}
+
+// CancelEvent cancels an event organized by the user, sending the comment to
+// the attendees with the cancellation.
+func (c *client) CancelEvent(remoteUserID, eventID, comment string) error {
+	params := struct {
+		Comment string `json:"comment,omitempty"`
+	}{comment}
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+	// Graph answers actions with 202 Accepted, which only CallJSON handles
+	_, err := c.CallJSON(http.MethodPost, "/users/"+url.PathEscape(remoteUserID)+"/events/"+url.PathEscape(eventID)+"/cancel", &params, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return errors.Wrap(err, "msgraph CancelEvent")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+)
+
+// The endpoints of the public cloud, used unless the plugin configuration
+// points to a national cloud or to a stand-in for Graph.
+const (
+	defaultGraphBaseURL         = "https://graph.microsoft.com/v1.0"
+	defaultIdentityAuthorityURL = "https://login.microsoftonline.com"
+)
+
+func graphBaseURL(conf *config.Config) string {
+	if conf == nil || conf.GraphBaseURL == "" {
+		return defaultGraphBaseURL
+	}
+	return strings.TrimSuffix(conf.GraphBaseURL, "/")
+}
+
+func identityAuthorityURL(conf *config.Config) string {
+	if conf == nil || conf.IdentityAuthorityURL == "" {
+		return defaultIdentityAuthorityURL
+	}
+	return strings.TrimSuffix(conf.IdentityAuthorityURL, "/")
+}
+
+// graphScope is the scope granting the application permissions of the app
+// on the Graph instance, e.g. https://graph.microsoft.us/.default.
+func graphScope(conf *config.Config) string {
+	u, err := url.Parse(graphBaseURL(conf))
+	if err != nil || u.Host == "" {
+		return "https://graph.microsoft.com/.default"
+	}
+	return u.Scheme + "://" + u.Host + "/.default"
+}
+
+func oauth2Endpoint(conf *config.Config) oauth2.Endpoint {
+	tenant := "common"
+	if conf != nil && conf.OAuth2Authority != "" {
+		tenant = conf.OAuth2Authority
+	}
+	base := identityAuthorityURL(conf) + "/" + url.PathEscape(tenant) + "/oauth2/v2.0"
+	return oauth2.Endpoint{
+		AuthURL:  base + "/authorize",
+		TokenURL: base + "/token",
+	}
+}
+
+func checkEndpointURL(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return errors.Errorf("%s must be an absolute URL, got %q", name, value)
+	}
+	return nil
+}
+
+// baseURLTransport sends the requests made for the public Graph instance,
+// such as the ones of the request builder, to the configured instance.
+type baseURLTransport struct {
+	base    http.RoundTripper
+	baseURL string
+}
+
+func newBaseURLTransport(base http.RoundTripper, baseURL string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if baseURL == defaultGraphBaseURL {
+		return base
+	}
+	return &baseURLTransport{
+		base:    base,
+		baseURL: baseURL,
+	}
+}
+
+func (t *baseURLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u := req.URL.String()
+	if !strings.HasPrefix(u, defaultGraphBaseURL) {
+		return t.base.RoundTrip(req)
+	}
+
+	target, err := url.Parse(t.baseURL + strings.TrimPrefix(u, defaultGraphBaseURL))
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.URL = target
+	r.Host = target.Host
+	return t.base.RoundTrip(r)
+}
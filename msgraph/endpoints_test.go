@@ -0,0 +1,82 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+)
+
+func govCloudConfig() *config.Config {
+	return &config.Config{
+		StoredConfig: config.StoredConfig{
+			OAuth2Authority:      "tenant_id",
+			GraphBaseURL:         "https://graph.microsoft.us/v1.0/",
+			IdentityAuthorityURL: "https://login.microsoftonline.us",
+		},
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	conf := govCloudConfig()
+	require.Equal(t, "https://graph.microsoft.us/v1.0", graphBaseURL(conf))
+	require.Equal(t, "https://graph.microsoft.us/.default", graphScope(conf))
+	require.Equal(t, "https://login.microsoftonline.us/tenant_id/oauth2/v2.0/authorize", oauth2Endpoint(conf).AuthURL)
+	require.Equal(t, "https://login.microsoftonline.us/tenant_id/oauth2/v2.0/token", oauth2Endpoint(conf).TokenURL)
+
+	publicCloud := &config.Config{StoredConfig: config.StoredConfig{OAuth2Authority: "tenant_id"}}
+	require.Equal(t, "https://graph.microsoft.com/v1.0", graphBaseURL(publicCloud))
+	require.Equal(t, "https://graph.microsoft.com/.default", graphScope(publicCloud))
+	require.Equal(t, "https://login.microsoftonline.com/tenant_id/oauth2/v2.0/token", oauth2Endpoint(publicCloud).TokenURL)
+}
+
+func TestOAuth2ConfigScopes(t *testing.T) {
+	r := &impl{conf: &config.Config{StoredConfig: config.StoredConfig{OAuth2Authority: "tenant_id"}}}
+	scopes := r.NewOAuth2Config().Scopes
+	require.Contains(t, scopes, "MailboxSettings.ReadWrite")
+	require.Contains(t, scopes, "Group.Read.All")
+	require.Contains(t, scopes, "Calendars.ReadWrite")
+	require.Contains(t, scopes, "offline_access")
+}
+
+func TestBaseURLTransport(t *testing.T) {
+	requested := []string{}
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.String())
+		return mockResponse(http.StatusOK, nil, `{"responses": []}`), nil
+	})
+	httpClient := &http.Client{Transport: newBaseURLTransport(base, "http://localhost:8080/graph")}
+	c := &client{
+		conf:       &config.Config{StoredConfig: config.StoredConfig{GraphBaseURL: "http://localhost:8080/graph"}},
+		httpClient: httpClient,
+		rbuilder:   msgraph.NewClient(httpClient),
+	}
+
+	_, err := c.CallJSON(http.MethodGet, "/me", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.batchRequest(fullBatchRequest{}, &batchResponse{}))
+	require.NoError(t, c.rbuilder.Me().Request().JSONRequest(c.ctx, http.MethodGet, "", nil, nil))
+
+	require.Equal(t, []string{
+		"http://localhost:8080/graph/me",
+		"http://localhost:8080/graph/$batch",
+		"http://localhost:8080/graph/me",
+	}, requested)
+}
+
+func TestCheckConfigurationEndpoints(t *testing.T) {
+	r := &impl{}
+	stored := govCloudConfig().StoredConfig
+	stored.OAuth2ClientID = "client_id"
+	stored.OAuth2ClientSecret = "client_secret"
+	require.NoError(t, r.CheckConfiguration(stored))
+
+	stored.GraphBaseURL = "graph.microsoft.us"
+	require.EqualError(t, r.CheckConfiguration(stored), `Graph base URL must be an absolute URL, got "graph.microsoft.us"`)
+}
@@ -31,14 +31,37 @@ var responseStatusConversion = map[string]string{
 	MicrosoftResponseStatusOrganizer: remote.EventResponseStatusNotAnswered,
 }
 
+const teamsApplicationName = "Microsoft Teams"
+
 // converts microsoft calendar responses to our representation of fields
 func normalizeEvents(events []*remote.Event) []*remote.Event {
 	for i := range events {
-		events[i].ResponseStatus.Response = responseStatusConversion[events[i].ResponseStatus.Response]
+		if events[i].ResponseStatus != nil {
+			events[i].ResponseStatus.Response = responseStatusConversion[events[i].ResponseStatus.Response]
+		}
+		setConference(events[i])
 	}
 	return events
 }
 
+// setConference exposes the join URL of the online meeting of the event as
+// its conference, as the other providers do.
+func setConference(e *remote.Event) *remote.Event {
+	if e == nil || e.OnlineMeeting == nil || e.OnlineMeeting.JoinURL == "" {
+		return e
+	}
+
+	application := e.OnlineMeetingProvider
+	if application == remote.OnlineMeetingProviderTeams {
+		application = teamsApplicationName
+	}
+	e.Conference = &remote.Conference{
+		Application: application,
+		URL:         e.OnlineMeeting.JoinURL,
+	}
+	return e
+}
+
 func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
 	e := &remote.Event{}
 
@@ -53,7 +76,7 @@ func (c *client) GetEvent(remoteUserID, eventID string) (*remote.Event, error) {
 		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
 		return nil, errors.Wrap(err, "msgraph GetEvent")
 	}
-	return e, nil
+	return setConference(e), nil
 }
 
 func (c *client) AcceptEvent(remoteUserID, eventID string) error {
@@ -112,5 +135,11 @@ func (c *client) GetEventsBetweenDates(remoteUserID string, start, end time.Time
 		return nil, errors.Wrap(err, "msgraph GetEventsBetweenDates")
 	}
 
-	return normalizeEvents(res.Value), nil
+	more, err := getNextPages[*remote.Event](c, res.NextLink)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph GetEventsBetweenDates")
+	}
+
+	return normalizeEvents(append(res.Value, more...)), nil
 }
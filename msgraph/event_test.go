@@ -0,0 +1,36 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestNormalizeEventsSetsConference(t *testing.T) {
+	data := `[{
+		"subject": "Design review",
+		"responseStatus": {"response": "accepted"},
+		"isOnlineMeeting": true,
+		"onlineMeetingProvider": "teamsForBusiness",
+		"onlineMeeting": {"joinUrl": "https://teams.microsoft.com/l/meetup-join/1"}
+	}, {
+		"subject": "Lunch",
+		"responseStatus": {"response": "none"}
+	}]`
+	events := []*remote.Event{}
+	require.NoError(t, json.Unmarshal([]byte(data), &events))
+
+	events = normalizeEvents(events)
+	require.Equal(t, &remote.Conference{
+		Application: "Microsoft Teams",
+		URL:         "https://teams.microsoft.com/l/meetup-join/1",
+	}, events[0].Conference)
+	require.Equal(t, remote.EventResponseStatusAccepted, events[0].ResponseStatus.Response)
+	require.Nil(t, events[1].Conference)
+}
@@ -4,13 +4,22 @@
 package msgraph
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
 )
 
+// findMeetingTimesRequest overrides the meeting duration of the parameters,
+// which Graph expects as an ISO 8601 duration.
+type findMeetingTimesRequest struct {
+	*remote.FindMeetingTimesParameters
+	MeetingDuration string `json:"meetingDuration,omitempty"`
+}
+
 // FindMeetingTimes finds meeting time suggestions for a calendar event
 func (c *client) FindMeetingTimes(remoteUserID string, params *remote.FindMeetingTimesParameters) (*remote.MeetingTimeSuggestionResults, error) {
 	meetingsOut := &remote.MeetingTimeSuggestionResults{}
@@ -18,11 +27,34 @@ func (c *client) FindMeetingTimes(remoteUserID string, params *remote.FindMeetin
 		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
 		return nil, errors.New(ErrorUserInactive)
 	}
+
+	paramsIn := &findMeetingTimesRequest{FindMeetingTimesParameters: params}
+	if params.MeetingDuration != nil {
+		paramsIn.MeetingDuration = isoDuration(*params.MeetingDuration)
+	}
+
 	req := c.rbuilder.Users().ID(remoteUserID).FindMeetingTimes(nil).Request()
-	err := req.JSONRequest(c.ctx, http.MethodPost, "", &params, &meetingsOut)
+	err := req.JSONRequest(c.ctx, http.MethodPost, "", paramsIn, &meetingsOut)
 	if err != nil {
 		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
 		return nil, errors.Wrap(err, "msgraph FindMeetingTimes")
 	}
 	return meetingsOut, nil
 }
+
+// isoDuration formats d as an ISO 8601 duration in hours and minutes, e.g.
+// PT1H30M.
+func isoDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+
+	s := "PT"
+	if hours > 0 {
+		s += fmt.Sprintf("%dH", hours)
+	}
+	if minutes > 0 || hours == 0 {
+		s += fmt.Sprintf("%dM", minutes)
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestFindMeetingTimesRequest(t *testing.T) {
+	duration := 90 * time.Minute
+	maxCandidates := 5
+	params := &remote.FindMeetingTimesParameters{
+		MeetingDuration: &duration,
+		MaxCandidates:   &maxCandidates,
+	}
+
+	out, err := json.Marshal(&findMeetingTimesRequest{
+		FindMeetingTimesParameters: params,
+		MeetingDuration:            isoDuration(duration),
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"meetingDuration":"PT1H30M","maxCandidates":5}`, string(out))
+}
+
+func TestISODuration(t *testing.T) {
+	require.Equal(t, "PT30M", isoDuration(30*time.Minute))
+	require.Equal(t, "PT1H", isoDuration(time.Hour))
+	require.Equal(t, "PT2H15M", isoDuration(2*time.Hour+15*time.Minute))
+	require.Equal(t, "PT0M", isoDuration(0))
+}
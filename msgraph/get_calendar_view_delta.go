@@ -0,0 +1,183 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// maxDeltaPages bounds the number of pages followed in a single round, in case
+// the remote keeps handing out next links.
+const maxDeltaPages = 50
+
+var errNoDeltaLink = errors.New("msgraph GetCalendarViewDelta: response has neither a next link nor a delta link")
+
+type deltaRemoved struct {
+	Reason string `json:"reason"`
+}
+
+type deltaEvent struct {
+	remote.Event
+	Removed *deltaRemoved `json:"@removed,omitempty"`
+}
+
+type calendarViewDeltaResponse struct {
+	Error     *remote.APIError `json:"error,omitempty"`
+	Value     []*deltaEvent    `json:"value"`
+	NextLink  string           `json:"@odata.nextLink"`
+	DeltaLink string           `json:"@odata.deltaLink"`
+}
+
+func (c *client) GetCalendarViewDelta(remoteUserID, calendarID string, start, end time.Time, deltaLink string) (*remote.CalendarViewDelta, error) {
+	u := deltaLink
+	if u == "" {
+		u = getCalendarViewDeltaURL(remoteUserID, calendarID, start, end)
+	}
+
+	return c.followCalendarViewDelta(&remote.CalendarViewDelta{}, u, 0)
+}
+
+// followCalendarViewDelta adds the pages of changes from u on to delta, page
+// being the number of pages already read.
+func (c *client) followCalendarViewDelta(delta *remote.CalendarViewDelta, u string, page int) (*remote.CalendarViewDelta, error) {
+	for ; page < maxDeltaPages; page++ {
+		res := &calendarViewDeltaResponse{}
+		_, err := c.CallJSON(http.MethodGet, u, nil, res)
+		if err != nil {
+			if isDeltaLinkExpired(err) {
+				return nil, remote.ErrDeltaLinkExpired
+			}
+			return nil, errors.Wrap(err, "msgraph GetCalendarViewDelta")
+		}
+
+		u = addCalendarViewDeltaPage(delta, res)
+		if delta.DeltaLink != "" {
+			return delta, nil
+		}
+		if u == "" {
+			return nil, errNoDeltaLink
+		}
+	}
+
+	return nil, errors.Errorf("msgraph GetCalendarViewDelta: no delta link after %d pages", maxDeltaPages)
+}
+
+// addCalendarViewDeltaPage adds the changes of the page to delta, and returns
+// the link to the next page. delta is complete once it has its delta link.
+func addCalendarViewDeltaPage(delta *remote.CalendarViewDelta, res *calendarViewDeltaResponse) string {
+	for _, item := range res.Value {
+		if item.Removed != nil {
+			delta.RemovedIDs = append(delta.RemovedIDs, item.ID)
+			continue
+		}
+		e := item.Event
+		delta.Events = append(delta.Events, &e)
+	}
+
+	if res.DeltaLink != "" {
+		delta.Events = normalizeEvents(delta.Events)
+		delta.DeltaLink = res.DeltaLink
+	}
+	return res.NextLink
+}
+
+// DoBatchCalendarViewDeltaRequests sends the first page of each request in
+// batches, and follows the next links of the views with more changes one
+// request at a time.
+func (c *client) DoBatchCalendarViewDeltaRequests(allParams []*remote.CalendarViewDeltaParams) ([]*remote.CalendarViewDeltaResponse, error) {
+	// Links are absolute, while batches take URLs relative to the API root.
+	baseURL := graphBaseURL(c.conf)
+	requests := []*singleRequest{}
+	for i, params := range allParams {
+		u := strings.TrimPrefix(params.DeltaLink, baseURL)
+		if u == "" {
+			u = getCalendarViewDeltaURL(params.RemoteUserID, params.CalendarID, params.StartTime, params.EndTime)
+		}
+		requests = append(requests, &singleRequest{
+			ID:      strconv.Itoa(i),
+			URL:     u,
+			Method:  http.MethodGet,
+			Headers: map[string]string{},
+		})
+	}
+
+	responses, err := c.doBatchRequests(requests)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph GetCalendarViewDelta batch request")
+	}
+
+	result := make([]*remote.CalendarViewDeltaResponse, len(allParams))
+	for _, res := range responses {
+		i, err := strconv.Atoi(res.ID)
+		if err != nil || i < 0 || i >= len(allParams) {
+			continue
+		}
+		delta, err := c.calendarViewDeltaFromBatch(res)
+		result[i] = &remote.CalendarViewDeltaResponse{Delta: delta, Error: err}
+	}
+	for i := range result {
+		if result[i] == nil {
+			result[i] = &remote.CalendarViewDeltaResponse{
+				Error: errors.New("msgraph GetCalendarViewDelta batch request: no response"),
+			}
+		}
+	}
+	return result, nil
+}
+
+func (c *client) calendarViewDeltaFromBatch(res *singleResponse) (*remote.CalendarViewDelta, error) {
+	if res.Status == http.StatusGone {
+		return nil, remote.ErrDeltaLinkExpired
+	}
+
+	body := &calendarViewDeltaResponse{}
+	if err := json.Unmarshal(res.Body, body); err != nil {
+		return nil, errors.Wrap(err, "msgraph GetCalendarViewDelta: invalid response")
+	}
+	if body.Error != nil {
+		return nil, errors.Errorf("msgraph GetCalendarViewDelta: %s", body.Error.Message)
+	}
+
+	delta := &remote.CalendarViewDelta{}
+	next := addCalendarViewDeltaPage(delta, body)
+	if delta.DeltaLink != "" {
+		return delta, nil
+	}
+	if next == "" {
+		return nil, errNoDeltaLink
+	}
+	return c.followCalendarViewDelta(delta, next, 1)
+}
+
+func getCalendarViewDeltaURL(remoteUserID, calendarID string, start, end time.Time) string {
+	q := url.Values{}
+	q.Add("startDateTime", start.Format(time.RFC3339))
+	q.Add("endDateTime", end.Format(time.RFC3339))
+
+	u := "/Users/" + url.PathEscape(remoteUserID)
+	if calendarID != "" {
+		u += "/calendars/" + url.PathEscape(calendarID)
+	}
+	return u + "/calendarView/delta?" + q.Encode()
+}
+
+// isDeltaLinkExpired tells whether the remote asks for a new full
+// synchronization, which it does with a 410 Gone once a delta link expired.
+func isDeltaLinkExpired(err error) bool {
+	var errResp *msgraph.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+	return errResp.Response.StatusCode == http.StatusGone
+}
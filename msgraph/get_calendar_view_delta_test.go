@@ -0,0 +1,124 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func TestGetCalendarViewDeltaURL(t *testing.T) {
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+
+	require.Equal(t,
+		"/Users/remote_user_id/calendarView/delta?endDateTime=2024-05-08T00%3A00%3A00Z&startDateTime=2024-05-06T00%3A00%3A00Z",
+		getCalendarViewDeltaURL("remote_user_id", "", start, end))
+	require.Equal(t,
+		"/Users/remote_user_id/calendars/cal%2F1/calendarView/delta?endDateTime=2024-05-08T00%3A00%3A00Z&startDateTime=2024-05-06T00%3A00%3A00Z",
+		getCalendarViewDeltaURL("remote_user_id", "cal/1", start, end))
+}
+
+func TestGetCalendarViewDelta(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("token") {
+		case "first":
+			fmt.Fprintf(w, `{
+				"value": [{"id": "event_1", "subject": "Standup", "responseStatus": {"response": "accepted"}}],
+				"@odata.nextLink": "%s/delta?token=second"
+			}`, srv.URL)
+		case "second":
+			fmt.Fprintf(w, `{
+				"value": [
+					{"id": "event_2", "subject": "Review"},
+					{"id": "event_3", "@removed": {"reason": "deleted"}}
+				],
+				"@odata.deltaLink": "%s/delta?token=next"
+			}`, srv.URL)
+		default:
+			w.WriteHeader(http.StatusGone)
+			fmt.Fprint(w, `{"error": {"code": "SyncStateNotFound", "message": "The sync state is no longer valid."}}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client()}
+
+	delta, err := c.GetCalendarViewDelta("remote_user_id", "", time.Time{}, time.Time{}, srv.URL+"/delta?token=first")
+	require.NoError(t, err)
+	require.Len(t, delta.Events, 2)
+	require.Equal(t, "event_1", delta.Events[0].ID)
+	require.Equal(t, remote.EventResponseStatusAccepted, delta.Events[0].ResponseStatus.Response)
+	require.Equal(t, "event_2", delta.Events[1].ID)
+	require.Equal(t, []string{"event_3"}, delta.RemovedIDs)
+	require.Equal(t, srv.URL+"/delta?token=next", delta.DeltaLink)
+
+	_, err = c.GetCalendarViewDelta("remote_user_id", "", time.Time{}, time.Time{}, srv.URL+"/delta?token=expired")
+	require.ErrorIs(t, err, remote.ErrDeltaLinkExpired)
+}
+
+func TestDoBatchCalendarViewDeltaRequests(t *testing.T) {
+	var batched fullBatchRequest
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodPost {
+			return mockResponse(http.StatusOK, nil, `{"value": [{"id": "event_2"}], "@odata.deltaLink": "https://graph.microsoft.com/v1.0/delta?token=2"}`), nil
+		}
+		if err := json.NewDecoder(req.Body).Decode(&batched); err != nil {
+			return nil, err
+		}
+		return mockResponse(http.StatusOK, nil, `{"responses": [
+			{"id": "0", "status": 200, "body": {
+				"value": [{"id": "event_1"}, {"id": "event_0", "@removed": {"reason": "deleted"}}],
+				"@odata.deltaLink": "https://graph.microsoft.com/v1.0/delta?token=1"
+			}},
+			{"id": "1", "status": 410, "body": {"error": {"code": "SyncStateNotFound", "message": "The sync state is no longer valid."}}},
+			{"id": "2", "status": 200, "body": {
+				"value": [{"id": "event_1"}],
+				"@odata.nextLink": "https://graph.microsoft.com/v1.0/next?page=2"
+			}},
+			{"id": "3", "status": 403, "body": {"error": {"code": "ErrorAccessDenied", "message": "Access is denied."}}}
+		]}`), nil
+	})
+	c := &client{httpClient: &http.Client{Transport: transport}, Logger: &bot.NilLogger{}}
+
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	responses, err := c.DoBatchCalendarViewDeltaRequests([]*remote.CalendarViewDeltaParams{
+		{RemoteUserID: "user_0", DeltaLink: "https://graph.microsoft.com/v1.0/Users/user_0/calendarView/delta?$deltatoken=0"},
+		{RemoteUserID: "user_1", DeltaLink: "https://graph.microsoft.com/v1.0/Users/user_1/calendarView/delta?$deltatoken=1"},
+		{RemoteUserID: "user_2", StartTime: start, EndTime: start.Add(48 * time.Hour)},
+		{RemoteUserID: "user_3", StartTime: start, EndTime: start.Add(48 * time.Hour)},
+		{RemoteUserID: "user_4", StartTime: start, EndTime: start.Add(48 * time.Hour)},
+	})
+	require.NoError(t, err)
+
+	// Links are sent relative to the API root.
+	require.Len(t, batched.Requests, 5)
+	require.Equal(t, "/Users/user_0/calendarView/delta?$deltatoken=0", batched.Requests[0].URL)
+	require.Equal(t, getCalendarViewDeltaURL("user_2", "", start, start.Add(48*time.Hour)), batched.Requests[2].URL)
+
+	require.Len(t, responses, 5)
+	require.NoError(t, responses[0].Error)
+	require.Equal(t, "event_1", responses[0].Delta.Events[0].ID)
+	require.Equal(t, []string{"event_0"}, responses[0].Delta.RemovedIDs)
+	require.Equal(t, "https://graph.microsoft.com/v1.0/delta?token=1", responses[0].Delta.DeltaLink)
+
+	require.ErrorIs(t, responses[1].Error, remote.ErrDeltaLinkExpired)
+
+	require.NoError(t, responses[2].Error)
+	require.Len(t, responses[2].Delta.Events, 2)
+	require.Equal(t, "https://graph.microsoft.com/v1.0/delta?token=2", responses[2].Delta.DeltaLink)
+
+	require.ErrorContains(t, responses[3].Error, "Access is denied.")
+	require.ErrorContains(t, responses[4].Error, "no response")
+}
@@ -4,8 +4,10 @@
 package msgraph
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,31 +16,50 @@ import (
 )
 
 type calendarViewResponse struct {
-	Error *remote.APIError `json:"error,omitempty"`
-	Value []*remote.Event  `json:"value,omitempty"`
+	Error    *remote.APIError `json:"error,omitempty"`
+	Value    []*remote.Event  `json:"value,omitempty"`
+	NextLink string           `json:"@odata.nextLink,omitempty"`
 }
 
-type calendarViewSingleResponse struct {
-	Headers map[string]string    `json:"headers"`
-	ID      string               `json:"id"`
-	Body    calendarViewResponse `json:"body"`
-	Status  int                  `json:"status"`
+func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
+	return c.GetEventsBetweenDates(remoteUserID, start, end)
 }
 
-type calendarViewBatchResponse struct {
-	Responses []*calendarViewSingleResponse `json:"responses"`
-}
+func (c *client) GetCalendarView(remoteUserID, calendarID string, start, end time.Time) ([]*remote.Event, error) {
+	if calendarID == "" {
+		return c.GetEventsBetweenDates(remoteUserID, start, end)
+	}
 
-func (c *client) GetDefaultCalendarView(remoteUserID string, start, end time.Time) ([]*remote.Event, error) {
-	return c.GetEventsBetweenDates(remoteUserID, start, end)
+	paramStr := getQueryParamStringForCalendarView(start, end)
+	res := &calendarViewResponse{}
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+	err := c.rbuilder.Users().ID(remoteUserID).Calendars().ID(calendarID).CalendarView().Request().JSONRequest(
+		c.ctx, http.MethodGet, paramStr, nil, res)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph GetCalendarView")
+	}
+
+	more, err := getNextPages[*remote.Event](c, res.NextLink)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph GetCalendarView")
+	}
+
+	return normalizeEvents(append(res.Value, more...)), nil
 }
 
 func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarParams) ([]*remote.ViewCalendarResponse, error) {
+	// Requests are identified by their index, since a user may have several
+	// calendars in the same batch.
 	requests := []*singleRequest{}
-	for _, params := range allParams {
+	for i, params := range allParams {
 		u := getCalendarViewURL(params)
 		req := &singleRequest{
-			ID:      params.RemoteUserID,
+			ID:      strconv.Itoa(i),
 			URL:     u,
 			Method:  http.MethodGet,
 			Headers: map[string]string{},
@@ -46,28 +67,39 @@ func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarPar
 		requests = append(requests, req)
 	}
 
-	batchRequests := prepareBatchRequests(requests)
-	var batchResponses []*calendarViewBatchResponse
-	for _, req := range batchRequests {
-		batchRes := &calendarViewBatchResponse{}
-		err := c.batchRequest(req, batchRes)
-		if err != nil {
-			return nil, errors.Wrap(err, "msgraph ViewCalendar batch request")
-		}
-
-		batchResponses = append(batchResponses, batchRes)
+	responses, err := c.doBatchRequests(requests)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph ViewCalendar batch request")
 	}
 
 	result := []*remote.ViewCalendarResponse{}
-	for _, batchRes := range batchResponses {
-		for _, res := range batchRes.Responses {
-			viewCalRes := &remote.ViewCalendarResponse{
-				RemoteUserID: res.ID,
-				Events:       normalizeEvents(res.Body.Value),
-				Error:        res.Body.Error,
+	for _, res := range responses {
+		i, err := strconv.Atoi(res.ID)
+		if err != nil || i < 0 || i >= len(allParams) {
+			continue
+		}
+		body := calendarViewResponse{}
+		err = json.Unmarshal(res.Body, &body)
+		if err != nil {
+			body.Error = &remote.APIError{Message: "invalid response: " + err.Error()}
+		}
+		if body.Error == nil && body.NextLink != "" {
+			more, err := getNextPages[*remote.Event](c, body.NextLink)
+			if err != nil {
+				// Report the calendar as failed rather than return part of it.
+				body.Error = &remote.APIError{Message: err.Error()}
+				body.Value = nil
+			} else {
+				body.Value = append(body.Value, more...)
 			}
-			result = append(result, viewCalRes)
 		}
+		viewCalRes := &remote.ViewCalendarResponse{
+			RemoteUserID: allParams[i].RemoteUserID,
+			CalendarID:   allParams[i].CalendarID,
+			Events:       normalizeEvents(body.Value),
+			Error:        body.Error,
+		}
+		result = append(result, viewCalRes)
 	}
 
 	return result, nil
@@ -75,7 +107,11 @@ func (c *client) DoBatchViewCalendarRequests(allParams []*remote.ViewCalendarPar
 
 func getCalendarViewURL(params *remote.ViewCalendarParams) string {
 	paramStr := getQueryParamStringForCalendarView(params.StartTime, params.EndTime)
-	return "/Users/" + url.PathEscape(params.RemoteUserID) + "/calendarView" + paramStr
+	u := "/Users/" + url.PathEscape(params.RemoteUserID)
+	if params.CalendarID != "" {
+		u += "/calendars/" + url.PathEscape(params.CalendarID)
+	}
+	return u + "/calendarView" + paramStr
 }
 
 func getQueryParamStringForCalendarView(start, end time.Time) string {
@@ -0,0 +1,85 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// groupTypeUnified is the type of Microsoft 365 groups, the only groups with
+// a calendar.
+const groupTypeUnified = "Unified"
+
+type group struct {
+	ID          string   `json:"id"`
+	DisplayName string   `json:"displayName"`
+	GroupTypes  []string `json:"groupTypes"`
+}
+
+// GetGroups returns the Microsoft 365 groups the user is a member of.
+func (c *client) GetGroups(remoteUserID string) ([]*remote.Group, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	// Group types can't be filtered on without an advanced query, so other
+	// groups are skipped here.
+	u := "/users/" + url.PathEscape(remoteUserID) + "/memberOf/microsoft.graph.group?$select=id,displayName,groupTypes"
+	res := page[*group]{}
+	_, err := c.CallJSON(http.MethodGet, u, nil, &res)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph GetGroups")
+	}
+	more, err := getNextPages[*group](c, res.NextLink)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph GetGroups")
+	}
+
+	groups := []*remote.Group{}
+	for _, g := range append(res.Value, more...) {
+		if slices.Contains(g.GroupTypes, groupTypeUnified) {
+			groups = append(groups, &remote.Group{ID: g.ID, DisplayName: g.DisplayName})
+		}
+	}
+	c.Logger.With(bot.LogContext{
+		"UserID": remoteUserID,
+	}).Debugf("msgraph: GetGroups returned `%d` groups.", len(groups))
+	return groups, nil
+}
+
+// GetGroupCalendarView returns the events of the calendar of the group between
+// start and end.
+func (c *client) GetGroupCalendarView(groupID string, start, end time.Time) ([]*remote.Event, error) {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return nil, errors.New(ErrorUserInactive)
+	}
+
+	u := "/groups/" + url.PathEscape(groupID) + "/calendar/calendarView" + getQueryParamStringForCalendarView(start, end)
+	res := &calendarViewResponse{}
+	_, err := c.CallJSON(http.MethodGet, u, nil, res)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph GetGroupCalendarView")
+	}
+
+	more, err := getNextPages[*remote.Event](c, res.NextLink)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph GetGroupCalendarView")
+	}
+
+	return normalizeEvents(append(res.Value, more...)), nil
+}
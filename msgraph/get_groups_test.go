@@ -0,0 +1,56 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestGetGroups(t *testing.T) {
+	var paths []string
+	c := newTestClient(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path)
+		if req.URL.Query().Get("$skiptoken") == "" {
+			return mockResponse(http.StatusOK, nil, `{
+				"value": [
+					{"id": "team_id", "displayName": "Team", "groupTypes": ["Unified"]},
+					{"id": "security_id", "displayName": "Security group", "groupTypes": []}
+				],
+				"@odata.nextLink": "https://graph.microsoft.com/v1.0/users/user_id/memberOf/microsoft.graph.group?$skiptoken=next"
+			}`), nil
+		}
+		return mockResponse(http.StatusOK, nil, `{
+			"value": [{"id": "project_id", "displayName": "Project", "groupTypes": ["Unified", "DynamicMembership"]}]
+		}`), nil
+	}))
+
+	groups, err := c.GetGroups("user_id")
+	require.NoError(t, err)
+	require.Equal(t, []*remote.Group{
+		{ID: "team_id", DisplayName: "Team"},
+		{ID: "project_id", DisplayName: "Project"},
+	}, groups)
+	require.Equal(t, "/v1.0/users/user_id/memberOf/microsoft.graph.group", paths[0])
+}
+
+func TestGetGroupCalendarView(t *testing.T) {
+	var path string
+	c := newTestClient(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		path = req.URL.Path
+		return mockResponse(http.StatusOK, nil, `{"value": [{"id": "event_id", "subject": "Team sync"}]}`), nil
+	}))
+
+	start := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	events, err := c.GetGroupCalendarView("group_id", start, start.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "Team sync", events[0].Subject)
+	require.Equal(t, "/v1.0/groups/group_id/calendar/calendarView", path)
+}
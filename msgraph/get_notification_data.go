@@ -5,6 +5,7 @@ package msgraph
 
 import (
 	"net/http"
+	"path"
 
 	"github.com/pkg/errors"
 
@@ -21,6 +22,19 @@ func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notific
 	}
 	switch wh.ResourceData.DataType {
 	case "#Microsoft.Graph.Event":
+		// Deleted events can't be fetched anymore, the handler is to look
+		// them up by ID.
+		if wh.ChangeType == remote.ChangeTypeDeleted {
+			id := wh.ResourceData.ID
+			if id == "" {
+				id = path.Base(wh.Resource)
+			}
+			n.Event = &remote.Event{ID: id}
+			n.ChangeType = wh.ChangeType
+			n.IsBare = false
+			break
+		}
+
 		event := remote.Event{}
 		_, err := c.CallJSON(http.MethodGet, wh.Resource, nil, &event)
 		if err != nil {
@@ -31,7 +45,7 @@ func (c *client) GetNotificationData(orig *remote.Notification) (*remote.Notific
 			}).Infof("msgraph: failed to fetch notification data resource: `%v`.", err)
 			return nil, errors.Wrap(err, "msgraph GetNotificationData")
 		}
-		n.Event = &event
+		n.Event = setConference(&event)
 		n.ChangeType = wh.ChangeType
 		n.IsBare = false
 
@@ -4,8 +4,10 @@
 package msgraph
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -17,17 +19,6 @@ type getScheduleResponse struct {
 	Value []*remote.ScheduleInformation `json:"value,omitempty"`
 }
 
-type getScheduleSingleResponse struct {
-	Headers map[string]string   `json:"headers"`
-	ID      string              `json:"id"`
-	Body    getScheduleResponse `json:"body"`
-	Status  int                 `json:"status"`
-}
-
-type getScheduleBatchResponse struct {
-	Responses []*getScheduleSingleResponse `json:"responses"`
-}
-
 type getScheduleRequestParams struct {
 	// Overall start and end of entire search window
 	StartTime *remote.DateTime `json:"startTime"`
@@ -53,31 +44,29 @@ func (c *client) GetSchedule(requests []*remote.ScheduleUserInfo, startTime, end
 	}
 
 	allRequests := []*singleRequest{}
-	for _, req := range requests {
-		allRequests = append(allRequests, makeSingleRequestForGetSchedule(req, params))
+	for i, req := range requests {
+		singleReq := makeSingleRequestForGetSchedule(req, params)
+		// Several schedules may be looked up by the same user.
+		singleReq.ID = strconv.Itoa(i)
+		allRequests = append(allRequests, singleReq)
 	}
-	batchRequests := prepareBatchRequests(allRequests)
-
-	var batchResponses []*getScheduleBatchResponse
-
-	for _, req := range batchRequests {
-		res := &getScheduleBatchResponse{}
-		err := c.batchRequest(req, res)
-		if err != nil {
-			return nil, errors.Wrap(err, "msgraph batch GetSchedule")
-		}
-
-		batchResponses = append(batchResponses, res)
+	responses, err := c.doBatchRequests(allRequests)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgraph batch GetSchedule")
 	}
 
 	result := []*remote.ScheduleInformation{}
-	for _, batchRes := range batchResponses {
-		for _, r := range batchRes.Responses {
-			if r.Body.Error == nil {
-				result = append(result, r.Body.Value...)
-			} else {
-				c.Warnf("Failed to process schedule. err=%s", r.Body.Error.Message)
-			}
+	for _, r := range responses {
+		body := getScheduleResponse{}
+		err = json.Unmarshal(r.Body, &body)
+		if err != nil {
+			c.Warnf("Failed to process schedule. err=%v", err)
+			continue
+		}
+		if body.Error == nil {
+			result = append(result, body.Value...)
+		} else {
+			c.Warnf("Failed to process schedule. err=%s", body.Error.Message)
 		}
 	}
 
@@ -23,8 +23,10 @@ type webhook struct {
 	Resource                       string `json:"resource,omitempty"`
 	SubscriptionExpirationDateTime string `json:"subscriptionExpirationDateTime,omitempty"`
 	SubscriptionID                 string `json:"subscriptionId"`
+	LifecycleEvent                 string `json:"lifecycleEvent,omitempty"`
 	ResourceData                   struct {
 		DataType string `json:"@odata.type"`
+		ID       string `json:"id"`
 	} `json:"resourceData"`
 }
 
@@ -76,6 +78,15 @@ func (r *impl) HandleWebhook(w http.ResponseWriter, req *http.Request) []*remote
 			Webhook:        wh,
 		}
 
+		// Lifecycle notifications are about the subscription itself, and
+		// carry no resource to fetch.
+		if wh.LifecycleEvent != "" {
+			n.LifecycleEvent = wh.LifecycleEvent
+			n.IsBare = false
+			notifications = append(notifications, n)
+			continue
+		}
+
 		expires, err := time.Parse(time.RFC3339, wh.SubscriptionExpirationDateTime)
 		if err != nil {
 			r.logger.With(bot.LogContext{
@@ -0,0 +1,49 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+func TestHandleWebhookLifecycleNotifications(t *testing.T) {
+	expires := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+	body := `{"value": [{
+		"subscriptionId": "subscription_1",
+		"subscriptionExpirationDateTime": "` + expires + `",
+		"clientState": "client_state",
+		"lifecycleEvent": "subscriptionRemoved"
+	}, {
+		"subscriptionId": "subscription_2",
+		"subscriptionExpirationDateTime": "` + expires + `",
+		"clientState": "client_state",
+		"changeType": "updated",
+		"resource": "Users/remote_user_id/Events/event_id"
+	}]}`
+
+	r := &impl{logger: &bot.NilLogger{}}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/notification/v1/lifecycle", strings.NewReader(body))
+
+	notifications := r.HandleWebhook(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Len(t, notifications, 2)
+
+	require.Equal(t, "subscription_1", notifications[0].SubscriptionID)
+	require.Equal(t, remote.LifecycleEventSubscriptionRemoved, notifications[0].LifecycleEvent)
+	require.False(t, notifications[0].IsBare)
+
+	require.Equal(t, "subscription_2", notifications[1].SubscriptionID)
+	require.Empty(t, notifications[1].LifecycleEvent)
+	require.True(t, notifications[1].IsBare)
+}
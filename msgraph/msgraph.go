@@ -29,6 +29,8 @@ func GetMSCalendarProviderConfig() config.ProviderConfig {
 		Features: config.ProviderFeatures{
 			EncryptedStore:     false,
 			EventNotifications: true,
+			OnlineMeetings:     true,
+			AutomaticReplies:   true,
 		},
 	}
 }
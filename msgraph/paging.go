@@ -0,0 +1,56 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// defaultMaxResultPages bounds the number of pages read for a single
+// collection, unless the plugin configuration sets another bound.
+const defaultMaxResultPages = 25
+
+// page is a page of a collection, with the link to the next page when the
+// collection has more values.
+type page[T any] struct {
+	Value    []T    `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
+func (c *client) maxResultPages() int {
+	if c.conf != nil && c.conf.MaxResultPages > 0 {
+		return c.conf.MaxResultPages
+	}
+	return defaultMaxResultPages
+}
+
+// getNextPages reads the pages of a collection that follow the first one,
+// starting from its next link, and returns their values. Reading stops at the
+// configured number of pages, the first one included, so that a huge
+// collection can't hold a request forever.
+func getNextPages[T any](c *client, nextLink string) ([]T, error) {
+	values := []T{}
+	maxPages := c.maxResultPages()
+	for pages := 1; nextLink != ""; pages++ {
+		if pages >= maxPages {
+			if c.Logger != nil {
+				c.Logger.With(bot.LogContext{
+					"max_pages": maxPages,
+				}).Warnf("msgraph: collection truncated after reading the maximum number of pages.")
+			}
+			break
+		}
+
+		p := page[T]{}
+		_, err := c.CallJSON(http.MethodGet, nextLink, nil, &p)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, p.Value...)
+		nextLink = p.NextLink
+	}
+	return values, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// pagesTransport serves the pages of a collection of events, each page linking
+// to the next one until the last page.
+func pagesTransport(lastPage int, requested *[]int) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		n, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		*requested = append(*requested, n)
+		next := ""
+		if n < lastPage {
+			next = fmt.Sprintf(`, "@odata.nextLink": "https://graph.microsoft.com/v1.0/next?page=%d"`, n+1)
+		}
+		return mockResponse(http.StatusOK, nil, fmt.Sprintf(`{"value": [{"id": "event_%d"}]%s}`, n, next)), nil
+	}
+}
+
+func TestGetNextPages(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxResultPages int
+		lastPage       int
+		requested      []int
+		events         int
+	}{
+		{name: "Read every page", lastPage: 4, requested: []int{2, 3, 4}, events: 3},
+		{name: "No next page", lastPage: 1, requested: []int{}, events: 0},
+		{name: "Stop at the configured bound", maxResultPages: 3, lastPage: 10, requested: []int{2, 3}, events: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested := []int{}
+			c := &client{
+				httpClient: &http.Client{Transport: pagesTransport(tt.lastPage, &requested)},
+				conf:       &config.Config{StoredConfig: config.StoredConfig{MaxResultPages: tt.maxResultPages}},
+				Logger:     &bot.NilLogger{},
+			}
+
+			nextLink := ""
+			if tt.lastPage > 1 {
+				nextLink = "https://graph.microsoft.com/v1.0/next?page=2"
+			}
+			events, err := getNextPages[*remote.Event](c, nextLink)
+			require.NoError(t, err)
+			require.Len(t, events, tt.events)
+			require.Equal(t, tt.requested, requested)
+		})
+	}
+}
+
+func TestDoBatchViewCalendarRequestsFollowsNextLinks(t *testing.T) {
+	requested := []int{}
+	pages := pagesTransport(3, &requested)
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPost {
+			return mockResponse(http.StatusOK, nil, `{"responses": [{"id": "0", "status": 200, "body": {
+				"value": [{"id": "event_1"}],
+				"@odata.nextLink": "https://graph.microsoft.com/v1.0/next?page=2"
+			}}]}`), nil
+		}
+		return pages(req)
+	})
+	c := &client{httpClient: &http.Client{Transport: transport}, Logger: &bot.NilLogger{}}
+
+	responses, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{{RemoteUserID: "user_0"}})
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	require.Nil(t, responses[0].Error)
+	require.Len(t, responses[0].Events, 3)
+	require.Equal(t, "event_3", responses[0].Events[2].ID)
+	require.Equal(t, []int{2, 3}, requested)
+}
+
+func TestDoBatchViewCalendarRequestsFailedNextPage(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPost {
+			return mockResponse(http.StatusOK, nil, `{"responses": [{"id": "0", "status": 200, "body": {
+				"value": [{"id": "event_1"}],
+				"@odata.nextLink": "https://graph.microsoft.com/v1.0/next?page=2"
+			}}]}`), nil
+		}
+		return mockResponse(http.StatusBadRequest, nil, `{"error": {"code": "BadRequest", "message": "Invalid page"}}`), nil
+	})
+	c := &client{httpClient: &http.Client{Transport: transport}, Logger: &bot.NilLogger{}}
+
+	responses, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{{RemoteUserID: "user_0"}})
+	require.NoError(t, err)
+	require.Len(t, responses, 1)
+	require.NotNil(t, responses[0].Error)
+	require.Empty(t, responses[0].Events)
+}
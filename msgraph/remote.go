@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/microsoft"
 
 	msgraph "github.com/yaegashi/msgraph.go/v1.0"
 
@@ -24,6 +23,9 @@ const Kind = "mscalendar"
 type impl struct {
 	conf   *config.Config
 	logger bot.Logger
+
+	// throttled counts the requests throttled by the remote
+	throttled int64
 }
 
 func init() {
@@ -40,6 +42,7 @@ func NewRemote(conf *config.Config, logger bot.Logger) remote.Remote {
 // MakeClient creates a new client for user-delegated permissions.
 func (r *impl) makeClient(ctx context.Context, token *oauth2.Token, mattermostUserID string, poster bot.Poster, userTokenHelpers remote.UserTokenHelpers) remote.Client {
 	httpClient := r.NewOAuth2Config().Client(ctx, token)
+	httpClient.Transport = newRetryTransport(newBaseURLTransport(httpClient.Transport, graphBaseURL(r.conf)), r.logger, &r.throttled)
 	c := &client{
 		conf:             r.conf,
 		ctx:              ctx,
@@ -49,6 +52,7 @@ func (r *impl) makeClient(ctx context.Context, token *oauth2.Token, mattermostUs
 		tokenHelpers:     userTokenHelpers,
 		mattermostUserID: mattermostUserID,
 		Poster:           poster,
+		throttled:        &r.throttled,
 	}
 
 	return c
@@ -101,9 +105,10 @@ func (r *impl) NewOAuth2Config() *oauth2.Config {
 			"User.Read",
 			"Calendars.ReadWrite",
 			"Calendars.ReadWrite.Shared",
-			"MailboxSettings.Read",
+			"MailboxSettings.ReadWrite",
+			"Group.Read.All",
 		},
-		Endpoint: microsoft.AzureADEndpoint(r.conf.OAuth2Authority),
+		Endpoint: oauth2Endpoint(r.conf),
 	}
 }
 
@@ -111,6 +116,12 @@ func (r *impl) CheckConfiguration(cfg config.StoredConfig) error {
 	if cfg.OAuth2ClientID == "" || cfg.OAuth2ClientSecret == "" || cfg.OAuth2Authority == "" {
 		return fmt.Errorf("OAuth2 credentials to be set in the config")
 	}
+	if err := checkEndpointURL("Graph base URL", cfg.GraphBaseURL); err != nil {
+		return err
+	}
+	if err := checkEndpointURL("Identity authority URL", cfg.IdentityAuthorityURL); err != nil {
+		return err
+	}
 
 	return nil
 }
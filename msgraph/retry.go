@@ -0,0 +1,189 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+// Throttled and transiently failing requests are sent again up to maxRetries
+// times. The delay comes from the Retry-After header of the response when
+// there is one, and doubles from retryBaseDelay otherwise. Requests are not
+// retried when the remote asks to wait longer than maxRetryDelay, so that a
+// sync job never stalls on a single user.
+const (
+	maxRetries     = 3
+	retryBaseDelay = 1 * time.Second
+	maxRetryDelay  = 30 * time.Second
+)
+
+// sleep waits for d, or until ctx is done. Tests replace it to run without
+// waiting.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryTransport sends requests again when the remote throttles them or fails
+// transiently. It sits under every call of the client, whether made with the
+// request builder or with CallJSON.
+type retryTransport struct {
+	base      http.RoundTripper
+	logger    bot.Logger
+	throttled *int64
+}
+
+func newRetryTransport(base http.RoundTripper, logger bot.Logger, throttled *int64) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{
+		base:      base,
+		logger:    logger,
+		throttled: throttled,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	idempotent := isIdempotent(req.Method)
+
+	r := req
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(r)
+		if attempt >= maxRetries || !canReplay {
+			return resp, err
+		}
+
+		var delay time.Duration
+		var retry bool
+		if err != nil {
+			// The remote may have processed the request, so only requests
+			// that can be repeated safely are sent again.
+			delay, retry = backoff(attempt), idempotent && req.Context().Err() == nil
+		} else {
+			delay, retry = retryDelay(resp.StatusCode, resp.Header.Get("Retry-After"), attempt, idempotent)
+		}
+		if !retry {
+			return resp, err
+		}
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		logRetry(t.logger, t.throttled, req.URL.Path, status, attempt, delay, err)
+
+		if sleepErr := sleep(req.Context(), delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+
+		r = req.Clone(req.Context())
+		if req.GetBody != nil {
+			r.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retryDelay tells whether a response with the status should be retried, and
+// how long to wait before doing so. Throttled requests are not processed by
+// the remote, so they are retried whatever their method, as are unavailable
+// responses that say when to come back.
+func retryDelay(status int, retryAfter string, attempt int, idempotent bool) (time.Duration, bool) {
+	switch status {
+	case http.StatusTooManyRequests:
+	case http.StatusServiceUnavailable:
+		if !idempotent && retryAfter == "" {
+			return 0, false
+		}
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		if !idempotent {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	delay, ok := parseRetryAfter(retryAfter)
+	if !ok {
+		delay = backoff(attempt)
+	}
+	if delay > maxRetryDelay {
+		return 0, false
+	}
+	return delay, true
+}
+
+// parseRetryAfter reads a Retry-After header, given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func backoff(attempt int) time.Duration {
+	return retryBaseDelay << attempt
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// logRetry reports the retry, along with the number of throttled requests
+// since the plugin started.
+func logRetry(logger bot.Logger, throttled *int64, path string, status, attempt int, delay time.Duration, err error) {
+	if logger == nil {
+		return
+	}
+
+	logContext := bot.LogContext{
+		"path":    path,
+		"status":  status,
+		"attempt": attempt + 1,
+		"delay":   delay.String(),
+	}
+	if err != nil {
+		logContext["err"] = err.Error()
+	}
+	if status == http.StatusTooManyRequests && throttled != nil {
+		logContext["throttled_total"] = atomic.AddInt64(throttled, 1)
+		logger.With(logContext).Warnf("msgraph: request throttled, retrying.")
+		return
+	}
+	logger.With(logContext).Infof("msgraph: request failed, retrying.")
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func mockResponse(status int, headers map[string]string, body string) *http.Response {
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func recordSleeps(t *testing.T) *[]time.Duration {
+	delays := []time.Duration{}
+	orig := sleep
+	sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	t.Cleanup(func() { sleep = orig })
+	return &delays
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		attempt    int
+		idempotent bool
+		delay      time.Duration
+		retry      bool
+	}{
+		{name: "Success", status: http.StatusOK, idempotent: true},
+		{name: "Not found", status: http.StatusNotFound, idempotent: true},
+		{name: "Throttled with Retry-After", status: http.StatusTooManyRequests, retryAfter: "7", delay: 7 * time.Second, retry: true},
+		{name: "Throttled without Retry-After", status: http.StatusTooManyRequests, attempt: 2, delay: 4 * time.Second, retry: true},
+		{name: "Throttled for too long", status: http.StatusTooManyRequests, retryAfter: "120"},
+		{name: "Unavailable read", status: http.StatusServiceUnavailable, idempotent: true, delay: time.Second, retry: true},
+		{name: "Unavailable write", status: http.StatusServiceUnavailable},
+		{name: "Unavailable write with Retry-After", status: http.StatusServiceUnavailable, retryAfter: "2", delay: 2 * time.Second, retry: true},
+		{name: "Gateway timeout read", status: http.StatusGatewayTimeout, idempotent: true, attempt: 1, delay: 2 * time.Second, retry: true},
+		{name: "Gateway timeout write", status: http.StatusGatewayTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := retryDelay(tt.status, tt.retryAfter, tt.attempt, tt.idempotent)
+			require.Equal(t, tt.retry, retry)
+			require.Equal(t, tt.delay, delay)
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	delay, ok := parseRetryAfter("3")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.Equal(t, time.Duration(0), delay)
+
+	_, ok = parseRetryAfter("")
+	require.False(t, ok)
+
+	_, ok = parseRetryAfter("soon")
+	require.False(t, ok)
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		responses []*http.Response
+		status    int
+		calls     int
+		delays    []time.Duration
+		throttled int64
+	}{
+		{
+			name:   "Retry throttled request",
+			method: http.MethodPost,
+			responses: []*http.Response{
+				mockResponse(http.StatusTooManyRequests, map[string]string{"Retry-After": "5"}, ""),
+				mockResponse(http.StatusOK, nil, "{}"),
+			},
+			status:    http.StatusOK,
+			calls:     2,
+			delays:    []time.Duration{5 * time.Second},
+			throttled: 1,
+		},
+		{
+			name:   "Give up after the last retry",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				mockResponse(http.StatusServiceUnavailable, nil, ""),
+				mockResponse(http.StatusServiceUnavailable, nil, ""),
+				mockResponse(http.StatusServiceUnavailable, nil, ""),
+				mockResponse(http.StatusServiceUnavailable, nil, ""),
+			},
+			status: http.StatusServiceUnavailable,
+			calls:  4,
+			delays: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			name:   "Do not retry unavailable write",
+			method: http.MethodPost,
+			responses: []*http.Response{
+				mockResponse(http.StatusServiceUnavailable, nil, ""),
+			},
+			status: http.StatusServiceUnavailable,
+			calls:  1,
+			delays: []time.Duration{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delays := recordSleeps(t)
+			calls := 0
+			bodies := []string{}
+			base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Body != nil {
+					b, _ := io.ReadAll(req.Body)
+					bodies = append(bodies, string(b))
+				}
+				resp := tt.responses[calls]
+				calls++
+				return resp, nil
+			})
+			var throttled int64
+			c := &http.Client{Transport: newRetryTransport(base, &bot.NilLogger{}, &throttled)}
+
+			req, err := http.NewRequest(tt.method, "https://graph.microsoft.com/v1.0/me", strings.NewReader(`{"a":1}`))
+			require.NoError(t, err)
+			resp, err := c.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			require.Equal(t, tt.status, resp.StatusCode)
+			require.Equal(t, tt.calls, calls)
+			require.Equal(t, tt.delays, *delays)
+			require.Equal(t, tt.throttled, throttled)
+			for _, b := range bodies {
+				require.Equal(t, `{"a":1}`, b)
+			}
+		})
+	}
+}
+
+func TestDoBatchViewCalendarRequestsRetriesFailedResponses(t *testing.T) {
+	delays := recordSleeps(t)
+
+	batches := [][]string{}
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		batch := fullBatchRequest{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&batch))
+		ids := []string{}
+		responses := []string{}
+		for _, r := range batch.Requests {
+			ids = append(ids, r.ID)
+			if r.ID == "1" && len(batches) == 0 {
+				responses = append(responses, `{"id": "1", "status": 429, "headers": {"retry-after": "3"}, "body": {"error": {"code": "TooManyRequests"}}}`)
+				continue
+			}
+			responses = append(responses, fmt.Sprintf(`{"id": "%s", "status": 200, "body": {"value": [{"id": "event_%s"}]}}`, r.ID, r.ID))
+		}
+		batches = append(batches, ids)
+		return mockResponse(http.StatusOK, nil, `{"responses": [`+strings.Join(responses, ",")+`]}`), nil
+	})
+	c := &client{httpClient: &http.Client{Transport: base}, Logger: &bot.NilLogger{}}
+
+	responses, err := c.DoBatchViewCalendarRequests([]*remote.ViewCalendarParams{
+		{RemoteUserID: "user_0"},
+		{RemoteUserID: "user_1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"0", "1"}, {"1"}}, batches)
+	require.Equal(t, []time.Duration{3 * time.Second}, *delays)
+	require.Len(t, responses, 2)
+	require.Equal(t, "user_0", responses[0].RemoteUserID)
+	require.Equal(t, "user_1", responses[1].RemoteUserID)
+	require.Nil(t, responses[1].Error)
+	require.Equal(t, "event_1", responses[1].Events[0].ID)
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+// SetAutomaticReplies patches the automatic replies of the mailbox settings,
+// leaving the other settings as they are.
+func (c *client) SetAutomaticReplies(remoteUserID string, setting *remote.AutomaticRepliesSetting) error {
+	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
+		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
+		return errors.New(ErrorUserInactive)
+	}
+
+	u := c.rbuilder.Users().ID(remoteUserID).URL() + "/mailboxSettings"
+	in := struct {
+		AutomaticRepliesSetting *remote.AutomaticRepliesSetting `json:"automaticRepliesSetting"`
+	}{setting}
+
+	_, err := c.CallJSON(http.MethodPatch, u, in, nil)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		if isForbidden(err) {
+			// Tokens granted before MailboxSettings.ReadWrite was requested
+			// can only read the mailbox settings.
+			return errors.Wrap(remote.ErrAutomaticRepliesForbidden, "msgraph SetAutomaticReplies")
+		}
+		return errors.Wrap(err, "msgraph SetAutomaticReplies")
+	}
+	return nil
+}
+
+func isForbidden(err error) bool {
+	var errResp *msgraph.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return false
+	}
+	return errResp.Response.StatusCode == http.StatusForbidden
+}
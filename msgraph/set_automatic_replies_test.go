@@ -0,0 +1,51 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+)
+
+func TestSetAutomaticReplies(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+	}{
+		{
+			name:   "automatic replies set",
+			status: http.StatusOK,
+			body:   `{}`,
+		},
+		{
+			name:     "token without MailboxSettings.ReadWrite",
+			status:   http.StatusForbidden,
+			body:     `{"error": {"code": "ErrorAccessDenied", "message": "Access is denied."}}`,
+			expected: remote.ErrAutomaticRepliesForbidden,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, path string
+			c := newTestClient(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				method, path = req.Method, req.URL.Path
+				return mockResponse(tt.status, nil, tt.body), nil
+			}))
+
+			err := c.SetAutomaticReplies("user_id", &remote.AutomaticRepliesSetting{Status: remote.AutomaticRepliesStatusDisabled})
+			require.Equal(t, http.MethodPatch, method)
+			require.Equal(t, "/v1.0/users/user_id/mailboxSettings", path)
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
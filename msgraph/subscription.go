@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/pkg/errors"
@@ -24,12 +25,25 @@ func newRandomString() string {
 }
 
 func (c *client) CreateMySubscription(notificationURL, _ string) (*remote.Subscription, error) {
+	return c.createSubscription(notificationURL, "me/events")
+}
+
+func (c *client) CreateCalendarSubscription(notificationURL, _, calendarID string) (*remote.Subscription, error) {
+	return c.createSubscription(notificationURL, "me/calendars/"+url.PathEscape(calendarID)+"/events")
+}
+
+func (c *client) CreateGroupCalendarSubscription(notificationURL, groupID string) (*remote.Subscription, error) {
+	return c.createSubscription(notificationURL, "groups/"+url.PathEscape(groupID)+"/calendar/events")
+}
+
+func (c *client) createSubscription(notificationURL, resource string) (*remote.Subscription, error) {
 	sub := &remote.Subscription{
-		Resource:           "me/events",
-		ChangeType:         "created,updated,deleted",
-		NotificationURL:    notificationURL,
-		ExpirationDateTime: time.Now().Add(subscribeTTL).Format(time.RFC3339),
-		ClientState:        newRandomString(),
+		Resource:                 resource,
+		ChangeType:               "created,updated,deleted",
+		NotificationURL:          notificationURL,
+		LifecycleNotificationURL: c.conf.GetLifecycleNotificationURL(),
+		ExpirationDateTime:       time.Now().Add(subscribeTTL).Format(time.RFC3339),
+		ClientState:              newRandomString(),
 	}
 
 	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
@@ -40,7 +54,7 @@ func (c *client) CreateMySubscription(notificationURL, _ string) (*remote.Subscr
 	err := c.rbuilder.Subscriptions().Request().JSONRequest(c.ctx, http.MethodPost, "", sub, sub)
 	if err != nil {
 		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
-		return nil, errors.Wrap(err, "msgraph CreateMySubscription")
+		return nil, errors.Wrap(err, "msgraph createSubscription "+resource)
 	}
 
 	c.Logger.With(bot.LogContext{
@@ -100,9 +114,7 @@ func (c *client) RenewSubscription(_, _ string, oldSub *remote.Subscription) (*r
 }
 
 func (c *client) ListSubscriptions() ([]*remote.Subscription, error) {
-	var v struct {
-		Value []*remote.Subscription `json:"value"`
-	}
+	v := page[*remote.Subscription]{}
 
 	if !c.tokenHelpers.CheckUserConnected(c.mattermostUserID) {
 		c.Logger.Warnf(LogUserInactive, c.mattermostUserID)
@@ -114,5 +126,11 @@ func (c *client) ListSubscriptions() ([]*remote.Subscription, error) {
 		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
 		return nil, errors.Wrap(err, "msgraph ListSubscriptions")
 	}
-	return v.Value, nil
+
+	more, err := getNextPages[*remote.Subscription](c, v.NextLink)
+	if err != nil {
+		c.tokenHelpers.DisconnectUserFromStoreIfNecessary(err, c.mattermostUserID)
+		return nil, errors.Wrap(err, "msgraph ListSubscriptions")
+	}
+	return append(v.Value, more...), nil
 }
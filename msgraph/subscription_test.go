@@ -0,0 +1,75 @@
+// Copyright (c) 2019-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package msgraph
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	msgraph "github.com/yaegashi/msgraph.go/v1.0"
+	"golang.org/x/oauth2"
+
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/remote"
+	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/utils/bot"
+)
+
+type testTokenHelpers struct{}
+
+func (testTokenHelpers) CheckUserConnected(string) bool                   { return true }
+func (testTokenHelpers) DisconnectUserFromStoreIfNecessary(error, string) {}
+func (testTokenHelpers) RefreshAndStoreToken(token *oauth2.Token, _ *oauth2.Config, _ string) (*oauth2.Token, error) {
+	return token, nil
+}
+
+func newTestClient(transport http.RoundTripper) *client {
+	httpClient := &http.Client{Transport: transport}
+	return &client{
+		conf:         &config.Config{},
+		httpClient:   httpClient,
+		rbuilder:     msgraph.NewClient(httpClient),
+		tokenHelpers: testTokenHelpers{},
+		Logger:       &bot.NilLogger{},
+	}
+}
+
+func TestCreateSubscriptionResource(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		subscribe func(c *client) (*remote.Subscription, error)
+		expected  string
+	}{
+		{
+			name: "calendar of the user",
+			subscribe: func(c *client) (*remote.Subscription, error) {
+				return c.CreateCalendarSubscription("https://mm.example.com/notification", "user_id", "AAMk/calendar+id=")
+			},
+			expected: "me/calendars/AAMk%2Fcalendar+id=/events",
+		},
+		{
+			name: "calendar of a group",
+			subscribe: func(c *client) (*remote.Subscription, error) {
+				return c.CreateGroupCalendarSubscription("https://mm.example.com/notification", "group_id")
+			},
+			expected: "groups/group_id/calendar/events",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var resource string
+			c := newTestClient(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				in := map[string]interface{}{}
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&in))
+				resource, _ = in["resource"].(string)
+				return mockResponse(http.StatusCreated, nil, `{"id": "subscription_id"}`), nil
+			}))
+
+			sub, err := tt.subscribe(c)
+			require.NoError(t, err)
+			require.Equal(t, "subscription_id", sub.ID)
+			require.Equal(t, tt.expected, resource)
+		})
+	}
+}
@@ -6,9 +6,11 @@ package main
 import (
 	mattermostplugin "github.com/mattermost/mattermost/server/public/plugin"
 
+	"github.com/mattermost/mattermost-plugin-mscalendar/caldav"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/config"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/engine"
 	"github.com/mattermost/mattermost-plugin-mscalendar/calendar/plugin"
+	"github.com/mattermost/mattermost-plugin-mscalendar/gcal"
 	"github.com/mattermost/mattermost-plugin-mscalendar/msgraph"
 )
 
@@ -18,7 +20,14 @@ var BuildDate string
 var CalendarProvider string
 
 func main() {
-	config.Provider = msgraph.GetMSCalendarProviderConfig()
+	switch CalendarProvider {
+	case caldav.Kind:
+		config.Provider = caldav.GetCalDAVProviderConfig()
+	case gcal.Kind:
+		config.Provider = gcal.GetGoogleCalendarProviderConfig()
+	default:
+		config.Provider = msgraph.GetMSCalendarProviderConfig()
+	}
 
 	mattermostplugin.ClientMain(
 		plugin.NewWithEnv(